@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/backup"
+	"barnlog/backend/internal/infrastructure/config"
+)
+
+func runBackup(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	switch {
+	case len(args) == 0 || (len(args) == 1 && args[0] == "create"):
+		db, err := openSQLiteDB(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = db.Close() }()
+
+		manager := backup.NewManager(db.Read, backup.Options{Dir: cfg.BackupDir, FileDir: cfg.FileDir, Keep: cfg.BackupKeep})
+		created, err := manager.Create(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(std.out, "created %s\n", created.Path)
+		return err
+	case len(args) == 1 && args[0] == "list":
+		backups, err := backup.List(cfg.BackupDir)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(std.out, 0, 0, 2, ' ', 0)
+		for _, b := range backups {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\n", b.Name, b.CreatedAt.Format(time.RFC3339))
+		}
+		return tw.Flush()
+	case len(args) == 2 && args[0] == "verify":
+		path := resolveBackup(cfg, args[1])
+		if err := backup.Verify(ctx, path); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(std.out, "%s: ok\n", path)
+		return err
+	default:
+		return fmt.Errorf("%w: usage: backup [create|list|verify NAME]", errUsage)
+	}
+}
+
+func runRestore(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: usage: restore NAME", errUsage)
+	}
+
+	path := resolveBackup(cfg, args[0])
+	result, err := backup.Restore(ctx, path, backup.RestoreTarget{DBPath: cfg.DBPath, FileDir: cfg.FileDir}, time.Now())
+	if err != nil {
+		var kept []string
+		if result.PreviousDB != "" {
+			kept = append(kept, "the previous database was moved to "+result.PreviousDB)
+		}
+		if result.PreviousFiles != "" {
+			kept = append(kept, "the previous uploads were moved to "+result.PreviousFiles)
+		}
+		if len(kept) > 0 {
+			return fmt.Errorf("%w (%s)", err, strings.Join(kept, ", "))
+		}
+		return err
+	}
+
+	_, _ = fmt.Fprintf(std.out, "restored %s into %s and %s\n", path, cfg.DBPath, cfg.FileDir)
+	if result.PreviousDB != "" {
+		_, _ = fmt.Fprintf(std.out, "previous database kept at %s\n", result.PreviousDB)
+	}
+	if result.PreviousFiles != "" {
+		_, _ = fmt.Fprintf(std.out, "previous uploads kept at %s\n", result.PreviousFiles)
+	}
+	return nil
+}
+
+// resolveBackup accepts a backup name from "backup list" or a path to a backup directory.
+func resolveBackup(cfg config.Config, arg string) string {
+	if filepath.Base(arg) == arg {
+		candidate := filepath.Join(cfg.BackupDir, arg)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return arg
+}
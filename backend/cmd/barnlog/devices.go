@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/config"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+)
+
+func runDevices(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: devices needs a subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		if len(args) != 3 {
+			return fmt.Errorf("%w: usage: devices create USERNAME NAME", errUsage)
+		}
+		return runDevicesCreate(ctx, cfg, args[1], args[2], std)
+	case "list":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: usage: devices list USERNAME", errUsage)
+		}
+		return runDevicesList(ctx, cfg, args[1], std)
+	case "revoke":
+		if len(args) != 3 {
+			return fmt.Errorf("%w: usage: devices revoke USERNAME DEVICE_ID", errUsage)
+		}
+		return runDevicesRevoke(ctx, cfg, args[1], args[2], std)
+	default:
+		return fmt.Errorf("%w: unknown devices subcommand %q", errUsage, args[0])
+	}
+}
+
+func runDevicesCreate(ctx context.Context, cfg config.Config, username, name string, std streams) error {
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	userID, err := lookupUserID(ctx, db, username)
+	if err != nil {
+		return err
+	}
+
+	out, err := application.NewDeviceManager(sqliteinfra.NewDeviceStore(db.Read, db.Write)).RegisterDevice(ctx, application.RegisterDeviceInput{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		return fmt.Errorf("register device: %w", err)
+	}
+	_, err = fmt.Fprintf(std.out, "registered device %s (%s); its secret is not shown again:\n%s\n", out.Name, out.ID, out.Secret)
+	return err
+}
+
+func runDevicesList(ctx context.Context, cfg config.Config, username string, std streams) error {
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	userID, err := lookupUserID(ctx, db, username)
+	if err != nil {
+		return err
+	}
+
+	devices, err := application.NewDeviceManager(sqliteinfra.NewDeviceStore(db.Read, db.Write)).ListDevices(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list devices: %w", err)
+	}
+	for _, device := range devices {
+		if _, err := fmt.Fprintf(std.out, "%s\t%s\tregistered %s\n",
+			device.ID, device.Name, device.CreatedAt.UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runDevicesRevoke(ctx context.Context, cfg config.Config, username, deviceID string, std streams) error {
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	userID, err := lookupUserID(ctx, db, username)
+	if err != nil {
+		return err
+	}
+
+	if err := application.NewDeviceManager(sqliteinfra.NewDeviceStore(db.Read, db.Write)).RevokeDevice(ctx, userID, deviceID); err != nil {
+		return fmt.Errorf("revoke device: %w", err)
+	}
+	_, err = fmt.Fprintf(std.out, "revoked device %s\n", deviceID)
+	return err
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/infrastructure/config"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+)
+
+func runEvents(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: events needs a subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "export":
+		return runEventsExport(ctx, cfg, args[1:], std)
+	case "import":
+		return runEventsImport(ctx, cfg, args[1:], std)
+	default:
+		return fmt.Errorf("%w: unknown events subcommand %q", errUsage, args[0])
+	}
+}
+
+func runEventsExport(ctx context.Context, cfg config.Config, args []string, std streams) (err error) {
+	flags := flag.NewFlagSet("events export", flag.ContinueOnError)
+	flags.SetOutput(std.err)
+	outPath := flags.String("o", "-", "output file, or - for stdout")
+	barn := flags.String("barn", "", "export only this barn (default: every barn)")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	barnID := domain.ServerBarnID
+	if *barn != "" {
+		if barnID, err = application.ParseBarnID(*barn); err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+	}
+
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	out := std.out
+	if *outPath != "-" {
+		// #nosec G304 -- the operator chooses where the export is written.
+		file, err := os.OpenFile(*outPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("create export file: %w", err)
+		}
+		defer func() {
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("close export file: %w", closeErr)
+			}
+		}()
+		out = file
+	}
+
+	buffered := bufio.NewWriter(out)
+	archive := application.NewEventArchive(sqliteinfra.NewEventArchiveStore(db.Read, db.Write))
+	if err := archive.Export(application.WithBarn(ctx, barnID), buffered); err != nil {
+		return fmt.Errorf("export events: %w", err)
+	}
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	return nil
+}
+
+func runEventsImport(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	flags := flag.NewFlagSet("events import", flag.ContinueOnError)
+	flags.SetOutput(std.err)
+	inPath := flags.String("i", "-", "NDJSON file to import, or - for stdin")
+	barn := flags.String("barn", domain.DefaultBarnID, "barn of events exported before barns existed")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	barnID, err := application.ParseBarnID(*barn)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	in := std.in
+	if *inPath != "-" {
+		// #nosec G304 -- the operator chooses which export to import.
+		file, err := os.Open(*inPath)
+		if err != nil {
+			return fmt.Errorf("open import file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		in = file
+	}
+
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	archive := application.NewEventArchive(sqliteinfra.NewEventArchiveStore(db.Read, db.Write))
+	result, err := archive.Import(application.WithBarn(ctx, barnID), in)
+	if err != nil {
+		return fmt.Errorf("import events: %w", err)
+	}
+
+	_, err = fmt.Fprintf(std.out, "imported: %d, skipped (already present): %d\n", result.Imported, result.Skipped)
+	return err
+}
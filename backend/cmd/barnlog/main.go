@@ -0,0 +1,130 @@
+// Command barnlog is the administrative CLI for a barnlog deployment.
+// It reads the same BARNLOG_* environment variables as the server.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/config"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+)
+
+const usage = `usage: barnlog <command> [arguments]
+
+commands:
+  migrate up                apply every pending migration
+  migrate down N            roll back the last N migrations
+  migrate status            show the applied version and pending migrations
+  migrate force VERSION     set the version without running SQL (-1 for none)
+  schema dump [-o file]     regenerate backend/db/schema.sql from the migrations
+  backup [create]           snapshot the database and uploads into BARNLOG_BACKUP_DIR
+  backup list               list backups, newest first
+  backup verify NAME        run an integrity check on a backup
+  restore NAME              replace the database and uploads with a backup (stop the server first)
+  events export [-barn BARN] [-o file]
+                            write the event log of one or every barn as NDJSON (default: stdout)
+  events import [-barn BARN] [-i file]
+                            append an NDJSON event log (default: stdin); -barn receives events without a barn_id
+  replication status        show the node role and the checkpoint of each replication target
+  replication follow        make this database a read-only replication follower
+  replication promote       make this database primary so it accepts writes again
+  users create [-barn BARN] [-role ROLE] USERNAME
+                            create a user (default viewer in barn default); the password is read from stdin
+  users passwd USERNAME     replace a user's password; the new one is read from stdin
+  users role [-barn BARN] USERNAME ROLE
+                            make a user owner, manager, worker or viewer of a barn (server: every barn)
+  tokens create -scope SCOPES [-expires DURATION] USERNAME NAME
+                            create an API token for a user and print it once
+  tokens list USERNAME      list a user's API tokens
+  tokens revoke USERNAME ID revoke one of a user's API tokens
+  devices create USERNAME NAME
+                            register a device for a user and print its secret once
+  devices list USERNAME     list a user's devices
+  devices revoke USERNAME ID
+                            revoke one of a user's devices, for example a lost phone
+`
+
+// errUsage reports a command line that does not name a known command.
+var errUsage = errors.New("invalid arguments")
+
+// streams are the standard streams a command reads from and writes to.
+type streams struct {
+	in  io.Reader
+	out io.Writer
+	err io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], streams{in: os.Stdin, out: os.Stdout, err: os.Stderr})
+	if err == nil {
+		return
+	}
+	if errors.Is(err, errUsage) {
+		_, _ = fmt.Fprintf(os.Stderr, "barnlog: %v\n\n%s", err, usage)
+		stop()
+		os.Exit(2)
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "barnlog: %v\n", err)
+	stop()
+	os.Exit(1)
+}
+
+func run(ctx context.Context, args []string, std streams) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	// Operators with shell access to the database already hold every right.
+	ctx = application.WithPrincipal(ctx, application.ServicePrincipal("cli"))
+
+	switch args[0] {
+	case "migrate":
+		return runMigrate(cfg, args[1:], std)
+	case "schema":
+		return runSchema(ctx, cfg, args[1:], std)
+	case "backup":
+		return runBackup(ctx, cfg, args[1:], std)
+	case "restore":
+		return runRestore(ctx, cfg, args[1:], std)
+	case "events":
+		return runEvents(ctx, cfg, args[1:], std)
+	case "replication":
+		return runReplication(ctx, cfg, args[1:], std)
+	case "users":
+		return runUsers(ctx, cfg, args[1:], std)
+	case "tokens":
+		return runTokens(ctx, cfg, args[1:], std)
+	case "devices":
+		return runDevices(ctx, cfg, args[1:], std)
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+}
+
+// openSQLiteDB opens the configured database without creating it, so a
+// mistyped BARNLOG_DB_PATH fails instead of producing an empty file.
+func openSQLiteDB(ctx context.Context, cfg config.Config) (*sqliteinfra.DB, error) {
+	if _, err := os.Stat(cfg.DBPath); err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	return sqliteinfra.Open(ctx, cfg.DBPath, sqliteinfra.Options{
+		BusyTimeout:  cfg.DBBusyTimeout,
+		JournalMode:  cfg.DBJournalMode,
+		Synchronous:  cfg.DBSynchronous,
+		MaxReadConns: cfg.DBMaxReadConns,
+	})
+}
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/infrastructure/migrations"
+	"barnlog/backend/internal/infrastructure/sqlite"
+	"barnlog/backend/internal/ports"
+)
+
+func TestRunEventsExportImport(t *testing.T) {
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "source.sqlite3")
+	targetPath := filepath.Join(dir, "target.sqlite3")
+	exportPath := filepath.Join(dir, "events.ndjson")
+	migrateTestDB(t, sourcePath)
+	migrateTestDB(t, targetPath)
+
+	db, err := sql.Open("sqlite", sourcePath)
+	if err != nil {
+		t.Fatalf("open source db: %v", err)
+	}
+	_, err = sqlite.NewAnimalWriteStore(db, t.TempDir()).CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    "north",
+		Name:      "Nanny",
+		Species:   "goat",
+		Source:    "test.cli",
+		RequestID: "req-1",
+		CreatedBy: "user:test",
+	})
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("seed source db: %v", err)
+	}
+
+	t.Setenv("BARNLOG_DB_PATH", sourcePath)
+	if _, err := runCLI(t, "", "events", "export", "-o", exportPath); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	exported, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if !strings.Contains(string(exported), `"barn_id":"north"`) || !strings.Contains(string(exported), `"request_id":"req-1"`) {
+		t.Fatalf("expected seeded event in export, got %q", exported)
+	}
+	if out, err := runCLI(t, "", "events", "export", "-barn", "south"); err != nil || out != "" {
+		t.Fatalf("expected an empty export of another barn, got %q (%v)", out, err)
+	}
+
+	t.Setenv("BARNLOG_DB_PATH", targetPath)
+	out, err := runCLI(t, "", "events", "import", "-i", exportPath)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if out != "imported: 1, skipped (already present): 0\n" {
+		t.Fatalf("unexpected import output %q", out)
+	}
+
+	out, err = runCLI(t, string(exported), "events", "import")
+	if err != nil {
+		t.Fatalf("re-import from stdin: %v", err)
+	}
+	if out != "imported: 0, skipped (already present): 1\n" {
+		t.Fatalf("unexpected re-import output %q", out)
+	}
+
+	out, err = runCLI(t, "", "events", "export")
+	if err != nil {
+		t.Fatalf("export to stdout: %v", err)
+	}
+	if out != string(exported) {
+		t.Fatalf("expected target export to match source:\n got %q\nwant %q", out, exported)
+	}
+}
+
+func TestRunMigrate(t *testing.T) {
+	t.Setenv("BARNLOG_DB_PATH", filepath.Join(t.TempDir(), "db", "barnlog.sqlite3"))
+	t.Setenv("BARNLOG_MIGRATIONS_PATH", testMigrationsPath(t))
+
+	out, err := runCLI(t, "", "migrate", "status")
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if !strings.HasPrefix(out, "version  0\n") || !strings.Contains(out, "000001  init") {
+		t.Fatalf("unexpected status of a new database:\n%s", out)
+	}
+
+	out, err = runCLI(t, "", "migrate", "up")
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if strings.Contains(out, "pending") {
+		t.Fatalf("expected every migration applied:\n%s", out)
+	}
+	if _, err := runCLI(t, "", "migrate", "up"); err != nil {
+		t.Fatalf("up with nothing pending: %v", err)
+	}
+
+	out, err = runCLI(t, "", "migrate", "down", "1")
+	if err != nil {
+		t.Fatalf("down 1: %v", err)
+	}
+	if strings.Count(out, "pending") != 1 {
+		t.Fatalf("expected one pending migration after down 1:\n%s", out)
+	}
+
+	out, err = runCLI(t, "", "migrate", "force", "1")
+	if err != nil {
+		t.Fatalf("force 1: %v", err)
+	}
+	if !strings.HasPrefix(out, "version  1\n") {
+		t.Fatalf("expected version 1 after force:\n%s", out)
+	}
+}
+
+func TestRunSchemaDump(t *testing.T) {
+	t.Setenv("BARNLOG_MIGRATIONS_PATH", testMigrationsPath(t))
+	outPath := filepath.Join(t.TempDir(), "schema.sql")
+
+	if _, err := runCLI(t, "", "schema", "dump", "-o", outPath); err != nil {
+		t.Fatalf("schema dump: %v", err)
+	}
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read dumped schema: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(testMigrationsPath(t), "..", "schema.sql"))
+	if err != nil {
+		t.Fatalf("read schema snapshot: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("dumped schema differs from backend/db/schema.sql:\n%s", got)
+	}
+
+	stdout, err := runCLI(t, "", "schema", "dump", "-o", "-")
+	if err != nil {
+		t.Fatalf("schema dump to stdout: %v", err)
+	}
+	if stdout != string(want) {
+		t.Fatalf("expected the schema on stdout, got:\n%s", stdout)
+	}
+}
+
+func TestRunBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "barnlog.sqlite3")
+	fileDir := filepath.Join(dir, "files")
+	t.Setenv("BARNLOG_DB_PATH", dbPath)
+	t.Setenv("BARNLOG_FILE_DIR", fileDir)
+	t.Setenv("BARNLOG_BACKUP_DIR", filepath.Join(dir, "backups"))
+	migrateTestDB(t, dbPath)
+	if err := os.MkdirAll(fileDir, 0o750); err != nil {
+		t.Fatalf("create upload dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(fileDir, "photo.jpg"), []byte("jpeg"), 0o600); err != nil {
+		t.Fatalf("write upload: %v", err)
+	}
+
+	if _, err := runCLI(t, "", "backup"); err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+	out, err := runCLI(t, "", "backup", "list")
+	if err != nil {
+		t.Fatalf("backup list: %v", err)
+	}
+	name, _, ok := strings.Cut(out, " ")
+	if !ok || !strings.HasPrefix(name, "barnlog-") || strings.Count(out, "\n") != 1 {
+		t.Fatalf("expected one listed backup, got %q", out)
+	}
+	if _, err := runCLI(t, "", "backup", "verify", name); err != nil {
+		t.Fatalf("backup verify: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(fileDir, "photo.jpg")); err != nil {
+		t.Fatalf("remove upload: %v", err)
+	}
+	out, err = runCLI(t, "", "restore", name)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if !strings.Contains(out, "previous database kept at "+dbPath+".pre-restore-") {
+		t.Fatalf("expected the previous database location, got %q", out)
+	}
+	if body, err := os.ReadFile(filepath.Join(fileDir, "photo.jpg")); err != nil || string(body) != "jpeg" {
+		t.Fatalf("expected the restored upload, got %q, %v", body, err)
+	}
+	if out, err := runCLI(t, "", "migrate", "status"); err != nil || strings.Contains(out, "pending") {
+		t.Fatalf("expected a fully migrated restored database, got %q, %v", out, err)
+	}
+
+	if _, err := runCLI(t, "", "restore", "barnlog-missing"); err == nil {
+		t.Fatalf("expected an error restoring a missing backup")
+	}
+}
+
+func TestRunReplicationRoles(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "barnlog.sqlite3")
+	t.Setenv("BARNLOG_DB_PATH", dbPath)
+	migrateTestDB(t, dbPath)
+
+	for _, step := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"replication", "status"}, want: "role  primary\n"},
+		{args: []string{"replication", "follow"}, want: "read-only follower"},
+		{args: []string{"replication", "status"}, want: "role  follower\n"},
+		{args: []string{"replication", "promote"}, want: "is now primary"},
+		{args: []string{"replication", "status"}, want: "role  primary\n"},
+	} {
+		out, err := runCLI(t, "", step.args...)
+		if err != nil {
+			t.Fatalf("%v: %v", step.args, err)
+		}
+		if !strings.Contains(out, step.want) {
+			t.Fatalf("%v: expected %q in output:\n%s", step.args, step.want, out)
+		}
+	}
+}
+
+func TestRunUsers(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "barnlog.sqlite3")
+	t.Setenv("BARNLOG_DB_PATH", dbPath)
+	migrateTestDB(t, dbPath)
+
+	out, err := runCLI(t, "correct horse battery\n", "users", "create", "-role", "owner", "Anna")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if !strings.HasPrefix(out, "created user anna (") || !strings.HasSuffix(out, " with role owner in barn default\n") {
+		t.Fatalf("unexpected create output %q", out)
+	}
+	out, err = runCLI(t, "correct horse battery\n", "users", "create", "sam")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if !strings.HasSuffix(out, " with role viewer in barn default\n") {
+		t.Fatalf("expected viewer by default, got %q", out)
+	}
+	if _, err := runCLI(t, "correct horse battery\n", "users", "create", "-role", "admin", "bert"); err == nil ||
+		!strings.Contains(err.Error(), "role must be") {
+		t.Fatalf("expected an unknown role error, got %v", err)
+	}
+
+	if _, err := runCLI(t, "another password\n", "users", "create", "anna"); err == nil || !strings.Contains(err.Error(), "is taken") {
+		t.Fatalf("expected a taken username for a second anna, got %v", err)
+	}
+	if _, err := runCLI(t, "", "users", "create", "bert"); err == nil || !strings.Contains(err.Error(), "stdin") {
+		t.Fatalf("expected an error without a password, got %v", err)
+	}
+
+	out, err = runCLI(t, "a new password\n", "users", "passwd", "anna")
+	if err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+	if out != "changed password of anna\n" {
+		t.Fatalf("unexpected passwd output %q", out)
+	}
+
+	out, err = runCLI(t, "", "users", "role", "sam", "Worker")
+	if err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if out != "sam is now worker in barn default\n" {
+		t.Fatalf("unexpected role output %q", out)
+	}
+	out, err = runCLI(t, "", "users", "role", "-barn", "North", "sam", "manager")
+	if err != nil {
+		t.Fatalf("change role in north: %v", err)
+	}
+	if out != "sam is now manager in barn north\n" {
+		t.Fatalf("unexpected role output %q", out)
+	}
+	if _, err := runCLI(t, "", "users", "role", "-barn", "../north", "sam", "manager"); err == nil ||
+		!strings.Contains(err.Error(), "barn must be") {
+		t.Fatalf("expected a malformed barn error, got %v", err)
+	}
+}
+
+func TestRunTokens(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "barnlog.sqlite3")
+	t.Setenv("BARNLOG_DB_PATH", dbPath)
+	migrateTestDB(t, dbPath)
+	if _, err := runCLI(t, "correct horse battery\n", "users", "create", "anna"); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	out, err := runCLI(t, "", "tokens", "create", "-scope", "events:import", "-expires", "720h", "anna", "replication")
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	if !strings.HasPrefix(out, "created token replication (") || !strings.Contains(out, "\nblt_") {
+		t.Fatalf("unexpected create output %q", out)
+	}
+	if _, err := runCLI(t, "", "tokens", "create", "-scope", "barn:burn", "anna", "bad"); err == nil ||
+		!strings.Contains(err.Error(), "unknown scope") {
+		t.Fatalf("expected an unknown scope error, got %v", err)
+	}
+	if _, err := runCLI(t, "", "tokens", "list", "bert"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected an unknown user error, got %v", err)
+	}
+
+	out, err = runCLI(t, "", "tokens", "list", "anna")
+	if err != nil {
+		t.Fatalf("list tokens: %v", err)
+	}
+	fields := strings.Split(strings.TrimSpace(out), "\t")
+	if len(fields) != 4 || fields[1] != "replication" || fields[2] != "events:import" {
+		t.Fatalf("unexpected list output %q", out)
+	}
+
+	if _, err := runCLI(t, "", "tokens", "revoke", "anna", fields[0]); err != nil {
+		t.Fatalf("revoke token: %v", err)
+	}
+	if out, err := runCLI(t, "", "tokens", "list", "anna"); err != nil || out != "" {
+		t.Fatalf("expected no tokens after revoke, got %q (%v)", out, err)
+	}
+}
+
+func TestRunDevices(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "barnlog.sqlite3")
+	t.Setenv("BARNLOG_DB_PATH", dbPath)
+	migrateTestDB(t, dbPath)
+	if _, err := runCLI(t, "correct horse battery\n", "users", "create", "anna"); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	out, err := runCLI(t, "", "devices", "create", "anna", "barn phone")
+	if err != nil {
+		t.Fatalf("create device: %v", err)
+	}
+	if !strings.HasPrefix(out, "registered device barn phone (") || !strings.Contains(out, "\nbld_") {
+		t.Fatalf("unexpected create output %q", out)
+	}
+
+	out, err = runCLI(t, "", "devices", "list", "anna")
+	if err != nil {
+		t.Fatalf("list devices: %v", err)
+	}
+	fields := strings.Split(strings.TrimSpace(out), "\t")
+	if len(fields) != 3 || fields[1] != "barn phone" {
+		t.Fatalf("unexpected list output %q", out)
+	}
+
+	if _, err := runCLI(t, "", "devices", "revoke", "anna", fields[0]); err != nil {
+		t.Fatalf("revoke device: %v", err)
+	}
+	if _, err := runCLI(t, "", "devices", "revoke", "anna", fields[0]); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected revoking twice to fail, got %v", err)
+	}
+	if out, err := runCLI(t, "", "devices", "list", "anna"); err != nil || out != "" {
+		t.Fatalf("expected no devices after revoke, got %q (%v)", out, err)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"nope"},
+		{"events"},
+		{"events", "nope"},
+		{"events", "export", "-x"},
+		{"migrate"},
+		{"migrate", "down"},
+		{"migrate", "down", "one"},
+		{"migrate", "force"},
+		{"migrate", "status", "now"},
+		{"schema"},
+		{"schema", "load"},
+		{"backup", "nope"},
+		{"backup", "verify"},
+		{"restore"},
+		{"replication"},
+		{"replication", "nope"},
+		{"users"},
+		{"users", "create"},
+		{"users", "delete", "anna"},
+		{"users", "create", "-role"},
+		{"users", "passwd"},
+		{"users", "role", "anna"},
+		{"tokens"},
+		{"tokens", "nope"},
+		{"tokens", "create", "anna"},
+		{"tokens", "list"},
+		{"tokens", "revoke", "anna"},
+		{"devices"},
+		{"devices", "nope"},
+		{"devices", "create", "anna"},
+		{"devices", "list"},
+		{"devices", "revoke", "anna"},
+	} {
+		if _, err := runCLI(t, "", args...); !errors.Is(err, errUsage) {
+			t.Fatalf("args %q: expected usage error, got %v", args, err)
+		}
+	}
+}
+
+func TestRunMissingDatabase(t *testing.T) {
+	t.Setenv("BARNLOG_DB_PATH", filepath.Join(t.TempDir(), "missing.sqlite3"))
+
+	if _, err := runCLI(t, "", "events", "export"); err == nil || !strings.Contains(err.Error(), "open sqlite") {
+		t.Fatalf("expected open error for a missing database, got %v", err)
+	}
+}
+
+func runCLI(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, streams{in: strings.NewReader(stdin), out: &stdout, err: &stderr})
+	return stdout.String(), err
+}
+
+func migrateTestDB(t *testing.T, dbPath string) {
+	t.Helper()
+
+	m, err := migrations.Open(dbPath, testMigrationsPath(t))
+	if err != nil {
+		t.Fatalf("open migrations: %v", err)
+	}
+	defer func() { _ = m.Close() }()
+	if err := m.Up(); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+}
+
+func testMigrationsPath(t *testing.T) string {
+	t.Helper()
+
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatalf("resolve caller path")
+	}
+	return filepath.Clean(filepath.Join(filepath.Dir(thisFile), "..", "..", "db", "migrations"))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/migrations"
+)
+
+func runMigrate(cfg config.Config, args []string, std streams) (err error) {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: migrate needs a subcommand", errUsage)
+	}
+
+	var apply func(*migrations.Migrator) error
+	switch args[0] {
+	case "up":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: migrate up takes no arguments", errUsage)
+		}
+		apply = func(m *migrations.Migrator) error {
+			if err := m.Up(); err != nil && !errors.Is(err, migrations.ErrNoChange) {
+				return err
+			}
+			return nil
+		}
+	case "down":
+		steps, err := intArg(args, "migrate down N")
+		if err != nil {
+			return err
+		}
+		apply = func(m *migrations.Migrator) error { return m.Down(steps) }
+	case "force":
+		version, err := intArg(args, "migrate force VERSION")
+		if err != nil {
+			return err
+		}
+		apply = func(m *migrations.Migrator) error { return m.Force(version) }
+	case "status":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: migrate status takes no arguments", errUsage)
+		}
+	default:
+		return fmt.Errorf("%w: unknown migrate subcommand %q", errUsage, args[0])
+	}
+
+	m, err := migrations.Open(cfg.DBPath, cfg.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := m.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	if apply != nil {
+		if err := apply(m); err != nil {
+			return err
+		}
+	}
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	return writeMigrationStatus(std, status)
+}
+
+// intArg parses the single integer argument of a subcommand such as "down 1".
+func intArg(args []string, form string) (int, error) {
+	if len(args) != 2 {
+		return 0, fmt.Errorf("%w: usage: %s", errUsage, form)
+	}
+	value, err := strconv.Atoi(args[1])
+	if err != nil {
+		return 0, fmt.Errorf("%w: usage: %s", errUsage, form)
+	}
+	return value, nil
+}
+
+func writeMigrationStatus(std streams, status migrations.Status) error {
+	tw := tabwriter.NewWriter(std.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "version\t%d", status.Version)
+	if status.Dirty {
+		_, _ = fmt.Fprint(tw, " (dirty: fix the failed migration, then run migrate force)")
+	}
+	_, _ = fmt.Fprintf(tw, "\nlatest\t%d\n\n", status.Latest())
+	for _, migration := range status.Migrations {
+		state := "pending"
+		if migration.Version <= status.Version {
+			state = "applied"
+		}
+		_, _ = fmt.Fprintf(tw, "%06d\t%s\t%s\n", migration.Version, migration.Name, state)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/config"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+)
+
+func runReplication(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) != 1 || !slices.Contains([]string{"status", "follow", "promote"}, args[0]) {
+		return fmt.Errorf("%w: usage: replication status|follow|promote", errUsage)
+	}
+
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	roles := application.NewNodeRoles(sqliteinfra.NewNodeRoleStore(db.Read, db.Write))
+
+	switch args[0] {
+	case "status":
+		role, err := roles.Role(ctx)
+		if err != nil {
+			return err
+		}
+		checkpoints, err := sqliteinfra.NewReplicationStore(db.Read, db.Write).ListReplicationCheckpoints(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(std.out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "role\t%s\n", role)
+		if len(checkpoints) > 0 {
+			_, _ = fmt.Fprintln(tw)
+		}
+		for _, c := range checkpoints {
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\n", c.Target, c.Position, c.UpdatedAt)
+		}
+		return tw.Flush()
+	case "follow":
+		if err := roles.Follow(ctx); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(std.out, "%s is now a read-only follower\n", cfg.DBPath)
+		return err
+	default:
+		if err := roles.Promote(ctx); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(std.out, "%s is now primary and accepts writes\n", cfg.DBPath)
+		return err
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/migrations"
+)
+
+func runSchema(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) == 0 || args[0] != "dump" {
+		return fmt.Errorf("%w: usage: schema dump [-o file]", errUsage)
+	}
+
+	flags := flag.NewFlagSet("schema dump", flag.ContinueOnError)
+	flags.SetOutput(std.err)
+	outPath := flags.String("o", "backend/db/schema.sql", "output file, or - for stdout")
+	if err := flags.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	schema, err := dumpMigratedSchema(ctx, cfg.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	if *outPath == "-" {
+		_, err := std.out.Write(schema)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(*outPath), 0o750); err != nil {
+		return fmt.Errorf("create schema directory: %w", err)
+	}
+	if err := os.WriteFile(*outPath, schema, 0o600); err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+	source := cfg.MigrationsPath
+	if source == "" {
+		source = migrations.EmbeddedSource + " migrations"
+	}
+	_, err = fmt.Fprintf(std.out, "generated %s from %s\n", *outPath, source)
+	return err
+}
+
+// dumpMigratedSchema applies every migration to a scratch database and
+// returns its schema, so the snapshot never depends on local data.
+func dumpMigratedSchema(ctx context.Context, migrationsPath string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "barnlog-schema-")
+	if err != nil {
+		return nil, fmt.Errorf("create scratch directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	dbPath := filepath.Join(dir, "schema.sqlite3")
+	m, err := migrations.Open(dbPath, migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+	upErr := m.Up()
+	if err := m.Close(); err != nil {
+		return nil, err
+	}
+	if upErr != nil {
+		return nil, upErr
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("open scratch database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var schema bytes.Buffer
+	if err := migrations.DumpSchema(ctx, db, &schema); err != nil {
+		return nil, err
+	}
+	return schema.Bytes(), nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/config"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+)
+
+func runTokens(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: tokens needs a subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		return runTokensCreate(ctx, cfg, args[1:], std)
+	case "list":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: usage: tokens list USERNAME", errUsage)
+		}
+		return runTokensList(ctx, cfg, args[1], std)
+	case "revoke":
+		if len(args) != 3 {
+			return fmt.Errorf("%w: usage: tokens revoke USERNAME TOKEN_ID", errUsage)
+		}
+		return runTokensRevoke(ctx, cfg, args[1], args[2], std)
+	default:
+		return fmt.Errorf("%w: unknown tokens subcommand %q", errUsage, args[0])
+	}
+}
+
+func runTokensCreate(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	flags := flag.NewFlagSet("tokens create", flag.ContinueOnError)
+	flags.SetOutput(std.err)
+	scopes := flags.String("scope", "", "comma-separated scopes: "+strings.Join(application.APITokenScopes, ", "))
+	expires := flags.Duration("expires", 0, "lifetime of the token, 0 for a token that does not expire")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("%w: usage: tokens create -scope SCOPES [-expires DURATION] USERNAME NAME", errUsage)
+	}
+
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	userID, err := lookupUserID(ctx, db, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	in := application.CreateAPITokenInput{
+		UserID: userID,
+		Name:   flags.Arg(1),
+		Scopes: strings.Split(*scopes, ","),
+	}
+	if *scopes == "" {
+		in.Scopes = nil
+	}
+	if *expires > 0 {
+		in.ExpiresAt = time.Now().Add(*expires)
+	}
+	out, err := application.NewAPITokenManager(sqliteinfra.NewAPITokenStore(db.Read, db.Write)).CreateToken(ctx, in)
+	if err != nil {
+		return fmt.Errorf("create token: %w", err)
+	}
+	_, err = fmt.Fprintf(std.out, "created token %s (%s); it is not shown again:\n%s\n", out.Name, out.ID, out.Token)
+	return err
+}
+
+func runTokensList(ctx context.Context, cfg config.Config, username string, std streams) error {
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	userID, err := lookupUserID(ctx, db, username)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := application.NewAPITokenManager(sqliteinfra.NewAPITokenStore(db.Read, db.Write)).ListTokens(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+	for _, token := range tokens {
+		expires := "never"
+		if !token.ExpiresAt.IsZero() {
+			expires = token.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		if _, err := fmt.Fprintf(std.out, "%s\t%s\t%s\texpires %s\n",
+			token.ID, token.Name, strings.Join(token.Scopes, ","), expires); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runTokensRevoke(ctx context.Context, cfg config.Config, username, tokenID string, std streams) error {
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	userID, err := lookupUserID(ctx, db, username)
+	if err != nil {
+		return err
+	}
+
+	if err := application.NewAPITokenManager(sqliteinfra.NewAPITokenStore(db.Read, db.Write)).RevokeToken(ctx, userID, tokenID); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	_, err = fmt.Fprintf(std.out, "revoked token %s\n", tokenID)
+	return err
+}
+
+func lookupUserID(ctx context.Context, db *sqliteinfra.DB, username string) (string, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	user, found, err := sqliteinfra.NewUserStore(db.Read, db.Write).FindUserByUsername(ctx, username)
+	if err != nil {
+		return "", fmt.Errorf("find user: %w", err)
+	}
+	if !found {
+		return "", fmt.Errorf("user %q not found", username)
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/passwords"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+)
+
+// cliSource is the request source recorded for events the CLI appends.
+const cliSource = "barnlog.cli"
+
+func runUsers(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: users needs a subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		return runUsersCreate(ctx, cfg, args[1:], std)
+	case "passwd":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: usage: users passwd USERNAME", errUsage)
+		}
+		return runUsersPasswd(ctx, cfg, args[1], std)
+	case "role":
+		return runUsersRole(ctx, cfg, args[1:], std)
+	default:
+		return fmt.Errorf("%w: unknown users subcommand %q", errUsage, args[0])
+	}
+}
+
+func runUsersCreate(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	flags := flag.NewFlagSet("users create", flag.ContinueOnError)
+	flags.SetOutput(std.err)
+	role := flags.String("role", string(domain.RoleViewer), "role of the user: owner, manager, worker or viewer")
+	barn := flags.String("barn", domain.DefaultBarnID, "barn the role applies in, or server for every barn")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: usage: users create [-barn BARN] [-role ROLE] USERNAME", errUsage)
+	}
+
+	password, err := readPassword(std.in)
+	if err != nil {
+		return err
+	}
+	return withUserManager(ctx, cfg, func(users application.UserManager, meta application.RequestMeta) error {
+		user, err := users.CreateUser(ctx, application.CreateUserInput{
+			Username: flags.Arg(0),
+			Password: password,
+			BarnID:   *barn,
+			Role:     *role,
+			Meta:     meta,
+		})
+		if err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+		barnID, role := onlyMembership(user.Memberships)
+		_, err = fmt.Fprintf(std.out, "created user %s (%s) with role %s in barn %s\n", user.Username, user.ID, role, barnID)
+		return err
+	})
+}
+
+func runUsersPasswd(ctx context.Context, cfg config.Config, username string, std streams) error {
+	password, err := readPassword(std.in)
+	if err != nil {
+		return err
+	}
+	return withUserManager(ctx, cfg, func(users application.UserManager, meta application.RequestMeta) error {
+		if err := users.SetPassword(ctx, application.SetPasswordInput{Username: username, Password: password, Meta: meta}); err != nil {
+			return fmt.Errorf("set password: %w", err)
+		}
+		_, err := fmt.Fprintf(std.out, "changed password of %s\n", strings.ToLower(strings.TrimSpace(username)))
+		return err
+	})
+}
+
+func runUsersRole(ctx context.Context, cfg config.Config, args []string, std streams) error {
+	flags := flag.NewFlagSet("users role", flag.ContinueOnError)
+	flags.SetOutput(std.err)
+	barn := flags.String("barn", domain.DefaultBarnID, "barn the role applies in, or server for every barn")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("%w: usage: users role [-barn BARN] USERNAME ROLE", errUsage)
+	}
+	username, role := flags.Arg(0), flags.Arg(1)
+
+	return withUserManager(ctx, cfg, func(users application.UserManager, meta application.RequestMeta) error {
+		if err := users.SetRole(ctx, application.SetRoleInput{Username: username, BarnID: *barn, Role: role, Meta: meta}); err != nil {
+			return fmt.Errorf("set role: %w", err)
+		}
+		_, err := fmt.Fprintf(std.out, "%s is now %s in barn %s\n",
+			strings.ToLower(strings.TrimSpace(username)), strings.ToLower(strings.TrimSpace(role)),
+			strings.ToLower(strings.TrimSpace(*barn)))
+		return err
+	})
+}
+
+// onlyMembership returns the membership of a freshly created user.
+func onlyMembership(memberships domain.Memberships) (string, domain.Role) {
+	for barnID, role := range memberships {
+		return barnID, role
+	}
+	return "", ""
+}
+
+// withUserManager opens the database and runs fn with a user manager and the
+// metadata of one CLI request.
+func withUserManager(
+	ctx context.Context,
+	cfg config.Config,
+	fn func(users application.UserManager, meta application.RequestMeta) error,
+) error {
+	db, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	meta, err := cliRequestMeta()
+	if err != nil {
+		return err
+	}
+	return fn(application.NewUserManager(
+		sqliteinfra.NewUserStore(db.Read, db.Write),
+		sqliteinfra.NewSessionStore(db.Read, db.Write),
+		passwords.NewArgon2id(passwords.DefaultParams),
+	), meta)
+}
+
+// readPassword reads the password from the first line of in, so it never
+// appears in the process list or shell history.
+func readPassword(in io.Reader) (string, error) {
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("read password: expected the password on stdin")
+	}
+	return password, nil
+}
+
+func cliRequestMeta() (application.RequestMeta, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return application.RequestMeta{}, fmt.Errorf("generate request id: %w", err)
+	}
+	return application.RequestMeta{
+		Source:    cliSource,
+		RequestID: hex.EncodeToString(id[:]),
+		Actor:     application.ServiceActor("cli"),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/backup"
+)
+
+// runBackups creates a backup every interval until ctx is cancelled. The first
+// one is due interval after the newest existing backup, so frequent restarts
+// do not postpone backups indefinitely. Failures are logged and retried on schedule.
+func runBackups(ctx context.Context, logger *slog.Logger, manager *backup.Manager, interval time.Duration) {
+	timer := time.NewTimer(firstBackupDelay(logger, manager, interval, time.Now()))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		started := time.Now()
+		created, err := manager.Create(ctx)
+		switch {
+		case err != nil && errors.Is(err, context.Canceled):
+			return
+		case err != nil && created.Path == "":
+			logger.Error("backup failed", slog.Any("error", err))
+		case err != nil:
+			logger.Warn("backup created but pruning failed", slog.String("path", created.Path), slog.Any("error", err))
+		default:
+			logger.Info("backup created", slog.String("path", created.Path), slog.Duration("took", time.Since(started)))
+		}
+		timer.Reset(interval)
+	}
+}
+
+func firstBackupDelay(logger *slog.Logger, manager *backup.Manager, interval time.Duration, now time.Time) time.Duration {
+	backups, err := manager.List()
+	if err != nil {
+		logger.Warn("list backups", slog.Any("error", err))
+		return 0
+	}
+	if len(backups) == 0 {
+		return 0
+	}
+	return max(0, backups[0].CreatedAt.Add(interval).Sub(now))
+}
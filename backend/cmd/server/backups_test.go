@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/backup"
+)
+
+func TestFirstBackupDelay(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "barnlog.sqlite3"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	manager := backup.NewManager(db, backup.Options{Dir: t.TempDir(), FileDir: t.TempDir()})
+
+	if delay := firstBackupDelay(logger, manager, time.Hour, time.Now()); delay != 0 {
+		t.Fatalf("expected an immediate first backup, got %s", delay)
+	}
+
+	created, err := manager.Create(context.Background())
+	if err != nil {
+		t.Fatalf("create backup: %v", err)
+	}
+	now := created.CreatedAt.Add(20 * time.Minute)
+	if delay := firstBackupDelay(logger, manager, time.Hour, now); delay != 40*time.Minute {
+		t.Fatalf("expected the next backup an interval after the last one, got %s", delay)
+	}
+	if delay := firstBackupDelay(logger, manager, time.Hour, now.Add(2*time.Hour)); delay != 0 {
+		t.Fatalf("expected an overdue backup to run immediately, got %s", delay)
+	}
+}
@@ -3,27 +3,29 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
-	"net/url"
 	"os"
 	"os/signal"
-	"path/filepath"
+	"slices"
 	"syscall"
 	"time"
 
 	"barnlog/backend/internal/adapters/httpapi"
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/backup"
 	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/metrics"
+	"barnlog/backend/internal/infrastructure/migrations"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+	"barnlog/backend/internal/infrastructure/tracing"
+	"barnlog/backend/internal/ports"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	gomigrate "github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "modernc.org/sqlite"
 )
 
 func main() {
@@ -43,14 +45,29 @@ func run() error {
 	}
 
 	logger := newLogger(cfg)
-	if cfg.AutoMigrate {
-		if err := runMigrations(logger, cfg); err != nil {
-			return err
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Exporter:     cfg.TraceExporter,
+		OTLPEndpoint: cfg.TraceOTLPEndpoint,
+		Environment:  cfg.Env,
+	})
+	if err != nil {
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		if flushErr := shutdownTracing(flushCtx); flushErr != nil {
+			logger.Warn("flush traces", slog.Any("error", flushErr))
 		}
-	} else {
-		logger.Info("auto migration disabled")
+	}()
+
+	if err := runMigrations(logger, cfg); err != nil {
+		return err
 	}
-	db, err := openSQLiteDB(cfg)
+	if err := moveLegacyUploads(logger, cfg.FileDir); err != nil {
+		return err
+	}
+	db, err := openSQLiteDB(ctx, cfg)
 	if err != nil {
 		return err
 	}
@@ -60,27 +77,138 @@ func run() error {
 		}
 	}()
 
-	services := newServices(cfg, db)
-	srv := newHTTPServer(cfg, buildRouter(cfg, logger, services))
+	schemaVersion, err := migrations.Latest(cfg.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	services := newServices(cfg, logger, db, schemaVersion)
+	// Deferred after db.Close, so it runs first.
+	defer services.Close()
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	dispatchDone := make(chan struct{})
+	go func() {
+		defer close(dispatchDone)
+		runWebhookDispatcher(jobsCtx, logger, services.WebhookDispatcher, cfg.WebhookPollInterval)
+	}()
+	defer func() {
+		stopJobs()
+		<-dispatchDone
+	}()
+
+	if cfg.BackupInterval > 0 {
+		backups := backup.NewManager(db.Read, backup.Options{Dir: cfg.BackupDir, FileDir: cfg.FileDir, Keep: cfg.BackupKeep})
+		backupsDone := make(chan struct{})
+		go func() {
+			defer close(backupsDone)
+			runBackups(jobsCtx, logger, backups, cfg.BackupInterval)
+		}()
+		defer func() {
+			stopJobs()
+			<-backupsDone
+		}()
+	} else {
+		logger.Info("scheduled backups disabled")
+	}
+
+	if cfg.ReplicationTarget != "" {
+		target, closeTarget, err := openReplicationTarget(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("open replication target: %w", err)
+		}
+		replicator := application.NewReplicator(sqliteinfra.NewReplicationStore(db.Read, db.Write), target, 0)
+		replicationDone := make(chan struct{})
+		go func() {
+			defer close(replicationDone)
+			runReplicator(jobsCtx, logger, replicator, cfg.ReplicationInterval)
+		}()
+		defer func() {
+			stopJobs()
+			<-replicationDone
+			if closeErr := closeTarget(); closeErr != nil {
+				logger.Warn("close replication target", slog.Any("error", closeErr))
+			}
+		}()
+	}
+
+	role, err := services.NodeRoles.Role(ctx)
+	if err != nil {
+		return err
+	}
+	if role == ports.NodeRoleFollower {
+		logger.Warn("node is a read-only replication follower; promote it with barnlog replication promote")
+	}
+
+	tlsConfig, err := newTLSConfig(cfg, logger, time.Now())
+	if err != nil {
+		return fmt.Errorf("set up TLS: %w", err)
+	}
+	if cfg.TLSMode == config.TLSModeSelfSigned {
+		renewDone := make(chan struct{})
+		go func() {
+			defer close(renewDone)
+			renewSelfSigned(jobsCtx, logger, cfg, selfSignedRenewInterval)
+		}()
+		defer func() {
+			stopJobs()
+			<-renewDone
+		}()
+	}
+	streamsDone := make(chan struct{})
+	srv := newHTTPServer(cfg, buildRouter(cfg, logger, services, streamsDone), tlsConfig)
+	srv.RegisterOnShutdown(func() { close(streamsDone) })
 
 	logger.Info(
 		"http server starting",
 		slog.String("addr", cfg.HTTPAddr),
 		slog.String("env", cfg.Env),
 		slog.Any("log_level", cfg.LogLevel),
+		slog.String("trace_exporter", cfg.TraceExporter),
+		slog.String("tls_mode", cfg.TLSMode),
 	)
 
-	errCh := make(chan error, 1)
+	errCh := make(chan error, 3)
 	go func() {
-		if serveErr := srv.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+		if serveErr := listenAndServe(srv); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
 			errCh <- serveErr
 		}
 	}()
 
+	var redirectSrv *http.Server
+	if cfg.TLSRedirectAddr != "" {
+		redirectSrv = newRedirectServer(cfg)
+		logger.Info("redirecting http to https", slog.String("addr", cfg.TLSRedirectAddr))
+		go func() {
+			if serveErr := redirectSrv.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("https redirect: %w", serveErr)
+			}
+		}()
+	}
+
+	var metricsSrv *http.Server
+	if cfg.MetricsAddr != "" && services.Metrics != nil {
+		metricsSrv = newMetricsServer(cfg, services.Metrics)
+		logger.Info("serving metrics", slog.String("addr", cfg.MetricsAddr))
+		go func() {
+			if serveErr := metricsSrv.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("metrics: %w", serveErr)
+			}
+		}()
+	}
+
 	if err := waitForShutdownSignalOrServerError(ctx, errCh); err != nil {
 		return err
 	}
 
+	if redirectSrv != nil {
+		if err := shutdownServer(cfg, redirectSrv); err != nil {
+			return err
+		}
+	}
+	if metricsSrv != nil {
+		if err := shutdownServer(cfg, metricsSrv); err != nil {
+			return err
+		}
+	}
 	if err := shutdownServer(cfg, srv); err != nil {
 		return err
 	}
@@ -89,42 +217,103 @@ func run() error {
 	return nil
 }
 
-func buildRouter(cfg config.Config, logger *slog.Logger, services Services) http.Handler {
+// buildRouter assembles the HTTP handler. Closing shutdown ends open event streams.
+func buildRouter(
+	cfg config.Config,
+	logger *slog.Logger,
+	services Services,
+	shutdown <-chan struct{},
+) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(httpapi.AccessLog(logger))
+	if services.Metrics != nil {
+		r.Use(recordHTTPMetrics(services.Metrics))
+	}
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(30 * time.Second))
+	r.Use(httpapi.SecurityHeaders(cfg.HSTSMaxAge))
+	r.Use(httpapi.CORS(httpapi.CORSConfig{
+		AllowedOrigins:   cfg.CORSAllowedOrigins,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	}))
+	r.Use(timeoutExcept(
+		30*time.Second,
+		httpapi.EventStreamPath,
+		httpapi.EventExportPath,
+		httpapi.EventImportPath,
+	))
 	r.Get("/swagger/openapi.json", httpapi.OpenAPIDoc)
 	r.Mount("/", httpapi.Routes(httpapi.RouteDeps{
-		Logger:       logger,
-		FileStoreDir: cfg.FileDir,
-		AnimalWriter: services.AnimalWriter,
+		Logger:         logger,
+		FileStoreDir:   cfg.FileDir,
+		AnimalWriter:   services.AnimalWriter,
+		AnimalReader:   services.AnimalReader,
+		EventCorrector: services.EventCorrector,
+		EventFeed:      services.EventFeed,
+		EventArchive:   services.EventArchive,
+		WebhookManager: services.WebhookManager,
+		Authenticator:  services.Authenticator,
+		APITokens:      services.APITokens,
+		Devices:        services.Devices,
+		SingleSignOn:   services.SingleSignOn,
+		NodeRoles:      services.NodeRoles,
+		Readiness:      services.Readiness,
+		UploadMetrics:  uploadMetrics(services.Metrics),
+		Shutdown:       shutdown,
+		RateLimit: httpapi.RateLimitConfig{
+			RequestsPerMinute:     cfg.RateLimitPerMinute,
+			Burst:                 cfg.RateLimitBurst,
+			UploadBytesPerHour:    cfg.UploadBytesPerHour,
+			AuthFailuresPerMinute: cfg.AuthFailuresPerMinute,
+		},
 	}))
 	return r
 }
 
-func openSQLiteDB(cfg config.Config) (*sql.DB, error) {
-	dbPath, err := filepath.Abs(cfg.DBPath)
-	if err != nil {
-		return nil, fmt.Errorf("resolve db path: %w", err)
+// uploadMetrics keeps a nil *metrics.Metrics from becoming a non-nil interface.
+func uploadMetrics(m *metrics.Metrics) httpapi.UploadMetrics {
+	if m == nil {
+		return nil
 	}
+	return m
+}
 
-	db, err := sql.Open("sqlite", dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("open sqlite: %w", err)
+// timeoutExcept applies middleware.Timeout to every request except the given
+// long-lived streaming paths, which bound their own writes instead.
+func timeoutExcept(timeout time.Duration, paths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		timed := middleware.Timeout(timeout)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(paths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timed.ServeHTTP(w, r)
+		})
 	}
-	if err := db.Ping(); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("ping sqlite: %w", err)
+}
+
+func openSQLiteDB(ctx context.Context, cfg config.Config) (*sqliteinfra.DB, error) {
+	return sqliteinfra.Open(ctx, cfg.DBPath, sqliteOptions(cfg))
+}
+
+func sqliteOptions(cfg config.Config) sqliteinfra.Options {
+	return sqliteinfra.Options{
+		BusyTimeout:  cfg.DBBusyTimeout,
+		JournalMode:  cfg.DBJournalMode,
+		Synchronous:  cfg.DBSynchronous,
+		MaxReadConns: cfg.DBMaxReadConns,
 	}
-	return db, nil
 }
 
-func newHTTPServer(cfg config.Config, handler http.Handler) *http.Server {
+// newHTTPServer serves handler on cfg.HTTPAddr, over TLS when tlsConfig is set.
+func newHTTPServer(cfg config.Config, handler http.Handler, tlsConfig *tls.Config) *http.Server {
 	return &http.Server{
 		Addr:              cfg.HTTPAddr,
 		Handler:           handler,
+		TLSConfig:         tlsConfig,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       15 * time.Second,
 		WriteTimeout:      30 * time.Second,
@@ -153,51 +342,38 @@ func shutdownServer(cfg config.Config, srv *http.Server) error {
 
 func newLogger(cfg config.Config) *slog.Logger {
 	handlerOpts := &slog.HandlerOptions{Level: cfg.LogLevel}
-	if cfg.Env == "local" || cfg.Env == "dev" {
+	if cfg.Development() {
 		return slog.New(slog.NewTextHandler(os.Stdout, handlerOpts))
 	}
 	return slog.New(slog.NewJSONHandler(os.Stdout, handlerOpts))
 }
 
+// runMigrations applies pending migrations when auto migration is enabled.
+// Either way it refuses a database migrated by a newer build.
 func runMigrations(logger *slog.Logger, cfg config.Config) error {
-	dbPath, err := filepath.Abs(cfg.DBPath)
-	if err != nil {
-		return fmt.Errorf("resolve db path: %w", err)
-	}
-	migrationsPath, err := filepath.Abs(cfg.MigrationsPath)
+	m, err := migrations.Open(cfg.DBPath, cfg.MigrationsPath)
 	if err != nil {
-		return fmt.Errorf("resolve migrations path: %w", err)
-	}
-
-	if err := os.MkdirAll(filepath.Dir(dbPath), 0o750); err != nil {
-		return fmt.Errorf("create db directory: %w", err)
-	}
-
-	dbURL := (&url.URL{Scheme: "sqlite", Path: dbPath}).String()
-	srcURL := (&url.URL{Scheme: "file", Path: migrationsPath}).String()
-
-	logger.Info("running migrations", slog.String("database", dbURL), slog.String("source", srcURL))
-
-	m, err := gomigrate.New(srcURL, dbURL)
-	if err != nil {
-		return fmt.Errorf("initialize migrate: %w", err)
+		return err
 	}
 	defer func() {
-		srcErr, dbErr := m.Close()
-		if srcErr != nil {
-			logger.Warn("close migration source", slog.Any("error", srcErr))
-		}
-		if dbErr != nil {
-			logger.Warn("close migration db", slog.Any("error", dbErr))
+		if closeErr := m.Close(); closeErr != nil {
+			logger.Warn("close migrations", slog.Any("error", closeErr))
 		}
 	}()
 
+	if !cfg.AutoMigrate {
+		logger.Info("auto migration disabled")
+		return m.CheckVersion()
+	}
+
+	logger.Info("running migrations", slog.String("database", m.DatabaseURL()), slog.String("source", m.Source()))
+
 	if err := m.Up(); err != nil {
-		if errors.Is(err, gomigrate.ErrNoChange) {
+		if errors.Is(err, migrations.ErrNoChange) {
 			logger.Info("no pending migrations")
 			return nil
 		}
-		return fmt.Errorf("run migrations: %w", err)
+		return err
 	}
 
 	logger.Info("migrations applied")
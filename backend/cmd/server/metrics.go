@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"barnlog/backend/internal/adapters/httpapi"
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/metrics"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// MetricsPath serves the Prometheus text exposition.
+const MetricsPath = "/metrics"
+
+// newMetricsServer serves only MetricsPath on cfg.MetricsAddr. Metrics are
+// not authenticated, so they stay off the API listener; bind MetricsAddr to
+// an address only the scraper can reach.
+func newMetricsServer(cfg config.Config, m *metrics.Metrics) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+MetricsPath, m.Handler())
+	return &http.Server{
+		Addr:              cfg.MetricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+}
+
+// recordHTTPMetrics counts each request and its latency under the chi route
+// pattern that served it.
+func recordHTTPMetrics(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				m.ObserveHTTPRequest(r.Method, httpapi.RoutePattern(r), status, time.Since(start))
+			}()
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
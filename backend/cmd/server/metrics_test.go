@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/metrics"
+)
+
+func TestBuildRouterRecordsRouteMetrics(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.NewMetrics()
+	router := buildRouter(
+		config.Config{FileDir: t.TempDir()},
+		testLogger(),
+		Services{
+			AnimalWriter:   noopAnimalWriter{},
+			AnimalReader:   noopAnimalReader{},
+			EventCorrector: noopEventCorrector{},
+			EventFeed:      noopEventFeed{},
+			EventArchive:   noopEventArchive{},
+			WebhookManager: noopWebhookManager{},
+			Authenticator:  allowAllAuthenticator{},
+			APITokens:      noopAPITokenManager{},
+			Devices:        noopDeviceManager{},
+			Metrics:        m,
+		},
+		nil,
+	)
+	for _, path := range []string{"/animals/a1", "/animals/a2", "/healthz", "/no-such-path/123"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the API listener not to serve metrics, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	newMetricsServer(config.Config{MetricsAddr: "127.0.0.1:0"}, m).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != metrics.ContentType {
+		t.Fatalf("expected content-type %q, got %q", metrics.ContentType, got)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`barnlog_http_requests_total{method="GET",route="/animals/{animalId}",status="200"} 2`,
+		`barnlog_http_requests_total{method="GET",route="/healthz",status="200"} 1`,
+		`barnlog_http_requests_total{method="GET",route="unmatched",status="404"} 2`,
+		`barnlog_http_request_duration_seconds_count{method="GET",route="/animals/{animalId}"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %s in metrics:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "/animals/a1") {
+		t.Fatalf("expected raw paths to stay out of the route label:\n%s", body)
+	}
+}
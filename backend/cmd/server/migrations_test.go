@@ -0,0 +1,38 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/migrations"
+)
+
+func TestRunMigrationsRefusesNewerDatabase(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := config.Config{DBPath: filepath.Join(t.TempDir(), "barnlog.sqlite3"), AutoMigrate: true}
+
+	if err := runMigrations(logger, cfg); err != nil {
+		t.Fatalf("migrate new database: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", cfg.DBPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if _, err := db.Exec(`UPDATE schema_migrations SET version = 999`); err != nil {
+		t.Fatalf("simulate newer database: %v", err)
+	}
+
+	for _, autoMigrate := range []bool{true, false} {
+		cfg.AutoMigrate = autoMigrate
+		if err := runMigrations(logger, cfg); !errors.Is(err, migrations.ErrDatabaseNewer) {
+			t.Fatalf("AutoMigrate=%t: expected ErrDatabaseNewer, got %v", autoMigrate, err)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/domain"
 	"barnlog/backend/internal/infrastructure/config"
 )
 
@@ -19,7 +20,18 @@ func TestBuildRouterServesOpenAPIDoc(t *testing.T) {
 	router := buildRouter(
 		config.Config{FileDir: t.TempDir()},
 		testLogger(),
-		Services{AnimalWriter: noopAnimalWriter{}},
+		Services{
+			AnimalWriter:   noopAnimalWriter{},
+			AnimalReader:   noopAnimalReader{},
+			EventCorrector: noopEventCorrector{},
+			EventFeed:      noopEventFeed{},
+			EventArchive:   noopEventArchive{},
+			WebhookManager: noopWebhookManager{},
+			Authenticator:  allowAllAuthenticator{},
+			APITokens:      noopAPITokenManager{},
+			Devices:        noopDeviceManager{},
+		},
+		nil,
 	)
 	request := httptest.NewRequest(http.MethodGet, "/swagger/openapi.json", nil)
 	recorder := httptest.NewRecorder()
@@ -51,3 +63,124 @@ type noopAnimalWriter struct{}
 func (noopAnimalWriter) Create(context.Context, application.CreateAnimalInput) (application.CreateAnimalOutput, error) {
 	return application.CreateAnimalOutput{}, nil
 }
+
+type noopAnimalReader struct{}
+
+func (noopAnimalReader) Get(context.Context, string) (application.GetAnimalOutput, error) {
+	return application.GetAnimalOutput{}, nil
+}
+
+func (noopAnimalReader) Timeline(context.Context, string) (application.AnimalTimelineOutput, error) {
+	return application.AnimalTimelineOutput{}, nil
+}
+
+type noopEventCorrector struct{}
+
+func (noopEventCorrector) CorrectAnimalEvent(
+	context.Context,
+	application.CorrectAnimalEventInput,
+) (application.EventAmendmentOutput, error) {
+	return application.EventAmendmentOutput{}, nil
+}
+
+func (noopEventCorrector) VoidAnimalEvent(
+	context.Context,
+	application.VoidAnimalEventInput,
+) (application.EventAmendmentOutput, error) {
+	return application.EventAmendmentOutput{}, nil
+}
+
+type noopEventFeed struct{}
+
+func (noopEventFeed) ListAfter(context.Context, application.EventFeedQuery) ([]application.FeedEvent, error) {
+	return nil, nil
+}
+
+func (noopEventFeed) LatestPosition(context.Context) (int64, error) {
+	return 0, nil
+}
+
+type noopEventArchive struct{}
+
+func (noopEventArchive) Export(context.Context, io.Writer) error {
+	return nil
+}
+
+func (noopEventArchive) Import(context.Context, io.Reader) (application.ImportEventsOutput, error) {
+	return application.ImportEventsOutput{}, nil
+}
+
+type noopWebhookManager struct{}
+
+func (noopWebhookManager) CreateWebhook(context.Context, application.CreateWebhookInput) (application.WebhookOutput, error) {
+	return application.WebhookOutput{}, nil
+}
+
+func (noopWebhookManager) GetWebhook(context.Context, string) (application.WebhookOutput, error) {
+	return application.WebhookOutput{}, nil
+}
+
+func (noopWebhookManager) ListWebhooks(context.Context) ([]application.WebhookOutput, error) {
+	return nil, nil
+}
+
+func (noopWebhookManager) UpdateWebhook(context.Context, application.UpdateWebhookInput) (application.WebhookOutput, error) {
+	return application.WebhookOutput{}, nil
+}
+
+func (noopWebhookManager) DeleteWebhook(context.Context, string) error {
+	return nil
+}
+
+// allowAllAuthenticator treats every request as signed in, with or without a cookie.
+type allowAllAuthenticator struct{}
+
+func (allowAllAuthenticator) Login(context.Context, application.LoginInput) (application.SessionOutput, error) {
+	return application.SessionOutput{}, nil
+}
+
+func (allowAllAuthenticator) Authenticate(context.Context, string) (application.Principal, error) {
+	return application.Principal{UserID: "u1", Username: "test", Memberships: domain.Memberships{domain.ServerBarnID: domain.RoleOwner}}, nil
+}
+
+func (allowAllAuthenticator) AuthenticateToken(context.Context, string) (application.Principal, error) {
+	return application.Principal{UserID: "u1", Username: "test", Memberships: domain.Memberships{domain.ServerBarnID: domain.RoleOwner}, TokenID: "t1"}, nil
+}
+
+func (allowAllAuthenticator) Logout(context.Context, string) error {
+	return nil
+}
+
+type noopAPITokenManager struct{}
+
+func (noopAPITokenManager) CreateToken(
+	context.Context,
+	application.CreateAPITokenInput,
+) (application.CreatedAPITokenOutput, error) {
+	return application.CreatedAPITokenOutput{}, nil
+}
+
+func (noopAPITokenManager) ListTokens(context.Context, string) ([]application.APITokenOutput, error) {
+	return nil, nil
+}
+
+func (noopAPITokenManager) RevokeToken(context.Context, string, string) error {
+	return nil
+}
+
+type noopDeviceManager struct{}
+
+func (noopDeviceManager) RegisterDevice(
+	context.Context,
+	application.RegisterDeviceInput,
+) (application.RegisteredDeviceOutput, error) {
+	return application.RegisteredDeviceOutput{}, nil
+}
+
+func (noopDeviceManager) ListDevices(context.Context, string) ([]application.DeviceOutput, error) {
+	return nil, nil
+}
+
+func (noopDeviceManager) RevokeDevice(context.Context, string, string) error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/migrations"
+	"barnlog/backend/internal/infrastructure/replication"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+	"barnlog/backend/internal/ports"
+)
+
+const replicationRequestTimeout = time.Minute
+
+// openReplicationTarget opens cfg.ReplicationTarget. A file target is migrated
+// and marked as a follower so a server started on it later refuses writes
+// until it is promoted. The returned close function releases the file.
+func openReplicationTarget(
+	ctx context.Context,
+	cfg config.Config,
+) (ports.ReplicationTarget, func() error, error) {
+	path, ok := strings.CutPrefix(cfg.ReplicationTarget, config.ReplicationFilePrefix)
+	if !ok {
+		return replication.NewPeerTarget(cfg.ReplicationTarget, cfg.ReplicationToken, replicationRequestTimeout), func() error { return nil }, nil
+	}
+
+	if err := migrateReplica(path, cfg.MigrationsPath); err != nil {
+		return nil, nil, err
+	}
+	db, err := sqliteinfra.Open(ctx, path, sqliteOptions(cfg))
+	if err != nil {
+		return nil, nil, fmt.Errorf("open replica: %w", err)
+	}
+	if err := sqliteinfra.NewNodeRoleStore(db.Read, db.Write).SetNodeRole(ctx, ports.NodeRoleFollower, time.Now()); err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("mark replica as follower: %w", err)
+	}
+	return sqliteinfra.NewReplicationTarget(cfg.ReplicationTarget, db.Read, db.Write), db.Close, nil
+}
+
+func migrateReplica(path, migrationsPath string) error {
+	m, err := migrations.Open(path, migrationsPath)
+	if err != nil {
+		return fmt.Errorf("open replica migrations: %w", err)
+	}
+	defer func() { _ = m.Close() }()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrations.ErrNoChange) {
+		return fmt.Errorf("migrate replica: %w", err)
+	}
+	return nil
+}
+
+// runReplicator ships new events to the replication target every interval
+// until ctx is cancelled. Errors are logged and the pass is retried on the next tick.
+func runReplicator(
+	ctx context.Context,
+	logger *slog.Logger,
+	replicator *application.Replicator,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := replicator.ReplicateOnce(ctx)
+		switch {
+		case err != nil && !errors.Is(err, context.Canceled):
+			logger.Error(
+				"replication failed",
+				slog.String("target", replicator.Target()),
+				slog.Int64("position", result.Position),
+				slog.Any("error", err),
+			)
+		case result.Shipped > 0:
+			logger.Info(
+				"replication",
+				slog.String("target", replicator.Target()),
+				slog.Int("shipped", result.Shipped),
+				slog.Int("imported", result.Imported),
+				slog.Int("skipped", result.Skipped),
+				slog.Int64("position", result.Position),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/infrastructure/config"
+	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+	"barnlog/backend/internal/ports"
+)
+
+func TestReplicationToFileTarget(t *testing.T) {
+	ctx := application.WithPrincipal(context.Background(), application.ServicePrincipal("test"))
+	dir := t.TempDir()
+	cfg := config.Config{
+		DBPath:            filepath.Join(dir, "primary.sqlite3"),
+		FileDir:           filepath.Join(dir, "files"),
+		AutoMigrate:       true,
+		ReplicationTarget: config.ReplicationFilePrefix + filepath.Join(dir, "replica.sqlite3"),
+	}
+	if err := runMigrations(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg); err != nil {
+		t.Fatalf("migrate primary: %v", err)
+	}
+	primary, err := openSQLiteDB(ctx, cfg)
+	if err != nil {
+		t.Fatalf("open primary: %v", err)
+	}
+	t.Cleanup(func() { _ = primary.Close() })
+
+	services := newServices(cfg, testLogger(), primary, 0)
+	t.Cleanup(services.Close)
+	for _, requestID := range []string{"req-1", "req-2"} {
+		if _, err := services.AnimalWriter.Create(ctx, application.CreateAnimalInput{
+			Name:    "Nanny",
+			Species: "goat",
+			Meta:    application.RequestMeta{Source: "test", RequestID: requestID, Actor: "test"},
+		}); err != nil {
+			t.Fatalf("create animal: %v", err)
+		}
+	}
+
+	target, closeTarget, err := openReplicationTarget(ctx, cfg)
+	if err != nil {
+		t.Fatalf("open replication target: %v", err)
+	}
+	replicator := application.NewReplicator(sqliteinfra.NewReplicationStore(primary.Read, primary.Write), target, 0)
+	result, err := replicator.ReplicateOnce(ctx)
+	if err != nil {
+		t.Fatalf("replicate: %v", err)
+	}
+	if result.Shipped != 2 || result.Imported != 2 {
+		t.Fatalf("expected 2 events shipped and imported, got %+v", result)
+	}
+	if err := closeTarget(); err != nil {
+		t.Fatalf("close target: %v", err)
+	}
+
+	replicaCfg := cfg
+	replicaCfg.DBPath = filepath.Join(dir, "replica.sqlite3")
+	replica, err := openSQLiteDB(ctx, replicaCfg)
+	if err != nil {
+		t.Fatalf("open replica: %v", err)
+	}
+	t.Cleanup(func() { _ = replica.Close() })
+
+	events, err := sqliteinfra.NewReplicationStore(replica.Read, replica.Write).ListEventsSince(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list replica events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 replicated events, got %d", len(events))
+	}
+	replicaServices := newServices(replicaCfg, testLogger(), replica, 0)
+	t.Cleanup(replicaServices.Close)
+	role, err := replicaServices.NodeRoles.Role(ctx)
+	if err != nil {
+		t.Fatalf("replica role: %v", err)
+	}
+	if role != ports.NodeRoleFollower {
+		t.Fatalf("expected replica to be a follower, got %q", role)
+	}
+}
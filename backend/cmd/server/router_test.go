@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/adapters/httpapi"
+	"barnlog/backend/internal/infrastructure/config"
+)
+
+func TestTimeoutExceptSkipsStreamingPaths(t *testing.T) {
+	t.Parallel()
+
+	handler := timeoutExcept(time.Minute, httpapi.EventStreamPath)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Deadline(); ok {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	for path, want := range map[string]int{
+		httpapi.EventStreamPath: http.StatusOK,
+		"/animals":              http.StatusAccepted,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Fatalf("%s: expected status %d, got %d", path, want, rec.Code)
+		}
+	}
+}
+
+func TestBuildRouterSecurityHeadersPerEnv(t *testing.T) {
+	const devServer = "http://localhost:5173"
+	tests := []struct {
+		env         string
+		allowOrigin string
+		hsts        bool
+	}{
+		{env: "dev", allowOrigin: devServer},
+		{env: "test"},
+		{env: "prod", hsts: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.env, func(t *testing.T) {
+			t.Setenv("BARNLOG_ENV", tc.env)
+			t.Setenv("BARNLOG_CORS_ALLOWED_ORIGINS", "")
+			t.Setenv("BARNLOG_HSTS_MAX_AGE", "")
+			cfg, err := config.LoadFromEnv()
+			if err != nil {
+				t.Fatalf("LoadFromEnv() error = %v", err)
+			}
+			cfg.FileDir = t.TempDir()
+			router := buildRouter(cfg, testLogger(), Services{
+				AnimalWriter:   noopAnimalWriter{},
+				AnimalReader:   noopAnimalReader{},
+				EventCorrector: noopEventCorrector{},
+				EventFeed:      noopEventFeed{},
+				EventArchive:   noopEventArchive{},
+				WebhookManager: noopWebhookManager{},
+				Authenticator:  allowAllAuthenticator{},
+				APITokens:      noopAPITokenManager{},
+				Devices:        noopDeviceManager{},
+			}, nil)
+
+			preflight := httptest.NewRequest(http.MethodOptions, "/animals", nil)
+			preflight.Header.Set("Origin", devServer)
+			preflight.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, preflight)
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tc.allowOrigin {
+				t.Fatalf("expected Access-Control-Allow-Origin %q, got %q", tc.allowOrigin, got)
+			}
+			if tc.allowOrigin != "" && rec.Code != http.StatusNoContent {
+				t.Fatalf("expected preflight status %d, got %d", http.StatusNoContent, rec.Code)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+			req.TLS = &tls.ConnectionState{}
+			rec = httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Header().Get("X-Content-Type-Options") != "nosniff" || rec.Header().Get("Content-Security-Policy") == "" {
+				t.Fatalf("expected security headers, got %v", rec.Header())
+			}
+			if got := rec.Header().Get("Strict-Transport-Security"); (got != "") != tc.hsts {
+				t.Fatalf("expected HSTS=%t, got %q", tc.hsts, got)
+			}
+		})
+	}
+}
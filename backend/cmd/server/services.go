@@ -1,21 +1,120 @@
 package main
 
 import (
-	"database/sql"
+	"log/slog"
+	"time"
 
 	"barnlog/backend/internal/application"
 	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/metrics"
+	"barnlog/backend/internal/infrastructure/oidc"
+	"barnlog/backend/internal/infrastructure/passwords"
 	sqliteinfra "barnlog/backend/internal/infrastructure/sqlite"
+	"barnlog/backend/internal/infrastructure/webhook"
+	"barnlog/backend/internal/ports"
 )
 
 // Services groups application services wired at process startup.
 type Services struct {
-	AnimalWriter application.AnimalWriter
+	AnimalWriter   application.AnimalWriter
+	AnimalReader   application.AnimalReader
+	EventCorrector application.EventCorrector
+	EventFeed      application.EventFeed
+	EventArchive   application.EventArchive
+	WebhookManager application.WebhookManager
+	Authenticator  application.Authenticator
+	APITokens      application.APITokenManager
+	Devices        application.DeviceManager
+	// SingleSignOn is nil unless BARNLOG_OIDC_ISSUER is set.
+	SingleSignOn application.SingleSignOn
+	NodeRoles    application.NodeRoles
+	Readiness    application.ReadinessChecker
+	// Metrics backs /metrics and is shared by the services that count events.
+	Metrics *metrics.Metrics
+	// WebhookDispatcher runs in the background; see runWebhookDispatcher.
+	WebhookDispatcher *application.WebhookDispatcher
+
+	// animalReads saves snapshots in the background until Close.
+	animalReads sqliteinfra.AnimalReadStore
+}
+
+// Close stops the background work of the services. Call it once the HTTP
+// server has stopped and before the database is closed.
+func (s Services) Close() {
+	if s.animalReads != nil {
+		s.animalReads.Close()
+	}
 }
 
-func newServices(cfg config.Config, db *sql.DB) Services {
-	store := sqliteinfra.NewAnimalWriteStore(db, cfg.FileDir)
+// newServices wires the application services. schemaVersion is the latest
+// migration of this build, which readiness expects the database to be at.
+func newServices(cfg config.Config, logger *slog.Logger, db *sqliteinfra.DB, schemaVersion uint) Services {
+	store := sqliteinfra.NewAnimalWriteStore(db.Write, cfg.FileDir)
+	webhooks := sqliteinfra.NewWebhookStore(db.Write)
+	apiTokens := sqliteinfra.NewAPITokenStore(db.Read, db.Write)
+	devices := sqliteinfra.NewDeviceStore(db.Read, db.Write)
+	sessions := sqliteinfra.NewSessionStore(db.Read, db.Write)
+	animalReads := sqliteinfra.NewAnimalReadStore(db.Read, db.Write, cfg.SnapshotEvery, func(err error) {
+		logger.Warn("save animal snapshot", slog.Any("error", err))
+	})
+	m := metrics.NewMetrics()
 	return Services{
-		AnimalWriter: application.NewCreateAnimalWriter(store),
+		AnimalWriter:   application.NewCreateAnimalWriter(store, m),
+		AnimalReader:   application.NewAnimalReader(animalReads),
+		EventCorrector: application.NewEventCorrector(sqliteinfra.NewEventCorrectionStore(db.Write), store, m),
+		EventFeed:      application.NewEventFeed(sqliteinfra.NewEventFeedStore(db.Read)),
+		EventArchive:   application.NewEventArchive(sqliteinfra.NewEventArchiveStore(db.Read, db.Write)),
+		WebhookManager: application.NewWebhookManager(webhooks),
+		Authenticator: application.NewAuthenticator(
+			sqliteinfra.NewUserStore(db.Read, db.Write),
+			sessions,
+			apiTokens,
+			devices,
+			passwords.NewArgon2id(passwords.DefaultParams),
+			application.AuthenticatorConfig{SessionTTL: cfg.SessionTTL},
+		),
+		APITokens:    application.NewAPITokenManager(apiTokens),
+		Devices:      application.NewDeviceManager(devices),
+		SingleSignOn: newSingleSignOn(cfg, db, sessions),
+		NodeRoles:    application.NewNodeRoles(sqliteinfra.NewNodeRoleStore(db.Read, db.Write)),
+		Readiness: application.NewReadinessChecker(sqliteinfra.NewReadinessStore(db.Read), application.ReadinessConfig{
+			SchemaVersion:     schemaVersion,
+			ReplicationTarget: cfg.ReplicationTarget,
+		}),
+		Metrics: m,
+		WebhookDispatcher: application.NewWebhookDispatcher(
+			sqliteinfra.NewWebhookOutboxStore(db.Write),
+			webhooks,
+			webhook.NewSender(cfg.WebhookTimeout),
+			application.WebhookDispatcherConfig{MaxAttempts: cfg.WebhookMaxAttempts},
+		),
+		animalReads: animalReads,
+	}
+}
+
+// oidcTimeout bounds each request to the OpenID Connect provider.
+const oidcTimeout = 10 * time.Second
+
+// newSingleSignOn wires OpenID Connect login, or returns nil when it is not configured.
+func newSingleSignOn(cfg config.Config, db *sqliteinfra.DB, sessions ports.SessionStore) application.SingleSignOn {
+	if cfg.OIDCIssuer == "" {
+		return nil
 	}
+	return application.NewSingleSignOn(
+		sqliteinfra.NewUserStore(db.Read, db.Write),
+		sessions,
+		oidc.NewProvider(oidc.Options{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+			Timeout:      oidcTimeout,
+		}),
+		application.SingleSignOnConfig{
+			UsernameClaim: cfg.OIDCUsernameClaim,
+			RolesClaim:    cfg.OIDCRolesClaim,
+			SessionTTL:    cfg.SessionTTL,
+		},
+	)
 }
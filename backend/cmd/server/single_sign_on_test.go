@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"barnlog/backend/internal/adapters/httpapi"
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/oidc/oidctest"
+)
+
+func TestSingleSignOnAgainstFakeProvider(t *testing.T) {
+	idp := oidctest.NewProvider(t, "barnlog", "client-secret")
+	idp.SetClaims(map[string]any{
+		"sub":                "subject-anna",
+		"preferred_username": "anna",
+		"barnlog_roles":      []string{"north:worker", "server:viewer"},
+	})
+
+	dir := t.TempDir()
+	cfg := config.Config{
+		DBPath:            filepath.Join(dir, "barnlog.sqlite3"),
+		FileDir:           filepath.Join(dir, "files"),
+		AutoMigrate:       true,
+		OIDCIssuer:        idp.Issuer,
+		OIDCClientID:      idp.ClientID,
+		OIDCClientSecret:  idp.ClientSecret,
+		OIDCRedirectURL:   "http://barnlog.test" + httpapi.AuthOIDCCallbackPath,
+		OIDCScopes:        []string{"openid", "profile"},
+		OIDCUsernameClaim: "preferred_username",
+		OIDCRolesClaim:    "barnlog_roles",
+	}
+	if err := runMigrations(testLogger(), cfg); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	db, err := openSQLiteDB(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	services := newServices(cfg, testLogger(), db, 0)
+	t.Cleanup(services.Close)
+	router := buildRouter(cfg, testLogger(), services, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, httpapi.AuthOIDCLoginPath, nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("expected a redirect to the provider, got %d: %s", rec.Code, rec.Body)
+	}
+	callback := idp.Authorize(t, rec.Header().Get("Location"))
+
+	req := httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	for _, cookie := range rec.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("expected a redirect after the callback, got %d: %s", rec.Code, rec.Body)
+	}
+	var session *http.Cookie
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "barnlog_session" && cookie.Value != "" {
+			session = cookie
+		}
+	}
+	if session == nil {
+		t.Fatal("expected a session cookie")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/auth/session", nil)
+	req.AddCookie(session)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the session to be valid, got %d: %s", rec.Code, rec.Body)
+	}
+	var payload struct {
+		Username    string `json:"username"`
+		Memberships []struct {
+			BarnID string `json:"barn_id"`
+			Role   string `json:"role"`
+		} `json:"memberships"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode session: %v", err)
+	}
+	if payload.Username != "anna" || len(payload.Memberships) != 2 ||
+		payload.Memberships[0].BarnID != "north" || payload.Memberships[0].Role != "worker" ||
+		payload.Memberships[1].BarnID != "server" || payload.Memberships[1].Role != "viewer" {
+		t.Fatalf("unexpected session %+v", payload)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/tlscert"
+)
+
+// selfSignedRenewInterval is how often self-signed mode checks whether the
+// server certificate must be reissued. The check only reads the current
+// certificate when nothing changed.
+const selfSignedRenewInterval = 24 * time.Hour
+
+// newTLSConfig returns the HTTPS configuration for cfg.TLSMode, or nil when
+// TLS is off. Self-signed mode first makes sure cfg.TLSDir holds a local CA
+// and a server certificate for this machine's names and addresses.
+func newTLSConfig(cfg config.Config, logger *slog.Logger, now time.Time) (*tls.Config, error) {
+	certFile, keyFile := cfg.TLSCertFile, cfg.TLSKeyFile
+	switch cfg.TLSMode {
+	case config.TLSModeOff:
+		return nil, nil
+	case config.TLSModeSelfSigned:
+		hosts := selfSignedHosts(cfg)
+		var err error
+		if certFile, keyFile, err = tlscert.EnsureSelfSigned(cfg.TLSDir, hosts, now); err != nil {
+			return nil, err
+		}
+		logger.Info(
+			"serving a self-signed certificate; install the local CA on devices to trust it",
+			slog.String("ca", filepath.Join(cfg.TLSDir, tlscert.CAFile)),
+			slog.Any("hosts", hosts),
+		)
+	}
+
+	reloader, err := tlscert.NewReloader(certFile, keyFile, func(err error) {
+		logger.Warn("reload TLS certificate", slog.Any("error", err))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: reloader.GetCertificate}, nil
+}
+
+// renewSelfSigned runs tlscert.EnsureSelfSigned every interval until ctx is
+// cancelled, so a long-running server reissues its certificate before it
+// expires or when an address changes; the reloader behind newTLSConfig serves
+// the new files. Failures are logged and retried on schedule.
+func renewSelfSigned(ctx context.Context, logger *slog.Logger, cfg config.Config, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, _, err := tlscert.EnsureSelfSigned(cfg.TLSDir, selfSignedHosts(cfg), now); err != nil {
+				logger.Error("renew self-signed TLS certificate", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// selfSignedHosts returns the names and addresses the self-signed server
+// certificate must cover.
+func selfSignedHosts(cfg config.Config) []string {
+	hosts := tlscert.LocalHosts()
+	for _, host := range cfg.TLSHosts {
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// listenAndServe serves HTTPS when srv has a TLS configuration and plain HTTP
+// otherwise.
+func listenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
+
+// newRedirectServer answers plain HTTP on cfg.TLSRedirectAddr with permanent
+// redirects to the same URL on the HTTPS port of cfg.HTTPAddr.
+func newRedirectServer(cfg config.Config) *http.Server {
+	_, port, _ := net.SplitHostPort(cfg.HTTPAddr)
+	return &http.Server{
+		Addr:              cfg.TLSRedirectAddr,
+		Handler:           redirectToHTTPS(port),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+}
+
+// redirectToHTTPS keeps the host the client asked for and swaps in the HTTPS
+// port, leaving it out when it is the default 443.
+func redirectToHTTPS(port string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		} else {
+			host = strings.Trim(host, "[]")
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		http.Redirect(w, r, fmt.Sprintf("https://%s%s", host, r.URL.RequestURI()), http.StatusPermanentRedirect)
+	})
+}
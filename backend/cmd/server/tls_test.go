@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/config"
+	"barnlog/backend/internal/infrastructure/tlscert"
+)
+
+func TestNewTLSConfigOff(t *testing.T) {
+	t.Parallel()
+
+	tlsConfig, err := newTLSConfig(config.Config{TLSMode: config.TLSModeOff}, testLogger(), time.Now())
+	if err != nil || tlsConfig != nil {
+		t.Fatalf("expected no TLS configuration, got %v, %v", tlsConfig, err)
+	}
+}
+
+func TestNewHTTPServerServesSelfSignedTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "tls")
+	cfg := config.Config{TLSMode: config.TLSModeSelfSigned, TLSDir: dir, TLSHosts: []string{"barn.test"}}
+	tlsConfig, err := newTLSConfig(cfg, testLogger(), time.Now())
+	if err != nil {
+		t.Fatalf("newTLSConfig() error = %v", err)
+	}
+	srv := newHTTPServer(cfg, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), tlsConfig)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	caPEM, err := os.ReadFile(filepath.Join(dir, tlscert.CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		t.Fatal("expected ca.pem to hold a certificate")
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+	t.Cleanup(client.CloseIdleConnections)
+
+	res, err := client.Get("https://" + listener.Addr().String() + "/healthz")
+	if err != nil {
+		t.Fatalf("expected a client trusting the local CA to connect, got %v", err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, res.StatusCode)
+	}
+
+	cert, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{ServerName: "barn.test"})
+	if err != nil || cert.Leaf.VerifyHostname("barn.test") != nil {
+		t.Fatalf("expected the certificate to name the configured host, got %v", err)
+	}
+}
+
+func TestRenewSelfSignedReissuesExpiringCertificate(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "tls")
+	cfg := config.Config{TLSMode: config.TLSModeSelfSigned, TLSDir: dir}
+	// Issued long enough ago to be inside the renewal window now.
+	if _, _, err := tlscert.EnsureSelfSigned(dir, selfSignedHosts(cfg), time.Now().Add(-380*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	issued := readLeafNotAfter(t, dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		renewSelfSigned(ctx, testLogger(), cfg, 10*time.Millisecond)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !readLeafNotAfter(t, dir).After(issued) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the expiring certificate to be reissued")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func readLeafNotAfter(t *testing.T, dir string) time.Time {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, tlscert.CertFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		// Caught halfway through a rewrite.
+		return time.Time{}
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}
+	}
+	return cert.NotAfter
+}
+
+func TestNewTLSConfigRejectsMissingFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.Config{
+		TLSMode:     config.TLSModeFiles,
+		TLSCertFile: filepath.Join(dir, "tls.crt"),
+		TLSKeyFile:  filepath.Join(dir, "tls.key"),
+	}
+	if _, err := newTLSConfig(cfg, testLogger(), time.Now()); err == nil {
+		t.Fatal("expected an error for missing certificate files")
+	}
+}
+
+func TestRedirectToHTTPS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		port   string
+		host   string
+		target string
+		want   string
+	}{
+		{"8443", "barn.local:8080", "/animals?limit=5", "https://barn.local:8443/animals?limit=5"},
+		{"8443", "192.168.1.20", "/", "https://192.168.1.20:8443/"},
+		{"443", "barn.local", "/healthz", "https://barn.local/healthz"},
+		{"443", "[::1]:80", "/", "https://[::1]/"},
+		{"8443", "[::1]", "/", "https://[::1]:8443/"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.host+tc.target, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, tc.target, nil)
+			req.Host = tc.host
+			rec := httptest.NewRecorder()
+			redirectToHTTPS(tc.port).ServeHTTP(rec, req)
+			if rec.Code != http.StatusPermanentRedirect {
+				t.Fatalf("expected status %d, got %d", http.StatusPermanentRedirect, rec.Code)
+			}
+			if got := rec.Header().Get("Location"); got != tc.want {
+				t.Fatalf("expected Location %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+)
+
+// moveLegacyUploads moves files uploaded before barns existed, which sit
+// directly in fileDir, into the directory of the default barn that their
+// events were migrated to.
+func moveLegacyUploads(logger *slog.Logger, fileDir string) error {
+	entries, err := os.ReadDir(fileDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read file dir: %w", err)
+	}
+
+	barnDir := filepath.Join(fileDir, domain.DefaultBarnID)
+	moved := 0
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if moved == 0 {
+			if err := os.MkdirAll(barnDir, 0o750); err != nil {
+				return fmt.Errorf("create default barn file dir: %w", err)
+			}
+		}
+		if err := os.Rename(filepath.Join(fileDir, entry.Name()), filepath.Join(barnDir, entry.Name())); err != nil {
+			return fmt.Errorf("move upload %s: %w", entry.Name(), err)
+		}
+		moved++
+	}
+	if moved > 0 {
+		logger.Info("moved uploads into the default barn", slog.Int("files", moved), slog.String("dir", barnDir))
+	}
+	return nil
+}
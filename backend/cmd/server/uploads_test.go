@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveLegacyUploads(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	fileDir := t.TempDir()
+	for _, name := range []string{"photo_1", ".readyz-1", filepath.Join("north", "photo_2")} {
+		path := filepath.Join(fileDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	if err := moveLegacyUploads(logger, fileDir); err != nil {
+		t.Fatalf("move legacy uploads: %v", err)
+	}
+	for _, name := range []string{filepath.Join("default", "photo_1"), ".readyz-1", filepath.Join("north", "photo_2")} {
+		if _, err := os.Stat(filepath.Join(fileDir, name)); err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(fileDir, "photo_1")); !os.IsNotExist(err) {
+		t.Fatalf("expected photo_1 to be moved, got %v", err)
+	}
+
+	if err := moveLegacyUploads(logger, filepath.Join(fileDir, "missing")); err != nil {
+		t.Fatalf("expected a missing file dir to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+// runWebhookDispatcher drains the outbox and retries due deliveries every
+// interval until ctx is cancelled. Errors are logged and retried on the next tick.
+func runWebhookDispatcher(
+	ctx context.Context,
+	logger *slog.Logger,
+	dispatcher *application.WebhookDispatcher,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := dispatcher.DispatchOnce(ctx)
+		switch {
+		case err != nil && !errors.Is(err, context.Canceled):
+			logger.Error("webhook dispatch failed", slog.Any("error", err))
+		case result != (application.WebhookDispatchResult{}):
+			logger.Info(
+				"webhook dispatch",
+				slog.Int("scheduled", result.Scheduled),
+				slog.Int("delivered", result.Delivered),
+				slog.Int("retrying", result.Retrying),
+				slog.Int("dead", result.Dead),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
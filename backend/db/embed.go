@@ -0,0 +1,9 @@
+// Package db holds the SQL assets that ship inside the backend binaries.
+package db
+
+import "embed"
+
+// Migrations contains the golang-migrate files of the migrations directory.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,57 @@
+CREATE TABLE events_without_position (
+    id TEXT PRIMARY KEY,
+    aggregate_type TEXT NOT NULL CHECK (length(trim(aggregate_type)) > 0),
+    aggregate_id TEXT NOT NULL CHECK (length(trim(aggregate_id)) > 0),
+    event_type TEXT NOT NULL CHECK (length(trim(event_type)) > 0),
+    created_by TEXT NOT NULL CHECK (length(trim(created_by)) > 0),
+    source TEXT NOT NULL CHECK (length(trim(source)) > 0),
+    request_id TEXT NOT NULL CHECK (length(trim(request_id)) > 0),
+    event_version INTEGER NOT NULL DEFAULT 1,
+    payload_json TEXT NOT NULL,
+    metadata_json TEXT,
+    occurred_at TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+INSERT INTO events_without_position (
+    id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    created_by,
+    source,
+    request_id,
+    event_version,
+    payload_json,
+    metadata_json,
+    occurred_at,
+    created_at
+)
+SELECT
+    id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    created_by,
+    source,
+    request_id,
+    event_version,
+    payload_json,
+    metadata_json,
+    occurred_at,
+    created_at
+FROM events
+ORDER BY position;
+
+DROP TABLE events;
+
+ALTER TABLE events_without_position RENAME TO events;
+
+CREATE INDEX IF NOT EXISTS idx_events_aggregate
+    ON events (aggregate_type, aggregate_id, occurred_at);
+
+CREATE INDEX IF NOT EXISTS idx_events_type_time
+    ON events (event_type, occurred_at);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_events_source_request_id
+    ON events (source, request_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS snapshots (
+    aggregate_type TEXT NOT NULL CHECK (length(trim(aggregate_type)) > 0),
+    aggregate_id TEXT NOT NULL CHECK (length(trim(aggregate_id)) > 0),
+    stream_version INTEGER NOT NULL CHECK (stream_version > 0),
+    last_position INTEGER NOT NULL,
+    fold_version INTEGER NOT NULL,
+    upcaster_version INTEGER NOT NULL,
+    state_json TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (aggregate_type, aggregate_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS node_role;
+DROP TABLE IF EXISTS replication_checkpoints;
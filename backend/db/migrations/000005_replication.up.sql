@@ -0,0 +1,11 @@
+CREATE TABLE replication_checkpoints (
+    target TEXT PRIMARY KEY CHECK (length(trim(target)) > 0),
+    position INTEGER NOT NULL CHECK (position >= 0),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE node_role (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    role TEXT NOT NULL CHECK (role IN ('primary', 'follower')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
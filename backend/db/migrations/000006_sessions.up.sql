@@ -0,0 +1,10 @@
+CREATE TABLE sessions (
+    token_hash TEXT PRIMARY KEY CHECK (length(token_hash) > 0),
+    user_id TEXT NOT NULL CHECK (length(trim(user_id)) > 0),
+    csrf_token TEXT NOT NULL CHECK (length(csrf_token) > 0),
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    expires_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_sessions_expires_at ON sessions (expires_at);
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
@@ -0,0 +1,11 @@
+CREATE TABLE api_tokens (
+    id TEXT PRIMARY KEY CHECK (length(trim(id)) > 0),
+    user_id TEXT NOT NULL CHECK (length(trim(user_id)) > 0),
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    token_hash TEXT NOT NULL UNIQUE CHECK (length(token_hash) > 0),
+    scopes_json TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    expires_at TEXT
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id);
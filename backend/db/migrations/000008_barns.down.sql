@@ -0,0 +1,27 @@
+DROP INDEX IF EXISTS idx_webhooks_barn_id;
+
+ALTER TABLE webhooks DROP COLUMN barn_id;
+
+DROP TABLE IF EXISTS snapshots;
+
+CREATE TABLE snapshots (
+    aggregate_type TEXT NOT NULL CHECK (length(trim(aggregate_type)) > 0),
+    aggregate_id TEXT NOT NULL CHECK (length(trim(aggregate_id)) > 0),
+    stream_version INTEGER NOT NULL CHECK (stream_version > 0),
+    last_position INTEGER NOT NULL,
+    fold_version INTEGER NOT NULL,
+    upcaster_version INTEGER NOT NULL,
+    state_json TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (aggregate_type, aggregate_id)
+);
+
+DROP INDEX IF EXISTS idx_events_barn_position;
+
+DROP INDEX IF EXISTS ux_events_barn_source_request_id;
+
+-- Fails if two barns used the same (source, request_id); merge them first.
+CREATE UNIQUE INDEX IF NOT EXISTS ux_events_source_request_id
+    ON events (source, request_id);
+
+ALTER TABLE events DROP COLUMN barn_id;
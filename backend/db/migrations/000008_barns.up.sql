@@ -0,0 +1,35 @@
+ALTER TABLE events ADD COLUMN barn_id TEXT NOT NULL DEFAULT 'default'
+    CHECK (length(trim(barn_id)) > 0);
+
+-- Users are shared by every barn; their streams live in the reserved server barn.
+UPDATE events SET barn_id = 'server' WHERE aggregate_type = 'user';
+
+DROP INDEX IF EXISTS ux_events_source_request_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_events_barn_source_request_id
+    ON events (barn_id, source, request_id);
+
+CREATE INDEX IF NOT EXISTS idx_events_barn_position
+    ON events (barn_id, position);
+
+-- Snapshots are a cache of folded streams; they are rebuilt on the next read.
+DROP TABLE IF EXISTS snapshots;
+
+CREATE TABLE snapshots (
+    barn_id TEXT NOT NULL CHECK (length(trim(barn_id)) > 0),
+    aggregate_type TEXT NOT NULL CHECK (length(trim(aggregate_type)) > 0),
+    aggregate_id TEXT NOT NULL CHECK (length(trim(aggregate_id)) > 0),
+    stream_version INTEGER NOT NULL CHECK (stream_version > 0),
+    last_position INTEGER NOT NULL,
+    fold_version INTEGER NOT NULL,
+    upcaster_version INTEGER NOT NULL,
+    state_json TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (barn_id, aggregate_type, aggregate_id)
+);
+
+ALTER TABLE webhooks ADD COLUMN barn_id TEXT NOT NULL DEFAULT 'default'
+    CHECK (length(trim(barn_id)) > 0);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_barn_id
+    ON webhooks (barn_id);
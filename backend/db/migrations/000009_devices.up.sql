@@ -0,0 +1,9 @@
+CREATE TABLE devices (
+    id TEXT PRIMARY KEY CHECK (length(trim(id)) > 0),
+    user_id TEXT NOT NULL CHECK (length(trim(user_id)) > 0),
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    secret_hash TEXT NOT NULL UNIQUE CHECK (length(secret_hash) > 0),
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX idx_devices_user_id ON devices (user_id);
@@ -0,0 +1,18 @@
+-- name: CreateApiToken :exec
+INSERT INTO api_tokens (id, user_id, name, token_hash, scopes_json, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteApiToken :execrows
+DELETE FROM api_tokens
+WHERE id = ? AND user_id = ?;
+
+-- name: GetApiTokenByHash :one
+SELECT id, user_id, name, token_hash, scopes_json, created_at, expires_at
+FROM api_tokens
+WHERE token_hash = ?;
+
+-- name: ListApiTokensByUser :many
+SELECT id, user_id, name, token_hash, scopes_json, created_at, expires_at
+FROM api_tokens
+WHERE user_id = ?
+ORDER BY created_at, id;
@@ -0,0 +1,18 @@
+-- name: CreateDevice :exec
+INSERT INTO devices (id, user_id, name, secret_hash, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: DeleteDevice :execrows
+DELETE FROM devices
+WHERE id = ? AND user_id = ?;
+
+-- name: GetDeviceBySecretHash :one
+SELECT id, user_id, name, secret_hash, created_at
+FROM devices
+WHERE secret_hash = ?;
+
+-- name: ListDevicesByUser :many
+SELECT id, user_id, name, secret_hash, created_at
+FROM devices
+WHERE user_id = ?
+ORDER BY created_at, id;
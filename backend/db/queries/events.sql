@@ -1,6 +1,7 @@
 -- name: CreateEvent :exec
 INSERT INTO events (
     id,
+    barn_id,
     aggregate_type,
     aggregate_id,
     event_type,
@@ -12,7 +13,7 @@ INSERT INTO events (
     metadata_json,
     occurred_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: GetEventBySourceRequestID :one
@@ -23,5 +24,133 @@ SELECT
     event_type,
     payload_json
 FROM events
-WHERE source = ? AND request_id = ?
+WHERE barn_id = ? AND source = ? AND request_id = ?
 LIMIT 1;
+
+-- name: ListAggregateEventsAfterPosition :many
+SELECT
+    position,
+    id,
+    event_type,
+    event_version,
+    payload_json
+FROM events
+WHERE barn_id = ? AND aggregate_type = ? AND aggregate_id = ? AND position > ?
+ORDER BY position;
+
+-- name: GetEventByID :one
+SELECT
+    position,
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    event_version,
+    created_by,
+    payload_json,
+    occurred_at,
+    created_at
+FROM events
+WHERE barn_id = ? AND id = ?
+LIMIT 1;
+
+-- name: ListAggregateEvents :many
+SELECT
+    position,
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    event_version,
+    created_by,
+    payload_json,
+    occurred_at,
+    created_at
+FROM events
+WHERE barn_id = ? AND aggregate_type = ? AND aggregate_id = ?
+ORDER BY position;
+
+-- name: ListEventsAfterPosition :many
+SELECT
+    position,
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    event_version,
+    created_by,
+    payload_json,
+    occurred_at,
+    created_at
+FROM events
+WHERE barn_id = sqlc.arg(barn_id)
+    AND position > sqlc.arg(after_position)
+    AND (sqlc.arg(aggregate_type) = '' OR aggregate_type = sqlc.arg(aggregate_type))
+    AND (sqlc.arg(aggregate_id) = '' OR aggregate_id = sqlc.arg(aggregate_id))
+    AND (sqlc.arg(event_type) = '' OR event_type = sqlc.arg(event_type))
+ORDER BY position
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetLatestEventPosition :one
+SELECT CAST(COALESCE(MAX(position), 0) AS INTEGER) AS position
+FROM events
+WHERE barn_id = ?;
+
+-- name: ListEventsForExport :many
+SELECT
+    position,
+    id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    created_by,
+    source,
+    request_id,
+    event_version,
+    payload_json,
+    metadata_json,
+    occurred_at,
+    created_at,
+    barn_id
+FROM events
+WHERE position > sqlc.arg(after_position)
+    AND (sqlc.arg(barn_id) = '' OR barn_id = sqlc.arg(barn_id))
+ORDER BY position
+LIMIT sqlc.arg(row_limit);
+
+-- name: ImportEvent :execrows
+INSERT INTO events (
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    created_by,
+    source,
+    request_id,
+    event_version,
+    payload_json,
+    metadata_json,
+    occurred_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT DO NOTHING;
+
+-- name: CountEventVoids :one
+SELECT COUNT(*)
+FROM events
+WHERE barn_id = ?
+  AND aggregate_type = ?
+  AND aggregate_id = ?
+  AND event_type = 'event.voided'
+  AND json_extract(payload_json, '$.target_event_id') = ?;
+
+-- name: CountEventsAfterPosition :one
+SELECT COUNT(*)
+FROM events
+WHERE position > ?;
@@ -0,0 +1,31 @@
+-- name: CreateOutboxEntry :exec
+INSERT INTO outbox (event_id) VALUES (?);
+
+-- name: ListPendingOutboxEvents :many
+SELECT
+    e.position,
+    e.id,
+    e.barn_id,
+    e.aggregate_type,
+    e.aggregate_id,
+    e.event_type,
+    e.event_version,
+    e.created_by,
+    e.payload_json,
+    e.occurred_at,
+    e.created_at
+FROM outbox o
+JOIN events e ON e.id = o.event_id
+WHERE o.dispatched_at IS NULL
+ORDER BY e.position
+LIMIT ?;
+
+-- name: MarkOutboxDispatched :exec
+UPDATE outbox
+SET dispatched_at = ?
+WHERE event_id = ?;
+
+-- name: CountPendingOutbox :one
+SELECT COUNT(*)
+FROM outbox
+WHERE dispatched_at IS NULL;
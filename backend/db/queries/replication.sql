@@ -0,0 +1,28 @@
+-- name: GetNodeRole :one
+SELECT role
+FROM node_role
+WHERE id = 1;
+
+-- name: GetReplicationCheckpoint :one
+SELECT position
+FROM replication_checkpoints
+WHERE target = ?;
+
+-- name: ListReplicationCheckpoints :many
+SELECT target, position, updated_at
+FROM replication_checkpoints
+ORDER BY target;
+
+-- name: SetNodeRole :exec
+INSERT INTO node_role (id, role, updated_at)
+VALUES (1, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    role = excluded.role,
+    updated_at = excluded.updated_at;
+
+-- name: UpsertReplicationCheckpoint :exec
+INSERT INTO replication_checkpoints (target, position, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT (target) DO UPDATE SET
+    position = excluded.position,
+    updated_at = excluded.updated_at;
@@ -0,0 +1,20 @@
+-- name: CreateSession :exec
+INSERT INTO sessions (token_hash, user_id, csrf_token, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions
+WHERE expires_at <= ?;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE token_hash = ?;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM sessions
+WHERE user_id = ?;
+
+-- name: GetSession :one
+SELECT token_hash, user_id, csrf_token, created_at, expires_at
+FROM sessions
+WHERE token_hash = ?;
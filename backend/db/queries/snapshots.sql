@@ -0,0 +1,31 @@
+-- name: GetSnapshot :one
+SELECT
+    stream_version,
+    last_position,
+    fold_version,
+    upcaster_version,
+    state_json
+FROM snapshots
+WHERE barn_id = ? AND aggregate_type = ? AND aggregate_id = ?
+LIMIT 1;
+
+-- name: UpsertSnapshot :exec
+INSERT INTO snapshots (
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    stream_version,
+    last_position,
+    fold_version,
+    upcaster_version,
+    state_json
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (barn_id, aggregate_type, aggregate_id) DO UPDATE SET
+    stream_version = excluded.stream_version,
+    last_position = excluded.last_position,
+    fold_version = excluded.fold_version,
+    upcaster_version = excluded.upcaster_version,
+    state_json = excluded.state_json,
+    created_at = datetime('now');
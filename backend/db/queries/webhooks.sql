@@ -0,0 +1,103 @@
+-- name: CreateWebhook :exec
+INSERT INTO webhooks (
+    id,
+    url,
+    secret,
+    event_types_json,
+    active,
+    barn_id
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetWebhook :one
+SELECT
+    id,
+    url,
+    secret,
+    event_types_json,
+    active,
+    created_at,
+    updated_at,
+    barn_id
+FROM webhooks
+WHERE barn_id = ? AND id = ?
+LIMIT 1;
+
+-- name: ListWebhooks :many
+SELECT
+    id,
+    url,
+    secret,
+    event_types_json,
+    active,
+    created_at,
+    updated_at,
+    barn_id
+FROM webhooks
+WHERE barn_id = ?
+ORDER BY created_at, id;
+
+-- name: UpdateWebhook :execrows
+UPDATE webhooks
+SET
+    url = ?,
+    event_types_json = ?,
+    active = ?,
+    updated_at = datetime('now')
+WHERE barn_id = ? AND id = ?;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE barn_id = ? AND id = ?;
+
+-- name: DeleteWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id = ?;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    webhook_id,
+    event_id,
+    next_attempt_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (webhook_id, event_id) DO NOTHING;
+
+-- name: ListDueWebhookDeliveries :many
+SELECT
+    d.id AS delivery_id,
+    d.webhook_id,
+    d.attempts,
+    w.url,
+    w.secret,
+    e.position,
+    e.id AS event_id,
+    e.barn_id,
+    e.aggregate_type,
+    e.aggregate_id,
+    e.event_type,
+    e.event_version,
+    e.created_by,
+    e.payload_json,
+    e.occurred_at,
+    e.created_at
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+JOIN events e ON e.id = d.event_id
+WHERE d.status = 'pending'
+    AND w.active = 1
+    AND d.next_attempt_at <= ?
+ORDER BY d.next_attempt_at, d.id
+LIMIT ?;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET
+    status = ?,
+    attempts = ?,
+    next_attempt_at = ?,
+    last_error = ?,
+    updated_at = datetime('now')
+WHERE id = ?;
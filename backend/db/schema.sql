@@ -1,5 +1,22 @@
-CREATE TABLE events (
-    id TEXT PRIMARY KEY,
+CREATE TABLE api_tokens (
+    id TEXT PRIMARY KEY CHECK (length(trim(id)) > 0),
+    user_id TEXT NOT NULL CHECK (length(trim(user_id)) > 0),
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    token_hash TEXT NOT NULL UNIQUE CHECK (length(token_hash) > 0),
+    scopes_json TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    expires_at TEXT
+);
+CREATE TABLE devices (
+    id TEXT PRIMARY KEY CHECK (length(trim(id)) > 0),
+    user_id TEXT NOT NULL CHECK (length(trim(user_id)) > 0),
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    secret_hash TEXT NOT NULL UNIQUE CHECK (length(secret_hash) > 0),
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+CREATE TABLE "events" (
+    position INTEGER PRIMARY KEY AUTOINCREMENT,
+    id TEXT NOT NULL UNIQUE,
     aggregate_type TEXT NOT NULL CHECK (length(trim(aggregate_type)) > 0),
     aggregate_id TEXT NOT NULL CHECK (length(trim(aggregate_id)) > 0),
     event_type TEXT NOT NULL CHECK (length(trim(event_type)) > 0),
@@ -11,11 +28,83 @@ CREATE TABLE events (
     metadata_json TEXT,
     occurred_at TEXT NOT NULL,
     created_at TEXT NOT NULL DEFAULT (datetime('now'))
+, barn_id TEXT NOT NULL DEFAULT 'default'
+    CHECK (length(trim(barn_id)) > 0));
+CREATE TABLE node_role (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    role TEXT NOT NULL CHECK (role IN ('primary', 'follower')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+CREATE TABLE outbox (
+    event_id TEXT PRIMARY KEY REFERENCES events (id),
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    dispatched_at TEXT
+);
+CREATE TABLE replication_checkpoints (
+    target TEXT PRIMARY KEY CHECK (length(trim(target)) > 0),
+    position INTEGER NOT NULL CHECK (position >= 0),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
 );
+CREATE TABLE sessions (
+    token_hash TEXT PRIMARY KEY CHECK (length(token_hash) > 0),
+    user_id TEXT NOT NULL CHECK (length(trim(user_id)) > 0),
+    csrf_token TEXT NOT NULL CHECK (length(csrf_token) > 0),
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    expires_at TEXT NOT NULL
+);
+CREATE TABLE snapshots (
+    barn_id TEXT NOT NULL CHECK (length(trim(barn_id)) > 0),
+    aggregate_type TEXT NOT NULL CHECK (length(trim(aggregate_type)) > 0),
+    aggregate_id TEXT NOT NULL CHECK (length(trim(aggregate_id)) > 0),
+    stream_version INTEGER NOT NULL CHECK (stream_version > 0),
+    last_position INTEGER NOT NULL,
+    fold_version INTEGER NOT NULL,
+    upcaster_version INTEGER NOT NULL,
+    state_json TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (barn_id, aggregate_type, aggregate_id)
+);
+CREATE TABLE webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL REFERENCES events (id),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    next_attempt_at TEXT NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE (webhook_id, event_id)
+);
+CREATE TABLE webhooks (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL CHECK (length(trim(url)) > 0),
+    secret TEXT NOT NULL CHECK (length(secret) > 0),
+    event_types_json TEXT NOT NULL DEFAULT '[]',
+    active INTEGER NOT NULL DEFAULT 1 CHECK (active IN (0, 1)),
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+, barn_id TEXT NOT NULL DEFAULT 'default'
+    CHECK (length(trim(barn_id)) > 0));
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id);
+CREATE INDEX idx_devices_user_id ON devices (user_id);
 CREATE INDEX idx_events_aggregate
     ON events (aggregate_type, aggregate_id, occurred_at);
+CREATE INDEX idx_events_aggregate_position
+    ON events (aggregate_type, aggregate_id, position);
+CREATE INDEX idx_events_barn_position
+    ON events (barn_id, position);
 CREATE INDEX idx_events_type_time
     ON events (event_type, occurred_at);
-CREATE UNIQUE INDEX ux_events_source_request_id
-    ON events (source, request_id);
+CREATE INDEX idx_outbox_pending
+    ON outbox (created_at)
+    WHERE dispatched_at IS NULL;
+CREATE INDEX idx_sessions_expires_at ON sessions (expires_at);
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+CREATE INDEX idx_webhook_deliveries_due
+    ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX idx_webhooks_barn_id
+    ON webhooks (barn_id);
+CREATE UNIQUE INDEX ux_events_barn_source_request_id
+    ON events (barn_id, source, request_id);
 CREATE UNIQUE INDEX version_unique ON schema_migrations (version);
@@ -0,0 +1,92 @@
+package httpapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// UnmatchedRoute is the route label of requests no route matched, so
+// arbitrary paths do not each become a log or metric label value.
+const UnmatchedRoute = "unmatched"
+
+// quietPaths are probe endpoints whose access lines are logged at debug level
+// so they do not drown out API traffic.
+var quietPaths = map[string]struct{}{
+	"/healthz": {},
+	"/readyz":  {},
+}
+
+type loggerContextKey struct{}
+
+// withLogger attaches a request-scoped logger to ctx.
+func withLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// requestLogger returns the logger AccessLog attached to ctx, or fallback
+// when the request did not pass through it.
+func requestLogger(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// AccessLog attaches a logger carrying the request ID and source to each
+// request and writes one access line when the request completes. It must run
+// after middleware.RequestID.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			reqLogger := logger.With(
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				slog.String("source", strings.TrimSpace(r.Header.Get(sourceHeaderName))),
+			)
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			r = r.WithContext(withLogger(r.Context(), reqLogger))
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				level := slog.LevelInfo
+				if _, quiet := quietPaths[r.URL.Path]; quiet {
+					level = slog.LevelDebug
+				}
+				if status >= http.StatusInternalServerError {
+					level = slog.LevelError
+				}
+				reqLogger.LogAttrs(r.Context(), level, "http request",
+					slog.String("method", r.Method),
+					slog.String("route", RoutePattern(r)),
+					slog.Int("status", status),
+					slog.Int("bytes", ww.BytesWritten()),
+					slog.Duration("duration", time.Since(start)),
+				)
+			}()
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// RoutePattern returns the chi route pattern that served r, such as
+// /animals/{animalId}, or UnmatchedRoute. Call it after the router has run.
+func RoutePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return UnmatchedRoute
+	}
+	pattern := rctx.RoutePattern()
+	if pattern == "" || pattern == "/*" {
+		return UnmatchedRoute
+	}
+	return pattern
+}
@@ -0,0 +1,76 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func TestAccessLogCorrelatesHandlerLogs(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(AccessLog(logger))
+	r.Mount("/", testRoutes(RouteDeps{
+		Logger:         logger,
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{err: errors.New("disk full")},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+	}))
+
+	rec := performCreateAnimal(t, r, `{"name":"Nanny","species":"goat"}`, withCreateAnimalHeaders(
+		"X-Request-Id", "req-123",
+		"X-Barnlog-Source", "web.app",
+	))
+	assertJSONStatus(t, rec, http.StatusInternalServerError)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a handler line and an access line (healthz is debug), got:\n%s", logs.String())
+	}
+
+	var failure, access map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &failure); err != nil {
+		t.Fatalf("decode handler line: %v", err)
+	}
+	if failure["msg"] != "create animal failed" || failure["request_id"] != "req-123" || failure["source"] != "web.app" {
+		t.Fatalf("expected a correlated handler line, got %v", failure)
+	}
+
+	if err := json.Unmarshal([]byte(lines[1]), &access); err != nil {
+		t.Fatalf("decode access line: %v", err)
+	}
+	for key, want := range map[string]any{
+		"msg":        "http request",
+		"level":      "ERROR",
+		"method":     http.MethodPost,
+		"route":      "/animals",
+		"status":     float64(http.StatusInternalServerError),
+		"bytes":      float64(rec.Body.Len()),
+		"request_id": "req-123",
+		"source":     "web.app",
+	} {
+		if access[key] != want {
+			t.Fatalf("expected %s=%v in access line, got %v", key, want, access)
+		}
+	}
+	if _, ok := access["duration"]; !ok {
+		t.Fatalf("expected a duration in access line, got %v", access)
+	}
+}
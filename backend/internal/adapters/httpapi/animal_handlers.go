@@ -1,6 +1,7 @@
 package httpapi
 
 import (
+	"encoding/json"
 	"log/slog"
 	"net/http"
 
@@ -10,12 +11,18 @@ import (
 type animalHandlers struct {
 	logger       *slog.Logger
 	animalWriter application.AnimalWriter
+	animalReader application.AnimalReader
 }
 
-func newAnimalHandlers(logger *slog.Logger, animalWriter application.AnimalWriter) animalHandlers {
+func newAnimalHandlers(
+	logger *slog.Logger,
+	animalWriter application.AnimalWriter,
+	animalReader application.AnimalReader,
+) animalHandlers {
 	return animalHandlers{
 		logger:       logger,
 		animalWriter: animalWriter,
+		animalReader: animalReader,
 	}
 }
 
@@ -37,6 +44,16 @@ type createAnimalResponse struct {
 	PhotoID   string `json:"photo_id,omitempty" example:"photo_1"`
 }
 
+type animalResponse struct {
+	AnimalID  string `json:"animal_id"`
+	Name      string `json:"name"`
+	Species   string `json:"species"`
+	Tag       string `json:"tag,omitempty"`
+	Birthdate string `json:"birthdate,omitempty"`
+	PhotoID   string `json:"photo_id,omitempty"`
+	Version   int64  `json:"version"`
+}
+
 // createAnimal godoc
 //
 // @Summary Create animal
@@ -45,7 +62,7 @@ type createAnimalResponse struct {
 // @Accept json
 // @Produce json
 // @Param X-Request-Id header string false "Idempotency request key (omit to disable idempotency)"
-// @Param X-Barnlog-Source header string false "Request source"
+// @Param X-Barnlog-Source header string false "Request source (ignored for registered devices, which always record device:<device ID>)"
 // @Param request body createAnimalRequest true "Create animal payload"
 // @Success 201 {object} createAnimalResponse
 // @Success 200 {object} createAnimalResponse "Idempotent replay"
@@ -74,19 +91,17 @@ func (h animalHandlers) createAnimal(w http.ResponseWriter, r *http.Request) {
 		Tag:       req.Tag,
 		Birthdate: req.Birthdate,
 		PhotoID:   req.PhotoID,
-		Meta: application.RequestMeta{
-			Source:    meta.Source,
-			RequestID: meta.RequestID,
-		},
+		Meta:      meta.toApplication(),
 	}
 
 	out, err := h.animalWriter.Create(r.Context(), in)
 	if err != nil {
-		if writeBusinessError(w, h.logger, err) {
+		logger := requestLogger(r.Context(), h.logger)
+		if writeBusinessError(w, logger, err) {
 			return
 		}
 
-		h.logger.Error("create animal failed", slog.Any("error", err))
+		logger.Error("create animal failed", slog.Any("error", err))
 		writeError(w, http.StatusInternalServerError, "internal_error")
 		return
 	}
@@ -106,3 +121,102 @@ func (h animalHandlers) createAnimal(w http.ResponseWriter, r *http.Request) {
 		PhotoID:   out.PhotoID,
 	})
 }
+
+// getAnimal returns the current state of an animal folded from its event stream.
+func (h animalHandlers) getAnimal(w http.ResponseWriter, r *http.Request, animalID string) {
+	out, err := h.animalReader.Get(r.Context(), animalID)
+	if err != nil {
+		logger := requestLogger(r.Context(), h.logger)
+		if writeBusinessError(w, logger, err) {
+			return
+		}
+
+		logger.Error("get animal failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, animalResponse{
+		AnimalID:  out.AnimalID,
+		Name:      out.Name,
+		Species:   out.Species,
+		Tag:       out.Tag,
+		Birthdate: out.Birthdate,
+		PhotoID:   out.PhotoID,
+		Version:   out.Version,
+	})
+}
+
+type timelineResponse struct {
+	AnimalID string                  `json:"animal_id"`
+	Entries  []timelineEntryResponse `json:"entries"`
+}
+
+type timelineEntryResponse struct {
+	EventID         string                       `json:"event_id"`
+	EventType       string                       `json:"event_type"`
+	Status          string                       `json:"status"`
+	CreatedBy       string                       `json:"created_by"`
+	OccurredAt      string                       `json:"occurred_at"`
+	RecordedAt      string                       `json:"recorded_at"`
+	Payload         json.RawMessage              `json:"payload"`
+	OriginalPayload json.RawMessage              `json:"original_payload"`
+	Corrections     []timelineCorrectionResponse `json:"corrections"`
+}
+
+type timelineCorrectionResponse struct {
+	EventID    string          `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Reason     string          `json:"reason"`
+	CreatedBy  string          `json:"created_by"`
+	OccurredAt string          `json:"occurred_at"`
+	RecordedAt string          `json:"recorded_at"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+// getAnimalTimeline returns the events of an animal with corrections and voids applied.
+func (h animalHandlers) getAnimalTimeline(w http.ResponseWriter, r *http.Request, animalID string) {
+	out, err := h.animalReader.Timeline(r.Context(), animalID)
+	if err != nil {
+		logger := requestLogger(r.Context(), h.logger)
+		if writeBusinessError(w, logger, err) {
+			return
+		}
+
+		logger.Error("get animal timeline failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	resp := timelineResponse{
+		AnimalID: out.AnimalID,
+		Entries:  make([]timelineEntryResponse, 0, len(out.Entries)),
+	}
+	for _, entry := range out.Entries {
+		corrections := make([]timelineCorrectionResponse, 0, len(entry.Corrections))
+		for _, correction := range entry.Corrections {
+			corrections = append(corrections, timelineCorrectionResponse{
+				EventID:    correction.EventID,
+				EventType:  correction.EventType,
+				Reason:     correction.Reason,
+				CreatedBy:  correction.CreatedBy,
+				OccurredAt: correction.OccurredAt,
+				RecordedAt: correction.RecordedAt,
+				Payload:    correction.Payload,
+			})
+		}
+		resp.Entries = append(resp.Entries, timelineEntryResponse{
+			EventID:         entry.EventID,
+			EventType:       entry.EventType,
+			Status:          entry.Status,
+			CreatedBy:       entry.CreatedBy,
+			OccurredAt:      entry.OccurredAt,
+			RecordedAt:      entry.RecordedAt,
+			Payload:         entry.Payload,
+			OriginalPayload: entry.OriginalPayload,
+			Corrections:     corrections,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
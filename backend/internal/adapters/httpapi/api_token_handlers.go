@@ -0,0 +1,124 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+type apiTokenHandlers struct {
+	logger *slog.Logger
+	tokens application.APITokenManager
+}
+
+func newAPITokenHandlers(logger *slog.Logger, tokens application.APITokenManager) apiTokenHandlers {
+	return apiTokenHandlers{
+		logger: logger,
+		tokens: tokens,
+	}
+}
+
+type createAPITokenRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"`
+}
+
+type apiTokenResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+	Token     string   `json:"token,omitempty"`
+}
+
+type apiTokenListResponse struct {
+	Items []apiTokenResponse `json:"items"`
+}
+
+// createToken creates an API token of the signed-in user and returns it once.
+func (h apiTokenHandlers) createToken(w http.ResponseWriter, r *http.Request) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	var req createAPITokenRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+	var expiresAt time.Time
+	if req.ExpiresAt != "" {
+		parsed, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_input")
+			return
+		}
+		expiresAt = parsed
+	}
+
+	out, err := h.tokens.CreateToken(r.Context(), application.CreateAPITokenInput{
+		UserID:    principal.UserID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "create api token failed")
+		return
+	}
+	resp := newAPITokenResponse(out.APITokenOutput)
+	resp.Token = out.Token
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// listTokens returns the API tokens of the signed-in user without their secrets.
+func (h apiTokenHandlers) listTokens(w http.ResponseWriter, r *http.Request) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	out, err := h.tokens.ListTokens(r.Context(), principal.UserID)
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "list api tokens failed")
+		return
+	}
+
+	items := make([]apiTokenResponse, 0, len(out))
+	for _, token := range out {
+		items = append(items, newAPITokenResponse(token))
+	}
+	writeJSON(w, http.StatusOK, apiTokenListResponse{Items: items})
+}
+
+// revokeToken deletes an API token of the signed-in user.
+func (h apiTokenHandlers) revokeToken(w http.ResponseWriter, r *http.Request, tokenID string) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	if err := h.tokens.RevokeToken(r.Context(), principal.UserID, tokenID); err != nil {
+		writeFailure(w, r, h.logger, err, "revoke api token failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newAPITokenResponse(out application.APITokenOutput) apiTokenResponse {
+	resp := apiTokenResponse{
+		ID:        out.ID,
+		Name:      out.Name,
+		Scopes:    out.Scopes,
+		CreatedAt: out.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if !out.ExpiresAt.IsZero() {
+		resp.ExpiresAt = out.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
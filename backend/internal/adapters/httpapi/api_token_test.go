@@ -0,0 +1,229 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/application"
+	spec "barnlog/backend/openapi"
+)
+
+const (
+	readToken  = "blt_read"
+	writeToken = "blt_write"
+)
+
+func tokenTestAuthenticator() *fakeAuthenticator {
+	auth := newFakeAuthenticator()
+	auth.tokens[readToken] = application.Principal{
+		UserID:   "u1",
+		Username: "anna",
+		TokenID:  "t-read",
+		Scopes:   []string{application.ScopeWebhooksRead},
+	}
+	auth.tokens[writeToken] = application.Principal{
+		UserID:   "u1",
+		Username: "anna",
+		TokenID:  "t-write",
+		Scopes:   []string{application.ScopeAnimalsWrite, application.ScopeWebhooksRead},
+	}
+	return auth
+}
+
+func performBearerRequest(router http.Handler, method, path, body, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", jsonContentType)
+	req.Header.Set("Authorization", authorization)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRequireAuthWithBearerTokens(t *testing.T) {
+	t.Parallel()
+
+	router := authTestRouter(tokenTestAuthenticator(), &fakeAnimalWriter{})
+	const animal = `{"name":"Nanny","species":"goat"}`
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		status        int
+		code          string
+	}{
+		{"read with scope", http.MethodGet, "/webhooks", "Bearer " + readToken, http.StatusOK, ""},
+		{"scheme is case-insensitive", http.MethodGet, "/webhooks", "bearer " + readToken, http.StatusOK, ""},
+		{"unknown token", http.MethodGet, "/webhooks", "Bearer blt_revoked", http.StatusUnauthorized, "unauthenticated"},
+		{"other scheme", http.MethodGet, "/webhooks", "Basic " + readToken, http.StatusUnauthorized, "unauthenticated"},
+		{"write without scope", http.MethodPost, "/animals", "Bearer " + readToken, http.StatusForbidden, "insufficient_scope"},
+		{"session-only operation", http.MethodGet, "/auth/session", "Bearer " + writeToken, http.StatusForbidden, "insufficient_scope"},
+		{"tokens cannot mint tokens", http.MethodPost, AuthTokensPath, "Bearer " + writeToken, http.StatusForbidden, "insufficient_scope"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := performBearerRequest(router, tc.method, tc.path, animal, tc.authorization)
+			assertJSONStatus(t, rec, tc.status)
+			if tc.code == "" {
+				return
+			}
+			var payload map[string]any
+			decodeJSON(t, rec, &payload)
+			if payload["code"] != tc.code {
+				t.Fatalf("expected code=%s, got %#v", tc.code, payload["code"])
+			}
+		})
+	}
+
+	t.Run("write with scope needs no csrf token", func(t *testing.T) {
+		t.Parallel()
+
+		writer := &fakeAnimalWriter{}
+		rec := performBearerRequest(
+			authTestRouter(tokenTestAuthenticator(), writer),
+			http.MethodPost, "/animals", animal, "Bearer "+writeToken,
+		)
+		assertJSONStatus(t, rec, http.StatusCreated)
+		if writer.in.Meta.Actor != "user:anna" {
+			t.Fatalf("expected actor user:anna, got %q", writer.in.Meta.Actor)
+		}
+	})
+}
+
+func TestAPITokenHandlers(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.AddDate(1, 0, 0)
+	scale := application.APITokenOutput{
+		ID:        "t1",
+		Name:      "scale",
+		Scopes:    []string{application.ScopeAnimalsWrite},
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}
+	tokens := &fakeAPITokenManager{
+		createOut: application.CreatedAPITokenOutput{APITokenOutput: scale, Token: "blt_secret"},
+		listed:    []application.APITokenOutput{scale},
+	}
+	router := authTestRouterWithTokens(newFakeAuthenticator(), &fakeAnimalWriter{}, tokens)
+
+	rec := performAuthRequest(router, http.MethodPost, AuthTokensPath,
+		`{"name":"scale","scopes":["animals:write"],"expires_at":"2027-03-01T12:00:00Z"}`,
+		testSessionToken, testCSRFToken)
+	assertJSONStatus(t, rec, http.StatusCreated)
+	if tokens.createIn.UserID != "u1" || tokens.createIn.Name != "scale" || !tokens.createIn.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("unexpected create input %+v", tokens.createIn)
+	}
+	var created map[string]any
+	decodeJSON(t, rec, &created)
+	if created["token"] != "blt_secret" || created["expires_at"] != "2027-03-01T12:00:00Z" {
+		t.Fatalf("unexpected created token %#v", created)
+	}
+
+	rec = performAuthRequest(router, http.MethodPost, AuthTokensPath,
+		`{"name":"scale","scopes":["animals:write"],"expires_at":"next year"}`,
+		testSessionToken, testCSRFToken)
+	assertJSONStatus(t, rec, http.StatusBadRequest)
+
+	rec = performAuthRequest(router, http.MethodGet, AuthTokensPath, "", testSessionToken, "")
+	assertJSONStatus(t, rec, http.StatusOK)
+	var listed struct {
+		Items []map[string]any `json:"items"`
+	}
+	decodeJSON(t, rec, &listed)
+	if tokens.listUser != "u1" || len(listed.Items) != 1 || listed.Items[0]["id"] != "t1" {
+		t.Fatalf("unexpected token list %#v", listed)
+	}
+	if _, leaked := listed.Items[0]["token"]; leaked {
+		t.Fatal("expected listed tokens to omit the secret")
+	}
+
+	rec = performAuthRequest(router, http.MethodDelete, AuthTokensPath+"/t1", "", testSessionToken, testCSRFToken)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+	if tokens.revoked != "u1/t1" {
+		t.Fatalf("expected token t1 of u1 to be revoked, got %q", tokens.revoked)
+	}
+
+	tokens.err = application.BusinessError{Code: application.CodeTokenNotFound}
+	rec = performAuthRequest(router, http.MethodDelete, AuthTokensPath+"/t2", "", testSessionToken, testCSRFToken)
+	assertJSONStatus(t, rec, http.StatusNotFound)
+}
+
+type fakeAPITokenManager struct {
+	createIn  application.CreateAPITokenInput
+	createOut application.CreatedAPITokenOutput
+	listed    []application.APITokenOutput
+	listUser  string
+	revoked   string
+	err       error
+}
+
+func (f *fakeAPITokenManager) CreateToken(
+	_ context.Context,
+	in application.CreateAPITokenInput,
+) (application.CreatedAPITokenOutput, error) {
+	f.createIn = in
+	return f.createOut, f.err
+}
+
+func (f *fakeAPITokenManager) ListTokens(_ context.Context, userID string) ([]application.APITokenOutput, error) {
+	f.listUser = userID
+	return f.listed, f.err
+}
+
+func (f *fakeAPITokenManager) RevokeToken(_ context.Context, userID string, tokenID string) error {
+	f.revoked = userID + "/" + tokenID
+	return f.err
+}
+
+var _ application.APITokenManager = (*fakeAPITokenManager)(nil)
+
+// TestSpecBearerScopesAreGrantable keeps the per-operation scopes of the
+// OpenAPI spec in step with the scopes a token can be created with.
+func TestSpecBearerScopesAreGrantable(t *testing.T) {
+	t.Parallel()
+
+	body, err := spec.JSON()
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	used := map[string]bool{}
+	for path, operations := range doc.Paths {
+		for method, operation := range operations {
+			for _, requirement := range operation.Security {
+				for _, scope := range requirement["bearerAuth"] {
+					if !slices.Contains(application.APITokenScopes, scope) {
+						t.Errorf("%s %s requires unknown scope %q", method, path, scope)
+					}
+					used[scope] = true
+				}
+			}
+		}
+	}
+	for _, scope := range application.APITokenScopes {
+		if !used[scope] {
+			t.Errorf("scope %q is grantable but no operation requires it", scope)
+		}
+	}
+}
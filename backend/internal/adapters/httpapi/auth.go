@@ -0,0 +1,134 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"barnlog/backend/internal/application"
+	openapicontract "barnlog/backend/internal/contracts/openapi"
+)
+
+// AuthLoginPath, AuthLogoutPath, AuthTokensPath and AuthDevicesPath manage
+// node-local sessions, API tokens and devices, so they stay writable on a
+// replication follower.
+const (
+	AuthLoginPath   = "/auth/login"
+	AuthLogoutPath  = "/auth/logout"
+	AuthTokensPath  = "/auth/tokens"
+	AuthDevicesPath = "/auth/devices"
+)
+
+const (
+	sessionCookieName = "barnlog_session"
+	csrfHeaderName    = "X-CSRF-Token"
+)
+
+// publicPaths are served without a session.
+var publicPaths = map[string]struct{}{
+	"/healthz":           {},
+	"/readyz":            {},
+	AuthLoginPath:        {},
+	AuthOIDCLoginPath:    {},
+	AuthOIDCCallbackPath: {},
+}
+
+// requireAuth answers 401 unauthenticated to requests outside publicPaths
+// that carry neither a valid "Authorization: Bearer" API token or device
+// secret nor a valid session cookie, and 403 csrf_token_invalid to cookie authenticated writes
+// whose X-CSRF-Token header does not match the session. Browsers never attach
+// bearer tokens on their own, so token requests need no CSRF token. The
+// principal is handed to the application services, which check its role. It
+// must run before withRequestMeta so the user is recorded as the actor.
+func requireAuth(logger *slog.Logger, auth application.Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, public := publicPaths[r.URL.Path]; public {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var (
+				principal application.Principal
+				err       error
+			)
+			if header := r.Header.Get("Authorization"); header != "" {
+				principal, err = auth.AuthenticateToken(r.Context(), bearerToken(header))
+			} else {
+				var token string
+				if cookie, cookieErr := r.Cookie(sessionCookieName); cookieErr == nil {
+					token = cookie.Value
+				}
+				principal, err = auth.Authenticate(r.Context(), token)
+			}
+			if err != nil {
+				reqLogger := requestLogger(r.Context(), logger)
+				if writeBusinessError(w, reqLogger, err) {
+					return
+				}
+				reqLogger.Error("authenticate session failed", slog.Any("error", err))
+				writeError(w, http.StatusInternalServerError, "internal_error")
+				return
+			}
+
+			if !principal.Scoped() && !isSafeMethod(r.Method) &&
+				!validCSRFToken(r.Header.Get(csrfHeaderName), principal.CSRFToken) {
+				writeError(w, http.StatusForbidden, "csrf_token_invalid")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(application.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// requireScope answers 403 insufficient_scope when an API token or device
+// calls an operation without the scope the OpenAPI spec declares for it. The
+// generated wrapper stores those scopes in the context; operations that
+// declare none are only available with a session. It runs as a handler middleware of the
+// generated router, after requireAuth.
+func requireScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := application.PrincipalFromContext(r.Context())
+		if !ok || !principal.Scoped() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scopes, _ := r.Context().Value(openapicontract.BearerAuthScopes).([]string)
+		if len(scopes) == 0 {
+			writeError(w, http.StatusForbidden, "insufficient_scope")
+			return
+		}
+		for _, scope := range scopes {
+			if !principal.HasScope(scope) {
+				writeError(w, http.StatusForbidden, "insufficient_scope")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header, or ""
+// for any other scheme.
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func validCSRFToken(got, want string) bool {
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
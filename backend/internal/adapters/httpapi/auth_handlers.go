@@ -0,0 +1,135 @@
+package httpapi
+
+import (
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/domain"
+)
+
+type authHandlers struct {
+	logger *slog.Logger
+	auth   application.Authenticator
+}
+
+func newAuthHandlers(logger *slog.Logger, auth application.Authenticator) authHandlers {
+	return authHandlers{
+		logger: logger,
+		auth:   auth,
+	}
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type sessionResponse struct {
+	UserID      string               `json:"user_id"`
+	Username    string               `json:"username"`
+	Memberships []membershipResponse `json:"memberships"`
+	CSRFToken   string               `json:"csrf_token"`
+	ExpiresAt   string               `json:"expires_at"`
+}
+
+type membershipResponse struct {
+	BarnID string `json:"barn_id"`
+	Role   string `json:"role"`
+}
+
+// newMembershipsResponse lists memberships ordered by barn ID.
+func newMembershipsResponse(memberships domain.Memberships) []membershipResponse {
+	out := make([]membershipResponse, 0, len(memberships))
+	for _, barnID := range slices.Sorted(maps.Keys(memberships)) {
+		out = append(out, membershipResponse{BarnID: barnID, Role: string(memberships[barnID])})
+	}
+	return out
+}
+
+// login checks credentials and sets the session cookie.
+func (h authHandlers) login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+
+	out, err := h.auth.Login(r.Context(), application.LoginInput{
+		Username: req.Username,
+		Password: req.Password,
+	})
+	if err != nil {
+		logger := requestLogger(r.Context(), h.logger)
+		if be, ok := application.AsBusinessError(err); ok && be.Code == application.CodeInvalidCredentials {
+			logger.Warn("login rejected", slog.String("username", req.Username))
+		}
+		writeFailure(w, r, h.logger, err, "login failed")
+		return
+	}
+
+	setSessionCookie(w, r, out)
+	writeJSON(w, http.StatusOK, sessionResponse{
+		UserID:      out.UserID,
+		Username:    out.Username,
+		Memberships: newMembershipsResponse(out.Memberships),
+		CSRFToken:   out.CSRFToken,
+		ExpiresAt:   out.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// setSessionCookie hands the token of a new session to the browser.
+func setSessionCookie(w http.ResponseWriter, r *http.Request, out application.SessionOutput) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    out.Token,
+		Path:     "/",
+		Expires:  out.ExpiresAt,
+		MaxAge:   int(time.Until(out.ExpiresAt).Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// logout ends the current session and clears the cookie.
+func (h authHandlers) logout(w http.ResponseWriter, r *http.Request) {
+	var token string
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		token = cookie.Value
+	}
+	if err := h.auth.Logout(r.Context(), token); err != nil {
+		writeFailure(w, r, h.logger, err, "logout failed")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// session returns the user and CSRF token of the current session.
+func (h authHandlers) session(w http.ResponseWriter, r *http.Request) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	writeJSON(w, http.StatusOK, sessionResponse{
+		UserID:      principal.UserID,
+		Username:    principal.Username,
+		Memberships: newMembershipsResponse(principal.Memberships),
+		CSRFToken:   principal.CSRFToken,
+		ExpiresAt:   principal.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,292 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/domain"
+)
+
+const (
+	testSessionToken = "test-session-token"
+	testCSRFToken    = "test-csrf-token"
+)
+
+// testRoutes builds Routes with fake auth services when none are given and
+// signs every request in as testPrincipal unless it already carries a session
+// cookie or an Authorization header.
+func testRoutes(deps RouteDeps) http.Handler {
+	if deps.Authenticator == nil {
+		deps.Authenticator = newFakeAuthenticator()
+	}
+	if deps.APITokens == nil {
+		deps.APITokens = &fakeAPITokenManager{}
+	}
+	if deps.Devices == nil {
+		deps.Devices = &fakeDeviceManager{}
+	}
+	h := Routes(deps)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie(sessionCookieName); err != nil && r.Header.Get("Authorization") == "" {
+			r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: testSessionToken})
+			if r.Header.Get(csrfHeaderName) == "" {
+				r.Header.Set(csrfHeaderName, testCSRFToken)
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+var testPrincipal = application.Principal{
+	UserID:      "u1",
+	Username:    "anna",
+	Memberships: domain.Memberships{domain.ServerBarnID: domain.RoleOwner},
+	CSRFToken:   testCSRFToken,
+	ExpiresAt:   time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC),
+}
+
+type fakeAuthenticator struct {
+	sessions  map[string]application.Principal
+	tokens    map[string]application.Principal
+	loginIn   application.LoginInput
+	loginOut  application.SessionOutput
+	loginErr  error
+	loggedOut string
+}
+
+func newFakeAuthenticator() *fakeAuthenticator {
+	return &fakeAuthenticator{
+		sessions: map[string]application.Principal{testSessionToken: testPrincipal},
+		tokens:   map[string]application.Principal{},
+	}
+}
+
+func (f *fakeAuthenticator) Login(_ context.Context, in application.LoginInput) (application.SessionOutput, error) {
+	f.loginIn = in
+	return f.loginOut, f.loginErr
+}
+
+func (f *fakeAuthenticator) Authenticate(_ context.Context, token string) (application.Principal, error) {
+	principal, ok := f.sessions[token]
+	if !ok {
+		return application.Principal{}, application.BusinessError{Code: application.CodeUnauthenticated}
+	}
+	return principal, nil
+}
+
+func (f *fakeAuthenticator) AuthenticateToken(_ context.Context, token string) (application.Principal, error) {
+	principal, ok := f.tokens[token]
+	if !ok {
+		return application.Principal{}, application.BusinessError{Code: application.CodeUnauthenticated}
+	}
+	return principal, nil
+}
+
+func (f *fakeAuthenticator) Logout(_ context.Context, token string) error {
+	f.loggedOut = token
+	return nil
+}
+
+func authTestRouter(auth *fakeAuthenticator, writer *fakeAnimalWriter) http.Handler {
+	return authTestRouterWithTokens(auth, writer, &fakeAPITokenManager{})
+}
+
+func authTestRouterWithTokens(auth *fakeAuthenticator, writer *fakeAnimalWriter, tokens *fakeAPITokenManager) http.Handler {
+	return Routes(RouteDeps{
+		Logger:         testLogger(),
+		AnimalWriter:   writer,
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		Authenticator:  auth,
+		APITokens:      tokens,
+		Devices:        &fakeDeviceManager{},
+	})
+}
+
+func performAuthRequest(router http.Handler, method, path, body, cookie, csrf string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", jsonContentType)
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: cookie})
+	}
+	if csrf != "" {
+		req.Header.Set(csrfHeaderName, csrf)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRequireSession(t *testing.T) {
+	t.Parallel()
+
+	router := authTestRouter(newFakeAuthenticator(), &fakeAnimalWriter{})
+	const animal = `{"name":"Nanny","species":"goat"}`
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		cookie string
+		csrf   string
+		status int
+		code   string
+	}{
+		{"healthz is public", http.MethodGet, "/healthz", "", "", http.StatusOK, ""},
+		{"missing cookie", http.MethodGet, "/webhooks", "", "", http.StatusUnauthorized, "unauthenticated"},
+		{"unknown session", http.MethodGet, "/webhooks", "stolen", "", http.StatusUnauthorized, "unauthenticated"},
+		{"unknown path without session", http.MethodGet, "/does-not-exist", "", "", http.StatusUnauthorized, "unauthenticated"},
+		{"read with session", http.MethodGet, "/webhooks", testSessionToken, "", http.StatusOK, ""},
+		{"write without csrf token", http.MethodPost, "/animals", testSessionToken, "", http.StatusForbidden, "csrf_token_invalid"},
+		{"write with wrong csrf token", http.MethodPost, "/animals", testSessionToken, "guess", http.StatusForbidden, "csrf_token_invalid"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := performAuthRequest(router, tc.method, tc.path, animal, tc.cookie, tc.csrf)
+			assertJSONStatus(t, rec, tc.status)
+			if tc.code == "" {
+				return
+			}
+			var payload map[string]any
+			decodeJSON(t, rec, &payload)
+			if payload["code"] != tc.code {
+				t.Fatalf("expected code=%s, got %#v", tc.code, payload["code"])
+			}
+		})
+	}
+
+	t.Run("write records the user as actor", func(t *testing.T) {
+		t.Parallel()
+
+		writer := &fakeAnimalWriter{}
+		rec := performAuthRequest(
+			authTestRouter(newFakeAuthenticator(), writer),
+			http.MethodPost, "/animals", animal, testSessionToken, testCSRFToken,
+		)
+		assertJSONStatus(t, rec, http.StatusCreated)
+		if writer.in.Meta.Actor != "user:anna" {
+			t.Fatalf("expected actor user:anna, got %q", writer.in.Meta.Actor)
+		}
+		if writer.principal.UserID != "u1" || writer.principal.Memberships[domain.ServerBarnID] != domain.RoleOwner {
+			t.Fatalf("expected the service to see principal u1, got %+v", writer.principal)
+		}
+	})
+
+	t.Run("role denied by the service", func(t *testing.T) {
+		t.Parallel()
+
+		writer := &fakeAnimalWriter{err: application.BusinessError{Code: application.CodeForbidden}}
+		rec := performAuthRequest(
+			authTestRouter(newFakeAuthenticator(), writer),
+			http.MethodPost, "/animals", animal, testSessionToken, testCSRFToken,
+		)
+		assertJSONStatus(t, rec, http.StatusForbidden)
+		var payload map[string]any
+		decodeJSON(t, rec, &payload)
+		if payload["code"] != "forbidden" {
+			t.Fatalf("expected code=forbidden, got %#v", payload["code"])
+		}
+	})
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	auth := newFakeAuthenticator()
+	auth.loginOut = application.SessionOutput{
+		Token:     "new-token",
+		CSRFToken: "new-csrf",
+		UserID:    "u1",
+		Username:  "anna",
+		Memberships: domain.Memberships{
+			"north":              domain.RoleViewer,
+			domain.DefaultBarnID: domain.RoleWorker,
+		},
+		ExpiresAt: expiresAt,
+	}
+	router := authTestRouter(auth, &fakeAnimalWriter{})
+
+	rec := performAuthRequest(router, http.MethodPost, AuthLoginPath, `{"username":"anna","password":"correct horse"}`, "", "")
+	assertJSONStatus(t, rec, http.StatusOK)
+	if auth.loginIn.Username != "anna" || auth.loginIn.Password != "correct horse" {
+		t.Fatalf("unexpected login input %+v", auth.loginIn)
+	}
+
+	var payload sessionResponse
+	decodeJSON(t, rec, &payload)
+	wantMemberships := []membershipResponse{{BarnID: "default", Role: "worker"}, {BarnID: "north", Role: "viewer"}}
+	if payload.CSRFToken != "new-csrf" || payload.Username != "anna" ||
+		!slices.Equal(payload.Memberships, wantMemberships) ||
+		payload.ExpiresAt != expiresAt.Format(time.RFC3339) {
+		t.Fatalf("unexpected session response %+v", payload)
+	}
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected one cookie, got %d", len(cookies))
+	}
+	cookie := cookies[0]
+	if cookie.Name != sessionCookieName || cookie.Value != "new-token" || !cookie.HttpOnly ||
+		cookie.SameSite != http.SameSiteStrictMode || cookie.Path != "/" {
+		t.Fatalf("unexpected session cookie %+v", cookie)
+	}
+}
+
+func TestLoginRejectsInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	auth := newFakeAuthenticator()
+	auth.loginErr = application.BusinessError{Code: application.CodeInvalidCredentials, Err: errors.New("bad password")}
+	rec := performAuthRequest(authTestRouter(auth, &fakeAnimalWriter{}), http.MethodPost, AuthLoginPath, `{"username":"anna","password":"wrong"}`, "", "")
+
+	assertJSONStatus(t, rec, http.StatusUnauthorized)
+	var payload map[string]any
+	decodeJSON(t, rec, &payload)
+	if payload["code"] != "invalid_credentials" {
+		t.Fatalf("expected code=invalid_credentials, got %#v", payload["code"])
+	}
+	if len(rec.Result().Cookies()) != 0 {
+		t.Fatal("expected no session cookie")
+	}
+}
+
+func TestLogoutAndSession(t *testing.T) {
+	t.Parallel()
+
+	auth := newFakeAuthenticator()
+	router := authTestRouter(auth, &fakeAnimalWriter{})
+
+	rec := performAuthRequest(router, http.MethodGet, "/auth/session", "", testSessionToken, "")
+	assertJSONStatus(t, rec, http.StatusOK)
+	var payload sessionResponse
+	decodeJSON(t, rec, &payload)
+	if payload.UserID != "u1" || payload.CSRFToken != testCSRFToken ||
+		!slices.Equal(payload.Memberships, []membershipResponse{{BarnID: "server", Role: "owner"}}) {
+		t.Fatalf("unexpected session response %+v", payload)
+	}
+
+	rec = performAuthRequest(router, http.MethodPost, AuthLogoutPath, "", testSessionToken, testCSRFToken)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+	if auth.loggedOut != testSessionToken {
+		t.Fatalf("expected session %q to be ended, got %q", testSessionToken, auth.loggedOut)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != sessionCookieName || cookies[0].MaxAge >= 0 {
+		t.Fatalf("expected the session cookie to be cleared, got %+v", cookies)
+	}
+}
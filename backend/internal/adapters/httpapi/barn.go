@@ -0,0 +1,28 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+
+	"barnlog/backend/internal/application"
+)
+
+// barnHeaderName selects the barn a request reads and writes. Requests
+// without it use the default barn.
+const barnHeaderName = "X-Barnlog-Barn"
+
+// withBarn answers 400 barn_invalid to a malformed X-Barnlog-Barn header and
+// otherwise hands the barn to the application services, which check that
+// the principal is a member of it.
+func withBarn(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			barnID, err := application.ParseBarnID(r.Header.Get(barnHeaderName))
+			if err != nil {
+				writeBusinessError(w, requestLogger(r.Context(), logger), err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(application.WithBarn(r.Context(), barnID)))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+)
+
+func TestWithBarn(t *testing.T) {
+	t.Parallel()
+
+	fileDir := t.TempDir()
+	writer := &fakeAnimalWriter{}
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   fileDir,
+		AnimalWriter:   writer,
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+	})
+
+	createAnimal := func(barn string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/animals", bytes.NewBufferString(`{"name":"Nanny","species":"goat"}`))
+		req.Header.Set("Content-Type", jsonContentType)
+		if barn != "" {
+			req.Header.Set(barnHeaderName, barn)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("default barn", func(t *testing.T) {
+		rec := createAnimal("")
+		assertJSONStatus(t, rec, http.StatusCreated)
+		if writer.barnID != domain.DefaultBarnID {
+			t.Fatalf("expected barn %q, got %q", domain.DefaultBarnID, writer.barnID)
+		}
+	})
+
+	t.Run("named barn", func(t *testing.T) {
+		rec := createAnimal(" North ")
+		assertJSONStatus(t, rec, http.StatusCreated)
+		if writer.barnID != "north" {
+			t.Fatalf("expected barn north, got %q", writer.barnID)
+		}
+	})
+
+	t.Run("malformed barn", func(t *testing.T) {
+		for _, barn := range []string{"../north", domain.ServerBarnID} {
+			rec := createAnimal(barn)
+			assertJSONStatus(t, rec, http.StatusBadRequest)
+			var payload map[string]any
+			decodeJSON(t, rec, &payload)
+			if payload["code"] != "barn_invalid" {
+				t.Fatalf("%s: expected code=barn_invalid, got %#v", barn, payload["code"])
+			}
+		}
+	})
+
+	t.Run("uploads are stored per barn", func(t *testing.T) {
+		body, contentType := buildMultipartBody(t, "file", "animal.png", samplePNGBytes())
+		req := httptest.NewRequest(http.MethodPost, "/uploads/animal-photos", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set(barnHeaderName, "north")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assertJSONStatus(t, rec, http.StatusCreated)
+
+		var payload map[string]any
+		decodeJSON(t, rec, &payload)
+		fileID, _ := payload["file_id"].(string)
+		if _, err := os.Stat(filepath.Join(fileDir, "north", fileID)); fileID == "" || err != nil {
+			t.Fatalf("expected the file in the north directory: %v", err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	const devServer = "http://localhost:5173"
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := CORS(CORSConfig{
+		AllowedOrigins:   []string{devServer},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})(next)
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		preflight   bool
+		status      int
+		allowOrigin string
+	}{
+		{"same origin", http.MethodGet, "", false, http.StatusOK, ""},
+		{"allowed origin", http.MethodGet, devServer, false, http.StatusOK, devServer},
+		{"foreign origin", http.MethodGet, "https://evil.example", false, http.StatusOK, ""},
+		{"allowed preflight", http.MethodOptions, devServer, true, http.StatusNoContent, devServer},
+		{"foreign preflight", http.MethodOptions, "https://evil.example", true, http.StatusOK, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tc.method, "/animals", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, rec.Code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tc.allowOrigin {
+				t.Fatalf("expected Access-Control-Allow-Origin %q, got %q", tc.allowOrigin, got)
+			}
+			if got := rec.Header().Get("Vary"); got == "" || !strings.HasPrefix(got, "Origin") {
+				t.Fatalf("expected Vary: Origin, got %q", got)
+			}
+			if tc.allowOrigin == "" {
+				return
+			}
+			if rec.Header().Get("Access-Control-Allow-Credentials") != "true" {
+				t.Fatal("expected credentials to be allowed")
+			}
+			if !tc.preflight {
+				if !strings.Contains(rec.Header().Get("Access-Control-Expose-Headers"), requestIDHeaderName) {
+					t.Fatalf("expected %s to be exposed, got %q", requestIDHeaderName, rec.Header().Get("Access-Control-Expose-Headers"))
+				}
+				return
+			}
+			if got := rec.Header().Get("Access-Control-Max-Age"); got != "600" {
+				t.Fatalf("expected Access-Control-Max-Age 600, got %q", got)
+			}
+			if !strings.Contains(rec.Header().Get("Access-Control-Allow-Headers"), csrfHeaderName) {
+				t.Fatalf("expected %s to be allowed, got %q", csrfHeaderName, rec.Header().Get("Access-Control-Allow-Headers"))
+			}
+		})
+	}
+}
+
+func TestCORSWildcardWithoutCredentials(t *testing.T) {
+	t.Parallel()
+
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"*"}, MaxAge: time.Minute})(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set("Origin", "https://anywhere.example")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Fatalf("expected Access-Control-Allow-Origin *, got %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Fatalf("expected no credentials header, got %q", got)
+	}
+}
+
+func TestCORSDisabledWithoutOrigins(t *testing.T) {
+	t.Parallel()
+
+	handler := CORS(CORSConfig{})(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodOptions, "/animals", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound || rec.Header().Get("Vary") != "" {
+		t.Fatalf("expected the request to pass through untouched, got %d with %v", rec.Code, rec.Header())
+	}
+}
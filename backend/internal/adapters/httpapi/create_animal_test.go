@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"barnlog/backend/internal/application"
@@ -20,6 +21,7 @@ func TestCreateAnimal(t *testing.T) {
 	t.Run("created", testCreateAnimalCreated)
 	t.Run("replayed", testCreateAnimalReplayed)
 	t.Run("errors", testCreateAnimalErrors)
+	t.Run("field errors", testCreateAnimalFieldErrors)
 }
 
 func testCreateAnimalCreated(t *testing.T) {
@@ -274,6 +276,67 @@ func testCreateAnimalReplayed(t *testing.T) {
 	})
 }
 
+func testCreateAnimalFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	writer := &fakeAnimalWriter{err: application.BusinessError{
+		Code: application.CodeNameRequired,
+		Err:  errors.New("name: name is required\nspecies: species is invalid"),
+		Fields: []application.FieldError{
+			{Field: "name", Code: application.CodeNameRequired, Message: "name is required"},
+			{Field: "species", Code: application.CodeSpeciesInvalid, Message: "species is invalid"},
+		},
+	}}
+	rec := performCreateAnimal(t, animalTestRouter(writer), `{"name":"","species":"horse"}`, nil)
+	assertJSONStatus(t, rec, http.StatusBadRequest)
+
+	var problem struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+		Code   string `json:"code"`
+		Errors []struct {
+			Field  string `json:"field"`
+			Code   string `json:"code"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+	decodeJSON(t, rec, &problem)
+	if problem.Type != "urn:barnlog:problem:name_required" || problem.Title != "Name is required" ||
+		problem.Status != http.StatusBadRequest || problem.Code != "name_required" ||
+		problem.Detail != "name is required; species is invalid" {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+	if len(problem.Errors) != 2 ||
+		problem.Errors[0].Field != "name" || problem.Errors[0].Code != "name_required" ||
+		problem.Errors[1].Field != "species" || problem.Errors[1].Code != "species_invalid" ||
+		problem.Errors[1].Detail != "species is invalid" {
+		t.Fatalf("unexpected field errors %+v", problem.Errors)
+	}
+}
+
+func TestCreateAnimalRequestTooLarge(t *testing.T) {
+	t.Parallel()
+
+	writer := &fakeAnimalWriter{}
+	body := `{"name":"` + strings.Repeat("N", maxJSONBodyBytes) + `","species":"goat"}`
+	rec := performCreateAnimal(t, animalTestRouter(writer), body, nil)
+	assertJSONStatus(t, rec, http.StatusRequestEntityTooLarge)
+
+	var problem struct {
+		Title string `json:"title"`
+		Code  string `json:"code"`
+	}
+	decodeJSON(t, rec, &problem)
+	if problem.Code != "request_too_large" || problem.Title != "Request body is too large" {
+		t.Fatalf("unexpected problem %+v", problem)
+	}
+	if writer.in.Name != "" {
+		t.Fatalf("expected the writer not to be called, got %+v", writer.in)
+	}
+}
+
 func businessErr(code application.BusinessCode, msg string) error {
 	return application.BusinessError{
 		Code: code,
@@ -282,20 +345,24 @@ func businessErr(code application.BusinessCode, msg string) error {
 }
 
 type fakeAnimalWriter struct {
-	in  application.CreateAnimalInput
-	out application.CreateAnimalOutput
-	err error
+	in        application.CreateAnimalInput
+	principal application.Principal
+	barnID    string
+	out       application.CreateAnimalOutput
+	err       error
 }
 
-func (f *fakeAnimalWriter) Create(_ context.Context, in application.CreateAnimalInput) (application.CreateAnimalOutput, error) {
+func (f *fakeAnimalWriter) Create(ctx context.Context, in application.CreateAnimalInput) (application.CreateAnimalOutput, error) {
 	f.in = in
+	f.principal, _ = application.PrincipalFromContext(ctx)
+	f.barnID = application.BarnFromContext(ctx)
 	return f.out, f.err
 }
 
 func animalTestRouter(writer application.AnimalWriter) http.Handler {
 	r := chi.NewRouter()
 	r.Use(withRequestMeta)
-	animal := newAnimalHandlers(testLogger(), writer)
+	animal := newAnimalHandlers(testLogger(), writer, &fakeAnimalReader{})
 	r.Post("/animals", animal.createAnimal)
 	return r
 }
@@ -330,7 +397,7 @@ func assertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, expected stri
 		t.Fatalf("unmarshal response: %v", err)
 	}
 
-	if payload["error"] != expected {
-		t.Fatalf("expected error=%q, got %#v", expected, payload["error"])
+	if payload["code"] != expected {
+		t.Fatalf("expected code=%q, got %#v", expected, payload["code"])
 	}
 }
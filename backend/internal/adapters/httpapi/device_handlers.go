@@ -0,0 +1,104 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+type deviceHandlers struct {
+	logger  *slog.Logger
+	devices application.DeviceManager
+}
+
+func newDeviceHandlers(logger *slog.Logger, devices application.DeviceManager) deviceHandlers {
+	return deviceHandlers{
+		logger:  logger,
+		devices: devices,
+	}
+}
+
+type registerDeviceRequest struct {
+	Name string `json:"name"`
+}
+
+type deviceResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	Secret    string `json:"secret,omitempty"`
+}
+
+type deviceListResponse struct {
+	Items []deviceResponse `json:"items"`
+}
+
+// registerDevice registers a device of the signed-in user and returns its secret once.
+func (h deviceHandlers) registerDevice(w http.ResponseWriter, r *http.Request) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	var req registerDeviceRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+
+	out, err := h.devices.RegisterDevice(r.Context(), application.RegisterDeviceInput{
+		UserID: principal.UserID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "register device failed")
+		return
+	}
+	resp := newDeviceResponse(out.DeviceOutput)
+	resp.Secret = out.Secret
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// listDevices returns the devices of the signed-in user without their secrets.
+func (h deviceHandlers) listDevices(w http.ResponseWriter, r *http.Request) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	out, err := h.devices.ListDevices(r.Context(), principal.UserID)
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "list devices failed")
+		return
+	}
+
+	items := make([]deviceResponse, 0, len(out))
+	for _, device := range out {
+		items = append(items, newDeviceResponse(device))
+	}
+	writeJSON(w, http.StatusOK, deviceListResponse{Items: items})
+}
+
+// revokeDevice deletes a device of the signed-in user.
+func (h deviceHandlers) revokeDevice(w http.ResponseWriter, r *http.Request, deviceID string) {
+	principal, ok := application.PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthenticated")
+		return
+	}
+	if err := h.devices.RevokeDevice(r.Context(), principal.UserID, deviceID); err != nil {
+		writeFailure(w, r, h.logger, err, "revoke device failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newDeviceResponse(out application.DeviceOutput) deviceResponse {
+	return deviceResponse{
+		ID:        out.ID,
+		Name:      out.Name,
+		CreatedAt: out.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
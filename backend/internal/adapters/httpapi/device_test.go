@@ -0,0 +1,134 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+const deviceSecret = "bld_phone"
+
+func TestDeviceCredentials(t *testing.T) {
+	t.Parallel()
+
+	auth := newFakeAuthenticator()
+	auth.tokens[deviceSecret] = application.Principal{
+		UserID:   "u1",
+		Username: "anna",
+		DeviceID: "d1",
+		Scopes:   application.DeviceScopes,
+	}
+	writer := &fakeAnimalWriter{}
+	router := deviceTestRouter(auth, writer, &fakeDeviceManager{})
+
+	req := `{"name":"Nanny","species":"goat"}`
+	rec := performBearerRequest(router, http.MethodPost, "/animals", req, "Bearer "+deviceSecret)
+	assertJSONStatus(t, rec, http.StatusCreated)
+	if writer.in.Meta.Source != "device:d1" || writer.in.Meta.DeviceID != "d1" || writer.in.Meta.Actor != "user:anna" {
+		t.Fatalf("expected the write to be bound to device d1, got %+v", writer.in.Meta)
+	}
+
+	rec = performBearerRequest(router, http.MethodGet, "/webhooks", "", "Bearer "+deviceSecret)
+	assertJSONStatus(t, rec, http.StatusForbidden)
+
+	rec = performBearerRequest(router, http.MethodPost, AuthDevicesPath, `{"name":"clone"}`, "Bearer "+deviceSecret)
+	assertJSONStatus(t, rec, http.StatusForbidden)
+}
+
+func TestDeviceHandlers(t *testing.T) {
+	t.Parallel()
+
+	phone := application.DeviceOutput{
+		ID:        "d1",
+		Name:      "barn phone",
+		CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	devices := &fakeDeviceManager{
+		registerOut: application.RegisteredDeviceOutput{DeviceOutput: phone, Secret: "bld_secret"},
+		listed:      []application.DeviceOutput{phone},
+	}
+	router := deviceTestRouter(newFakeAuthenticator(), &fakeAnimalWriter{}, devices)
+
+	rec := performAuthRequest(router, http.MethodPost, AuthDevicesPath, `{"name":"barn phone"}`, testSessionToken, testCSRFToken)
+	assertJSONStatus(t, rec, http.StatusCreated)
+	if devices.registerIn.UserID != "u1" || devices.registerIn.Name != "barn phone" {
+		t.Fatalf("unexpected register input %+v", devices.registerIn)
+	}
+	var registered map[string]any
+	decodeJSON(t, rec, &registered)
+	if registered["secret"] != "bld_secret" || registered["created_at"] != "2026-03-01T12:00:00Z" {
+		t.Fatalf("unexpected registered device %#v", registered)
+	}
+
+	rec = performAuthRequest(router, http.MethodGet, AuthDevicesPath, "", testSessionToken, "")
+	assertJSONStatus(t, rec, http.StatusOK)
+	var listed struct {
+		Items []map[string]any `json:"items"`
+	}
+	decodeJSON(t, rec, &listed)
+	if devices.listUser != "u1" || len(listed.Items) != 1 || listed.Items[0]["id"] != "d1" {
+		t.Fatalf("unexpected device list %#v", listed)
+	}
+	if _, leaked := listed.Items[0]["secret"]; leaked {
+		t.Fatal("expected listed devices to omit the secret")
+	}
+
+	rec = performAuthRequest(router, http.MethodDelete, AuthDevicesPath+"/d1", "", testSessionToken, testCSRFToken)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+	if devices.revoked != "u1/d1" {
+		t.Fatalf("expected device d1 of u1 to be revoked, got %q", devices.revoked)
+	}
+
+	devices.err = application.BusinessError{Code: application.CodeDeviceNotFound}
+	rec = performAuthRequest(router, http.MethodDelete, AuthDevicesPath+"/d2", "", testSessionToken, testCSRFToken)
+	assertJSONStatus(t, rec, http.StatusNotFound)
+}
+
+func deviceTestRouter(auth *fakeAuthenticator, writer *fakeAnimalWriter, devices *fakeDeviceManager) http.Handler {
+	return Routes(RouteDeps{
+		Logger:         testLogger(),
+		AnimalWriter:   writer,
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		Authenticator:  auth,
+		APITokens:      &fakeAPITokenManager{},
+		Devices:        devices,
+	})
+}
+
+type fakeDeviceManager struct {
+	registerIn  application.RegisterDeviceInput
+	registerOut application.RegisteredDeviceOutput
+	listed      []application.DeviceOutput
+	listUser    string
+	revoked     string
+	err         error
+}
+
+func (f *fakeDeviceManager) RegisterDevice(
+	_ context.Context,
+	in application.RegisterDeviceInput,
+) (application.RegisteredDeviceOutput, error) {
+	f.registerIn = in
+	return f.registerOut, f.err
+}
+
+func (f *fakeDeviceManager) ListDevices(_ context.Context, userID string) ([]application.DeviceOutput, error) {
+	f.listUser = userID
+	return f.listed, f.err
+}
+
+func (f *fakeDeviceManager) RevokeDevice(_ context.Context, userID string, deviceID string) error {
+	f.revoked = userID + "/" + deviceID
+	return f.err
+}
+
+var _ application.DeviceManager = (*fakeDeviceManager)(nil)
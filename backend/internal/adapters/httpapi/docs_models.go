@@ -2,7 +2,9 @@ package httpapi
 
 import (
 	"fmt"
+	"strings"
 
+	"barnlog/backend/internal/application"
 	openapicontract "barnlog/backend/internal/contracts/openapi"
 )
 
@@ -10,15 +12,55 @@ func newStatusResponse(status string) openapicontract.HttpapiStatusResponse {
 	return openapicontract.HttpapiStatusResponse{Status: status}
 }
 
-func newReadyResponse(status, timestamp string) openapicontract.HttpapiReadyResponse {
-	return openapicontract.HttpapiReadyResponse{
-		Status:    status,
+func newReadyResponse(checks []application.ReadinessCheck, timestamp string) openapicontract.HttpapiReadyResponse {
+	resp := openapicontract.HttpapiReadyResponse{
+		Status:    openapicontract.Ready,
 		Timestamp: timestamp,
+		Checks:    make([]openapicontract.HttpapiReadyCheck, 0, len(checks)),
 	}
+	for _, check := range checks {
+		item := openapicontract.HttpapiReadyCheck{
+			Name:   openapicontract.HttpapiReadyCheckName(check.Name),
+			Status: openapicontract.Ok,
+			Lag:    check.Lag,
+		}
+		if !check.OK {
+			resp.Status = openapicontract.NotReady
+			item.Status = openapicontract.Fail
+			item.Error = &check.Error
+		}
+		resp.Checks = append(resp.Checks, item)
+	}
+	return resp
 }
 
-func newErrorResponse(code string) openapicontract.HttpapiErrorResponse {
-	return openapicontract.HttpapiErrorResponse{Error: code}
+func newErrorResponse(status int, code string, fields []application.FieldError) openapicontract.HttpapiErrorResponse {
+	resp := openapicontract.HttpapiErrorResponse{
+		Type:   problemTypePrefix + code,
+		Title:  errorTitles[code],
+		Status: status,
+		Code:   code,
+	}
+	if len(fields) == 0 {
+		return resp
+	}
+
+	items := make([]openapicontract.HttpapiFieldError, 0, len(fields))
+	details := make([]string, 0, len(fields))
+	for _, field := range fields {
+		item := openapicontract.HttpapiFieldError{Field: field.Field, Code: normalizeErrorCode(string(field.Code))}
+		if field.Message != "" {
+			item.Detail = &field.Message
+			details = append(details, field.Message)
+		}
+		items = append(items, item)
+	}
+	resp.Errors = &items
+	if len(details) > 0 {
+		detail := strings.Join(details, "; ")
+		resp.Detail = &detail
+	}
+	return resp
 }
 
 func newUploadFileResponse(
@@ -0,0 +1,117 @@
+package httpapi
+
+import (
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/contracts/eventlog"
+)
+
+// EventExportPath and EventImportPath move the whole event log, so they set
+// their own deadlines and request timeout middleware must not wrap them.
+const (
+	EventExportPath = "/events/export"
+	EventImportPath = "/events/import"
+)
+
+const (
+	ndjsonContentType      = eventlog.ContentType
+	eventExportWriteWindow = 30 * time.Second
+	eventImportReadTimeout = 10 * time.Minute
+	maxEventImportBytes    = 256 << 20 // 256 MiB
+)
+
+type eventArchiveHandlers struct {
+	logger  *slog.Logger
+	archive application.EventArchive
+}
+
+func newEventArchiveHandlers(logger *slog.Logger, archive application.EventArchive) eventArchiveHandlers {
+	return eventArchiveHandlers{
+		logger:  logger,
+		archive: archive,
+	}
+}
+
+type importEventsResponse struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+// exportEvents streams every stored event as NDJSON in position order.
+func (h eventArchiveHandlers) exportEvents(w http.ResponseWriter, r *http.Request) {
+	out := &exportWriter{w: w, rc: http.NewResponseController(w)}
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="barnlog-events.ndjson"`)
+
+	if err := h.archive.Export(r.Context(), out); err != nil {
+		requestLogger(r.Context(), h.logger).Error("export events failed", slog.Any("error", err))
+		if !out.written {
+			writeError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		// Abort instead of ending the body cleanly so a truncated export is
+		// never mistaken for a complete one.
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// importEvents replays an NDJSON export into the event log in one transaction.
+func (h eventArchiveHandlers) importEvents(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(r.Header.Get("Content-Type")))
+	if err != nil || mediaType != ndjsonContentType {
+		writeError(w, http.StatusUnsupportedMediaType, "unsupported_media_type")
+		return
+	}
+
+	logger := requestLogger(r.Context(), h.logger)
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Now().Add(eventImportReadTimeout)); err != nil &&
+		!errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("extend import read deadline", slog.Any("error", err))
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxEventImportBytes)
+
+	out, err := h.archive.Import(r.Context(), r.Body)
+	if err != nil {
+		if writeBusinessError(w, logger, err) {
+			return
+		}
+		if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+			writeError(w, http.StatusRequestEntityTooLarge, "request_too_large")
+			return
+		}
+
+		logger.Error("import events failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, importEventsResponse{
+		Imported: out.Imported,
+		Skipped:  out.Skipped,
+	})
+}
+
+// exportWriter extends the write deadline on every write, replacing the
+// server-wide WriteTimeout for as long as the client keeps reading.
+type exportWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	written bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if err := e.rc.SetWriteDeadline(time.Now().Add(eventExportWriteWindow)); err != nil &&
+		!errors.Is(err, http.ErrNotSupported) {
+		return 0, err
+	}
+	e.written = true
+	// #nosec G705 -- NDJSON lines are JSON-encoded and served as application/x-ndjson.
+	return e.w.Write(p)
+}
@@ -0,0 +1,147 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestExportEvents(t *testing.T) {
+	t.Parallel()
+
+	archive := &fakeEventArchive{export: "{\"id\":\"e1\"}\n{\"id\":\"e2\"}\n"}
+	rec := performArchiveRequest(t, archive, http.MethodGet, EventExportPath, "", "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != ndjsonContentType {
+		t.Fatalf("expected content-type %s, got %q", ndjsonContentType, got)
+	}
+	if rec.Body.String() != archive.export {
+		t.Fatalf("unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestExportEventsFailureBeforeFirstLine(t *testing.T) {
+	t.Parallel()
+
+	rec := performArchiveRequest(t, &fakeEventArchive{err: errors.New("db down")}, http.MethodGet, EventExportPath, "", "")
+	assertJSONStatus(t, rec, http.StatusInternalServerError)
+}
+
+func TestImportEvents(t *testing.T) {
+	t.Parallel()
+
+	archive := &fakeEventArchive{out: application.ImportEventsOutput{Imported: 2, Skipped: 1}}
+	rec := performArchiveRequest(t, archive, http.MethodPost, EventImportPath, ndjsonContentType, "{\"id\":\"e1\"}\n")
+	assertJSONStatus(t, rec, http.StatusOK)
+
+	var payload importEventsResponse
+	decodeJSON(t, rec, &payload)
+	if payload.Imported != 2 || payload.Skipped != 1 {
+		t.Fatalf("unexpected response %+v", payload)
+	}
+	if archive.imported != "{\"id\":\"e1\"}\n" {
+		t.Fatalf("expected request body to reach import, got %q", archive.imported)
+	}
+}
+
+func TestImportEventsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		err         error
+		status      int
+		code        string
+	}{
+		{name: "wrong content type", contentType: "application/json", status: http.StatusUnsupportedMediaType, code: "unsupported_media_type"},
+		{
+			name:        "invalid line",
+			contentType: ndjsonContentType,
+			err:         application.BusinessError{Code: application.CodeImportInvalid},
+			status:      http.StatusBadRequest,
+			code:        "import_invalid",
+		},
+		{
+			name:        "conflict",
+			contentType: ndjsonContentType,
+			err:         application.BusinessError{Code: application.CodeConflict},
+			status:      http.StatusConflict,
+			code:        "conflict",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := performArchiveRequest(t, &fakeEventArchive{err: tc.err}, http.MethodPost, EventImportPath, tc.contentType, "{}\n")
+			assertJSONStatus(t, rec, tc.status)
+
+			var payload map[string]any
+			decodeJSON(t, rec, &payload)
+			if payload["code"] != tc.code {
+				t.Fatalf("expected code %q, got %#v", tc.code, payload["code"])
+			}
+		})
+	}
+}
+
+type fakeEventArchive struct {
+	export   string
+	out      application.ImportEventsOutput
+	err      error
+	imported string
+}
+
+func (f *fakeEventArchive) Export(_ context.Context, w io.Writer) error {
+	if f.err != nil {
+		return f.err
+	}
+	_, err := io.WriteString(w, f.export)
+	return err
+}
+
+func (f *fakeEventArchive) Import(_ context.Context, r io.Reader) (application.ImportEventsOutput, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return application.ImportEventsOutput{}, err
+	}
+	f.imported = string(body)
+	return f.out, f.err
+}
+
+func performArchiveRequest(
+	t *testing.T,
+	archive application.EventArchive,
+	method, path, contentType, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   archive,
+		WebhookManager: &fakeWebhookManager{},
+	})
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
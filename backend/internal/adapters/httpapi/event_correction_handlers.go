@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"barnlog/backend/internal/application"
+)
+
+type eventCorrectionHandlers struct {
+	logger         *slog.Logger
+	eventCorrector application.EventCorrector
+}
+
+func newEventCorrectionHandlers(logger *slog.Logger, eventCorrector application.EventCorrector) eventCorrectionHandlers {
+	return eventCorrectionHandlers{
+		logger:         logger,
+		eventCorrector: eventCorrector,
+	}
+}
+
+type correctEventRequest struct {
+	Reason  string          `json:"reason"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type voidEventRequest struct {
+	Reason string `json:"reason"`
+}
+
+type eventAmendmentResponse struct {
+	AnimalID      string `json:"animal_id"`
+	EventID       string `json:"event_id"`
+	TargetEventID string `json:"target_event_id"`
+}
+
+// correctAnimalEvent appends an event.corrected event replacing the payload of an earlier animal event.
+func (h eventCorrectionHandlers) correctAnimalEvent(w http.ResponseWriter, r *http.Request, animalID, eventID string) {
+	var req correctEventRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+
+	meta, ok := requestMeta(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	out, err := h.eventCorrector.CorrectAnimalEvent(r.Context(), application.CorrectAnimalEventInput{
+		AnimalID: animalID,
+		EventID:  eventID,
+		Reason:   req.Reason,
+		Payload:  req.Payload,
+		Meta:     meta.toApplication(),
+	})
+	h.writeAmendment(w, r, out, err, "correct animal event failed")
+}
+
+// voidAnimalEvent appends an event.voided event retracting an earlier animal event.
+func (h eventCorrectionHandlers) voidAnimalEvent(w http.ResponseWriter, r *http.Request, animalID, eventID string) {
+	var req voidEventRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+
+	meta, ok := requestMeta(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	out, err := h.eventCorrector.VoidAnimalEvent(r.Context(), application.VoidAnimalEventInput{
+		AnimalID: animalID,
+		EventID:  eventID,
+		Reason:   req.Reason,
+		Meta:     meta.toApplication(),
+	})
+	h.writeAmendment(w, r, out, err, "void animal event failed")
+}
+
+func (h eventCorrectionHandlers) writeAmendment(
+	w http.ResponseWriter,
+	r *http.Request,
+	out application.EventAmendmentOutput,
+	err error,
+	failure string,
+) {
+	if err != nil {
+		logger := requestLogger(r.Context(), h.logger)
+		if writeBusinessError(w, logger, err) {
+			return
+		}
+
+		logger.Error(failure, slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	status := http.StatusCreated
+	if out.Replayed {
+		status = http.StatusOK
+	}
+
+	writeJSON(w, status, eventAmendmentResponse{
+		AnimalID:      out.AnimalID,
+		EventID:       out.EventID,
+		TargetEventID: out.TargetEventID,
+	})
+}
@@ -0,0 +1,193 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestCorrectAnimalEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("created", func(t *testing.T) {
+		t.Parallel()
+
+		corrector := &fakeEventCorrector{
+			out: application.EventAmendmentOutput{AnimalID: "a1", EventID: "e2", TargetEventID: "e1"},
+		}
+		rec := performAmendment(t, corrector, "/animals/a1/events/e1/correction",
+			`{"reason":"typo","payload":{"name":"Nanny","species":"goat","tag":"G-8"}}`)
+		assertJSONStatus(t, rec, http.StatusCreated)
+
+		var payload map[string]any
+		decodeJSON(t, rec, &payload)
+		if payload["event_id"] != "e2" || payload["target_event_id"] != "e1" {
+			t.Fatalf("unexpected response %#v", payload)
+		}
+		if corrector.correctIn.AnimalID != "a1" || corrector.correctIn.EventID != "e1" {
+			t.Fatalf("unexpected path params %+v", corrector.correctIn)
+		}
+		if string(corrector.correctIn.Payload) != `{"name":"Nanny","species":"goat","tag":"G-8"}` {
+			t.Fatalf("unexpected payload %s", corrector.correctIn.Payload)
+		}
+		if corrector.correctIn.Meta.RequestID != "req-1" || corrector.correctIn.Meta.Source != "web.app" {
+			t.Fatalf("unexpected request meta %+v", corrector.correctIn.Meta)
+		}
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		t.Parallel()
+
+		rec := performAmendment(t, &fakeEventCorrector{
+			out: application.EventAmendmentOutput{EventID: "e2", Replayed: true},
+		}, "/animals/a1/events/e1/correction", `{"reason":"typo","payload":{}}`)
+		assertJSONStatus(t, rec, http.StatusOK)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			code   application.BusinessCode
+			status int
+		}{
+			{code: application.CodeReasonRequired, status: http.StatusBadRequest},
+			{code: application.CodePayloadInvalid, status: http.StatusBadRequest},
+			{code: application.CodeEventAggregateMismatch, status: http.StatusBadRequest},
+			{code: application.CodeEventNotCorrectable, status: http.StatusBadRequest},
+			{code: application.CodeEventNotFound, status: http.StatusNotFound},
+			{code: application.CodeEventVoided, status: http.StatusConflict},
+		}
+		for _, tt := range tests {
+			rec := performAmendment(t, &fakeEventCorrector{
+				err: businessErr(tt.code, string(tt.code)),
+			}, "/animals/a1/events/e1/correction", `{"reason":"typo","payload":{}}`)
+			assertJSONStatus(t, rec, tt.status)
+			assertErrorCode(t, rec, string(tt.code))
+		}
+	})
+}
+
+func TestVoidAnimalEvent(t *testing.T) {
+	t.Parallel()
+
+	corrector := &fakeEventCorrector{
+		out: application.EventAmendmentOutput{AnimalID: "a1", EventID: "e3", TargetEventID: "e1"},
+	}
+	rec := performAmendment(t, corrector, "/animals/a1/events/e1/void", `{"reason":"duplicate"}`)
+	assertJSONStatus(t, rec, http.StatusCreated)
+	if corrector.voidIn.Reason != "duplicate" || corrector.voidIn.EventID != "e1" {
+		t.Fatalf("unexpected void input %+v", corrector.voidIn)
+	}
+
+	rec = performAmendment(t, corrector, "/animals/a1/events/e1/void", `{"reason":"duplicate","payload":{}}`)
+	assertJSONStatus(t, rec, http.StatusBadRequest)
+	assertErrorCode(t, rec, "invalid_json")
+}
+
+func TestGetAnimalTimeline(t *testing.T) {
+	t.Parallel()
+
+	reader := &fakeAnimalReader{
+		timeline: application.AnimalTimelineOutput{
+			AnimalID: "a1",
+			Entries: []application.TimelineEntryOutput{{
+				EventID:         "e1",
+				EventType:       "animal.created",
+				Status:          application.TimelineStatusVoided,
+				Payload:         []byte(`{"name":"Nanny"}`),
+				OriginalPayload: []byte(`{"name":"Nanny"}`),
+				Corrections: []application.TimelineCorrectionOutput{{
+					EventID:   "e2",
+					EventType: "event.voided",
+					Reason:    "duplicate",
+				}},
+			}},
+		},
+	}
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   reader,
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/animals/a1/timeline", nil))
+	assertJSONStatus(t, rec, http.StatusOK)
+
+	var payload struct {
+		AnimalID string `json:"animal_id"`
+		Entries  []struct {
+			Status      string         `json:"status"`
+			Payload     map[string]any `json:"payload"`
+			Corrections []struct {
+				Reason  string         `json:"reason"`
+				Payload map[string]any `json:"payload"`
+			} `json:"corrections"`
+		} `json:"entries"`
+	}
+	decodeJSON(t, rec, &payload)
+	if payload.AnimalID != "a1" || len(payload.Entries) != 1 {
+		t.Fatalf("unexpected timeline %+v", payload)
+	}
+	entry := payload.Entries[0]
+	if entry.Status != "voided" || entry.Payload["name"] != "Nanny" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if len(entry.Corrections) != 1 || entry.Corrections[0].Reason != "duplicate" || entry.Corrections[0].Payload != nil {
+		t.Fatalf("unexpected corrections %+v", entry.Corrections)
+	}
+}
+
+type fakeEventCorrector struct {
+	correctIn application.CorrectAnimalEventInput
+	voidIn    application.VoidAnimalEventInput
+	out       application.EventAmendmentOutput
+	err       error
+}
+
+func (f *fakeEventCorrector) CorrectAnimalEvent(
+	_ context.Context,
+	in application.CorrectAnimalEventInput,
+) (application.EventAmendmentOutput, error) {
+	f.correctIn = in
+	return f.out, f.err
+}
+
+func (f *fakeEventCorrector) VoidAnimalEvent(
+	_ context.Context,
+	in application.VoidAnimalEventInput,
+) (application.EventAmendmentOutput, error) {
+	f.voidIn = in
+	return f.out, f.err
+}
+
+func performAmendment(t *testing.T, corrector application.EventCorrector, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: corrector,
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+	})
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "req-1")
+	req.Header.Set("X-Barnlog-Source", "web.app")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
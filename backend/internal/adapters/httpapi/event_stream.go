@@ -0,0 +1,217 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/application"
+	openapicontract "barnlog/backend/internal/contracts/openapi"
+)
+
+// EventStreamPath is the long-lived SSE endpoint. It manages its own write
+// deadlines, so request timeout middleware must not wrap it.
+const EventStreamPath = "/events/stream"
+
+const (
+	eventStreamPollInterval      = time.Second
+	eventStreamHeartbeatInterval = 15 * time.Second
+	eventStreamWriteTimeout      = 10 * time.Second
+	eventStreamRetry             = 3 * time.Second
+	eventStreamBatchSize         = 100
+)
+
+type eventStreamHandlers struct {
+	logger            *slog.Logger
+	feed              application.EventFeed
+	shutdown          <-chan struct{}
+	pollInterval      time.Duration
+	heartbeatInterval time.Duration
+}
+
+func newEventStreamHandlers(
+	logger *slog.Logger,
+	feed application.EventFeed,
+	shutdown <-chan struct{},
+) eventStreamHandlers {
+	return eventStreamHandlers{
+		logger:            logger,
+		feed:              feed,
+		shutdown:          shutdown,
+		pollInterval:      eventStreamPollInterval,
+		heartbeatInterval: eventStreamHeartbeatInterval,
+	}
+}
+
+type streamEvent struct {
+	Position      int64           `json:"position"`
+	EventID       string          `json:"event_id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	CreatedBy     string          `json:"created_by"`
+	OccurredAt    string          `json:"occurred_at"`
+	RecordedAt    string          `json:"recorded_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// streamEvents pushes newly appended events to the client as Server-Sent Events.
+// The event log is polled by global position, so events appended by any process are delivered.
+func (h eventStreamHandlers) streamEvents(
+	w http.ResponseWriter,
+	r *http.Request,
+	params openapicontract.GetEventsStreamParams,
+) {
+	ctx := r.Context()
+	logger := requestLogger(ctx, h.logger)
+	query := application.EventFeedQuery{
+		AggregateType: deref(params.AggregateType),
+		AggregateID:   deref(params.AggregateId),
+		EventType:     deref(params.EventType),
+		Limit:         eventStreamBatchSize,
+	}
+
+	switch {
+	case params.LastEventID != nil && strings.TrimSpace(*params.LastEventID) != "":
+		position, err := strconv.ParseInt(strings.TrimSpace(*params.LastEventID), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, string(application.CodeInvalidCursor))
+			return
+		}
+		query.AfterPosition = position
+	case params.After != nil:
+		query.AfterPosition = *params.After
+	default:
+		latest, err := h.feed.LatestPosition(ctx)
+		if err != nil {
+			logger.Error("event stream latest position failed", slog.Any("error", err))
+			writeError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		query.AfterPosition = latest
+	}
+
+	// The first page is read before any bytes are sent so cursor errors still get a JSON response.
+	events, err := h.feed.ListAfter(ctx, query)
+	if err != nil {
+		if writeBusinessError(w, logger, err) {
+			return
+		}
+		logger.Error("event stream read failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	stream := sseWriter{w: w, rc: rc}
+	if err := stream.write(fmt.Sprintf("retry: %d\n\n", eventStreamRetry.Milliseconds())); err != nil {
+		logger.Warn("event stream not writable", slog.Any("error", err))
+		return
+	}
+
+	poll := time.NewTicker(h.pollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		for _, event := range events {
+			if err := stream.event(event); err != nil {
+				return
+			}
+			query.AfterPosition = event.Position
+		}
+
+		if len(events) < query.Limit {
+			if !h.wait(ctx, poll, heartbeat, stream) {
+				return
+			}
+		}
+
+		events, err = h.feed.ListAfter(ctx, query)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error("event stream read failed", slog.Any("error", err))
+			}
+			return
+		}
+	}
+}
+
+// wait blocks until the next poll, sending heartbeats meanwhile.
+// It reports false once the client is gone or the server is shutting down.
+func (h eventStreamHandlers) wait(
+	ctx context.Context,
+	poll, heartbeat *time.Ticker,
+	stream sseWriter,
+) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-h.shutdown:
+			return false
+		case <-poll.C:
+			return true
+		case <-heartbeat.C:
+			if err := stream.write(": keep-alive\n\n"); err != nil {
+				return false
+			}
+		}
+	}
+}
+
+// sseWriter writes Server-Sent Events frames with a per-write deadline,
+// replacing the server-wide WriteTimeout for the lifetime of the stream.
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s sseWriter) event(event application.FeedEvent) error {
+	data, err := json.Marshal(streamEvent{
+		Position:      event.Position,
+		EventID:       event.EventID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.EventType,
+		CreatedBy:     event.CreatedBy,
+		OccurredAt:    event.OccurredAt,
+		RecordedAt:    event.RecordedAt,
+		Payload:       event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal stream event: %w", err)
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.Position, event.EventType, data))
+}
+
+func (s sseWriter) write(frame string) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout)); err != nil &&
+		!errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	// #nosec G705 -- frames carry JSON-encoded data and are served as text/event-stream.
+	if _, err := s.w.Write([]byte(frame)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
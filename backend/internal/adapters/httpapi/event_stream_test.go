@@ -0,0 +1,236 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/application"
+	openapicontract "barnlog/backend/internal/contracts/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("starts at the end of the log", func(t *testing.T) {
+		t.Parallel()
+
+		feed := &fakeEventFeed{latest: 2, events: feedEvents(1, 2)}
+		lines := openEventStream(t, feed, "", nil)
+
+		feed.append(feedEvents(3)...)
+		frame := readSSEFrame(t, lines)
+		if !strings.Contains(frame, "id: 3\n") || !strings.Contains(frame, "event: animal.created\n") {
+			t.Fatalf("expected event 3, got %q", frame)
+		}
+		if !strings.Contains(frame, `"payload":{"name":"Nanny"}`) {
+			t.Fatalf("expected payload in data, got %q", frame)
+		}
+	})
+
+	t.Run("resumes after Last-Event-ID", func(t *testing.T) {
+		t.Parallel()
+
+		feed := &fakeEventFeed{latest: 3, events: feedEvents(1, 2, 3)}
+		lines := openEventStream(t, feed, "?after=0&aggregate_type=animal", map[string]string{
+			"Last-Event-ID": "1",
+		})
+
+		for _, want := range []string{"id: 2\n", "id: 3\n"} {
+			if frame := readSSEFrame(t, lines); !strings.Contains(frame, want) {
+				t.Fatalf("expected frame with %q, got %q", want, frame)
+			}
+		}
+		if got := feed.lastQuery().AggregateType; got != "animal" {
+			t.Fatalf("expected aggregate_type filter, got %q", got)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		h := eventStreamTestRouter(&fakeEventFeed{})
+		req := httptest.NewRequest(http.MethodGet, EventStreamPath, nil)
+		req.Header.Set("Last-Event-ID", "abc")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assertJSONStatus(t, rec, http.StatusBadRequest)
+		assertErrorCode(t, rec, "invalid_cursor")
+	})
+}
+
+func TestStreamEventsEndsOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	shutdown := make(chan struct{})
+	h := newEventStreamHandlers(testLogger(), &fakeEventFeed{}, shutdown)
+	h.pollInterval = time.Hour
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req := httptest.NewRequest(http.MethodGet, EventStreamPath, nil)
+		h.streamEvents(httptest.NewRecorder(), req, streamParams(nil))
+	}()
+
+	close(shutdown)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stream did not end after shutdown")
+	}
+}
+
+func openEventStream(
+	t *testing.T,
+	feed *fakeEventFeed,
+	query string,
+	headers map[string]string,
+) <-chan string {
+	t.Helper()
+
+	server := httptest.NewServer(eventStreamTestRouter(feed))
+	t.Cleanup(server.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+EventStreamPath+query, nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", got)
+	}
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+// readSSEFrame returns the next event frame, skipping retry and comment frames.
+func readSSEFrame(t *testing.T, lines <-chan string) string {
+	t.Helper()
+
+	var frame strings.Builder
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("stream closed, partial frame %q", frame.String())
+			}
+			if line != "" {
+				frame.WriteString(line + "\n")
+				continue
+			}
+			if strings.Contains(frame.String(), "id: ") {
+				return frame.String()
+			}
+			frame.Reset()
+		case <-timeout:
+			t.Fatalf("timed out waiting for event frame")
+		}
+	}
+}
+
+func eventStreamTestRouter(feed application.EventFeed) http.Handler {
+	stream := newEventStreamHandlers(testLogger(), feed, nil)
+	stream.pollInterval = 10 * time.Millisecond
+
+	r := chi.NewRouter()
+	r.Use(withRequestMeta)
+	openapicontract.HandlerFromMux(oapiServerAdapter{stream: stream}, r)
+	return r
+}
+
+func streamParams(lastEventID *string) openapicontract.GetEventsStreamParams {
+	return openapicontract.GetEventsStreamParams{LastEventID: lastEventID}
+}
+
+func feedEvents(positions ...int64) []application.FeedEvent {
+	events := make([]application.FeedEvent, 0, len(positions))
+	for _, position := range positions {
+		events = append(events, application.FeedEvent{
+			Position:      position,
+			EventID:       fmt.Sprintf("e%d", position),
+			AggregateType: "animal",
+			AggregateID:   "a1",
+			EventType:     "animal.created",
+			CreatedBy:     "user:test",
+			Payload:       []byte(`{"name":"Nanny"}`),
+		})
+	}
+	return events
+}
+
+type fakeEventFeed struct {
+	mu      sync.Mutex
+	latest  int64
+	events  []application.FeedEvent
+	queries []application.EventFeedQuery
+}
+
+func (f *fakeEventFeed) append(events ...application.FeedEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, events...)
+}
+
+func (f *fakeEventFeed) lastQuery() application.EventFeedQuery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.queries) == 0 {
+		return application.EventFeedQuery{}
+	}
+	return f.queries[len(f.queries)-1]
+}
+
+func (f *fakeEventFeed) ListAfter(_ context.Context, q application.EventFeedQuery) ([]application.FeedEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, q)
+
+	if q.AfterPosition < 0 {
+		return nil, businessErr(application.CodeInvalidCursor, "cursor must not be negative")
+	}
+	var out []application.FeedEvent
+	for _, event := range f.events {
+		if event.Position > q.AfterPosition {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeEventFeed) LatestPosition(context.Context) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.latest, nil
+}
@@ -14,8 +14,10 @@ import (
 
 var errFileTooLarge = errors.New("file too large")
 
+// fileStore keeps each barn's files in their own directory, so a photo_id
+// only resolves within the barn it was uploaded to.
 type fileStore interface {
-	Save(ctx context.Context, source io.Reader, maxBytes int64) (fileID string, sizeBytes int64, err error)
+	Save(ctx context.Context, barnID string, source io.Reader, maxBytes int64) (fileID string, sizeBytes int64, err error)
 }
 
 type diskFileStore struct {
@@ -33,13 +35,11 @@ func newFileStore(baseDir string) *diskFileStore {
 
 func (s *diskFileStore) Save(
 	ctx context.Context,
+	barnID string,
 	source io.Reader,
 	maxBytes int64,
 ) (fileID string, sizeBytes int64, err error) {
-	if err := os.MkdirAll(s.baseDir, 0o750); err != nil {
-		return "", 0, fmt.Errorf("create file dir: %w", err)
-	}
-	root, err := os.OpenRoot(s.baseDir)
+	root, err := s.openBarnRoot(barnID)
 	if err != nil {
 		return "", 0, fmt.Errorf("open file root: %w", err)
 	}
@@ -106,6 +106,39 @@ func (s *diskFileStore) Save(
 	return fileID, writtenBytes, nil
 }
 
+// openBarnRoot opens the directory of barnID, creating it if needed. Barn IDs
+// are validated before they reach the store, so they are safe path elements.
+func (s *diskFileStore) openBarnRoot(barnID string) (*os.Root, error) {
+	dir := filepath.Join(s.baseDir, barnID)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create file dir: %w", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open file root: %w", err)
+	}
+	return root, nil
+}
+
+// CheckWritable creates and removes a probe file in the base directory.
+func (s *diskFileStore) CheckWritable() error {
+	if err := os.MkdirAll(s.baseDir, 0o750); err != nil {
+		return fmt.Errorf("create file dir: %w", err)
+	}
+	probe, err := os.CreateTemp(s.baseDir, ".readyz-*")
+	if err != nil {
+		return fmt.Errorf("create probe file: %w", err)
+	}
+	closeErr := probe.Close()
+	if err := os.Remove(probe.Name()); err != nil {
+		return fmt.Errorf("remove probe file: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("close probe file: %w", closeErr)
+	}
+	return nil
+}
+
 func newFileID() (string, error) {
 	var bytes [16]byte
 	if _, err := rand.Read(bytes[:]); err != nil {
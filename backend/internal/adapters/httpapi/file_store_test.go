@@ -5,6 +5,8 @@ import (
 	"errors"
 	"io"
 	"testing"
+
+	"barnlog/backend/internal/domain"
 )
 
 func TestNewFileStoreRejectsInvalidBaseDir(t *testing.T) {
@@ -39,7 +41,7 @@ func TestFileStoreSaveHonorsContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	source := &cancelAfterFirstRead{cancel: cancel}
 
-	_, _, err := store.Save(ctx, source, 1024)
+	_, _, err := store.Save(ctx, domain.DefaultBarnID, source, 1024)
 	if !errors.Is(err, context.Canceled) {
 		t.Fatalf("expected context canceled error, got %v", err)
 	}
@@ -0,0 +1,104 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestGetAnimal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeAnimalReader{
+			out: application.GetAnimalOutput{
+				AnimalID: "animal_123",
+				Name:     "Nanny",
+				Species:  "goat",
+				Tag:      "G-7",
+				Version:  3,
+			},
+		}
+
+		rec := performGetAnimal(t, reader, "animal_123")
+		assertJSONStatus(t, rec, http.StatusOK)
+
+		var payload map[string]any
+		decodeJSON(t, rec, &payload)
+		if payload["animal_id"] != "animal_123" {
+			t.Fatalf("expected animal_id=animal_123, got %#v", payload["animal_id"])
+		}
+		if payload["name"] != "Nanny" {
+			t.Fatalf("expected name=Nanny, got %#v", payload["name"])
+		}
+		if payload["version"] != float64(3) {
+			t.Fatalf("expected version=3, got %#v", payload["version"])
+		}
+		if _, ok := payload["birthdate"]; ok {
+			t.Fatalf("expected empty birthdate to be omitted, got %#v", payload["birthdate"])
+		}
+		if reader.animalID != "animal_123" {
+			t.Fatalf("expected reader to receive animal_123, got %q", reader.animalID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		rec := performGetAnimal(t, &fakeAnimalReader{
+			err: businessErr(application.CodeAnimalNotFound, "animal not found"),
+		}, "missing")
+		assertJSONStatus(t, rec, http.StatusNotFound)
+		assertErrorCode(t, rec, "animal_not_found")
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		rec := performGetAnimal(t, &fakeAnimalReader{err: errors.New("boom")}, "animal_123")
+		assertJSONStatus(t, rec, http.StatusInternalServerError)
+		assertErrorCode(t, rec, "internal_error")
+	})
+}
+
+type fakeAnimalReader struct {
+	animalID string
+	out      application.GetAnimalOutput
+	timeline application.AnimalTimelineOutput
+	err      error
+}
+
+func (f *fakeAnimalReader) Get(_ context.Context, animalID string) (application.GetAnimalOutput, error) {
+	f.animalID = animalID
+	return f.out, f.err
+}
+
+func (f *fakeAnimalReader) Timeline(_ context.Context, animalID string) (application.AnimalTimelineOutput, error) {
+	f.animalID = animalID
+	return f.timeline, f.err
+}
+
+func performGetAnimal(t *testing.T, reader application.AnimalReader, animalID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   reader,
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/animals/"+animalID, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
@@ -3,24 +3,37 @@ package httpapi
 
 import (
 	"log/slog"
+
+	"barnlog/backend/internal/application"
 )
 
 type handlers struct {
-	logger *slog.Logger
+	logger    *slog.Logger
+	readiness application.ReadinessChecker
+	files     *diskFileStore
 }
 
 type uploadHandlers struct {
 	logger    *slog.Logger
 	fileStore fileStore
+	metrics   UploadMetrics
 }
 
-func newHandlers(logger *slog.Logger) handlers {
-	return handlers{logger: logger}
+func newHandlers(logger *slog.Logger, readiness application.ReadinessChecker, files *diskFileStore) handlers {
+	return handlers{
+		logger:    logger,
+		readiness: readiness,
+		files:     files,
+	}
 }
 
-func newUploadHandlers(logger *slog.Logger, fileStore fileStore) uploadHandlers {
+func newUploadHandlers(logger *slog.Logger, fileStore fileStore, metrics UploadMetrics) uploadHandlers {
+	if metrics == nil {
+		metrics = noopUploadMetrics{}
+	}
 	return uploadHandlers{
 		logger:    logger,
 		fileStore: fileStore,
+		metrics:   metrics,
 	}
 }
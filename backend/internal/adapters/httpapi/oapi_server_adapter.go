@@ -9,15 +9,115 @@ import (
 var _ openapicontract.ServerInterface = (*oapiServerAdapter)(nil)
 
 type oapiServerAdapter struct {
-	system handlers
-	animal animalHandlers
-	upload uploadHandlers
+	system     handlers
+	auth       authHandlers
+	sso        singleSignOnHandlers
+	tokens     apiTokenHandlers
+	devices    deviceHandlers
+	animal     animalHandlers
+	correction eventCorrectionHandlers
+	archive    eventArchiveHandlers
+	stream     eventStreamHandlers
+	upload     uploadHandlers
+	webhook    webhookHandlers
 }
 
 func (a oapiServerAdapter) PostAnimals(w http.ResponseWriter, r *http.Request, _ openapicontract.PostAnimalsParams) {
 	a.animal.createAnimal(w, r)
 }
 
+func (a oapiServerAdapter) GetAnimalsAnimalId(w http.ResponseWriter, r *http.Request, animalID string) {
+	a.animal.getAnimal(w, r, animalID)
+}
+
+func (a oapiServerAdapter) PostAnimalsAnimalIdEventsEventIdCorrection(
+	w http.ResponseWriter,
+	r *http.Request,
+	animalID string,
+	eventID string,
+	_ openapicontract.PostAnimalsAnimalIdEventsEventIdCorrectionParams,
+) {
+	a.correction.correctAnimalEvent(w, r, animalID, eventID)
+}
+
+func (a oapiServerAdapter) PostAnimalsAnimalIdEventsEventIdVoid(
+	w http.ResponseWriter,
+	r *http.Request,
+	animalID string,
+	eventID string,
+	_ openapicontract.PostAnimalsAnimalIdEventsEventIdVoidParams,
+) {
+	a.correction.voidAnimalEvent(w, r, animalID, eventID)
+}
+
+func (a oapiServerAdapter) GetAnimalsAnimalIdTimeline(w http.ResponseWriter, r *http.Request, animalID string) {
+	a.animal.getAnimalTimeline(w, r, animalID)
+}
+
+func (a oapiServerAdapter) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	a.auth.login(w, r)
+}
+
+func (a oapiServerAdapter) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+	a.auth.logout(w, r)
+}
+
+func (a oapiServerAdapter) GetAuthOidcLogin(w http.ResponseWriter, r *http.Request) {
+	a.sso.login(w, r)
+}
+
+func (a oapiServerAdapter) GetAuthOidcCallback(
+	w http.ResponseWriter,
+	r *http.Request,
+	params openapicontract.GetAuthOidcCallbackParams,
+) {
+	a.sso.callback(w, r, deref(params.Code), deref(params.State), deref(params.Error))
+}
+
+func (a oapiServerAdapter) GetAuthTokens(w http.ResponseWriter, r *http.Request) {
+	a.tokens.listTokens(w, r)
+}
+
+func (a oapiServerAdapter) PostAuthTokens(w http.ResponseWriter, r *http.Request) {
+	a.tokens.createToken(w, r)
+}
+
+func (a oapiServerAdapter) DeleteAuthTokensTokenId(w http.ResponseWriter, r *http.Request, tokenID string) {
+	a.tokens.revokeToken(w, r, tokenID)
+}
+
+func (a oapiServerAdapter) GetAuthDevices(w http.ResponseWriter, r *http.Request) {
+	a.devices.listDevices(w, r)
+}
+
+func (a oapiServerAdapter) PostAuthDevices(w http.ResponseWriter, r *http.Request) {
+	a.devices.registerDevice(w, r)
+}
+
+func (a oapiServerAdapter) DeleteAuthDevicesDeviceId(w http.ResponseWriter, r *http.Request, deviceID string) {
+	a.devices.revokeDevice(w, r, deviceID)
+}
+
+func (a oapiServerAdapter) GetAuthSession(w http.ResponseWriter, r *http.Request) {
+	a.auth.session(w, r)
+}
+
+func (a oapiServerAdapter) GetEventsExport(w http.ResponseWriter, r *http.Request) {
+	a.archive.exportEvents(w, r)
+}
+
+func (a oapiServerAdapter) PostEventsImport(w http.ResponseWriter, r *http.Request) {
+	a.archive.importEvents(w, r)
+}
+
+func (a oapiServerAdapter) GetEventsStream(
+	w http.ResponseWriter,
+	r *http.Request,
+	params openapicontract.GetEventsStreamParams,
+) {
+	a.stream.streamEvents(w, r, params)
+}
+
 func (a oapiServerAdapter) GetHealthz(w http.ResponseWriter, r *http.Request) {
 	a.system.healthz(w, r)
 }
@@ -29,3 +129,23 @@ func (a oapiServerAdapter) GetReadyz(w http.ResponseWriter, r *http.Request) {
 func (a oapiServerAdapter) PostUploadsAnimalPhotos(w http.ResponseWriter, r *http.Request) {
 	a.upload.uploadAnimalPhoto(w, r)
 }
+
+func (a oapiServerAdapter) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	a.webhook.listWebhooks(w, r)
+}
+
+func (a oapiServerAdapter) PostWebhooks(w http.ResponseWriter, r *http.Request) {
+	a.webhook.createWebhook(w, r)
+}
+
+func (a oapiServerAdapter) DeleteWebhooksWebhookId(w http.ResponseWriter, r *http.Request, webhookID string) {
+	a.webhook.deleteWebhook(w, r, webhookID)
+}
+
+func (a oapiServerAdapter) GetWebhooksWebhookId(w http.ResponseWriter, r *http.Request, webhookID string) {
+	a.webhook.getWebhook(w, r, webhookID)
+}
+
+func (a oapiServerAdapter) PutWebhooksWebhookId(w http.ResponseWriter, r *http.Request, webhookID string) {
+	a.webhook.updateWebhook(w, r, webhookID)
+}
@@ -0,0 +1,262 @@
+package httpapi
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"barnlog/backend/internal/application"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RateLimitConfig throttles each client with token buckets. Zero values
+// disable a limit.
+type RateLimitConfig struct {
+	// RequestsPerMinute refills a client's request bucket, which holds at
+	// most Burst requests.
+	RequestsPerMinute int
+	Burst             int
+	// UploadBytesPerHour is how many bytes a client may upload per hour.
+	UploadBytesPerHour int64
+	// AuthFailuresPerMinute refills each IP address's budget of as many
+	// requests answered 401, such as failed logins and unknown tokens.
+	AuthFailuresPerMinute int
+}
+
+const (
+	uploadPathPrefix = "/uploads/"
+	// rateLimitSweepInterval is how often idle clients' buckets are dropped.
+	rateLimitSweepInterval = time.Minute
+)
+
+// rateLimiter keeps a request bucket and an upload byte bucket per client,
+// and an authentication failure bucket per IP address.
+type rateLimiter struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	requests *bucketSet
+	uploads  *bucketSet
+	failures *bucketSet
+}
+
+func newRateLimiter(cfg RateLimitConfig, now func() time.Time) *rateLimiter {
+	l := &rateLimiter{now: now}
+	if cfg.RequestsPerMinute > 0 {
+		l.requests = newBucketSet(float64(max(cfg.Burst, 1)), float64(cfg.RequestsPerMinute)/time.Minute.Seconds())
+	}
+	if cfg.UploadBytesPerHour > 0 {
+		l.uploads = newBucketSet(float64(cfg.UploadBytesPerHour), float64(cfg.UploadBytesPerHour)/time.Hour.Seconds())
+	}
+	if cfg.AuthFailuresPerMinute > 0 {
+		l.failures = newBucketSet(float64(cfg.AuthFailuresPerMinute), float64(cfg.AuthFailuresPerMinute)/time.Minute.Seconds())
+	}
+	return l
+}
+
+// enabled reports whether a request or upload limit is configured.
+func (l *rateLimiter) enabled() bool {
+	return l.requests != nil || l.uploads != nil
+}
+
+// limit answers 429 rate_limited with Retry-After once a client has used up
+// its request budget, or its upload byte budget on POST /uploads/*. Uploads
+// are charged their Content-Length, or the largest accepted upload when the
+// length is unknown. Clients are keyed by principal, so a device or API token
+// does not eat into its user's other clients, and by IP address on public
+// paths. It must run after requireAuth; probes are never limited.
+func (l *rateLimiter) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := rateLimitKey(r)
+		uploadBytes := int64(0)
+		if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, uploadPathPrefix) {
+			uploadBytes = r.ContentLength
+			if uploadBytes < 0 {
+				uploadBytes = animalPhotoUploadPolicy.maxFileSizeBytes + maxMultipartOverheadBytes
+			}
+		}
+		if wait, ok := l.take(key, uploadBytes); !ok {
+			writeRateLimited(w, wait)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// guardAuth answers 429 rate_limited to every request from an IP address that
+// has used up its budget of 401 answers, so guessed passwords and tokens are
+// throttled before they reach the authenticator. A client behind the same
+// address with valid credentials waits too, since credentials cannot be told
+// apart before they are checked. It must run before requireAuth; probes are
+// never limited.
+func (l *rateLimiter) guardAuth(next http.Handler) http.Handler {
+	if l.failures == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := clientIPKey(r)
+		if wait := l.failureWait(key); wait > 0 {
+			writeRateLimited(w, wait)
+			return
+		}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		if ww.Status() == http.StatusUnauthorized {
+			l.chargeFailure(key)
+		}
+	})
+}
+
+// take spends one request and uploadBytes from key's buckets, or spends
+// nothing and reports how long until both can pay.
+func (l *rateLimiter) take(key string, uploadBytes int64) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var wait time.Duration
+	if l.requests != nil {
+		wait = max(wait, l.requests.wait(key, 1, now))
+	}
+	if l.uploads != nil && uploadBytes > 0 {
+		wait = max(wait, l.uploads.wait(key, float64(uploadBytes), now))
+	}
+	if wait > 0 {
+		return max(wait, time.Second), false
+	}
+	if l.requests != nil {
+		l.requests.spend(key, 1)
+	}
+	if l.uploads != nil && uploadBytes > 0 {
+		l.uploads.spend(key, float64(uploadBytes))
+	}
+	return 0, true
+}
+
+// failureWait reports how long until key may fail authentication again.
+func (l *rateLimiter) failureWait(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if wait := l.failures.wait(key, 1, l.now()); wait > 0 {
+		return max(wait, time.Second)
+	}
+	return 0
+}
+
+// chargeFailure spends one of key's authentication failures. Concurrent
+// failures may overdraw the budget slightly, as each was checked before it
+// was answered.
+func (l *rateLimiter) chargeFailure(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failures.wait(key, 1, l.now())
+	l.failures.spend(key, 1)
+}
+
+func writeRateLimited(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	writeError(w, http.StatusTooManyRequests, "rate_limited")
+}
+
+func isProbe(r *http.Request) bool {
+	return r.URL.Path == "/healthz" || r.URL.Path == "/readyz"
+}
+
+// rateLimitKey names the client a request is charged to.
+func rateLimitKey(r *http.Request) string {
+	if principal, ok := application.PrincipalFromContext(r.Context()); ok {
+		switch {
+		case principal.DeviceID != "":
+			return "device:" + principal.DeviceID
+		case principal.TokenID != "":
+			return "token:" + principal.TokenID
+		default:
+			return "user:" + principal.UserID
+		}
+	}
+	return clientIPKey(r)
+}
+
+// clientIPKey names the IP address a request came from.
+func clientIPKey(r *http.Request) string {
+	// middleware.RealIP leaves a bare IP; without it RemoteAddr is host:port.
+	host := r.RemoteAddr
+	if ip, _, err := net.SplitHostPort(host); err == nil {
+		host = ip
+	}
+	return "ip:" + host
+}
+
+// bucketSet is one token bucket per client, all with the same capacity and
+// refill rate. Buckets that have refilled completely are dropped, since a
+// fresh bucket starts full.
+type bucketSet struct {
+	capacity  float64
+	perSecond float64
+	buckets   map[string]*tokenBucket
+	sweptAt   time.Time
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func newBucketSet(capacity, perSecond float64) *bucketSet {
+	return &bucketSet{capacity: capacity, perSecond: perSecond, buckets: map[string]*tokenBucket{}}
+}
+
+// wait refills key's bucket up to now and returns how long until it holds n
+// tokens, which is zero when it already does. n is capped at the capacity,
+// so a single request larger than the whole budget waits for a full bucket.
+func (s *bucketSet) wait(key string, n float64, now time.Time) time.Duration {
+	s.sweep(now)
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: s.capacity, updatedAt: now}
+		s.buckets[key] = bucket
+	}
+	bucket.tokens = min(s.capacity, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*s.perSecond)
+	bucket.updatedAt = now
+
+	missing := min(n, s.capacity) - bucket.tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / s.perSecond * float64(time.Second))
+}
+
+// spend takes n tokens from key's bucket, which wait has just refilled.
+func (s *bucketSet) spend(key string, n float64) {
+	bucket := s.buckets[key]
+	bucket.tokens = max(0, bucket.tokens-n)
+}
+
+func (s *bucketSet) sweep(now time.Time) {
+	if now.Sub(s.sweptAt) < rateLimitSweepInterval {
+		return
+	}
+	s.sweptAt = now
+	for key, bucket := range s.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*s.perSecond >= s.capacity {
+			delete(s.buckets, key)
+		}
+	}
+}
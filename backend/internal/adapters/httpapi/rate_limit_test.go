@@ -0,0 +1,249 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestRateLimiterRequests(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(RateLimitConfig{RequestsPerMinute: 60, Burst: 2}, func() time.Time { return clock })
+	handler := limiter.limit(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/animals", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for range 2 {
+		if rec := request("192.0.2.1:4000"); rec.Code != http.StatusNoContent {
+			t.Fatalf("expected the burst to be allowed, got %d", rec.Code)
+		}
+	}
+	rec := request("192.0.2.1:4001")
+	assertJSONStatus(t, rec, http.StatusTooManyRequests)
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Fatalf("expected Retry-After 1, got %q", got)
+	}
+	var payload map[string]any
+	decodeJSON(t, rec, &payload)
+	if payload["code"] != "rate_limited" {
+		t.Fatalf("expected code=rate_limited, got %#v", payload["code"])
+	}
+
+	if rec := request("192.0.2.2:4000"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected another client to have its own budget, got %d", rec.Code)
+	}
+
+	clock = clock.Add(time.Second)
+	if rec := request("192.0.2.1:4000"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected a refilled token after a second, got %d", rec.Code)
+	}
+	if rec := request("192.0.2.1:4000"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected the refilled token to be spent, got %d", rec.Code)
+	}
+}
+
+func TestRateLimiterUploadBytes(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(RateLimitConfig{UploadBytesPerHour: 3600}, func() time.Time { return clock })
+	handler := limiter.limit(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	upload := func(size int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/uploads/animal-photos", strings.NewReader(strings.Repeat("x", size)))
+		req = req.WithContext(application.WithPrincipal(req.Context(), application.Principal{UserID: "u1", DeviceID: "d1"}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := upload(3000); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected an upload within the budget, got %d", rec.Code)
+	}
+	rec := upload(1000)
+	assertJSONStatus(t, rec, http.StatusTooManyRequests)
+	if got := rec.Header().Get("Retry-After"); got != "400" {
+		t.Fatalf("expected Retry-After 400, got %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/animals", nil)
+	req = req.WithContext(application.WithPrincipal(req.Context(), application.Principal{UserID: "u1", DeviceID: "d1"}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected other requests to ignore the upload budget, got %d", rec.Code)
+	}
+
+	clock = clock.Add(400 * time.Second)
+	if rec := upload(1000); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected the upload once enough bytes refilled, got %d", rec.Code)
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		principal *application.Principal
+		remote    string
+		want      string
+	}{
+		{"session", &application.Principal{UserID: "u1"}, "192.0.2.1:4000", "user:u1"},
+		{"api token", &application.Principal{UserID: "u1", TokenID: "t1"}, "192.0.2.1:4000", "token:t1"},
+		{"device", &application.Principal{UserID: "u1", DeviceID: "d1"}, "192.0.2.1:4000", "device:d1"},
+		{"anonymous", nil, "192.0.2.1:4000", "ip:192.0.2.1"},
+		{"anonymous behind RealIP", nil, "2001:db8::1", "ip:2001:db8::1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/animals", nil)
+			req.RemoteAddr = tc.remote
+			if tc.principal != nil {
+				req = req.WithContext(application.WithPrincipal(req.Context(), *tc.principal))
+			}
+			if got := rateLimitKey(req); got != tc.want {
+				t.Fatalf("expected key %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBucketSetDropsIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	set := newBucketSet(2, 1)
+	set.wait("a", 1, now)
+	set.spend("a", 1)
+	set.wait("b", 1, now)
+	set.spend("b", 2)
+
+	set.wait("c", 1, now.Add(rateLimitSweepInterval))
+	if _, ok := set.buckets["a"]; ok {
+		t.Fatal("expected the refilled bucket to be dropped")
+	}
+	if len(set.buckets) != 1 {
+		t.Fatalf("expected only the new bucket to remain, got %d", len(set.buckets))
+	}
+}
+
+func TestRoutesRateLimit(t *testing.T) {
+	t.Parallel()
+
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		RateLimit:      RateLimitConfig{RequestsPerMinute: 1, Burst: 1},
+	})
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assertJSONStatus(t, rec, http.StatusOK)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	assertJSONStatus(t, rec, http.StatusOK)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	assertJSONStatus(t, rec, http.StatusTooManyRequests)
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header")
+	}
+}
+
+func TestRoutesRateLimitAuthFailures(t *testing.T) {
+	t.Parallel()
+
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		RateLimit:      RateLimitConfig{AuthFailuresPerMinute: 3},
+	})
+
+	for range 3 {
+		rec := performBearerRequest(router, http.MethodGet, "/webhooks", "", "Bearer blt_guess")
+		assertJSONStatus(t, rec, http.StatusUnauthorized)
+	}
+	rec := performBearerRequest(router, http.MethodGet, "/webhooks", "", "Bearer blt_guess")
+	assertJSONStatus(t, rec, http.StatusTooManyRequests)
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header")
+	}
+	var payload map[string]any
+	decodeJSON(t, rec, &payload)
+	if payload["code"] != "rate_limited" {
+		t.Fatalf("expected code=rate_limited, got %#v", payload["code"])
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assertJSONStatus(t, rec, http.StatusOK)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	req.RemoteAddr = "198.51.100.7:4000"
+	req.Header.Set("Authorization", "Bearer blt_guess")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assertJSONStatus(t, rec, http.StatusUnauthorized)
+}
+
+func TestRoutesRateLimitFailedLogins(t *testing.T) {
+	t.Parallel()
+
+	auth := newFakeAuthenticator()
+	auth.loginErr = application.BusinessError{Code: application.CodeInvalidCredentials}
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		Authenticator:  auth,
+		RateLimit:      RateLimitConfig{AuthFailuresPerMinute: 2},
+	})
+
+	const login = `{"username":"anna","password":"guess"}`
+	for range 2 {
+		rec := performBearerRequest(router, http.MethodPost, AuthLoginPath, login, "")
+		assertJSONStatus(t, rec, http.StatusUnauthorized)
+	}
+	rec := performBearerRequest(router, http.MethodPost, AuthLoginPath, login, "")
+	assertJSONStatus(t, rec, http.StatusTooManyRequests)
+	if auth.loginIn.Password != "guess" {
+		t.Fatalf("expected the earlier attempts to reach the authenticator, got %+v", auth.loginIn)
+	}
+}
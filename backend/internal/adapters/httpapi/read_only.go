@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+
+	"barnlog/backend/internal/application"
+)
+
+// rejectWritesOnFollower answers mutating requests with 503 read_only while the
+// node is a replication follower. EventImportPath stays open because that is
+// how a primary ships events to a follower over HTTP, and login and logout stay
+// open so users can still read from the follower. API tokens and devices are
+// long-lived credentials; on a follower they are managed with the admin CLI,
+// like the replication token.
+func rejectWritesOnFollower(logger *slog.Logger, roles application.NodeRoles) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			switch r.URL.Path {
+			case EventImportPath, AuthLoginPath, AuthLogoutPath:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := roles.RequireWritable(r.Context()); err != nil {
+				writeFailure(w, r, logger, err, "check node role failed")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
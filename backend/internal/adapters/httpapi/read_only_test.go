@@ -0,0 +1,94 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/application"
+	"barnlog/backend/internal/ports"
+)
+
+func TestRoutes_FollowerRejectsWrites(t *testing.T) {
+	t.Parallel()
+
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		NodeRoles:      &fakeNodeRoles{role: ports.NodeRoleFollower},
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "create animal", method: http.MethodPost, path: "/animals", contentType: "application/json", wantStatus: http.StatusServiceUnavailable},
+		{name: "delete webhook", method: http.MethodDelete, path: "/webhooks/wh1", wantStatus: http.StatusServiceUnavailable},
+		{name: "readyz", method: http.MethodGet, path: "/readyz", wantStatus: http.StatusOK},
+		{name: "import", method: http.MethodPost, path: EventImportPath, contentType: ndjsonContentType, wantStatus: http.StatusOK},
+		{name: "login", method: http.MethodPost, path: AuthLoginPath, contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "create api token", method: http.MethodPost, path: AuthTokensPath, contentType: "application/json", wantStatus: http.StatusServiceUnavailable},
+		{name: "revoke api token", method: http.MethodDelete, path: AuthTokensPath + "/tok1", wantStatus: http.StatusServiceUnavailable},
+		{name: "register device", method: http.MethodPost, path: AuthDevicesPath, contentType: "application/json", wantStatus: http.StatusServiceUnavailable},
+		{name: "revoke device", method: http.MethodDelete, path: AuthDevicesPath + "/dev1", wantStatus: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assertJSONStatus(t, rec, tt.wantStatus)
+			if tt.wantStatus != http.StatusServiceUnavailable {
+				return
+			}
+			var payload map[string]any
+			decodeJSON(t, rec, &payload)
+			if payload["code"] != "read_only" {
+				t.Fatalf("expected code=read_only, got %#v", payload["code"])
+			}
+		})
+	}
+}
+
+type fakeNodeRoles struct {
+	role ports.NodeRole
+}
+
+func (f *fakeNodeRoles) Role(context.Context) (ports.NodeRole, error) {
+	return f.role, nil
+}
+
+func (f *fakeNodeRoles) RequireWritable(context.Context) error {
+	if f.role == ports.NodeRoleFollower {
+		return application.BusinessError{Code: application.CodeReadOnly, Err: errors.New("follower")}
+	}
+	return nil
+}
+
+func (f *fakeNodeRoles) Promote(context.Context) error {
+	f.role = ports.NodeRolePrimary
+	return nil
+}
+
+func (f *fakeNodeRoles) Follow(context.Context) error {
+	f.role = ports.NodeRoleFollower
+	return nil
+}
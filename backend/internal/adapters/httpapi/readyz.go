@@ -1,11 +1,56 @@
 package httpapi
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"barnlog/backend/internal/application"
+	openapicontract "barnlog/backend/internal/contracts/openapi"
+)
+
+const (
+	readinessCheckFileDir = "file_dir"
+	readinessTimeout      = 2 * time.Second
 )
 
-// readyz returns service readiness status and current UTC timestamp.
-func (h handlers) readyz(w http.ResponseWriter, _ *http.Request) {
-	writeJSON(w, http.StatusOK, newReadyResponse("ready", time.Now().UTC().Format(time.RFC3339)))
+// readyz checks the dependencies a request needs and reports every check.
+// It answers 503 with the same body when any check fails.
+func (h handlers) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	var checks []application.ReadinessCheck
+	if h.readiness != nil {
+		checks = h.readiness.CheckReadiness(ctx)
+	}
+	checks = append(checks, h.checkFileDir())
+
+	resp := newReadyResponse(checks, time.Now().UTC().Format(time.RFC3339))
+	if resp.Status != openapicontract.Ready {
+		for _, check := range checks {
+			if !check.OK {
+				requestLogger(r.Context(), h.logger).Warn("readiness check failed", slog.String("check", check.Name), slog.Any("error", check.Cause))
+			}
+		}
+		writeJSON(w, http.StatusServiceUnavailable, resp)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h handlers) checkFileDir() application.ReadinessCheck {
+	if h.files == nil {
+		return application.ReadinessCheck{
+			Name:  readinessCheckFileDir,
+			Error: "file store dir is not configured",
+			Cause: errors.New("no file store dir"),
+		}
+	}
+	if err := h.files.CheckWritable(); err != nil {
+		return application.ReadinessCheck{Name: readinessCheckFileDir, Error: "file store dir is not writable", Cause: err}
+	}
+	return application.ReadinessCheck{Name: readinessCheckFileDir, OK: true}
 }
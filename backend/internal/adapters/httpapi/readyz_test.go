@@ -0,0 +1,128 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestReadyzReportsChecks(t *testing.T) {
+	t.Parallel()
+
+	lag := int64(3)
+	tests := []struct {
+		name       string
+		fileDir    func(t *testing.T) string
+		checks     []application.ReadinessCheck
+		wantStatus int
+		wantBody   string
+		wantFailed []string
+	}{
+		{
+			name:    "all ok",
+			fileDir: func(t *testing.T) string { return t.TempDir() },
+			checks: []application.ReadinessCheck{
+				{Name: application.ReadinessCheckDatabase, OK: true},
+				{Name: application.ReadinessCheckWebhookOutbox, OK: true, Lag: &lag},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ready",
+		},
+		{
+			name:    "migrations behind",
+			fileDir: func(t *testing.T) string { return t.TempDir() },
+			checks: []application.ReadinessCheck{
+				{Name: application.ReadinessCheckDatabase, OK: true},
+				{Name: application.ReadinessCheckMigrations, Error: "database schema does not match this build"},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "not_ready",
+			wantFailed: []string{application.ReadinessCheckMigrations},
+		},
+		{
+			name: "file dir not writable",
+			fileDir: func(t *testing.T) string {
+				blocker := filepath.Join(t.TempDir(), "blocker")
+				if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+					t.Fatalf("write blocker: %v", err)
+				}
+				return filepath.Join(blocker, "files")
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "not_ready",
+			wantFailed: []string{readinessCheckFileDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := testRoutes(RouteDeps{
+				Logger:         testLogger(),
+				FileStoreDir:   tt.fileDir(t),
+				AnimalWriter:   &fakeAnimalWriter{},
+				AnimalReader:   &fakeAnimalReader{},
+				EventCorrector: &fakeEventCorrector{},
+				EventFeed:      &fakeEventFeed{},
+				EventArchive:   &fakeEventArchive{},
+				WebhookManager: &fakeWebhookManager{},
+				Readiness:      fakeReadinessChecker(tt.checks),
+			})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			// A failing readiness check is a report, not a problem.
+			if rec.Code != tt.wantStatus || rec.Header().Get("Content-Type") != jsonContentType {
+				t.Fatalf("expected status %d with %s, got %d %q", tt.wantStatus, jsonContentType, rec.Code, rec.Header().Get("Content-Type"))
+			}
+			var payload struct {
+				Status string `json:"status"`
+				Checks []struct {
+					Name   string `json:"name"`
+					Status string `json:"status"`
+					Error  string `json:"error"`
+					Lag    *int64 `json:"lag"`
+				} `json:"checks"`
+			}
+			decodeJSON(t, rec, &payload)
+			if payload.Status != tt.wantBody {
+				t.Fatalf("expected status=%s, got %q", tt.wantBody, payload.Status)
+			}
+			if len(payload.Checks) != len(tt.checks)+1 {
+				t.Fatalf("expected %d checks including file_dir, got %+v", len(tt.checks)+1, payload.Checks)
+			}
+
+			var failed []string
+			for _, check := range payload.Checks {
+				if check.Status == "fail" {
+					if check.Error == "" {
+						t.Fatalf("expected an error on failed check %s", check.Name)
+					}
+					if strings.Contains(check.Error, "blocker") {
+						t.Fatalf("expected check %s to hide the file store path, got %q", check.Name, check.Error)
+					}
+					failed = append(failed, check.Name)
+				}
+				if check.Name == application.ReadinessCheckWebhookOutbox && (check.Lag == nil || *check.Lag != lag) {
+					t.Fatalf("expected webhook_outbox lag %d, got %v", lag, check.Lag)
+				}
+			}
+			if len(failed) != len(tt.wantFailed) || (len(failed) > 0 && failed[0] != tt.wantFailed[0]) {
+				t.Fatalf("expected failed checks %v, got %v", tt.wantFailed, failed)
+			}
+		})
+	}
+}
+
+type fakeReadinessChecker []application.ReadinessCheck
+
+func (f fakeReadinessChecker) CheckReadiness(context.Context) []application.ReadinessCheck {
+	return f
+}
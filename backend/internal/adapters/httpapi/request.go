@@ -9,40 +9,78 @@ import (
 	"net/http"
 	"strings"
 
+	"barnlog/backend/internal/application"
+
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 const (
-	jsonContentType      = "application/json"
-	sourceHeaderName     = "X-Barnlog-Source"
-	requestIDHeaderName  = "X-Request-Id"
-	defaultRequestSource = "http.api"
-	maxJSONBodyBytes     = 1 << 20 // 1 MiB
+	jsonContentType         = "application/json"
+	sourceHeaderName        = "X-Barnlog-Source"
+	requestIDHeaderName     = "X-Request-Id"
+	clientVersionHeaderName = "X-Barnlog-Client-Version"
+	defaultRequestSource    = "http.api"
+	maxJSONBodyBytes        = 1 << 20 // 1 MiB
 )
 
 // RequestMeta carries request-scoped metadata used by command handlers.
 type RequestMeta struct {
-	Source    string
-	RequestID string
+	Source        string
+	RequestID     string
+	Actor         string
+	DeviceID      string
+	ClientVersion string
+}
+
+func (m RequestMeta) toApplication() application.RequestMeta {
+	return application.RequestMeta{
+		Source:        m.Source,
+		RequestID:     m.RequestID,
+		Actor:         m.Actor,
+		DeviceID:      m.DeviceID,
+		ClientVersion: m.ClientVersion,
+	}
 }
 
 type requestMetaContextKey struct{}
 
+// withRequestMeta records the request metadata. The signed-in user is the
+// actor, so requireAuth must run first. Requests of a registered
+// device get the device's source and ID regardless of X-Barnlog-Source, so one
+// device cannot reuse another's idempotency keys; other clients may not claim
+// a device source and get 400 invalid_input for trying.
 func withRequestMeta(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		source := strings.TrimSpace(r.Header.Get(sourceHeaderName))
 		if source == "" {
 			source = defaultRequestSource
 		}
+		principal, authenticated := application.PrincipalFromContext(r.Context())
+		var deviceID string
+		if authenticated && principal.DeviceID != "" {
+			deviceID = principal.DeviceID
+			source = application.DeviceSource(deviceID)
+		} else if application.IsDeviceSource(source) {
+			writeError(w, http.StatusBadRequest, string(application.CodeInvalidInput))
+			return
+		}
 
 		requestID := strings.TrimSpace(r.Header.Get(requestIDHeaderName))
 		if requestID == "" {
 			requestID = strings.TrimSpace(middleware.GetReqID(r.Context()))
 		}
 
+		actor := application.ActorAnonymous
+		if authenticated {
+			actor = application.UserActor(principal.Username)
+		}
+
 		meta := RequestMeta{
-			Source:    source,
-			RequestID: requestID,
+			Source:        source,
+			RequestID:     requestID,
+			Actor:         actor,
+			DeviceID:      deviceID,
+			ClientVersion: strings.TrimSpace(r.Header.Get(clientVersionHeaderName)),
 		}
 		ctx := context.WithValue(r.Context(), requestMetaContextKey{}, meta)
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -55,6 +93,9 @@ func requestMeta(ctx context.Context) (RequestMeta, bool) {
 }
 
 func decodeJSONRequest(w http.ResponseWriter, r *http.Request, dst any) (status int, code string, ok bool) {
+	_, span := startChildSpan(r.Context(), "DecodeJSONRequest")
+	defer span.End()
+
 	contentType := strings.TrimSpace(r.Header.Get("Content-Type"))
 	if contentType == "" {
 		return http.StatusUnsupportedMediaType, "unsupported_media_type", false
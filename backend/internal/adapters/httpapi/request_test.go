@@ -1,10 +1,13 @@
 package httpapi
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"barnlog/backend/internal/application"
 )
 
 func TestDecodeJSONRequest(t *testing.T) {
@@ -49,3 +52,76 @@ func TestDecodeJSONRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestWithRequestMeta(t *testing.T) {
+	t.Parallel()
+
+	capture := func(ctx context.Context, headers map[string]string) (RequestMeta, int) {
+		t.Helper()
+
+		var meta RequestMeta
+		handler := withRequestMeta(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			meta, _ = requestMeta(r.Context())
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/animals", nil).WithContext(ctx)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return meta, rec.Code
+	}
+
+	t.Run("anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		meta, _ := capture(context.Background(), nil)
+		if meta.Actor != application.ActorAnonymous {
+			t.Fatalf("expected actor %q, got %q", application.ActorAnonymous, meta.Actor)
+		}
+		if meta.Source != defaultRequestSource {
+			t.Fatalf("expected default source, got %q", meta.Source)
+		}
+	})
+
+	t.Run("principal and client headers", func(t *testing.T) {
+		t.Parallel()
+
+		meta, _ := capture(application.WithPrincipal(context.Background(), application.Principal{UserID: "u1", Username: "anna"}), map[string]string{
+			sourceHeaderName:        " web.ui ",
+			"X-Barnlog-Device-Id":   "tablet-3",
+			clientVersionHeaderName: "ios/2.1.0",
+		})
+		if meta.Actor != "user:anna" {
+			t.Fatalf("expected actor user:anna, got %q", meta.Actor)
+		}
+		if meta.Source != "web.ui" {
+			t.Fatalf("expected trimmed source, got %q", meta.Source)
+		}
+		if meta.DeviceID != "" {
+			t.Fatalf("expected the client's device id header to be ignored, got %q", meta.DeviceID)
+		}
+		if meta.ClientVersion != "ios/2.1.0" {
+			t.Fatalf("expected client version ios/2.1.0, got %q", meta.ClientVersion)
+		}
+	})
+
+	t.Run("device principal", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := application.WithPrincipal(context.Background(), application.Principal{UserID: "u1", DeviceID: "d1"})
+		meta, _ := capture(ctx, map[string]string{sourceHeaderName: "device:d2"})
+		if meta.Source != application.DeviceSource("d1") || meta.DeviceID != "d1" {
+			t.Fatalf("expected the source of device d1, got %+v", meta)
+		}
+	})
+
+	t.Run("device source claimed without a device", func(t *testing.T) {
+		t.Parallel()
+
+		meta, status := capture(context.Background(), map[string]string{sourceHeaderName: "device:d1"})
+		if status != http.StatusBadRequest || meta.Source != "" {
+			t.Fatalf("expected 400 for a claimed device source, got %d %+v", status, meta)
+		}
+	})
+}
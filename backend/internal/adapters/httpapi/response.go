@@ -8,72 +8,164 @@ import (
 	"barnlog/backend/internal/application"
 )
 
-var allowedErrorCodes = map[string]struct{}{
-	"birthdate_invalid":               {},
-	"conflict":                        {},
-	"file_required":                   {},
-	"file_too_large":                  {},
-	"idempotency_event_type_mismatch": {},
-	"idempotency_payload_mismatch":    {},
-	"internal_error":                  {},
-	"invalid_input":                   {},
-	"invalid_file":                    {},
-	"invalid_json":                    {},
-	"invalid_multipart":               {},
-	"multiple_files_not_allowed":      {},
-	"name_required":                   {},
-	"not_found":                       {},
-	"photo_not_found":                 {},
-	"species_invalid":                 {},
-	"unsupported_media_type":          {},
-	"unsupported_file_type":           {},
+const (
+	// problemContentType is the media type of RFC 9457 error bodies.
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:barnlog:problem:"
+)
+
+// errorTitles are the codes an error response may carry and the title of each.
+var errorTitles = map[string]string{
+	"animal_not_found":                "Animal not found",
+	"barn_invalid":                    "Barn is invalid",
+	"birthdate_invalid":               "Birthdate is invalid",
+	"conflict":                        "Request conflicts with an earlier one",
+	"csrf_token_invalid":              "CSRF token is missing or invalid",
+	"device_not_found":                "Device not found",
+	"event_aggregate_mismatch":        "Event belongs to another animal",
+	"event_not_correctable":           "Event cannot be corrected",
+	"event_not_found":                 "Event not found",
+	"event_voided":                    "Event is voided",
+	"file_required":                   "File is required",
+	"file_too_large":                  "File is too large",
+	"forbidden":                       "Operation not allowed",
+	"idempotency_event_type_mismatch": "Request ID was used for another event type",
+	"idempotency_payload_mismatch":    "Request ID was used with another payload",
+	"import_invalid":                  "Import is invalid",
+	"insufficient_scope":              "Token lacks the required scope",
+	"internal_error":                  "Internal error",
+	"invalid_credentials":             "Invalid username or password",
+	"invalid_cursor":                  "Cursor is invalid",
+	"invalid_file":                    "File is invalid",
+	"invalid_input":                   "Input is invalid",
+	"invalid_json":                    "Body is not valid JSON",
+	"invalid_multipart":               "Body is not valid multipart form data",
+	"multiple_files_not_allowed":      "Only one file is allowed",
+	"name_required":                   "Name is required",
+	"not_found":                       "Not found",
+	"password_too_short":              "Password is too short",
+	"payload_invalid":                 "Payload is invalid",
+	"photo_not_found":                 "Photo not found",
+	"rate_limited":                    "Too many requests",
+	"read_only":                       "Node is read-only",
+	"reason_required":                 "Reason is required",
+	"request_too_large":               "Request body is too large",
+	"role_invalid":                    "Role is invalid",
+	"scope_invalid":                   "Scope is invalid",
+	"species_invalid":                 "Species is invalid",
+	"sso_failed":                      "Single sign-on failed",
+	"token_not_found":                 "API token not found",
+	"unauthenticated":                 "Authentication required",
+	"unsupported_file_type":           "File type is not supported",
+	"unsupported_media_type":          "Content type is not supported",
+	"user_not_found":                  "User not found",
+	"username_invalid":                "Username is invalid",
+	"username_taken":                  "Username is taken",
+	"webhook_event_type_invalid":      "Webhook event type is invalid",
+	"webhook_not_found":               "Webhook not found",
+	"webhook_url_invalid":             "Webhook URL is invalid",
 }
 
 func writeJSON(w http.ResponseWriter, status int, payload any) {
+	writeJSONAs(w, jsonContentType, status, payload)
+}
+
+func writeJSONAs(w http.ResponseWriter, contentType string, status int, payload any) {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
-	// #nosec G705 -- payload is JSON-encoded and served with application/json.
+	// #nosec G705 -- payload is JSON-encoded and served with a JSON media type.
 	_, _ = w.Write(append(body, '\n'))
 }
 
 func writeError(w http.ResponseWriter, status int, code string) {
-	writeJSON(w, status, newErrorResponse(normalizeErrorCode(code)))
+	writeProblem(w, status, code, nil)
+}
+
+// writeProblem writes an RFC 9457 problem with code and, for validation
+// errors, every invalid field.
+func writeProblem(w http.ResponseWriter, status int, code string, fields []application.FieldError) {
+	writeJSONAs(w, problemContentType, status, newErrorResponse(status, normalizeErrorCode(code), fields))
 }
 
 func normalizeErrorCode(code string) string {
-	if _, ok := allowedErrorCodes[code]; ok {
+	if _, ok := errorTitles[code]; ok {
 		return code
 	}
 
 	return "internal_error"
 }
 
+// writeFailure writes err as a problem response: business errors keep their
+// code, anything else is logged as failure and answered with internal_error.
+func writeFailure(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error, failure string) {
+	logger = requestLogger(r.Context(), logger)
+	if writeBusinessError(w, logger, err) {
+		return
+	}
+
+	logger.Error(failure, slog.Any("error", err))
+	writeError(w, http.StatusInternalServerError, "internal_error")
+}
+
 func writeBusinessError(w http.ResponseWriter, logger *slog.Logger, err error) bool {
 	be, ok := application.AsBusinessError(err)
 	if !ok {
 		return false
 	}
 
+	var status int
 	switch be.Code {
 	case application.CodeInvalidInput,
 		application.CodeNameRequired,
 		application.CodeSpeciesInvalid,
 		application.CodeBirthdateInvalid,
-		application.CodePhotoNotFound:
-		writeError(w, http.StatusBadRequest, string(be.Code))
+		application.CodePhotoNotFound,
+		application.CodeReasonRequired,
+		application.CodePayloadInvalid,
+		application.CodeEventAggregateMismatch,
+		application.CodeEventNotCorrectable,
+		application.CodeInvalidCursor,
+		application.CodeWebhookURLInvalid,
+		application.CodeWebhookEventTypeInvalid,
+		application.CodeImportInvalid,
+		application.CodeUsernameInvalid,
+		application.CodePasswordTooShort,
+		application.CodeScopeInvalid,
+		application.CodeRoleInvalid,
+		application.CodeBarnInvalid:
+		status = http.StatusBadRequest
+	case application.CodeInvalidCredentials,
+		application.CodeUnauthenticated,
+		application.CodeSingleSignOnFailed:
+		status = http.StatusUnauthorized
+	case application.CodeForbidden:
+		status = http.StatusForbidden
 	case application.CodeConflict,
 		application.CodeIdempotencyPayloadMismatch,
-		application.CodeIdempotencyEventTypeMismatch:
-		writeError(w, http.StatusConflict, string(be.Code))
+		application.CodeIdempotencyEventTypeMismatch,
+		application.CodeEventVoided,
+		application.CodeUsernameTaken:
+		status = http.StatusConflict
+	case application.CodeAnimalNotFound,
+		application.CodeEventNotFound,
+		application.CodeWebhookNotFound,
+		application.CodeUserNotFound,
+		application.CodeTokenNotFound,
+		application.CodeDeviceNotFound:
+		status = http.StatusNotFound
+	case application.CodeReadOnly:
+		status = http.StatusServiceUnavailable
 	default:
 		logger.Error("unknown business error code", slog.String("code", string(be.Code)), slog.Any("error", err))
 		writeError(w, http.StatusInternalServerError, "internal_error")
+		return true
 	}
+	writeProblem(w, status, string(be.Code), be.Fields)
 	return true
 }
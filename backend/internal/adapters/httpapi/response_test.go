@@ -52,9 +52,16 @@ func TestWriteError_UsesAllowedCode(t *testing.T) {
 		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
 	}
 
+	if got := rec.Header().Get("Content-Type"); got != problemContentType {
+		t.Fatalf("expected content-type %s, got %q", problemContentType, got)
+	}
 	payload := mustDecodeResponse(t, rec)
-	if payload["error"] != "not_found" {
-		t.Fatalf("expected error=not_found, got %#v", payload["error"])
+	if payload["code"] != "not_found" || payload["type"] != "urn:barnlog:problem:not_found" ||
+		payload["title"] != "Not found" || payload["status"] != float64(http.StatusBadRequest) {
+		t.Fatalf("unexpected problem %#v", payload)
+	}
+	if _, ok := payload["errors"]; ok {
+		t.Fatalf("expected no field errors, got %#v", payload["errors"])
 	}
 }
 
@@ -68,8 +75,8 @@ func TestWriteError_NormalizesUnknownCode(t *testing.T) {
 	}
 
 	payload := mustDecodeResponse(t, rec)
-	if payload["error"] != "internal_error" {
-		t.Fatalf("expected error=internal_error, got %#v", payload["error"])
+	if payload["code"] != "internal_error" {
+		t.Fatalf("expected code=internal_error, got %#v", payload["code"])
 	}
 }
 
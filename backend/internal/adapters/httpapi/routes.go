@@ -4,19 +4,47 @@ package httpapi
 import (
 	"log/slog"
 	"net/http"
+	"time"
 
 	"barnlog/backend/internal/application"
 	openapicontract "barnlog/backend/internal/contracts/openapi"
 
 	"github.com/go-chi/chi/v5"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RouteDeps contains dependencies required to build HTTP routes.
 type RouteDeps struct {
-	Logger       *slog.Logger
-	FileStoreDir string
-	AnimalWriter application.AnimalWriter
+	Logger         *slog.Logger
+	FileStoreDir   string
+	AnimalWriter   application.AnimalWriter
+	AnimalReader   application.AnimalReader
+	EventCorrector application.EventCorrector
+	EventFeed      application.EventFeed
+	EventArchive   application.EventArchive
+	WebhookManager application.WebhookManager
+	Authenticator  application.Authenticator
+	APITokens      application.APITokenManager
+	Devices        application.DeviceManager
+	// SingleSignOn serves the OpenID Connect login. Optional; without it those
+	// paths answer 404.
+	SingleSignOn application.SingleSignOn
+	// NodeRoles makes the API read-only while this node is a replication
+	// follower. Optional; without it the node always accepts writes.
+	NodeRoles application.NodeRoles
+	// Readiness runs the database checks behind /readyz. Optional; without it
+	// readyz only checks that the file directory is writable.
+	Readiness application.ReadinessChecker
+	// UploadMetrics counts accepted and rejected uploads. Optional.
+	UploadMetrics UploadMetrics
+	// RateLimit throttles each client. Optional; the zero value disables it.
+	RateLimit RateLimitConfig
+	// TracerProvider records request spans. Optional; defaults to the global provider.
+	TracerProvider trace.TracerProvider
+	// Shutdown is closed when the server starts shutting down so long-lived
+	// event streams end instead of holding graceful shutdown open. Optional.
+	Shutdown <-chan struct{}
 }
 
 // Routes builds the public HTTP router for backend endpoints.
@@ -24,24 +52,89 @@ func Routes(deps RouteDeps) http.Handler {
 	if deps.AnimalWriter == nil {
 		panic("httpapi: AnimalWriter is required")
 	}
+	if deps.AnimalReader == nil {
+		panic("httpapi: AnimalReader is required")
+	}
+
+	if deps.EventCorrector == nil {
+		panic("httpapi: EventCorrector is required")
+	}
+
+	if deps.EventFeed == nil {
+		panic("httpapi: EventFeed is required")
+	}
+
+	if deps.EventArchive == nil {
+		panic("httpapi: EventArchive is required")
+	}
+
+	if deps.WebhookManager == nil {
+		panic("httpapi: WebhookManager is required")
+	}
 
+	if deps.Authenticator == nil {
+		panic("httpapi: Authenticator is required")
+	}
+
+	if deps.APITokens == nil {
+		panic("httpapi: APITokens is required")
+	}
+
+	if deps.Devices == nil {
+		panic("httpapi: Devices is required")
+	}
+
+	limiter := newRateLimiter(deps.RateLimit, time.Now)
 	r := chi.NewRouter()
+	r.Use(traceRequests(deps.TracerProvider))
+	r.Use(limiter.guardAuth)
+	r.Use(requireAuth(deps.Logger, deps.Authenticator))
+	if limiter.enabled() {
+		r.Use(limiter.limit)
+	}
+	r.Use(withBarn(deps.Logger))
 	r.Use(withRequestMeta)
+	if deps.NodeRoles != nil {
+		r.Use(rejectWritesOnFollower(deps.Logger, deps.NodeRoles))
+	}
 
-	h := newHandlers(deps.Logger)
-	animal := newAnimalHandlers(deps.Logger, deps.AnimalWriter)
+	auth := newAuthHandlers(deps.Logger, deps.Authenticator)
+	sso := newSingleSignOnHandlers(deps.Logger, deps.SingleSignOn)
+	tokens := newAPITokenHandlers(deps.Logger, deps.APITokens)
+	devices := newDeviceHandlers(deps.Logger, deps.Devices)
+	animal := newAnimalHandlers(deps.Logger, deps.AnimalWriter, deps.AnimalReader)
+	correction := newEventCorrectionHandlers(deps.Logger, deps.EventCorrector)
+	archive := newEventArchiveHandlers(deps.Logger, deps.EventArchive)
+	stream := newEventStreamHandlers(deps.Logger, deps.EventFeed, deps.Shutdown)
 	store := newFileStore(deps.FileStoreDir)
 	if store == nil {
 		deps.Logger.Error("invalid file store dir", slog.String("file_store_dir", deps.FileStoreDir))
 	}
-	upload := newUploadHandlers(deps.Logger, store)
+	h := newHandlers(deps.Logger, deps.Readiness, store)
+	upload := newUploadHandlers(deps.Logger, store, deps.UploadMetrics)
+	webhook := newWebhookHandlers(deps.Logger, deps.WebhookManager)
 	server := oapiServerAdapter{
-		system: h,
-		animal: animal,
-		upload: upload,
+		system:     h,
+		auth:       auth,
+		sso:        sso,
+		tokens:     tokens,
+		devices:    devices,
+		animal:     animal,
+		correction: correction,
+		archive:    archive,
+		stream:     stream,
+		upload:     upload,
+		webhook:    webhook,
 	}
 
-	openapicontract.HandlerFromMux(server, r)
+	openapicontract.HandlerWithOptions(server, openapicontract.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []openapicontract.MiddlewareFunc{requireScope},
+		// Malformed path, query or header parameters.
+		ErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, _ error) {
+			writeError(w, http.StatusBadRequest, "invalid_input")
+		},
+	})
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/openapi.json"),
 	))
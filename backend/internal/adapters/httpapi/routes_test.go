@@ -49,6 +49,22 @@ func TestRoutes(t *testing.T) {
 				if _, err := time.Parse("2006-01-02T15:04:05Z", timestamp); err != nil {
 					t.Fatalf("expected RFC3339 UTC timestamp without fractional seconds, got %q: %v", timestamp, err)
 				}
+				checks, ok := payload["checks"].([]any)
+				if !ok || len(checks) != 1 {
+					t.Fatalf("expected only the file_dir check without a readiness checker, got %#v", payload["checks"])
+				}
+			},
+		},
+		{
+			name:           "malformed parameter",
+			method:         http.MethodGet,
+			path:           "/events/stream?after=first",
+			expectedStatus: http.StatusBadRequest,
+			assertBody: func(t *testing.T, payload map[string]any) {
+				t.Helper()
+				if payload["code"] != "invalid_input" {
+					t.Fatalf("expected code=invalid_input, got %#v", payload["code"])
+				}
 			},
 		},
 		{
@@ -58,8 +74,8 @@ func TestRoutes(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			assertBody: func(t *testing.T, payload map[string]any) {
 				t.Helper()
-				if payload["error"] != "not_found" {
-					t.Fatalf("expected error=not_found, got %#v", payload["error"])
+				if payload["code"] != "not_found" {
+					t.Fatalf("expected code=not_found, got %#v", payload["code"])
 				}
 			},
 		},
@@ -82,10 +98,15 @@ func TestRoutes(t *testing.T) {
 func TestRoutesSwaggerUI(t *testing.T) {
 	t.Parallel()
 
-	h := Routes(RouteDeps{
-		Logger:       testLogger(),
-		FileStoreDir: t.TempDir(),
-		AnimalWriter: &fakeAnimalWriter{},
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
 	})
 	req := httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
 	rec := httptest.NewRecorder()
@@ -102,10 +123,15 @@ func TestRoutesSwaggerUI(t *testing.T) {
 func performRequest(t *testing.T, method, path string) *httptest.ResponseRecorder {
 	t.Helper()
 
-	h := Routes(RouteDeps{
-		Logger:       testLogger(),
-		FileStoreDir: t.TempDir(),
-		AnimalWriter: &fakeAnimalWriter{},
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
 	})
 	req := httptest.NewRequest(method, path, nil)
 	rec := httptest.NewRecorder()
@@ -119,8 +145,12 @@ func assertJSONStatus(t *testing.T, rec *httptest.ResponseRecorder, expectedStat
 	if rec.Code != expectedStatus {
 		t.Fatalf("expected status %d, got %d", expectedStatus, rec.Code)
 	}
-	if got := rec.Header().Get("Content-Type"); got != "application/json" {
-		t.Fatalf("expected content-type application/json, got %q", got)
+	want := jsonContentType
+	if expectedStatus >= http.StatusBadRequest {
+		want = problemContentType
+	}
+	if got := rec.Header().Get("Content-Type"); got != want {
+		t.Fatalf("expected content-type %s, got %q", want, got)
 	}
 }
 
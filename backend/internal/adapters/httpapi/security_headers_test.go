@@ -0,0 +1,50 @@
+package httpapi
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		tls  bool
+		hsts time.Duration
+		csp  string
+		want string
+	}{
+		{"api over http", "/animals", false, time.Hour, apiContentSecurityPolicy, ""},
+		{"api over tls", "/animals", true, time.Hour, apiContentSecurityPolicy, "max-age=3600; includeSubDomains"},
+		{"hsts disabled", "/animals", true, 0, apiContentSecurityPolicy, ""},
+		{"swagger ui", "/swagger/index.html", false, 0, swaggerContentSecurityPolicy, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rec := httptest.NewRecorder()
+			SecurityHeaders(tc.hsts)(http.NotFoundHandler()).ServeHTTP(rec, req)
+
+			header := rec.Header()
+			if header.Get("X-Content-Type-Options") != "nosniff" || header.Get("Referrer-Policy") != "no-referrer" {
+				t.Fatalf("expected nosniff and no-referrer, got %v", header)
+			}
+			if got := header.Get("Content-Security-Policy"); got != tc.csp {
+				t.Fatalf("expected Content-Security-Policy %q, got %q", tc.csp, got)
+			}
+			if got := header.Get("Strict-Transport-Security"); got != tc.want {
+				t.Fatalf("expected Strict-Transport-Security %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
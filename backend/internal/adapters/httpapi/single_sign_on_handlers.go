@@ -0,0 +1,107 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+// AuthOIDCLoginPath and AuthOIDCCallbackPath run the OpenID Connect login.
+// Like AuthLoginPath they are served without a session.
+const (
+	AuthOIDCLoginPath    = "/auth/oidc/login"
+	AuthOIDCCallbackPath = "/auth/oidc/callback"
+)
+
+const (
+	// oidcCookieName holds the state, nonce and PKCE verifier of a login in
+	// progress. It is SameSite=Lax because the provider's redirect back to the
+	// callback is a cross-site navigation.
+	oidcCookieName = "barnlog_oidc"
+	oidcCookiePath = "/auth/oidc"
+	oidcCookieTTL  = 10 * time.Minute
+)
+
+type singleSignOnHandlers struct {
+	logger *slog.Logger
+	sso    application.SingleSignOn
+}
+
+func newSingleSignOnHandlers(logger *slog.Logger, sso application.SingleSignOn) singleSignOnHandlers {
+	return singleSignOnHandlers{
+		logger: logger,
+		sso:    sso,
+	}
+}
+
+// login redirects the browser to the identity provider.
+func (h singleSignOnHandlers) login(w http.ResponseWriter, r *http.Request) {
+	if h.sso == nil {
+		writeError(w, http.StatusNotFound, "not_found")
+		return
+	}
+	login, err := h.sso.Begin(r.Context())
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "start single sign-on failed")
+		return
+	}
+
+	// The secrets are base64url, so "." cannot occur in them.
+	setOIDCCookie(w, r, strings.Join([]string{login.State, login.Nonce, login.CodeVerifier}, "."), int(oidcCookieTTL.Seconds()))
+	http.Redirect(w, r, login.AuthURL, http.StatusFound)
+}
+
+// callback finishes the login the barnlog_oidc cookie describes and sets the session cookie.
+func (h singleSignOnHandlers) callback(w http.ResponseWriter, r *http.Request, code, state, providerError string) {
+	if h.sso == nil {
+		writeError(w, http.StatusNotFound, "not_found")
+		return
+	}
+	// The login is single-use whatever the outcome.
+	setOIDCCookie(w, r, "", -1)
+
+	logger := requestLogger(r.Context(), h.logger)
+	if providerError != "" {
+		logger.Warn("single sign-on refused by provider", slog.String("provider_error", providerError))
+		writeError(w, http.StatusUnauthorized, string(application.CodeSingleSignOnFailed))
+		return
+	}
+	var login application.SingleSignOnLogin
+	if cookie, err := r.Cookie(oidcCookieName); err == nil {
+		parts := strings.Split(cookie.Value, ".")
+		if len(parts) == 3 {
+			login = application.SingleSignOnLogin{State: parts[0], Nonce: parts[1], CodeVerifier: parts[2]}
+		}
+	}
+
+	out, err := h.sso.Complete(r.Context(), application.CompleteSingleSignOnInput{
+		Code:  code,
+		State: state,
+		Login: login,
+	})
+	if err != nil {
+		if be, ok := application.AsBusinessError(err); ok {
+			logger.Warn("single sign-on rejected", slog.String("code", string(be.Code)), slog.Any("error", err))
+		}
+		writeFailure(w, r, h.logger, err, "finish single sign-on failed")
+		return
+	}
+
+	setSessionCookie(w, r, out)
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func setOIDCCookie(w http.ResponseWriter, r *http.Request, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    value,
+		Path:     oidcCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,124 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestSingleSignOn(t *testing.T) {
+	t.Parallel()
+
+	sso := &fakeSingleSignOn{
+		login: application.SingleSignOnLogin{
+			AuthURL:      "https://idp.example/authorize?state=state-1",
+			State:        "state-1",
+			Nonce:        "nonce-1",
+			CodeVerifier: "verifier-1",
+		},
+		out: application.SessionOutput{Token: "sso-token", ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	router := ssoTestRouter(sso)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, AuthOIDCLoginPath, nil))
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != sso.login.AuthURL {
+		t.Fatalf("expected a redirect to the provider, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oidcCookieName || cookies[0].Path != oidcCookiePath ||
+		!cookies[0].HttpOnly || cookies[0].SameSite != http.SameSiteLaxMode {
+		t.Fatalf("unexpected login cookies %+v", cookies)
+	}
+	loginCookie := cookies[0]
+
+	t.Run("callback starts a session", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, AuthOIDCCallbackPath+"?code=code-1&state=state-1", nil)
+		req.AddCookie(loginCookie)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/" {
+			t.Fatalf("expected a redirect home, got %d %q: %s", rec.Code, rec.Header().Get("Location"), rec.Body)
+		}
+		if sso.in.Code != "code-1" || sso.in.State != "state-1" ||
+			sso.in.Login.State != "state-1" || sso.in.Login.Nonce != "nonce-1" || sso.in.Login.CodeVerifier != "verifier-1" {
+			t.Fatalf("unexpected callback input %+v", sso.in)
+		}
+		byName := map[string]*http.Cookie{}
+		for _, cookie := range rec.Result().Cookies() {
+			byName[cookie.Name] = cookie
+		}
+		if session := byName[sessionCookieName]; session == nil || session.Value != "sso-token" {
+			t.Fatalf("expected the session cookie, got %+v", byName)
+		}
+		if login := byName[oidcCookieName]; login == nil || login.MaxAge >= 0 {
+			t.Fatalf("expected the login cookie to be cleared, got %+v", login)
+		}
+	})
+
+	t.Run("rejected callback", func(t *testing.T) {
+		sso.err = application.BusinessError{Code: application.CodeSingleSignOnFailed, Err: errors.New("state mismatch")}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, AuthOIDCCallbackPath+"?code=code-1&state=forged", nil))
+		assertJSONStatus(t, rec, http.StatusUnauthorized)
+		var payload map[string]any
+		decodeJSON(t, rec, &payload)
+		if payload["code"] != "sso_failed" {
+			t.Fatalf("expected code=sso_failed, got %#v", payload["code"])
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, AuthOIDCCallbackPath+"?error=access_denied", nil))
+		assertJSONStatus(t, rec, http.StatusUnauthorized)
+	})
+}
+
+func TestSingleSignOnNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	router := ssoTestRouter(nil)
+	for _, path := range []string{AuthOIDCLoginPath, AuthOIDCCallbackPath + "?code=c&state=s"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assertJSONStatus(t, rec, http.StatusNotFound)
+	}
+}
+
+func ssoTestRouter(sso application.SingleSignOn) http.Handler {
+	return Routes(RouteDeps{
+		Logger:         testLogger(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		Authenticator:  newFakeAuthenticator(),
+		APITokens:      &fakeAPITokenManager{},
+		Devices:        &fakeDeviceManager{},
+		SingleSignOn:   sso,
+	})
+}
+
+type fakeSingleSignOn struct {
+	login application.SingleSignOnLogin
+	in    application.CompleteSingleSignOnInput
+	out   application.SessionOutput
+	err   error
+}
+
+func (f *fakeSingleSignOn) Begin(context.Context) (application.SingleSignOnLogin, error) {
+	return f.login, nil
+}
+
+func (f *fakeSingleSignOn) Complete(_ context.Context, in application.CompleteSingleSignOnInput) (application.SessionOutput, error) {
+	f.in = in
+	return f.out, f.err
+}
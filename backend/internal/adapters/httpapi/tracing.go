@@ -0,0 +1,44 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "barnlog/backend/internal/adapters/httpapi"
+
+// traceRequests starts a server span for every request, continuing the W3C
+// traceparent and baggage of the incoming headers. The span is renamed after
+// the chi route pattern once routing is done. A nil provider uses the global one.
+func traceRequests(provider trace.TracerProvider) func(http.Handler) http.Handler {
+	opts := []otelhttp.Option{
+		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		)),
+	}
+	if provider != nil {
+		opts = append(opts, otelhttp.WithTracerProvider(provider))
+	}
+	return func(next http.Handler) http.Handler {
+		named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			route := RoutePattern(r)
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		})
+		return otelhttp.NewHandler(named, "http.request", opts...)
+	}
+}
+
+// startChildSpan starts a span with the tracer provider of the request span,
+// so handler spans land wherever the request span is recorded.
+func startChildSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name)
+}
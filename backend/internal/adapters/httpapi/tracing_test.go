@@ -0,0 +1,69 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRoutesTraceRequests(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+	rec := performCreateAnimal(t, router, `{"name":"Nanny","species":"goat"}`, withCreateAnimalHeaders(
+		"traceparent", "00-"+traceID+"-"+parentID+"-01",
+	))
+	assertJSONStatus(t, rec, http.StatusCreated)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected a decode span and a server span, got %d", len(spans))
+	}
+	decode, server := spans[0], spans[1]
+
+	if server.Name() != "POST /animals" {
+		t.Fatalf("expected server span named after the route, got %q", server.Name())
+	}
+	if got := server.SpanContext().TraceID().String(); got != traceID {
+		t.Fatalf("expected the incoming trace ID %s, got %s", traceID, got)
+	}
+	if got := server.Parent().SpanID().String(); got != parentID || !server.Parent().IsRemote() {
+		t.Fatalf("expected remote parent %s, got %s", parentID, got)
+	}
+	if !hasAttribute(server.Attributes(), attribute.String("http.route", "/animals")) {
+		t.Fatalf("expected http.route=/animals, got %v", server.Attributes())
+	}
+
+	if decode.Name() != "DecodeJSONRequest" || decode.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Fatalf("expected DecodeJSONRequest as a child of the server span, got %q with parent %s",
+			decode.Name(), decode.Parent().SpanID())
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"path"
 	"strings"
+
+	"barnlog/backend/internal/application"
 )
 
 const (
@@ -24,6 +26,17 @@ var animalPhotoAllowedContentTypes = map[string]struct{}{
 	"image/gif":  {},
 }
 
+// UploadMetrics counts upload outcomes.
+type UploadMetrics interface {
+	UploadAccepted(size int64)
+	UploadRejected(code string)
+}
+
+type noopUploadMetrics struct{}
+
+func (noopUploadMetrics) UploadAccepted(int64)  {}
+func (noopUploadMetrics) UploadRejected(string) {}
+
 type uploadPolicy struct {
 	maxFileSizeBytes     int64
 	maxFilesPerUpload    int
@@ -44,9 +57,14 @@ func (h uploadHandlers) uploadAnimalPhoto(w http.ResponseWriter, r *http.Request
 }
 
 func (h uploadHandlers) uploadWithPolicy(w http.ResponseWriter, r *http.Request, policy uploadPolicy) {
+	logger := requestLogger(r.Context(), h.logger)
 	if h.fileStore == nil {
-		h.logger.Error("file store is nil")
-		writeError(w, http.StatusInternalServerError, "internal_error")
+		logger.Error("file store is nil")
+		h.reject(w, http.StatusInternalServerError, "internal_error")
+		return
+	}
+	if err := application.Authorize(r.Context(), application.PermissionUploadFiles); err != nil {
+		h.reject(w, http.StatusForbidden, string(application.CodeForbidden))
 		return
 	}
 
@@ -55,81 +73,82 @@ func (h uploadHandlers) uploadWithPolicy(w http.ResponseWriter, r *http.Request,
 
 	if err := r.ParseMultipartForm(policy.maxFileSizeBytes + 512); err != nil {
 		if isRequestTooLarge(err) {
-			writeError(w, http.StatusRequestEntityTooLarge, "file_too_large")
+			h.reject(w, http.StatusRequestEntityTooLarge, "file_too_large")
 			return
 		}
 
-		writeError(w, http.StatusBadRequest, "invalid_multipart")
+		h.reject(w, http.StatusBadRequest, "invalid_multipart")
 		return
 	}
 
 	if r.MultipartForm != nil {
 		defer func() {
 			if err := r.MultipartForm.RemoveAll(); err != nil {
-				h.logger.Warn("remove multipart temp files", slog.Any("error", err))
+				logger.Warn("remove multipart temp files", slog.Any("error", err))
 			}
 		}()
 	}
 
 	totalFiles := countMultipartFiles(r.MultipartForm.File)
 	if totalFiles == 0 {
-		writeError(w, http.StatusBadRequest, "file_required")
+		h.reject(w, http.StatusBadRequest, "file_required")
 		return
 	}
 	if totalFiles > policy.maxFilesPerUpload {
-		writeError(w, http.StatusBadRequest, "multiple_files_not_allowed")
+		h.reject(w, http.StatusBadRequest, "multiple_files_not_allowed")
 		return
 	}
 
 	fileHeaders := r.MultipartForm.File[fileFieldName]
 	if len(fileHeaders) != policy.maxFilesPerUpload {
-		writeError(w, http.StatusBadRequest, "file_required")
+		h.reject(w, http.StatusBadRequest, "file_required")
 		return
 	}
 
 	fileHeader := fileHeaders[0]
 	file, err := fileHeader.Open()
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "invalid_file")
+		h.reject(w, http.StatusBadRequest, "invalid_file")
 		return
 	}
 	defer func() {
 		if closeErr := file.Close(); closeErr != nil {
-			h.logger.Warn("close uploaded file", slog.Any("error", closeErr))
+			logger.Warn("close uploaded file", slog.Any("error", closeErr))
 		}
 	}()
 
 	sniffBuffer := make([]byte, 512)
 	sniffBytesRead, sniffErr := io.ReadFull(file, sniffBuffer)
 	if sniffErr != nil && !errors.Is(sniffErr, io.EOF) && !errors.Is(sniffErr, io.ErrUnexpectedEOF) {
-		writeError(w, http.StatusBadRequest, "invalid_file")
+		h.reject(w, http.StatusBadRequest, "invalid_file")
 		return
 	}
 	if sniffBytesRead == 0 {
-		writeError(w, http.StatusBadRequest, "invalid_file")
+		h.reject(w, http.StatusBadRequest, "invalid_file")
 		return
 	}
 
 	sniffBuffer = sniffBuffer[:sniffBytesRead]
 	contentType := http.DetectContentType(sniffBuffer)
 	if _, ok := policy.allowedContentTypes[contentType]; !ok {
-		writeError(w, http.StatusBadRequest, policy.unsupportedTypeError)
+		h.reject(w, http.StatusBadRequest, policy.unsupportedTypeError)
 		return
 	}
 
 	fileID, totalBytes, err := h.fileStore.Save(
 		r.Context(),
+		application.BarnFromContext(r.Context()),
 		io.MultiReader(bytes.NewReader(sniffBuffer), file),
 		policy.maxFileSizeBytes,
 	)
 	if err != nil {
 		if errors.Is(err, errFileTooLarge) {
-			writeError(w, http.StatusRequestEntityTooLarge, "file_too_large")
+			h.reject(w, http.StatusRequestEntityTooLarge, "file_too_large")
 			return
 		}
 
-		h.logger.Error("save file", slog.Any("error", err))
-		writeError(w, http.StatusInternalServerError, "internal_error")
+		logger.Error("save file", slog.Any("error", err))
+		h.reject(w, http.StatusInternalServerError, "internal_error")
 		return
 	}
 
@@ -140,14 +159,21 @@ func (h uploadHandlers) uploadWithPolicy(w http.ResponseWriter, r *http.Request,
 		totalBytes,
 	)
 	if err != nil {
-		h.logger.Error("map upload response", slog.Any("error", err))
-		writeError(w, http.StatusInternalServerError, "internal_error")
+		logger.Error("map upload response", slog.Any("error", err))
+		h.reject(w, http.StatusInternalServerError, "internal_error")
 		return
 	}
 
+	h.metrics.UploadAccepted(totalBytes)
 	writeJSON(w, http.StatusCreated, response)
 }
 
+// reject writes an upload error response and counts it by code.
+func (h uploadHandlers) reject(w http.ResponseWriter, status int, code string) {
+	h.metrics.UploadRejected(code)
+	writeError(w, status, code)
+}
+
 func countMultipartFiles(files map[string][]*multipart.FileHeader) int {
 	totalFiles := 0
 	for _, fileHeaders := range files {
@@ -8,17 +8,25 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+
+	"barnlog/backend/internal/domain"
 )
 
 func TestUploadAnimalPhoto(t *testing.T) {
 	t.Parallel()
 
 	fileDir := t.TempDir()
-	router := Routes(RouteDeps{
-		Logger:       testLogger(),
-		FileStoreDir: fileDir,
-		AnimalWriter: &fakeAnimalWriter{},
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   fileDir,
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
 	})
 
 	t.Run("created", func(t *testing.T) {
@@ -49,7 +57,7 @@ func TestUploadAnimalPhoto(t *testing.T) {
 			t.Fatalf("expected size_bytes=%d, got %#v", len(samplePNGBytes()), payload["size_bytes"])
 		}
 
-		if _, err := os.Stat(filepath.Join(fileDir, fileID)); err != nil {
+		if _, err := os.Stat(filepath.Join(fileDir, domain.DefaultBarnID, fileID)); err != nil {
 			t.Fatalf("expected saved file: %v", err)
 		}
 	})
@@ -62,8 +70,8 @@ func TestUploadAnimalPhoto(t *testing.T) {
 
 		var payload map[string]any
 		decodeJSON(t, rec, &payload)
-		if payload["error"] != "file_required" {
-			t.Fatalf("expected error=file_required, got %#v", payload["error"])
+		if payload["code"] != "file_required" {
+			t.Fatalf("expected code=file_required, got %#v", payload["code"])
 		}
 	})
 
@@ -75,8 +83,8 @@ func TestUploadAnimalPhoto(t *testing.T) {
 
 		var payload map[string]any
 		decodeJSON(t, rec, &payload)
-		if payload["error"] != "unsupported_file_type" {
-			t.Fatalf("expected error=unsupported_file_type, got %#v", payload["error"])
+		if payload["code"] != "unsupported_file_type" {
+			t.Fatalf("expected code=unsupported_file_type, got %#v", payload["code"])
 		}
 	})
 
@@ -88,8 +96,8 @@ func TestUploadAnimalPhoto(t *testing.T) {
 
 		var payload map[string]any
 		decodeJSON(t, rec, &payload)
-		if payload["error"] != "multiple_files_not_allowed" {
-			t.Fatalf("expected error=multiple_files_not_allowed, got %#v", payload["error"])
+		if payload["code"] != "multiple_files_not_allowed" {
+			t.Fatalf("expected code=multiple_files_not_allowed, got %#v", payload["code"])
 		}
 	})
 
@@ -101,8 +109,8 @@ func TestUploadAnimalPhoto(t *testing.T) {
 
 		var payload map[string]any
 		decodeJSON(t, rec, &payload)
-		if payload["error"] != "file_required" {
-			t.Fatalf("expected error=file_required, got %#v", payload["error"])
+		if payload["code"] != "file_required" {
+			t.Fatalf("expected code=file_required, got %#v", payload["code"])
 		}
 	})
 
@@ -115,12 +123,85 @@ func TestUploadAnimalPhoto(t *testing.T) {
 
 		var payload map[string]any
 		decodeJSON(t, rec, &payload)
-		if payload["error"] != "file_too_large" {
-			t.Fatalf("expected error=file_too_large, got %#v", payload["error"])
+		if payload["code"] != "file_too_large" {
+			t.Fatalf("expected code=file_too_large, got %#v", payload["code"])
 		}
 	})
 }
 
+func TestUploadAnimalPhotoForbiddenForViewers(t *testing.T) {
+	t.Parallel()
+
+	fileDir := t.TempDir()
+	auth := newFakeAuthenticator()
+	viewer := testPrincipal
+	viewer.Memberships = domain.Memberships{domain.ServerBarnID: domain.RoleViewer}
+	auth.sessions[testSessionToken] = viewer
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   fileDir,
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		Authenticator:  auth,
+	})
+
+	rec := performUpload(t, router, "animal.png", samplePNGBytes())
+	assertJSONStatus(t, rec, http.StatusForbidden)
+	var payload map[string]any
+	decodeJSON(t, rec, &payload)
+	if payload["code"] != "forbidden" {
+		t.Fatalf("expected code=forbidden, got %#v", payload["code"])
+	}
+	if entries, err := os.ReadDir(fileDir); err != nil || len(entries) != 0 {
+		t.Fatalf("expected no stored files, got %d (%v)", len(entries), err)
+	}
+}
+
+func TestUploadAnimalPhotoCountsMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := &fakeUploadMetrics{}
+	router := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: &fakeWebhookManager{},
+		UploadMetrics:  metrics,
+	})
+
+	assertJSONStatus(t, performUpload(t, router, "animal.png", samplePNGBytes()), http.StatusCreated)
+	assertJSONStatus(t, performUpload(t, router, "animal.txt", []byte("not-an-image")), http.StatusBadRequest)
+	assertJSONStatus(t, performUploadWithoutFile(t, router), http.StatusBadRequest)
+
+	if want := []int64{int64(len(samplePNGBytes()))}; !slices.Equal(metrics.accepted, want) {
+		t.Fatalf("expected accepted sizes %v, got %v", want, metrics.accepted)
+	}
+	if want := []string{"unsupported_file_type", "file_required"}; !slices.Equal(metrics.rejected, want) {
+		t.Fatalf("expected rejections %v, got %v", want, metrics.rejected)
+	}
+}
+
+type fakeUploadMetrics struct {
+	accepted []int64
+	rejected []string
+}
+
+func (f *fakeUploadMetrics) UploadAccepted(size int64) {
+	f.accepted = append(f.accepted, size)
+}
+
+func (f *fakeUploadMetrics) UploadRejected(code string) {
+	f.rejected = append(f.rejected, code)
+}
+
 func performUpload(t *testing.T, router http.Handler, filename string, content []byte) *httptest.ResponseRecorder {
 	t.Helper()
 
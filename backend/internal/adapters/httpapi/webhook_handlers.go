@@ -0,0 +1,137 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+
+	"barnlog/backend/internal/application"
+)
+
+type webhookHandlers struct {
+	logger   *slog.Logger
+	webhooks application.WebhookManager
+}
+
+func newWebhookHandlers(logger *slog.Logger, webhooks application.WebhookManager) webhookHandlers {
+	return webhookHandlers{
+		logger:   logger,
+		webhooks: webhooks,
+	}
+}
+
+type createWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
+
+type updateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
+
+type webhookResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	Secret     string   `json:"secret,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type webhookListResponse struct {
+	Items []webhookResponse `json:"items"`
+}
+
+// createWebhook registers a webhook subscription and returns its signing secret once.
+func (h webhookHandlers) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var req createWebhookRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+
+	out, err := h.webhooks.CreateWebhook(r.Context(), application.CreateWebhookInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     req.Active,
+	})
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "create webhook failed")
+		return
+	}
+	writeJSON(w, http.StatusCreated, newWebhookResponse(out))
+}
+
+// listWebhooks returns every webhook subscription without secrets.
+func (h webhookHandlers) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	out, err := h.webhooks.ListWebhooks(r.Context())
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "list webhooks failed")
+		return
+	}
+
+	items := make([]webhookResponse, 0, len(out))
+	for _, webhook := range out {
+		items = append(items, newWebhookResponse(webhook))
+	}
+	writeJSON(w, http.StatusOK, webhookListResponse{Items: items})
+}
+
+// getWebhook returns one webhook subscription without its secret.
+func (h webhookHandlers) getWebhook(w http.ResponseWriter, r *http.Request, webhookID string) {
+	out, err := h.webhooks.GetWebhook(r.Context(), webhookID)
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "get webhook failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookResponse(out))
+}
+
+// updateWebhook replaces the URL, filter and active flag of a webhook subscription.
+func (h webhookHandlers) updateWebhook(w http.ResponseWriter, r *http.Request, webhookID string) {
+	var req updateWebhookRequest
+	if status, code, ok := decodeJSONRequest(w, r, &req); !ok {
+		writeError(w, status, code)
+		return
+	}
+	if req.Active == nil {
+		writeError(w, http.StatusBadRequest, "invalid_json")
+		return
+	}
+
+	out, err := h.webhooks.UpdateWebhook(r.Context(), application.UpdateWebhookInput{
+		WebhookID:  webhookID,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     *req.Active,
+	})
+	if err != nil {
+		writeFailure(w, r, h.logger, err, "update webhook failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookResponse(out))
+}
+
+// deleteWebhook removes a webhook subscription and its pending deliveries.
+func (h webhookHandlers) deleteWebhook(w http.ResponseWriter, r *http.Request, webhookID string) {
+	if err := h.webhooks.DeleteWebhook(r.Context(), webhookID); err != nil {
+		writeFailure(w, r, h.logger, err, "delete webhook failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newWebhookResponse(out application.WebhookOutput) webhookResponse {
+	return webhookResponse{
+		ID:         out.ID,
+		URL:        out.URL,
+		EventTypes: out.EventTypes,
+		Active:     out.Active,
+		Secret:     out.Secret,
+		CreatedAt:  out.CreatedAt,
+		UpdatedAt:  out.UpdatedAt,
+	}
+}
@@ -0,0 +1,163 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/application"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	manager := &fakeWebhookManager{
+		out: application.WebhookOutput{
+			ID:         "w1",
+			URL:        "https://example.test/hook",
+			EventTypes: []string{"animal.created"},
+			Active:     true,
+			Secret:     "whsec_x",
+		},
+	}
+	rec := performWebhookRequest(t, manager, http.MethodPost, "/webhooks",
+		`{"url":"https://example.test/hook","event_types":["animal.created"]}`)
+	assertJSONStatus(t, rec, http.StatusCreated)
+
+	var payload webhookResponse
+	decodeJSON(t, rec, &payload)
+	if payload.ID != "w1" || payload.Secret != "whsec_x" {
+		t.Fatalf("unexpected response %+v", payload)
+	}
+	if manager.createIn.URL != "https://example.test/hook" || manager.createIn.Active != nil {
+		t.Fatalf("unexpected create input %+v", manager.createIn)
+	}
+}
+
+func TestGetWebhookOmitsSecret(t *testing.T) {
+	t.Parallel()
+
+	manager := &fakeWebhookManager{out: application.WebhookOutput{ID: "w1", EventTypes: []string{}}}
+	rec := performWebhookRequest(t, manager, http.MethodGet, "/webhooks/w1", "")
+	assertJSONStatus(t, rec, http.StatusOK)
+	if strings.Contains(rec.Body.String(), "secret") {
+		t.Fatalf("expected no secret in %s", rec.Body.String())
+	}
+	if manager.webhookID != "w1" {
+		t.Fatalf("expected webhook id w1, got %q", manager.webhookID)
+	}
+}
+
+func TestUpdateWebhookRequiresActive(t *testing.T) {
+	t.Parallel()
+
+	rec := performWebhookRequest(t, &fakeWebhookManager{}, http.MethodPut, "/webhooks/w1",
+		`{"url":"https://example.test/hook"}`)
+	assertJSONStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	rec := performWebhookRequest(t, &fakeWebhookManager{}, http.MethodDelete, "/webhooks/w1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+}
+
+func TestWebhookBusinessErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		code   application.BusinessCode
+		status int
+	}{
+		{name: "not found", code: application.CodeWebhookNotFound, status: http.StatusNotFound},
+		{name: "url invalid", code: application.CodeWebhookURLInvalid, status: http.StatusBadRequest},
+		{name: "event type invalid", code: application.CodeWebhookEventTypeInvalid, status: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			manager := &fakeWebhookManager{err: application.BusinessError{Code: tc.code}}
+			rec := performWebhookRequest(t, manager, http.MethodPut, "/webhooks/w1",
+				`{"url":"https://example.test/hook","active":true}`)
+			assertJSONStatus(t, rec, tc.status)
+
+			var payload map[string]any
+			decodeJSON(t, rec, &payload)
+			if payload["code"] != string(tc.code) {
+				t.Fatalf("expected code %q, got %#v", tc.code, payload["code"])
+			}
+		})
+	}
+}
+
+type fakeWebhookManager struct {
+	out       application.WebhookOutput
+	err       error
+	createIn  application.CreateWebhookInput
+	updateIn  application.UpdateWebhookInput
+	webhookID string
+}
+
+func (f *fakeWebhookManager) CreateWebhook(
+	_ context.Context,
+	in application.CreateWebhookInput,
+) (application.WebhookOutput, error) {
+	f.createIn = in
+	return f.out, f.err
+}
+
+func (f *fakeWebhookManager) GetWebhook(_ context.Context, webhookID string) (application.WebhookOutput, error) {
+	f.webhookID = webhookID
+	return f.out, f.err
+}
+
+func (f *fakeWebhookManager) ListWebhooks(context.Context) ([]application.WebhookOutput, error) {
+	return []application.WebhookOutput{f.out}, f.err
+}
+
+func (f *fakeWebhookManager) UpdateWebhook(
+	_ context.Context,
+	in application.UpdateWebhookInput,
+) (application.WebhookOutput, error) {
+	f.updateIn = in
+	return f.out, f.err
+}
+
+func (f *fakeWebhookManager) DeleteWebhook(_ context.Context, webhookID string) error {
+	f.webhookID = webhookID
+	return f.err
+}
+
+func performWebhookRequest(
+	t *testing.T,
+	manager application.WebhookManager,
+	method, path, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := testRoutes(RouteDeps{
+		Logger:         testLogger(),
+		FileStoreDir:   t.TempDir(),
+		AnimalWriter:   &fakeAnimalWriter{},
+		AnimalReader:   &fakeAnimalReader{},
+		EventCorrector: &fakeEventCorrector{},
+		EventFeed:      &fakeEventFeed{},
+		EventArchive:   &fakeEventArchive{},
+		WebhookManager: manager,
+	})
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
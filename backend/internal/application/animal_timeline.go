@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+// Timeline entry statuses.
+const (
+	TimelineStatusActive    = "active"
+	TimelineStatusCorrected = "corrected"
+	TimelineStatusVoided    = "voided"
+)
+
+// AnimalTimelineOutput is the application result for reading an animal history.
+type AnimalTimelineOutput struct {
+	AnimalID string
+	Entries  []TimelineEntryOutput
+}
+
+// TimelineEntryOutput is one historical event with corrections and voids applied.
+type TimelineEntryOutput struct {
+	EventID         string
+	EventType       string
+	CreatedBy       string
+	OccurredAt      string
+	RecordedAt      string
+	Status          string
+	Payload         json.RawMessage
+	OriginalPayload json.RawMessage
+	Corrections     []TimelineCorrectionOutput
+}
+
+// TimelineCorrectionOutput is an event.corrected or event.voided applied to a timeline entry.
+type TimelineCorrectionOutput struct {
+	EventID    string
+	EventType  string
+	Reason     string
+	CreatedBy  string
+	OccurredAt string
+	RecordedAt string
+	Payload    json.RawMessage
+}
+
+func (r animalReader) Timeline(ctx context.Context, animalID string) (AnimalTimelineOutput, error) {
+	if err := Authorize(ctx, PermissionReadAnimals); err != nil {
+		return AnimalTimelineOutput{}, err
+	}
+	animalID = strings.TrimSpace(animalID)
+	if animalID == "" {
+		return AnimalTimelineOutput{}, BusinessError{
+			Code: CodeAnimalNotFound,
+			Err:  errors.New("animal not found"),
+		}
+	}
+
+	records, err := r.store.ListAnimalEvents(ctx, BarnFromContext(ctx), animalID)
+	if err != nil {
+		return AnimalTimelineOutput{}, fmt.Errorf("list animal events: %w", err)
+	}
+	if len(records) == 0 {
+		return AnimalTimelineOutput{}, BusinessError{
+			Code: CodeAnimalNotFound,
+			Err:  errors.New("animal not found"),
+		}
+	}
+
+	entries, err := buildTimeline(records)
+	if err != nil {
+		return AnimalTimelineOutput{}, err
+	}
+
+	out := AnimalTimelineOutput{
+		AnimalID: animalID,
+		Entries:  make([]TimelineEntryOutput, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		status := TimelineStatusActive
+		switch {
+		case entry.Voided:
+			status = TimelineStatusVoided
+		case entry.Corrected():
+			status = TimelineStatusCorrected
+		}
+
+		corrections := make([]TimelineCorrectionOutput, 0, len(entry.Corrections))
+		for _, correction := range entry.Corrections {
+			corrections = append(corrections, TimelineCorrectionOutput{
+				EventID:    correction.Event.ID,
+				EventType:  correction.Event.Type,
+				Reason:     correction.Reason,
+				CreatedBy:  correction.Event.CreatedBy,
+				OccurredAt: correction.Event.OccurredAt,
+				RecordedAt: correction.Event.RecordedAt,
+				Payload:    correction.Payload,
+			})
+		}
+
+		out.Entries = append(out.Entries, TimelineEntryOutput{
+			EventID:         entry.Event.ID,
+			EventType:       entry.Event.Type,
+			CreatedBy:       entry.Event.CreatedBy,
+			OccurredAt:      entry.Event.OccurredAt,
+			RecordedAt:      entry.Event.RecordedAt,
+			Status:          status,
+			Payload:         entry.Payload,
+			OriginalPayload: entry.Event.Payload,
+			Corrections:     corrections,
+		})
+	}
+	return out, nil
+}
+
+// buildTimeline upcasts stored events and applies corrections and voids to them.
+func buildTimeline(records []ports.EventRecord) ([]domain.TimelineEntry, error) {
+	events := make([]domain.Event, 0, len(records))
+	for _, record := range records {
+		payload, err := domain.Upcast(record.EventType, record.EventVersion, []byte(record.PayloadJSON))
+		if err != nil {
+			return nil, fmt.Errorf("upcast event %s: %w", record.ID, err)
+		}
+		events = append(events, domain.Event{
+			Position:   record.Position,
+			ID:         record.ID,
+			Type:       record.EventType,
+			Payload:    payload,
+			CreatedBy:  record.CreatedBy,
+			OccurredAt: record.OccurredAt,
+			RecordedAt: record.RecordedAt,
+		})
+	}
+
+	entries, err := domain.BuildTimeline(events)
+	if err != nil {
+		return nil, fmt.Errorf("build timeline: %w", err)
+	}
+	return entries, nil
+}
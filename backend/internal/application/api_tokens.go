@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"barnlog/backend/internal/ports"
+)
+
+// API token scopes. Each API operation declares the scope a token needs in the
+// OpenAPI spec; session cookies are not scoped.
+const (
+	ScopeAnimalsRead   = "animals:read"
+	ScopeAnimalsWrite  = "animals:write"
+	ScopeEventsRead    = "events:read"
+	ScopeEventsImport  = "events:import"
+	ScopeUploadsWrite  = "uploads:write"
+	ScopeWebhooksRead  = "webhooks:read"
+	ScopeWebhooksWrite = "webhooks:write"
+)
+
+// APITokenScopes lists every scope an API token can be granted.
+var APITokenScopes = []string{
+	ScopeAnimalsRead,
+	ScopeAnimalsWrite,
+	ScopeEventsImport,
+	ScopeEventsRead,
+	ScopeUploadsWrite,
+	ScopeWebhooksRead,
+	ScopeWebhooksWrite,
+}
+
+const (
+	// CodeScopeInvalid indicates a missing or unknown API token scope.
+	CodeScopeInvalid BusinessCode = "scope_invalid"
+	// CodeTokenNotFound indicates the API token does not exist or belongs to another user.
+	CodeTokenNotFound BusinessCode = "token_not_found"
+
+	// apiTokenPrefix marks barnlog API tokens so secret scanners and log
+	// reviews can recognise them.
+	apiTokenPrefix        = "blt_"
+	maxAPITokenNameLength = 100
+)
+
+// CreateAPITokenInput describes a new API token of UserID. A zero ExpiresAt
+// creates a token that does not expire.
+type CreateAPITokenInput struct {
+	UserID    string
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// APITokenOutput is an API token without its secret.
+type APITokenOutput struct {
+	ID        string
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// CreatedAPITokenOutput is a new API token. Token is only returned here; the
+// server keeps a hash of it.
+type CreatedAPITokenOutput struct {
+	APITokenOutput
+	Token string
+}
+
+// APITokenManager creates, lists and revokes the API tokens of a user.
+type APITokenManager interface {
+	CreateToken(ctx context.Context, in CreateAPITokenInput) (CreatedAPITokenOutput, error)
+	ListTokens(ctx context.Context, userID string) ([]APITokenOutput, error)
+	RevokeToken(ctx context.Context, userID string, tokenID string) error
+}
+
+type apiTokenManager struct {
+	store ports.APITokenStore
+	now   func() time.Time
+}
+
+// NewAPITokenManager builds the API token application service.
+func NewAPITokenManager(store ports.APITokenStore) APITokenManager {
+	return apiTokenManager{store: store, now: time.Now}
+}
+
+func (m apiTokenManager) CreateToken(ctx context.Context, in CreateAPITokenInput) (CreatedAPITokenOutput, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPITokenNameLength {
+		return CreatedAPITokenOutput{}, BusinessError{
+			Code: CodeInvalidInput,
+			Err:  fmt.Errorf("token name must be 1-%d characters", maxAPITokenNameLength),
+		}
+	}
+	scopes, err := normalizeScopes(in.Scopes)
+	if err != nil {
+		return CreatedAPITokenOutput{}, err
+	}
+	now := m.now().UTC().Truncate(time.Second)
+	expiresAt := in.ExpiresAt
+	if !expiresAt.IsZero() {
+		expiresAt = expiresAt.UTC().Truncate(time.Second)
+		if !expiresAt.After(now) {
+			return CreatedAPITokenOutput{}, BusinessError{
+				Code: CodeInvalidInput,
+				Err:  errors.New("token expiry must be in the future"),
+			}
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return CreatedAPITokenOutput{}, err
+	}
+	token := apiTokenPrefix + secret
+	stored, err := m.store.CreateAPIToken(ctx, ports.APITokenRecordInput{
+		UserID:    in.UserID,
+		Name:      name,
+		TokenHash: hashSecret(token),
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return CreatedAPITokenOutput{}, fmt.Errorf("create api token: %w", err)
+	}
+	return CreatedAPITokenOutput{APITokenOutput: apiTokenOutputFromRecord(stored), Token: token}, nil
+}
+
+func (m apiTokenManager) ListTokens(ctx context.Context, userID string) ([]APITokenOutput, error) {
+	tokens, err := m.store.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	out := make([]APITokenOutput, 0, len(tokens))
+	for _, token := range tokens {
+		out = append(out, apiTokenOutputFromRecord(token))
+	}
+	return out, nil
+}
+
+func (m apiTokenManager) RevokeToken(ctx context.Context, userID string, tokenID string) error {
+	deleted, err := m.store.DeleteAPIToken(ctx, userID, tokenID)
+	if err != nil {
+		return fmt.Errorf("delete api token %s: %w", tokenID, err)
+	}
+	if !deleted {
+		return BusinessError{Code: CodeTokenNotFound, Err: fmt.Errorf("api token %q not found", tokenID)}
+	}
+	return nil
+}
+
+// AuthenticateToken resolves a bearer credential: an API token or, by its
+// prefix, a device secret.
+func (a authenticator) AuthenticateToken(ctx context.Context, token string) (Principal, error) {
+	if strings.HasPrefix(token, devicePrefix) {
+		return a.authenticateDevice(ctx, token)
+	}
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return Principal{}, errUnauthenticated()
+	}
+	stored, found, err := a.tokens.GetAPITokenByHash(ctx, hashSecret(token))
+	if err != nil {
+		return Principal{}, fmt.Errorf("get api token: %w", err)
+	}
+	if !found || (!stored.ExpiresAt.IsZero() && !a.now().Before(stored.ExpiresAt)) {
+		return Principal{}, errUnauthenticated()
+	}
+
+	user, found, err := a.users.GetUser(ctx, stored.UserID)
+	if err != nil {
+		return Principal{}, fmt.Errorf("get user %s: %w", stored.UserID, err)
+	}
+	if !found {
+		return Principal{}, errUnauthenticated()
+	}
+	return Principal{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Memberships: user.Memberships,
+		ExpiresAt:   stored.ExpiresAt,
+		TokenID:     stored.ID,
+		Scopes:      stored.Scopes,
+	}, nil
+}
+
+// normalizeScopes checks scopes against APITokenScopes and returns them sorted
+// without duplicates.
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, BusinessError{Code: CodeScopeInvalid, Err: errors.New("at least one scope is required")}
+	}
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(APITokenScopes, scope) {
+			return nil, BusinessError{Code: CodeScopeInvalid, Err: fmt.Errorf("unknown scope %q", scope)}
+		}
+		out = append(out, scope)
+	}
+	slices.Sort(out)
+	return slices.Compact(out), nil
+}
+
+func apiTokenOutputFromRecord(token ports.APIToken) APITokenOutput {
+	return APITokenOutput{
+		ID:        token.ID,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
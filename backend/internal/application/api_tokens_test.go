@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestAPITokenManager_CreateListRevoke(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeAPITokenStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := NewAPITokenManager(store).(apiTokenManager)
+	manager.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	created, err := manager.CreateToken(ctx, CreateAPITokenInput{
+		UserID: "u1",
+		Name:   "  scale  ",
+		Scopes: []string{ScopeAnimalsWrite, ScopeAnimalsRead, ScopeAnimalsWrite},
+	})
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	if !strings.HasPrefix(created.Token, apiTokenPrefix) || created.Name != "scale" || !created.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected token %+v", created)
+	}
+	if !slices.Equal(created.Scopes, []string{ScopeAnimalsRead, ScopeAnimalsWrite}) {
+		t.Fatalf("expected sorted unique scopes, got %v", created.Scopes)
+	}
+	if _, stored := store.byHash[created.Token]; stored {
+		t.Fatal("expected only a hash of the token to be stored")
+	}
+
+	listed, err := manager.ListTokens(ctx, "u1")
+	if err != nil {
+		t.Fatalf("list tokens: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID {
+		t.Fatalf("unexpected tokens %+v", listed)
+	}
+
+	if err := manager.RevokeToken(ctx, "u2", created.ID); !hasCode(err, CodeTokenNotFound) {
+		t.Fatalf("expected %q revoking another user's token, got %v", CodeTokenNotFound, err)
+	}
+	if err := manager.RevokeToken(ctx, "u1", created.ID); err != nil {
+		t.Fatalf("revoke token: %v", err)
+	}
+	if listed, _ := manager.ListTokens(ctx, "u1"); len(listed) != 0 {
+		t.Fatalf("expected no tokens after revoke, got %+v", listed)
+	}
+}
+
+func TestAPITokenManager_CreateRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := NewAPITokenManager(newFakeAPITokenStore()).(apiTokenManager)
+	manager.now = func() time.Time { return now }
+
+	tests := []struct {
+		name string
+		in   CreateAPITokenInput
+		code BusinessCode
+	}{
+		{name: "blank name", in: CreateAPITokenInput{Name: " ", Scopes: []string{ScopeAnimalsRead}}, code: CodeInvalidInput},
+		{name: "long name", in: CreateAPITokenInput{Name: strings.Repeat("n", 101), Scopes: []string{ScopeAnimalsRead}}, code: CodeInvalidInput},
+		{name: "no scopes", in: CreateAPITokenInput{Name: "scale"}, code: CodeScopeInvalid},
+		{name: "unknown scope", in: CreateAPITokenInput{Name: "scale", Scopes: []string{"animals:delete"}}, code: CodeScopeInvalid},
+		{
+			name: "past expiry",
+			in:   CreateAPITokenInput{Name: "scale", Scopes: []string{ScopeAnimalsRead}, ExpiresAt: now.Add(-time.Minute)},
+			code: CodeInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.in.UserID = "u1"
+			if _, err := manager.CreateToken(context.Background(), tt.in); !hasCode(err, tt.code) {
+				t.Fatalf("expected %q, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_AuthenticateToken(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	user := users.add("anna", "hashed:correct horse")
+	tokens := newFakeAPITokenStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := NewAPITokenManager(tokens).(apiTokenManager)
+	manager.now = func() time.Time { return now }
+	auth := NewAuthenticator(users, newFakeSessionStore(), tokens, newFakeDeviceStore(), fakePasswordHasher{}, AuthenticatorConfig{}).(authenticator)
+	auth.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	created, err := manager.CreateToken(ctx, CreateAPITokenInput{
+		UserID:    user.ID,
+		Name:      "scale",
+		Scopes:    []string{ScopeAnimalsWrite},
+		ExpiresAt: now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	principal, err := auth.AuthenticateToken(ctx, created.Token)
+	if err != nil {
+		t.Fatalf("authenticate token: %v", err)
+	}
+	if principal.Username != "anna" || principal.TokenID != created.ID {
+		t.Fatalf("unexpected principal %+v", principal)
+	}
+	if !principal.HasScope(ScopeAnimalsWrite) || principal.HasScope(ScopeAnimalsRead) {
+		t.Fatalf("expected only the granted scope, got %v", principal.Scopes)
+	}
+	if !(Principal{UserID: user.ID}).HasScope(ScopeWebhooksWrite) {
+		t.Fatal("expected session principals to have every scope")
+	}
+
+	for _, token := range []string{"", "blt_unknown", strings.TrimPrefix(created.Token, apiTokenPrefix)} {
+		if _, err := auth.AuthenticateToken(ctx, token); !hasCode(err, CodeUnauthenticated) {
+			t.Fatalf("expected %q for token %q, got %v", CodeUnauthenticated, token, err)
+		}
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := auth.AuthenticateToken(ctx, created.Token); !hasCode(err, CodeUnauthenticated) {
+		t.Fatalf("expected %q once expired, got %v", CodeUnauthenticated, err)
+	}
+}
+
+type fakeAPITokenStore struct {
+	byHash map[string]ports.APIToken
+	nextID int
+}
+
+func newFakeAPITokenStore() *fakeAPITokenStore {
+	return &fakeAPITokenStore{byHash: map[string]ports.APIToken{}}
+}
+
+func (f *fakeAPITokenStore) CreateAPIToken(_ context.Context, in ports.APITokenRecordInput) (ports.APIToken, error) {
+	f.nextID++
+	token := ports.APIToken{
+		ID:        fmt.Sprintf("token-%d", f.nextID),
+		UserID:    in.UserID,
+		Name:      in.Name,
+		TokenHash: in.TokenHash,
+		Scopes:    in.Scopes,
+		CreatedAt: in.CreatedAt,
+		ExpiresAt: in.ExpiresAt,
+	}
+	f.byHash[in.TokenHash] = token
+	return token, nil
+}
+
+func (f *fakeAPITokenStore) GetAPITokenByHash(_ context.Context, tokenHash string) (ports.APIToken, bool, error) {
+	token, ok := f.byHash[tokenHash]
+	return token, ok, nil
+}
+
+func (f *fakeAPITokenStore) ListAPITokens(_ context.Context, userID string) ([]ports.APIToken, error) {
+	var tokens []ports.APIToken
+	for _, token := range f.byHash {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (f *fakeAPITokenStore) DeleteAPIToken(_ context.Context, userID string, tokenID string) (bool, error) {
+	for hash, token := range f.byHash {
+		if token.ID == tokenID && token.UserID == userID {
+			delete(f.byHash, hash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var _ ports.APITokenStore = (*fakeAPITokenStore)(nil)
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"barnlog/backend/internal/domain"
+)
+
+// CodeForbidden indicates the principal's role in the barn does not allow the operation.
+const CodeForbidden BusinessCode = "forbidden"
+
+// Permission is an operation guarded by the role a principal has in a barn.
+// Services check it with Authorize before they touch any state.
+type Permission string
+
+const (
+	// PermissionReadAnimals allows reading animals and their timelines.
+	PermissionReadAnimals Permission = "read animals"
+	// PermissionRecordEvents allows creating animals and correcting their events.
+	PermissionRecordEvents Permission = "record events"
+	// PermissionUploadFiles allows uploading photos for animals to reference.
+	PermissionUploadFiles Permission = "upload files"
+	// PermissionVoidEvents allows voiding events.
+	PermissionVoidEvents Permission = "void events"
+	// PermissionReadEvents allows following the event feed.
+	PermissionReadEvents Permission = "read events"
+	// PermissionTransferEvents allows exporting and importing the event log of a barn.
+	PermissionTransferEvents Permission = "transfer events"
+	// PermissionManageWebhooks allows reading and changing webhook subscriptions.
+	PermissionManageWebhooks Permission = "manage webhooks"
+	// PermissionManageUsers allows creating users and changing their roles in a barn.
+	// Changing passwords needs it in domain.ServerBarnID, since users are shared by every barn.
+	PermissionManageUsers Permission = "manage users"
+)
+
+// permissionRoles is the least privileged role granted each permission.
+var permissionRoles = map[Permission]domain.Role{
+	PermissionReadAnimals:    domain.RoleViewer,
+	PermissionRecordEvents:   domain.RoleWorker,
+	PermissionUploadFiles:    domain.RoleWorker,
+	PermissionVoidEvents:     domain.RoleManager,
+	PermissionReadEvents:     domain.RoleViewer,
+	PermissionTransferEvents: domain.RoleManager,
+	PermissionManageWebhooks: domain.RoleManager,
+	PermissionManageUsers:    domain.RoleOwner,
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a context whose operations run on behalf of principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored by WithPrincipal.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
+
+// ServicePrincipal is the principal of an operator command or background job
+// named name. It is an owner of every barn.
+func ServicePrincipal(name string) Principal {
+	return Principal{
+		Username:    ServiceActor(name),
+		Memberships: domain.Memberships{domain.ServerBarnID: domain.RoleOwner},
+	}
+}
+
+// Authorize returns a CodeForbidden error unless the principal in ctx has a
+// role in the barn of ctx that grants permission. A context without a
+// principal is forbidden, as is a barn the principal is no member of.
+func Authorize(ctx context.Context, permission Permission) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return BusinessError{Code: CodeForbidden, Err: errors.New("no principal")}
+	}
+	barnID := BarnFromContext(ctx)
+	role, member := principal.Memberships.RoleIn(barnID)
+	if !member {
+		return BusinessError{Code: CodeForbidden, Err: fmt.Errorf("not a member of barn %q", barnID)}
+	}
+	if !roleGrants(role, permission) {
+		return BusinessError{
+			Code: CodeForbidden,
+			Err:  fmt.Errorf("role %q may not %s in barn %q", role, permission, barnID),
+		}
+	}
+	return nil
+}
+
+// roleGrants reports whether role is at least the role permissionRoles lists
+// for permission.
+func roleGrants(role domain.Role, permission Permission) bool {
+	required, ok := permissionRoles[permission]
+	return ok && role.Includes(required)
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+)
+
+// roleContext returns a context whose principal has role in every barn.
+func roleContext(role domain.Role) context.Context {
+	return barnRoleContext(domain.ServerBarnID, role)
+}
+
+// barnRoleContext returns a context whose principal has role in barnID only.
+func barnRoleContext(barnID string, role domain.Role) context.Context {
+	return WithPrincipal(context.Background(), Principal{
+		UserID:      "u1",
+		Username:    "anna",
+		Memberships: domain.Memberships{barnID: role},
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	// Each permission is allowed from the listed role upwards.
+	lowest := map[Permission]domain.Role{
+		PermissionReadAnimals:    domain.RoleViewer,
+		PermissionReadEvents:     domain.RoleViewer,
+		PermissionRecordEvents:   domain.RoleWorker,
+		PermissionUploadFiles:    domain.RoleWorker,
+		PermissionVoidEvents:     domain.RoleManager,
+		PermissionTransferEvents: domain.RoleManager,
+		PermissionManageWebhooks: domain.RoleManager,
+		PermissionManageUsers:    domain.RoleOwner,
+	}
+	for permission, required := range lowest {
+		allowed := true
+		for _, role := range domain.Roles {
+			err := Authorize(roleContext(role), permission)
+			if allowed && err != nil {
+				t.Errorf("expected %s to %s, got %v", role, permission, err)
+			}
+			if !allowed && !hasCode(err, CodeForbidden) {
+				t.Errorf("expected %s to be forbidden to %s, got %v", role, permission, err)
+			}
+			if role == required {
+				allowed = false
+			}
+		}
+	}
+
+	if err := Authorize(context.Background(), PermissionReadAnimals); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q without a principal, got %v", CodeForbidden, err)
+	}
+	if err := Authorize(roleContext(""), PermissionReadAnimals); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q without a role, got %v", CodeForbidden, err)
+	}
+	if err := Authorize(WithPrincipal(context.Background(), ServicePrincipal("cli")), PermissionManageUsers); err != nil {
+		t.Fatalf("expected service principals to manage users, got %v", err)
+	}
+}
+
+func TestAuthorize_Barns(t *testing.T) {
+	t.Parallel()
+
+	ctx := barnRoleContext("north", domain.RoleManager)
+	if err := Authorize(WithBarn(ctx, "north"), PermissionVoidEvents); err != nil {
+		t.Fatalf("expected a manager of north to void events there, got %v", err)
+	}
+	if err := Authorize(WithBarn(ctx, "south"), PermissionReadAnimals); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q outside the principal's barns, got %v", CodeForbidden, err)
+	}
+	if err := Authorize(ctx, PermissionReadAnimals); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q in the default barn, got %v", CodeForbidden, err)
+	}
+
+	ctx = WithPrincipal(context.Background(), Principal{Memberships: domain.Memberships{
+		"north":             domain.RoleOwner,
+		domain.ServerBarnID: domain.RoleViewer,
+	}})
+	if err := Authorize(WithBarn(ctx, "north"), PermissionManageUsers); err != nil {
+		t.Fatalf("expected the barn role to apply in its barn, got %v", err)
+	}
+	if err := Authorize(WithBarn(ctx, "south"), PermissionReadAnimals); err != nil {
+		t.Fatalf("expected the server role to apply in every barn, got %v", err)
+	}
+	if err := Authorize(WithBarn(ctx, "south"), PermissionRecordEvents); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q beyond the server role, got %v", CodeForbidden, err)
+	}
+}
+
+func TestParseBarnID(t *testing.T) {
+	t.Parallel()
+
+	for raw, want := range map[string]string{"": domain.DefaultBarnID, " North ": "north", "barn-2": "barn-2"} {
+		got, err := ParseBarnID(raw)
+		if err != nil || got != want {
+			t.Errorf("ParseBarnID(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{domain.ServerBarnID, "../north", "-north", "north barn"} {
+		if _, err := ParseBarnID(raw); !hasCode(err, CodeBarnInvalid) {
+			t.Errorf("ParseBarnID(%q): expected %q, got %v", raw, CodeBarnInvalid, err)
+		}
+	}
+}
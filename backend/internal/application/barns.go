@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+)
+
+// CodeBarnInvalid indicates a barn ID that is malformed or names the reserved
+// server barn where a single barn is required.
+const CodeBarnInvalid BusinessCode = "barn_invalid"
+
+type barnContextKey struct{}
+
+// WithBarn returns a context whose operations read and write the events of barnID.
+func WithBarn(ctx context.Context, barnID string) context.Context {
+	return context.WithValue(ctx, barnContextKey{}, barnID)
+}
+
+// BarnFromContext returns the barn stored by WithBarn, or domain.DefaultBarnID.
+func BarnFromContext(ctx context.Context) string {
+	if barnID, ok := ctx.Value(barnContextKey{}).(string); ok && barnID != "" {
+		return barnID
+	}
+	return domain.DefaultBarnID
+}
+
+// ParseBarnID normalizes the barn a request names. Empty names
+// domain.DefaultBarnID; domain.ServerBarnID is rejected because it holds no
+// animals or webhooks of its own.
+func ParseBarnID(raw string) (string, error) {
+	barnID := strings.ToLower(strings.TrimSpace(raw))
+	if barnID == "" {
+		return domain.DefaultBarnID, nil
+	}
+	if barnID == domain.ServerBarnID || !domain.ValidBarnID(barnID) {
+		return "", BusinessError{
+			Code: CodeBarnInvalid,
+			Err:  fmt.Errorf("barn must be 1-63 characters of a-z, 0-9 or '-' and not %q, got %q", domain.ServerBarnID, raw),
+		}
+	}
+	return barnID, nil
+}
+
+// parseMembershipBarnID is ParseBarnID for memberships, which may also name
+// domain.ServerBarnID to apply to every barn.
+func parseMembershipBarnID(raw string) (string, error) {
+	if strings.ToLower(strings.TrimSpace(raw)) == domain.ServerBarnID {
+		return domain.ServerBarnID, nil
+	}
+	return ParseBarnID(raw)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"barnlog/backend/internal/domain"
 	"barnlog/backend/internal/ports"
 )
 
@@ -26,9 +27,40 @@ const (
 )
 
 // BusinessError wraps a business code and optional underlying cause.
+// Validation errors list every invalid field in Fields; Code is then the code
+// of the first one.
 type BusinessError struct {
-	Code BusinessCode
-	Err  error
+	Code   BusinessCode
+	Err    error
+	Fields []FieldError
+}
+
+// FieldError is one invalid input field. Message is written for the client
+// and may be shown next to the field.
+type FieldError struct {
+	Field   string
+	Code    BusinessCode
+	Message string
+}
+
+// fieldErrors collects the FieldErrors of one validation pass.
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(field string, code BusinessCode, message string) {
+	*f = append(*f, FieldError{Field: field, Code: code, Message: message})
+}
+
+// err returns a BusinessError listing every collected field, or nil when
+// there are none.
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(f))
+	for _, field := range f {
+		errs = append(errs, fmt.Errorf("%s: %s", field.Field, field.Message))
+	}
+	return BusinessError{Code: f[0].Code, Err: errors.Join(errs...), Fields: f}
 }
 
 // Error returns the underlying error message or business code.
@@ -53,10 +85,39 @@ func AsBusinessError(err error) (BusinessError, bool) {
 	return be, true
 }
 
+// ActorAnonymous is recorded for requests that carry no authenticated principal.
+const ActorAnonymous = "anonymous"
+
+// ServiceActor returns the actor identity recorded for events appended by a background job.
+func ServiceActor(name string) string {
+	return "service:" + name
+}
+
 // RequestMeta carries request-scoped metadata used for idempotent writes.
+// Actor identifies the principal recorded as the event author; DeviceID and
+// ClientVersion are optional context stored alongside it.
 type RequestMeta struct {
-	Source    string
-	RequestID string
+	Source        string
+	RequestID     string
+	Actor         string
+	DeviceID      string
+	ClientVersion string
+}
+
+func validateRequestMeta(meta RequestMeta) error {
+	if meta.Source == "" || meta.RequestID == "" {
+		return BusinessError{
+			Code: CodeInvalidInput,
+			Err:  errors.New("source and request_id are required"),
+		}
+	}
+	if meta.Actor == "" {
+		return BusinessError{
+			Code: CodeInvalidInput,
+			Err:  errors.New("actor is required"),
+		}
+	}
+	return nil
 }
 
 // CreateAnimalInput is the application command for animal creation.
@@ -87,46 +148,69 @@ type AnimalWriter interface {
 }
 
 type createAnimalWriter struct {
-	store ports.AnimalWriteStore
+	store   ports.AnimalWriteStore
+	metrics ports.EventMetrics
 }
 
 // NewCreateAnimalWriter builds the create-animal application service.
-func NewCreateAnimalWriter(store ports.AnimalWriteStore) AnimalWriter {
-	return createAnimalWriter{store: store}
+// metrics may be nil.
+func NewCreateAnimalWriter(store ports.AnimalWriteStore, metrics ports.EventMetrics) AnimalWriter {
+	return createAnimalWriter{store: store, metrics: eventMetricsOrNoop(metrics)}
 }
 
 func (w createAnimalWriter) Create(ctx context.Context, in CreateAnimalInput) (CreateAnimalOutput, error) {
+	ctx, span := startSpan(ctx, "CreateAnimal",
+		attrBarnID.String(BarnFromContext(ctx)),
+		attrSource.String(in.Meta.Source),
+		attrRequestID.String(in.Meta.RequestID),
+	)
+	out, err := w.create(ctx, in)
+	span.SetAttributes(attrReplayed.Bool(out.Replayed))
+	endSpan(span, err)
+	return out, err
+}
+
+func (w createAnimalWriter) create(ctx context.Context, in CreateAnimalInput) (CreateAnimalOutput, error) {
+	if err := Authorize(ctx, PermissionRecordEvents); err != nil {
+		return CreateAnimalOutput{}, err
+	}
 	in = normalizeCreateAnimalInput(in)
 
 	if err := validateCreateAnimalInput(in); err != nil {
 		return CreateAnimalOutput{}, err
 	}
 
-	if in.Meta.Source == "" || in.Meta.RequestID == "" {
-		return CreateAnimalOutput{}, BusinessError{
-			Code: CodeInvalidInput,
-			Err:  errors.New("source and request_id are required"),
-		}
+	if err := validateRequestMeta(in.Meta); err != nil {
+		return CreateAnimalOutput{}, err
 	}
 
+	barnID := BarnFromContext(ctx)
 	storeIn := ports.CreateAnimalRecordInput{
-		Name:      in.Name,
-		Species:   in.Species,
-		Tag:       in.Tag,
-		Birthdate: in.Birthdate,
-		PhotoID:   in.PhotoID,
-		Source:    in.Meta.Source,
-		RequestID: in.Meta.RequestID,
+		BarnID:        barnID,
+		Name:          in.Name,
+		Species:       in.Species,
+		Tag:           in.Tag,
+		Birthdate:     in.Birthdate,
+		PhotoID:       in.PhotoID,
+		Source:        in.Meta.Source,
+		RequestID:     in.Meta.RequestID,
+		CreatedBy:     in.Meta.Actor,
+		DeviceID:      in.Meta.DeviceID,
+		ClientVersion: in.Meta.ClientVersion,
 	}
 
-	replay, found, err := w.store.FindCreateAnimalReplay(ctx, storeIn)
+	lookupCtx, span := startSpan(ctx, "CreateAnimal.FindReplay")
+	replay, found, err := w.store.FindCreateAnimalReplay(lookupCtx, storeIn)
+	endSpan(span, err)
 	if err != nil {
 		if code, ok := createAnimalConflictCode(err); ok {
 			return CreateAnimalOutput{}, BusinessError{Code: code, Err: err}
 		}
+		w.metrics.EventAppendFailed(domain.AnimalCreatedEventType)
 		return CreateAnimalOutput{}, fmt.Errorf("find create-animal replay: %w", err)
 	}
 	if found {
+		w.metrics.EventReplayed(domain.AnimalCreatedEventType)
 		return CreateAnimalOutput{
 			AnimalID:  replay.AnimalID,
 			EventID:   replay.EventID,
@@ -140,7 +224,9 @@ func (w createAnimalWriter) Create(ctx context.Context, in CreateAnimalInput) (C
 	}
 
 	if in.PhotoID != "" {
-		exists, err := w.store.PhotoExists(ctx, in.PhotoID)
+		photoCtx, span := startSpan(ctx, "CreateAnimal.PhotoExists")
+		exists, err := w.store.PhotoExists(photoCtx, barnID, in.PhotoID)
+		endSpan(span, err)
 		if err != nil {
 			return CreateAnimalOutput{}, fmt.Errorf("photo exists: %w", err)
 		}
@@ -152,7 +238,9 @@ func (w createAnimalWriter) Create(ctx context.Context, in CreateAnimalInput) (C
 		}
 	}
 
-	out, err := w.store.CreateAnimalRecord(ctx, storeIn)
+	insertCtx, span := startSpan(ctx, "CreateAnimal.CreateRecord")
+	out, err := w.store.CreateAnimalRecord(insertCtx, storeIn)
+	endSpan(span, err)
 	if err != nil {
 		if code, ok := createAnimalConflictCode(err); ok {
 			return CreateAnimalOutput{}, BusinessError{
@@ -160,8 +248,14 @@ func (w createAnimalWriter) Create(ctx context.Context, in CreateAnimalInput) (C
 				Err:  err,
 			}
 		}
+		w.metrics.EventAppendFailed(domain.AnimalCreatedEventType)
 		return CreateAnimalOutput{}, fmt.Errorf("create animal record: %w", err)
 	}
+	if out.Replayed {
+		w.metrics.EventReplayed(domain.AnimalCreatedEventType)
+	} else {
+		w.metrics.EventAppended(domain.AnimalCreatedEventType)
+	}
 
 	return CreateAnimalOutput{
 		AnimalID:  out.AnimalID,
@@ -2,8 +2,12 @@ package application
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"slices"
 	"testing"
 
+	"barnlog/backend/internal/domain"
 	"barnlog/backend/internal/ports"
 )
 
@@ -12,15 +16,16 @@ func TestCreateAnimalWriter_PhotoNotFound(t *testing.T) {
 
 	w := NewCreateAnimalWriter(&fakeAnimalWriteStore{
 		photoExists: false,
-	})
+	}, nil)
 
-	_, err := w.Create(context.Background(), CreateAnimalInput{
+	_, err := w.Create(roleContext(domain.RoleOwner), CreateAnimalInput{
 		Name:    "Nanny",
 		Species: "goat",
 		PhotoID: "p1",
 		Meta: RequestMeta{
 			Source:    "test",
 			RequestID: "req-1",
+			Actor:     "user:test",
 		},
 	})
 	if err == nil {
@@ -51,6 +56,7 @@ func TestCreateAnimalWriter_ValidationErrors(t *testing.T) {
 				Meta: RequestMeta{
 					Source:    "test",
 					RequestID: "req-1",
+					Actor:     "user:test",
 				},
 			},
 			code: CodeNameRequired,
@@ -63,6 +69,7 @@ func TestCreateAnimalWriter_ValidationErrors(t *testing.T) {
 				Meta: RequestMeta{
 					Source:    "test",
 					RequestID: "req-1",
+					Actor:     "user:test",
 				},
 			},
 			code: CodeSpeciesInvalid,
@@ -76,6 +83,7 @@ func TestCreateAnimalWriter_ValidationErrors(t *testing.T) {
 				Meta: RequestMeta{
 					Source:    "test",
 					RequestID: "req-1",
+					Actor:     "user:test",
 				},
 			},
 			code: CodeBirthdateInvalid,
@@ -88,8 +96,8 @@ func TestCreateAnimalWriter_ValidationErrors(t *testing.T) {
 
 			w := NewCreateAnimalWriter(&fakeAnimalWriteStore{
 				photoExists: true,
-			})
-			_, err := w.Create(context.Background(), tc.in)
+			}, nil)
+			_, err := w.Create(roleContext(domain.RoleOwner), tc.in)
 			if err == nil {
 				t.Fatalf("expected error")
 			}
@@ -104,6 +112,35 @@ func TestCreateAnimalWriter_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestCreateAnimalWriter_ReportsEveryInvalidField(t *testing.T) {
+	t.Parallel()
+
+	w := NewCreateAnimalWriter(&fakeAnimalWriteStore{photoExists: true}, nil)
+	_, err := w.Create(roleContext(domain.RoleOwner), CreateAnimalInput{
+		Species:   "horse",
+		Birthdate: "yesterday",
+		Meta:      RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:test"},
+	})
+	be, ok := AsBusinessError(err)
+	if !ok {
+		t.Fatalf("expected business error, got %v", err)
+	}
+	if be.Code != CodeNameRequired {
+		t.Fatalf("expected the first field's code %q, got %q", CodeNameRequired, be.Code)
+	}
+	var got []string
+	for _, field := range be.Fields {
+		if field.Message == "" {
+			t.Fatalf("expected a message for %+v", field)
+		}
+		got = append(got, field.Field+"="+string(field.Code))
+	}
+	want := []string{"name=name_required", "species=species_invalid", "birthdate=birthdate_invalid"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected fields %v, got %v", want, got)
+	}
+}
+
 func TestCreateAnimalWriter_Conflict(t *testing.T) {
 	t.Parallel()
 
@@ -125,15 +162,16 @@ func TestCreateAnimalWriter_Conflict(t *testing.T) {
 			w := NewCreateAnimalWriter(&fakeAnimalWriteStore{
 				photoExists: true,
 				createErr:   tc.err,
-			})
+			}, nil)
 
-			_, err := w.Create(context.Background(), CreateAnimalInput{
+			_, err := w.Create(roleContext(domain.RoleOwner), CreateAnimalInput{
 				Name:    "Nanny",
 				Species: "goat",
 				PhotoID: "p1",
 				Meta: RequestMeta{
 					Source:    "test",
 					RequestID: "req-1",
+					Actor:     "user:test",
 				},
 			})
 			if err == nil {
@@ -160,14 +198,15 @@ func TestCreateAnimalWriter_Replayed(t *testing.T) {
 			EventID:  "e1",
 			Replayed: true,
 		},
-	})
+	}, nil)
 
-	out, err := w.Create(context.Background(), CreateAnimalInput{
+	out, err := w.Create(roleContext(domain.RoleOwner), CreateAnimalInput{
 		Name:    "Nanny",
 		Species: "goat",
 		Meta: RequestMeta{
 			Source:    "test",
 			RequestID: "req-1",
+			Actor:     "user:test",
 		},
 	})
 	if err != nil {
@@ -178,6 +217,40 @@ func TestCreateAnimalWriter_Replayed(t *testing.T) {
 	}
 }
 
+func TestCreateAnimalWriter_ActorRequired(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCreateAnimalWriter(&fakeAnimalWriteStore{photoExists: true}, nil).Create(roleContext(domain.RoleOwner), CreateAnimalInput{
+		Name:    "Nanny",
+		Species: "goat",
+		Meta: RequestMeta{
+			Source:    "test",
+			RequestID: "req-1",
+		},
+	})
+	be, ok := AsBusinessError(err)
+	if !ok || be.Code != CodeInvalidInput {
+		t.Fatalf("expected %q, got %v", CodeInvalidInput, err)
+	}
+}
+
+func TestCreateAnimalWriter_ViewerForbidden(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeAnimalWriteStore{photoExists: true}
+	_, err := NewCreateAnimalWriter(store, nil).Create(roleContext(domain.RoleViewer), CreateAnimalInput{
+		Name:    "Nanny",
+		Species: "goat",
+		Meta:    RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:anna"},
+	})
+	if !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q, got %v", CodeForbidden, err)
+	}
+	if store.createIn.Name != "" {
+		t.Fatalf("expected nothing to be stored, got %+v", store.createIn)
+	}
+}
+
 func TestCreateAnimalWriter_ReplayedBeforePhotoExistsCheck(t *testing.T) {
 	t.Parallel()
 
@@ -189,15 +262,16 @@ func TestCreateAnimalWriter_ReplayedBeforePhotoExistsCheck(t *testing.T) {
 			EventID:  "e1",
 			Replayed: true,
 		},
-	})
+	}, nil)
 
-	out, err := w.Create(context.Background(), CreateAnimalInput{
+	out, err := w.Create(roleContext(domain.RoleOwner), CreateAnimalInput{
 		Name:    "Nanny",
 		Species: "goat",
 		PhotoID: "photo_1",
 		Meta: RequestMeta{
 			Source:    "test",
 			RequestID: "req-1",
+			Actor:     "user:test",
 		},
 	})
 	if err != nil {
@@ -214,9 +288,9 @@ func TestCreateAnimalWriter_NormalizesInput(t *testing.T) {
 	store := &fakeAnimalWriteStore{
 		photoExists: true,
 	}
-	w := NewCreateAnimalWriter(store)
+	w := NewCreateAnimalWriter(store, nil)
 
-	out, err := w.Create(context.Background(), CreateAnimalInput{
+	out, err := w.Create(roleContext(domain.RoleOwner), CreateAnimalInput{
 		Name:      " Nanny ",
 		Species:   " goat ",
 		Tag:       " G-7 ",
@@ -225,6 +299,7 @@ func TestCreateAnimalWriter_NormalizesInput(t *testing.T) {
 		Meta: RequestMeta{
 			Source:    "test",
 			RequestID: "req-1",
+			Actor:     "user:test",
 		},
 	})
 	if err != nil {
@@ -245,6 +320,9 @@ func TestCreateAnimalWriter_NormalizesInput(t *testing.T) {
 	if store.createIn.PhotoID != "photo_1" {
 		t.Fatalf("expected trimmed PhotoID, got %q", store.createIn.PhotoID)
 	}
+	if store.createIn.CreatedBy != "user:test" {
+		t.Fatalf("expected CreatedBy from actor, got %q", store.createIn.CreatedBy)
+	}
 
 	if out.Name != "Nanny" {
 		t.Fatalf("expected normalized output Name, got %q", out.Name)
@@ -263,6 +341,58 @@ func TestCreateAnimalWriter_NormalizesInput(t *testing.T) {
 	}
 }
 
+func TestCreateAnimalWriter_CountsEventMetrics(t *testing.T) {
+	t.Parallel()
+
+	in := CreateAnimalInput{
+		Name:    "Nanny",
+		Species: "goat",
+		Meta: RequestMeta{
+			Source:    "test",
+			RequestID: "req-1",
+			Actor:     "user:test",
+		},
+	}
+	metrics := &fakeEventMetrics{}
+
+	for _, store := range []*fakeAnimalWriteStore{
+		{createOut: ports.CreateAnimalRecordOutput{AnimalID: "a1", EventID: "e1"}},
+		{replayFound: true, replayOut: ports.CreateAnimalRecordOutput{AnimalID: "a1", EventID: "e1"}},
+		{createOut: ports.CreateAnimalRecordOutput{AnimalID: "a1", EventID: "e1", Replayed: true}},
+		{createErr: errors.New("disk full")},
+		{createErr: ports.ErrConflict},
+	} {
+		_, _ = NewCreateAnimalWriter(store, metrics).Create(roleContext(domain.RoleOwner), in)
+	}
+
+	want := fakeEventMetrics{
+		appended: []string{domain.AnimalCreatedEventType},
+		replayed: []string{domain.AnimalCreatedEventType, domain.AnimalCreatedEventType},
+		failed:   []string{domain.AnimalCreatedEventType},
+	}
+	if !reflect.DeepEqual(*metrics, want) {
+		t.Fatalf("expected %+v, got %+v", want, *metrics)
+	}
+}
+
+type fakeEventMetrics struct {
+	appended []string
+	replayed []string
+	failed   []string
+}
+
+func (f *fakeEventMetrics) EventAppended(eventType string) {
+	f.appended = append(f.appended, eventType)
+}
+
+func (f *fakeEventMetrics) EventReplayed(eventType string) {
+	f.replayed = append(f.replayed, eventType)
+}
+
+func (f *fakeEventMetrics) EventAppendFailed(eventType string) {
+	f.failed = append(f.failed, eventType)
+}
+
 type fakeAnimalWriteStore struct {
 	photoExists bool
 	photoErr    error
@@ -300,7 +430,7 @@ func (f *fakeAnimalWriteStore) FindCreateAnimalReplay(_ context.Context, in port
 	return ports.CreateAnimalRecordOutput{}, false, nil
 }
 
-func (f *fakeAnimalWriteStore) PhotoExists(context.Context, string) (bool, error) {
+func (f *fakeAnimalWriteStore) PhotoExists(context.Context, string, string) (bool, error) {
 	if f.photoErr != nil {
 		return false, f.photoErr
 	}
@@ -1,9 +1,6 @@
 package application
 
-import (
-	"errors"
-	"time"
-)
+import "time"
 
 const (
 	// CodeNameRequired indicates the animal name was missing or blank.
@@ -14,19 +11,22 @@ const (
 	CodeBirthdateInvalid BusinessCode = "birthdate_invalid"
 )
 
+// validateCreateAnimalInput reports every invalid field of in at once so a
+// form can mark all of them.
 func validateCreateAnimalInput(in CreateAnimalInput) error {
+	var fields fieldErrors
 	if in.Name == "" {
-		return BusinessError{Code: CodeNameRequired, Err: errors.New("name is required")}
+		fields.add("name", CodeNameRequired, "name is required")
 	}
 	switch in.Species {
 	case "goat", "pig", "dog", "cat":
 	default:
-		return BusinessError{Code: CodeSpeciesInvalid, Err: errors.New("species is invalid")}
+		fields.add("species", CodeSpeciesInvalid, "species must be one of goat, pig, dog, cat")
 	}
 	if in.Birthdate != "" {
 		if _, err := time.Parse(time.DateOnly, in.Birthdate); err != nil {
-			return BusinessError{Code: CodeBirthdateInvalid, Err: errors.New("birthdate is invalid")}
+			fields.add("birthdate", CodeBirthdateInvalid, "birthdate must be a YYYY-MM-DD date")
 		}
 	}
-	return nil
+	return fields.err()
 }
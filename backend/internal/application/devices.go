@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeDeviceNotFound indicates the device does not exist or belongs to another user.
+	CodeDeviceNotFound BusinessCode = "device_not_found"
+
+	// devicePrefix marks barnlog device secrets, like apiTokenPrefix does for API tokens.
+	devicePrefix        = "bld_"
+	deviceSourcePrefix  = "device:"
+	maxDeviceNameLength = 100
+)
+
+// DeviceScopes are the operations a registered device may use: the ones a
+// field client needs to record and sync events.
+var DeviceScopes = []string{
+	ScopeAnimalsRead,
+	ScopeAnimalsWrite,
+	ScopeEventsRead,
+	ScopeUploadsWrite,
+}
+
+// DeviceSource returns the event source recorded for writes made by device
+// deviceID. Binding the source to the device keeps the request IDs of
+// different devices from colliding in the idempotency index.
+func DeviceSource(deviceID string) string {
+	return deviceSourcePrefix + deviceID
+}
+
+// IsDeviceSource reports whether source has the form DeviceSource returns,
+// which clients must not claim for themselves.
+func IsDeviceSource(source string) bool {
+	return strings.HasPrefix(source, deviceSourcePrefix)
+}
+
+// RegisterDeviceInput describes a new device of UserID.
+type RegisterDeviceInput struct {
+	UserID string
+	Name   string
+}
+
+// DeviceOutput is a registered device without its secret.
+type DeviceOutput struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+}
+
+// RegisteredDeviceOutput is a new device. Secret is only returned here; the
+// server keeps a hash of it.
+type RegisteredDeviceOutput struct {
+	DeviceOutput
+	Secret string
+}
+
+// DeviceManager registers, lists and revokes the devices of a user.
+type DeviceManager interface {
+	RegisterDevice(ctx context.Context, in RegisterDeviceInput) (RegisteredDeviceOutput, error)
+	ListDevices(ctx context.Context, userID string) ([]DeviceOutput, error)
+	RevokeDevice(ctx context.Context, userID string, deviceID string) error
+}
+
+type deviceManager struct {
+	store ports.DeviceStore
+	now   func() time.Time
+}
+
+// NewDeviceManager builds the device registration application service.
+func NewDeviceManager(store ports.DeviceStore) DeviceManager {
+	return deviceManager{store: store, now: time.Now}
+}
+
+func (m deviceManager) RegisterDevice(ctx context.Context, in RegisterDeviceInput) (RegisteredDeviceOutput, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxDeviceNameLength {
+		return RegisteredDeviceOutput{}, BusinessError{
+			Code: CodeInvalidInput,
+			Err:  fmt.Errorf("device name must be 1-%d characters", maxDeviceNameLength),
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return RegisteredDeviceOutput{}, err
+	}
+	secret = devicePrefix + secret
+	stored, err := m.store.CreateDevice(ctx, ports.DeviceRecordInput{
+		UserID:     in.UserID,
+		Name:       name,
+		SecretHash: hashSecret(secret),
+		CreatedAt:  m.now().UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return RegisteredDeviceOutput{}, fmt.Errorf("create device: %w", err)
+	}
+	return RegisteredDeviceOutput{DeviceOutput: deviceOutputFromRecord(stored), Secret: secret}, nil
+}
+
+func (m deviceManager) ListDevices(ctx context.Context, userID string) ([]DeviceOutput, error) {
+	devices, err := m.store.ListDevices(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list devices: %w", err)
+	}
+	out := make([]DeviceOutput, 0, len(devices))
+	for _, device := range devices {
+		out = append(out, deviceOutputFromRecord(device))
+	}
+	return out, nil
+}
+
+func (m deviceManager) RevokeDevice(ctx context.Context, userID string, deviceID string) error {
+	deleted, err := m.store.DeleteDevice(ctx, userID, deviceID)
+	if err != nil {
+		return fmt.Errorf("delete device %s: %w", deviceID, err)
+	}
+	if !deleted {
+		return BusinessError{Code: CodeDeviceNotFound, Err: fmt.Errorf("device %q not found", deviceID)}
+	}
+	return nil
+}
+
+// authenticateDevice resolves a device secret to a principal acting as the
+// device's user with DeviceScopes.
+func (a authenticator) authenticateDevice(ctx context.Context, secret string) (Principal, error) {
+	device, found, err := a.devices.GetDeviceBySecretHash(ctx, hashSecret(secret))
+	if err != nil {
+		return Principal{}, fmt.Errorf("get device: %w", err)
+	}
+	if !found {
+		return Principal{}, errUnauthenticated()
+	}
+
+	user, found, err := a.users.GetUser(ctx, device.UserID)
+	if err != nil {
+		return Principal{}, fmt.Errorf("get user %s: %w", device.UserID, err)
+	}
+	if !found {
+		return Principal{}, errUnauthenticated()
+	}
+	return Principal{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Memberships: user.Memberships,
+		DeviceID:    device.ID,
+		Scopes:      DeviceScopes,
+	}, nil
+}
+
+func deviceOutputFromRecord(device ports.Device) DeviceOutput {
+	return DeviceOutput{
+		ID:        device.ID,
+		Name:      device.Name,
+		CreatedAt: device.CreatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestDeviceManager_RegisterListRevoke(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeDeviceStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager := NewDeviceManager(store).(deviceManager)
+	manager.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	registered, err := manager.RegisterDevice(ctx, RegisterDeviceInput{UserID: "u1", Name: "  barn phone  "})
+	if err != nil {
+		t.Fatalf("register device: %v", err)
+	}
+	if !strings.HasPrefix(registered.Secret, devicePrefix) || registered.Name != "barn phone" || !registered.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected device %+v", registered)
+	}
+	if _, stored := store.byHash[registered.Secret]; stored {
+		t.Fatal("expected only a hash of the secret to be stored")
+	}
+
+	listed, err := manager.ListDevices(ctx, "u1")
+	if err != nil {
+		t.Fatalf("list devices: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != registered.ID {
+		t.Fatalf("unexpected devices %+v", listed)
+	}
+
+	if err := manager.RevokeDevice(ctx, "u2", registered.ID); !hasCode(err, CodeDeviceNotFound) {
+		t.Fatalf("expected %q revoking another user's device, got %v", CodeDeviceNotFound, err)
+	}
+	if err := manager.RevokeDevice(ctx, "u1", registered.ID); err != nil {
+		t.Fatalf("revoke device: %v", err)
+	}
+	if listed, _ := manager.ListDevices(ctx, "u1"); len(listed) != 0 {
+		t.Fatalf("expected no devices after revoke, got %+v", listed)
+	}
+
+	for _, name := range []string{" ", strings.Repeat("x", maxDeviceNameLength+1)} {
+		if _, err := manager.RegisterDevice(ctx, RegisterDeviceInput{UserID: "u1", Name: name}); !hasCode(err, CodeInvalidInput) {
+			t.Fatalf("expected %q for name %q, got %v", CodeInvalidInput, name, err)
+		}
+	}
+}
+
+func TestAuthenticator_AuthenticateDevice(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	user := users.add("anna", "hashed:correct horse")
+	devices := newFakeDeviceStore()
+	manager := NewDeviceManager(devices)
+	auth := NewAuthenticator(users, newFakeSessionStore(), newFakeAPITokenStore(), devices, fakePasswordHasher{}, AuthenticatorConfig{})
+	ctx := context.Background()
+
+	registered, err := manager.RegisterDevice(ctx, RegisterDeviceInput{UserID: user.ID, Name: "barn phone"})
+	if err != nil {
+		t.Fatalf("register device: %v", err)
+	}
+
+	principal, err := auth.AuthenticateToken(ctx, registered.Secret)
+	if err != nil {
+		t.Fatalf("authenticate device: %v", err)
+	}
+	if principal.Username != "anna" || principal.DeviceID != registered.ID || principal.TokenID != "" || !principal.Scoped() {
+		t.Fatalf("unexpected principal %+v", principal)
+	}
+	if !principal.HasScope(ScopeAnimalsWrite) || principal.HasScope(ScopeWebhooksWrite) {
+		t.Fatalf("expected device scopes, got %v", principal.Scopes)
+	}
+
+	if err := manager.RevokeDevice(ctx, user.ID, registered.ID); err != nil {
+		t.Fatalf("revoke device: %v", err)
+	}
+	if _, err := auth.AuthenticateToken(ctx, registered.Secret); !hasCode(err, CodeUnauthenticated) {
+		t.Fatalf("expected %q once revoked, got %v", CodeUnauthenticated, err)
+	}
+}
+
+type fakeDeviceStore struct {
+	byHash map[string]ports.Device
+	nextID int
+}
+
+func newFakeDeviceStore() *fakeDeviceStore {
+	return &fakeDeviceStore{byHash: map[string]ports.Device{}}
+}
+
+func (f *fakeDeviceStore) CreateDevice(_ context.Context, in ports.DeviceRecordInput) (ports.Device, error) {
+	f.nextID++
+	device := ports.Device{
+		ID:         fmt.Sprintf("device-%d", f.nextID),
+		UserID:     in.UserID,
+		Name:       in.Name,
+		SecretHash: in.SecretHash,
+		CreatedAt:  in.CreatedAt,
+	}
+	f.byHash[in.SecretHash] = device
+	return device, nil
+}
+
+func (f *fakeDeviceStore) GetDeviceBySecretHash(_ context.Context, secretHash string) (ports.Device, bool, error) {
+	device, ok := f.byHash[secretHash]
+	return device, ok, nil
+}
+
+func (f *fakeDeviceStore) ListDevices(_ context.Context, userID string) ([]ports.Device, error) {
+	var devices []ports.Device
+	for _, device := range f.byHash {
+		if device.UserID == userID {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (f *fakeDeviceStore) DeleteDevice(_ context.Context, userID string, deviceID string) (bool, error) {
+	for hash, device := range f.byHash {
+		if device.ID == deviceID && device.UserID == userID {
+			delete(f.byHash, hash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var _ ports.DeviceStore = (*fakeDeviceStore)(nil)
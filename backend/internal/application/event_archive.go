@@ -0,0 +1,181 @@
+package application
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"barnlog/backend/internal/contracts/eventlog"
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+// CodeImportInvalid indicates an import line that is not a valid archived event.
+const CodeImportInvalid BusinessCode = "import_invalid"
+
+// ImportEventsOutput counts imported events and events skipped as already present.
+type ImportEventsOutput struct {
+	Imported int
+	Skipped  int
+}
+
+// EventArchive exports and imports the event log as newline-delimited JSON,
+// one events row per line with every column.
+//
+// Export writes the events of the barn in ctx, or of every barn when that is
+// domain.ServerBarnID. Import writes each event to the barn it names and
+// requires PermissionTransferEvents there; events without a barn_id go to the
+// barn in ctx, or to domain.ServerBarnID for user streams. User streams carry
+// password hashes and the memberships of every barn, so both directions need
+// PermissionManageUsers in domain.ServerBarnID for them: Export leaves them out
+// for anyone else and Import rejects them.
+type EventArchive interface {
+	Export(ctx context.Context, w io.Writer) error
+	Import(ctx context.Context, r io.Reader) (ImportEventsOutput, error)
+}
+
+type eventArchive struct {
+	store ports.EventArchiveStore
+}
+
+// NewEventArchive builds the event log export/import application service.
+func NewEventArchive(store ports.EventArchiveStore) EventArchive {
+	return eventArchive{store: store}
+}
+
+func (a eventArchive) Export(ctx context.Context, w io.Writer) error {
+	if err := Authorize(ctx, PermissionTransferEvents); err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(w)
+	enc := eventlog.NewEncoder(buffered)
+	barnID := BarnFromContext(ctx)
+	if barnID == domain.ServerBarnID {
+		barnID = ""
+	}
+	mayTransferUsers := authorizeUserTransfer(ctx) == nil
+	for event, err := range a.store.ExportEvents(ctx, barnID) {
+		if err != nil {
+			return fmt.Errorf("export events: %w", err)
+		}
+		if event.AggregateType == domain.UserAggregateType && !mayTransferUsers {
+			continue
+		}
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("flush export: %w", err)
+	}
+	return nil
+}
+
+func (a eventArchive) Import(ctx context.Context, r io.Reader) (ImportEventsOutput, error) {
+	if err := Authorize(ctx, PermissionTransferEvents); err != nil {
+		return ImportEventsOutput{}, err
+	}
+	result, err := a.store.ImportEvents(ctx, assignBarns(ctx, decodeArchive(r)))
+	if err != nil {
+		if _, ok := AsBusinessError(err); ok {
+			return ImportEventsOutput{}, err
+		}
+		if errors.Is(err, ports.ErrConflict) {
+			return ImportEventsOutput{}, BusinessError{Code: CodeConflict, Err: err}
+		}
+		return ImportEventsOutput{}, fmt.Errorf("import events: %w", err)
+	}
+	return ImportEventsOutput{Imported: result.Imported, Skipped: result.Skipped}, nil
+}
+
+// assignBarns fills in the barn of events exported before barns existed and
+// stops at the first event the principal may not import into its barn. User
+// streams always belong to domain.ServerBarnID and are checked against
+// PermissionManageUsers there, never against a barn's transfer permission.
+func assignBarns(ctx context.Context, events iter.Seq2[ports.ArchivedEvent, error]) iter.Seq2[ports.ArchivedEvent, error] {
+	fallback := BarnFromContext(ctx)
+	if fallback == domain.ServerBarnID {
+		fallback = domain.DefaultBarnID
+	}
+	return func(yield func(ports.ArchivedEvent, error) bool) {
+		for event, err := range events {
+			if err != nil {
+				yield(event, err)
+				return
+			}
+			switch {
+			case event.AggregateType == domain.UserAggregateType && event.BarnID == "":
+				event.BarnID = domain.ServerBarnID
+			case event.AggregateType == domain.UserAggregateType && event.BarnID != domain.ServerBarnID:
+				yield(ports.ArchivedEvent{}, BusinessError{
+					Code: CodeImportInvalid,
+					Err:  fmt.Errorf("event %s: user streams belong to barn %q, not %q", event.ID, domain.ServerBarnID, event.BarnID),
+				})
+				return
+			case event.BarnID == "":
+				event.BarnID = fallback
+			}
+			if err := authorizeTransfer(ctx, event); err != nil {
+				yield(ports.ArchivedEvent{}, err)
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// authorizeTransfer checks that the principal may import event into its barn.
+func authorizeTransfer(ctx context.Context, event ports.ArchivedEvent) error {
+	if event.AggregateType == domain.UserAggregateType {
+		return authorizeUserTransfer(ctx)
+	}
+	return Authorize(WithBarn(ctx, event.BarnID), PermissionTransferEvents)
+}
+
+// authorizeUserTransfer checks that the principal may export or import user
+// streams, which only an owner of the server barn may.
+func authorizeUserTransfer(ctx context.Context) error {
+	return Authorize(WithBarn(ctx, domain.ServerBarnID), PermissionManageUsers)
+}
+
+// decodeArchive yields one archived event per non-blank line of r.
+func decodeArchive(r io.Reader) iter.Seq2[ports.ArchivedEvent, error] {
+	return func(yield func(ports.ArchivedEvent, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64<<10), eventlog.MaxLineBytes)
+		lineNo := 0
+		for scanner.Scan() {
+			lineNo++
+			raw := bytes.TrimSpace(scanner.Bytes())
+			if len(raw) == 0 {
+				continue
+			}
+			event, err := eventlog.Parse(raw)
+			if err != nil {
+				yield(ports.ArchivedEvent{}, BusinessError{
+					Code: CodeImportInvalid,
+					Err:  fmt.Errorf("line %d: %w", lineNo, err),
+				})
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			if errors.Is(err, bufio.ErrTooLong) {
+				err = BusinessError{
+					Code: CodeImportInvalid,
+					Err:  fmt.Errorf("line %d: longer than %d bytes", lineNo+1, eventlog.MaxLineBytes),
+				}
+			}
+			yield(ports.ArchivedEvent{}, err)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"iter"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestEventArchive_ExportImportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeEventArchiveStore{events: []ports.ArchivedEvent{
+		{
+			Position:      1,
+			ID:            "e1",
+			BarnID:        "north",
+			AggregateType: "animal",
+			AggregateID:   "a1",
+			EventType:     "animal.created",
+			CreatedBy:     "user:anna",
+			Source:        "web.app",
+			RequestID:     "req-1",
+			EventVersion:  1,
+			PayloadJSON:   `{"name":"Nanny"}`,
+			MetadataJSON:  `{"actor":"user:anna"}`,
+			OccurredAt:    "2026-03-04T05:06:07Z",
+			RecordedAt:    "2026-03-04 05:06:07",
+		},
+		{
+			Position:      2,
+			ID:            "e2",
+			BarnID:        domain.DefaultBarnID,
+			AggregateType: "animal",
+			AggregateID:   "a1",
+			EventType:     "event.voided",
+			CreatedBy:     "user:anna",
+			Source:        "web.app",
+			RequestID:     "req-2",
+			EventVersion:  1,
+			PayloadJSON:   `{"reason":"duplicate"}`,
+			OccurredAt:    "2026-03-04T05:07:07Z",
+			RecordedAt:    "2026-03-04 05:07:07",
+		},
+	}}
+	archive := NewEventArchive(store)
+
+	var exported bytes.Buffer
+	if err := archive.Export(WithBarn(roleContext(domain.RoleOwner), domain.ServerBarnID), &exported); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if store.exportBarn != "" {
+		t.Fatalf("expected the server barn to export every barn, got %q", store.exportBarn)
+	}
+	lines := strings.Split(strings.TrimSuffix(exported.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON lines, got %q", exported.String())
+	}
+	if !strings.Contains(lines[0], `"payload":{"name":"Nanny"}`) || !strings.Contains(lines[1], `"metadata":null`) {
+		t.Fatalf("unexpected export %q", exported.String())
+	}
+
+	out, err := archive.Import(roleContext(domain.RoleOwner), &exported)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if out.Imported != 2 {
+		t.Fatalf("expected 2 imported, got %+v", out)
+	}
+	if len(store.imported) != 2 {
+		t.Fatalf("expected 2 events passed to the store, got %d", len(store.imported))
+	}
+	for i, got := range store.imported {
+		if got != store.events[i] {
+			t.Fatalf("event %d did not round-trip:\n got %+v\nwant %+v", i, got, store.events[i])
+		}
+	}
+}
+
+func TestEventArchive_Barns(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeEventArchiveStore{}
+	archive := NewEventArchive(store)
+	ctx := WithBarn(barnRoleContext("north", domain.RoleManager), "north")
+
+	if err := archive.Export(ctx, &bytes.Buffer{}); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if store.exportBarn != "north" {
+		t.Fatalf("expected the export of north, got %q", store.exportBarn)
+	}
+
+	line := func(barnID, aggregateType string) string {
+		return `{"id":"e1",` + barnID + `"aggregate_type":"` + aggregateType + `","aggregate_id":"a1",` +
+			`"event_type":"animal.created","event_version":1,"created_by":"u","source":"s","request_id":"r",` +
+			`"payload":{},"metadata":null,"occurred_at":"t","created_at":"t"}` + "\n"
+	}
+	if _, err := archive.Import(ctx, strings.NewReader(line("", "animal"))); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if got := store.imported[0].BarnID; got != "north" {
+		t.Fatalf("expected events without a barn to go to north, got %q", got)
+	}
+	if _, err := archive.Import(ctx, strings.NewReader(line(`"barn_id":"south",`, "animal"))); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q importing into another barn, got %v", CodeForbidden, err)
+	}
+	if _, err := archive.Import(ctx, strings.NewReader(line("", domain.UserAggregateType))); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q importing users without a server membership, got %v", CodeForbidden, err)
+	}
+	serverManager := WithBarn(roleContext(domain.RoleManager), "north")
+	if _, err := archive.Import(serverManager, strings.NewReader(line("", domain.UserAggregateType))); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q importing users as a server manager, got %v", CodeForbidden, err)
+	}
+	owner := roleContext(domain.RoleOwner)
+	if _, err := archive.Import(owner, strings.NewReader(line(`"barn_id":"north",`, domain.UserAggregateType))); !hasCode(err, CodeImportInvalid) {
+		t.Fatalf("expected %q for a user stream outside the server barn, got %v", CodeImportInvalid, err)
+	}
+	store.imported = nil
+	if _, err := archive.Import(owner, strings.NewReader(line("", domain.UserAggregateType))); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if got := store.imported[0].BarnID; got != domain.ServerBarnID {
+		t.Fatalf("expected user streams without a barn to go to %q, got %q", domain.ServerBarnID, got)
+	}
+	if _, err := archive.Import(ctx, strings.NewReader(line(`"barn_id":"../south",`, "animal"))); !hasCode(err, CodeImportInvalid) {
+		t.Fatalf("expected %q for a malformed barn, got %v", CodeImportInvalid, err)
+	}
+}
+
+func TestEventArchive_UserStreamsNeedManageUsers(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeEventArchiveStore{events: []ports.ArchivedEvent{
+		{
+			Position:      1,
+			ID:            "e1",
+			BarnID:        domain.ServerBarnID,
+			AggregateType: domain.UserAggregateType,
+			AggregateID:   "u2",
+			EventType:     domain.UserCreatedEventType,
+			CreatedBy:     "service:cli",
+			Source:        "cli",
+			RequestID:     "req-1",
+			EventVersion:  1,
+			PayloadJSON:   `{"username":"bob","password_hash":"$argon2id$secret","role":"manager","barn_id":"server"}`,
+			OccurredAt:    "2026-03-04T05:06:07Z",
+			RecordedAt:    "2026-03-04 05:06:07",
+		},
+		{
+			Position:      2,
+			ID:            "e2",
+			BarnID:        domain.DefaultBarnID,
+			AggregateType: "animal",
+			AggregateID:   "a1",
+			EventType:     "animal.created",
+			CreatedBy:     "user:bob",
+			Source:        "web.app",
+			RequestID:     "req-2",
+			EventVersion:  1,
+			PayloadJSON:   `{"name":"Nanny"}`,
+			OccurredAt:    "2026-03-04T05:07:07Z",
+			RecordedAt:    "2026-03-04 05:07:07",
+		},
+	}}
+	archive := NewEventArchive(store)
+	manager := roleContext(domain.RoleManager)
+
+	var exported bytes.Buffer
+	if err := archive.Export(WithBarn(manager, domain.ServerBarnID), &exported); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if strings.Contains(exported.String(), "password_hash") || strings.Contains(exported.String(), `"aggregate_type":"user"`) {
+		t.Fatalf("expected a manager's export to leave out user streams, got %q", exported.String())
+	}
+	if !strings.Contains(exported.String(), `"id":"e2"`) {
+		t.Fatalf("expected a manager's export to keep animal events, got %q", exported.String())
+	}
+
+	exported.Reset()
+	if err := archive.Export(WithBarn(roleContext(domain.RoleOwner), domain.ServerBarnID), &exported); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if !strings.Contains(exported.String(), `"password_hash":"$argon2id$secret"`) {
+		t.Fatalf("expected an owner's export to keep user streams, got %q", exported.String())
+	}
+
+	promote := `{"id":"e3","barn_id":"server","aggregate_type":"user","aggregate_id":"u1",` +
+		`"event_type":"user.role_changed","event_version":1,"created_by":"user:anna","source":"s","request_id":"r",` +
+		`"payload":{"role":"owner","barn_id":"server"},"metadata":null,"occurred_at":"t","created_at":"t"}` + "\n"
+	importStore := &fakeEventArchiveStore{}
+	if _, err := NewEventArchive(importStore).Import(manager, strings.NewReader(promote)); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q for a manager importing a role change, got %v", CodeForbidden, err)
+	}
+	if len(importStore.imported) != 0 {
+		t.Fatalf("expected nothing imported, got %+v", importStore.imported)
+	}
+	if _, err := NewEventArchive(importStore).Import(roleContext(domain.RoleOwner), strings.NewReader(promote)); err != nil {
+		t.Fatalf("expected an owner to import user streams, got %v", err)
+	}
+}
+
+func TestEventArchive_ImportCompactsJSON(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeEventArchiveStore{}
+	line := `{"position":9,"id":"e1","aggregate_type":"animal","aggregate_id":"a1","event_type":"animal.created",` +
+		`"event_version":1,"created_by":"user:anna","source":"web.app","request_id":"req-1",` +
+		`"payload":{ "name": "Nanny" },"metadata":{ "actor": "user:anna" },` +
+		`"occurred_at":"2026-03-04T05:06:07Z","created_at":"2026-03-04 05:06:07"}`
+
+	if _, err := NewEventArchive(store).Import(roleContext(domain.RoleOwner), strings.NewReader("\n"+line+"\n\n")); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(store.imported) != 1 {
+		t.Fatalf("expected blank lines to be skipped, got %d events", len(store.imported))
+	}
+	if store.imported[0].PayloadJSON != `{"name":"Nanny"}` || store.imported[0].MetadataJSON != `{"actor":"user:anna"}` {
+		t.Fatalf("expected compact JSON, got %+v", store.imported[0])
+	}
+}
+
+func TestEventArchive_ImportInvalidLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{name: "not json", line: `not json`, want: "line 2: invalid JSON"},
+		{name: "missing source", line: `{"id":"e1"}`, want: "line 2: aggregate_type is required"},
+		{
+			name: "payload not object",
+			line: `{"id":"e1","aggregate_type":"animal","aggregate_id":"a1","event_type":"animal.created",` +
+				`"event_version":1,"created_by":"u","source":"s","request_id":"r","payload":[],` +
+				`"metadata":null,"occurred_at":"t","created_at":"t"}`,
+			want: "line 2: payload must be a JSON object",
+		},
+		{name: "unknown field", line: `{"id":"e1","extra":true}`, want: "line 2: invalid JSON"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewEventArchive(&fakeEventArchiveStore{}).Import(roleContext(domain.RoleOwner), strings.NewReader("\n"+tc.line+"\n"))
+			be, ok := AsBusinessError(err)
+			if !ok || be.Code != CodeImportInvalid {
+				t.Fatalf("expected %q, got %v", CodeImportInvalid, err)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected %q in %q", tc.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestEventArchive_ImportConflict(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEventArchive(&fakeEventArchiveStore{importErr: ports.ErrConflict}).
+		Import(roleContext(domain.RoleOwner), strings.NewReader(""))
+	be, ok := AsBusinessError(err)
+	if !ok || be.Code != CodeConflict {
+		t.Fatalf("expected %q, got %v", CodeConflict, err)
+	}
+}
+
+type fakeEventArchiveStore struct {
+	exportBarn string
+	events     []ports.ArchivedEvent
+	imported   []ports.ArchivedEvent
+	importErr  error
+}
+
+func (f *fakeEventArchiveStore) ExportEvents(_ context.Context, barnID string) iter.Seq2[ports.ArchivedEvent, error] {
+	f.exportBarn = barnID
+	return func(yield func(ports.ArchivedEvent, error) bool) {
+		for _, event := range f.events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeEventArchiveStore) ImportEvents(
+	_ context.Context,
+	events iter.Seq2[ports.ArchivedEvent, error],
+) (ports.ImportEventsResult, error) {
+	if f.importErr != nil {
+		return ports.ImportEventsResult{}, f.importErr
+	}
+	for event, err := range events {
+		if err != nil {
+			return ports.ImportEventsResult{}, err
+		}
+		f.imported = append(f.imported, event)
+	}
+	return ports.ImportEventsResult{Imported: len(f.imported)}, nil
+}
+
+var _ ports.EventArchiveStore = (*fakeEventArchiveStore)(nil)
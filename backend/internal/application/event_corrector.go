@@ -0,0 +1,328 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeReasonRequired indicates a correction or void was submitted without a reason.
+	CodeReasonRequired BusinessCode = "reason_required"
+	// CodePayloadInvalid indicates the corrected payload is not a JSON object of the target event.
+	CodePayloadInvalid BusinessCode = "payload_invalid"
+	// CodeEventNotFound indicates the target event does not exist.
+	CodeEventNotFound BusinessCode = "event_not_found"
+	// CodeEventAggregateMismatch indicates the target event belongs to another aggregate.
+	CodeEventAggregateMismatch BusinessCode = "event_aggregate_mismatch"
+	// CodeEventNotCorrectable indicates the target event type cannot be corrected or voided.
+	CodeEventNotCorrectable BusinessCode = "event_not_correctable"
+	// CodeEventVoided indicates the target event was already voided.
+	CodeEventVoided BusinessCode = "event_voided"
+)
+
+// CorrectAnimalEventInput is the application command for replacing the payload of an animal event.
+type CorrectAnimalEventInput struct {
+	AnimalID string
+	EventID  string
+	Reason   string
+	Payload  []byte
+	Meta     RequestMeta
+}
+
+// VoidAnimalEventInput is the application command for marking an animal event as void.
+type VoidAnimalEventInput struct {
+	AnimalID string
+	EventID  string
+	Reason   string
+	Meta     RequestMeta
+}
+
+// EventAmendmentOutput is the application result for corrections and voids.
+type EventAmendmentOutput struct {
+	AnimalID      string
+	EventID       string
+	TargetEventID string
+	Replayed      bool
+}
+
+// EventCorrector appends corrections and voids for historical events.
+// The original events are never modified.
+type EventCorrector interface {
+	CorrectAnimalEvent(ctx context.Context, in CorrectAnimalEventInput) (EventAmendmentOutput, error)
+	VoidAnimalEvent(ctx context.Context, in VoidAnimalEventInput) (EventAmendmentOutput, error)
+}
+
+type eventCorrector struct {
+	store   ports.EventCorrectionStore
+	photos  ports.PhotoStore
+	metrics ports.EventMetrics
+}
+
+// NewEventCorrector builds the correction and void application service.
+// metrics may be nil.
+func NewEventCorrector(store ports.EventCorrectionStore, photos ports.PhotoStore, metrics ports.EventMetrics) EventCorrector {
+	return eventCorrector{store: store, photos: photos, metrics: eventMetricsOrNoop(metrics)}
+}
+
+func (c eventCorrector) CorrectAnimalEvent(ctx context.Context, in CorrectAnimalEventInput) (EventAmendmentOutput, error) {
+	if err := Authorize(ctx, PermissionRecordEvents); err != nil {
+		return EventAmendmentOutput{}, err
+	}
+	in.AnimalID = strings.TrimSpace(in.AnimalID)
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	if err := validateAmendmentMeta(in.Reason, in.Meta); err != nil {
+		return EventAmendmentOutput{}, err
+	}
+
+	entry, err := c.loadTarget(ctx, in.AnimalID, in.EventID)
+	if err != nil {
+		return EventAmendmentOutput{}, err
+	}
+	if entry.Event.Type != domain.AnimalCreatedEventType {
+		return EventAmendmentOutput{}, BusinessError{
+			Code: CodeEventNotCorrectable,
+			Err:  fmt.Errorf("%s events cannot be corrected", entry.Event.Type),
+		}
+	}
+
+	created, err := decodeAnimalCreatedCorrection(in.Payload)
+	if err != nil {
+		return EventAmendmentOutput{}, err
+	}
+	payload, err := json.Marshal(created)
+	if err != nil {
+		return EventAmendmentOutput{}, fmt.Errorf("marshal corrected payload: %w", err)
+	}
+
+	storeIn := ports.EventCorrectionRecordInput{
+		BarnID:         BarnFromContext(ctx),
+		AggregateType:  domain.AnimalAggregateType,
+		AggregateID:    in.AnimalID,
+		EventType:      domain.EventCorrectedEventType,
+		TargetEventID:  in.EventID,
+		Reason:         in.Reason,
+		PayloadVersion: domain.CurrentEventVersion(entry.Event.Type),
+		Payload:        payload,
+		Source:         in.Meta.Source,
+		RequestID:      in.Meta.RequestID,
+		CreatedBy:      in.Meta.Actor,
+		DeviceID:       in.Meta.DeviceID,
+		ClientVersion:  in.Meta.ClientVersion,
+	}
+
+	return c.append(ctx, storeIn, entry, func() error {
+		if created.PhotoID == "" || created.PhotoID == entryPhotoID(entry) {
+			return nil
+		}
+		exists, err := c.photos.PhotoExists(ctx, BarnFromContext(ctx), created.PhotoID)
+		if err != nil {
+			return fmt.Errorf("photo exists: %w", err)
+		}
+		if !exists {
+			return BusinessError{Code: CodePhotoNotFound, Err: errors.New("photo not found")}
+		}
+		return nil
+	})
+}
+
+func (c eventCorrector) VoidAnimalEvent(ctx context.Context, in VoidAnimalEventInput) (EventAmendmentOutput, error) {
+	if err := Authorize(ctx, PermissionVoidEvents); err != nil {
+		return EventAmendmentOutput{}, err
+	}
+	in.AnimalID = strings.TrimSpace(in.AnimalID)
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	if err := validateAmendmentMeta(in.Reason, in.Meta); err != nil {
+		return EventAmendmentOutput{}, err
+	}
+
+	entry, err := c.loadTarget(ctx, in.AnimalID, in.EventID)
+	if err != nil {
+		return EventAmendmentOutput{}, err
+	}
+
+	return c.append(ctx, ports.EventCorrectionRecordInput{
+		BarnID:        BarnFromContext(ctx),
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   in.AnimalID,
+		EventType:     domain.EventVoidedEventType,
+		TargetEventID: in.EventID,
+		Reason:        in.Reason,
+		Source:        in.Meta.Source,
+		RequestID:     in.Meta.RequestID,
+		CreatedBy:     in.Meta.Actor,
+		DeviceID:      in.Meta.DeviceID,
+		ClientVersion: in.Meta.ClientVersion,
+	}, entry, func() error { return nil })
+}
+
+// append replays an earlier identical request or appends a new amendment.
+// Replays are resolved before checking whether the target is voided so a
+// retried void still answers with its original result.
+func (c eventCorrector) append(
+	ctx context.Context,
+	storeIn ports.EventCorrectionRecordInput,
+	entry domain.TimelineEntry,
+	check func() error,
+) (EventAmendmentOutput, error) {
+	replay, found, err := c.store.FindEventCorrectionReplay(ctx, storeIn)
+	if err != nil {
+		if code, ok := createAnimalConflictCode(err); ok {
+			return EventAmendmentOutput{}, BusinessError{Code: code, Err: err}
+		}
+		c.metrics.EventAppendFailed(storeIn.EventType)
+		return EventAmendmentOutput{}, fmt.Errorf("find %s replay: %w", storeIn.EventType, err)
+	}
+	if found {
+		c.metrics.EventReplayed(storeIn.EventType)
+		return EventAmendmentOutput{
+			AnimalID:      storeIn.AggregateID,
+			EventID:       replay.EventID,
+			TargetEventID: storeIn.TargetEventID,
+			Replayed:      true,
+		}, nil
+	}
+
+	if entry.Voided {
+		return EventAmendmentOutput{}, BusinessError{
+			Code: CodeEventVoided,
+			Err:  errors.New("event was voided"),
+		}
+	}
+	if err := check(); err != nil {
+		return EventAmendmentOutput{}, err
+	}
+
+	out, err := c.store.AppendEventCorrection(ctx, storeIn)
+	if err != nil {
+		if errors.Is(err, ports.ErrEventVoided) {
+			return EventAmendmentOutput{}, BusinessError{Code: CodeEventVoided, Err: err}
+		}
+		if code, ok := createAnimalConflictCode(err); ok {
+			return EventAmendmentOutput{}, BusinessError{Code: code, Err: err}
+		}
+		c.metrics.EventAppendFailed(storeIn.EventType)
+		return EventAmendmentOutput{}, fmt.Errorf("append %s: %w", storeIn.EventType, err)
+	}
+	if out.Replayed {
+		c.metrics.EventReplayed(storeIn.EventType)
+	} else {
+		c.metrics.EventAppended(storeIn.EventType)
+	}
+
+	return EventAmendmentOutput{
+		AnimalID:      storeIn.AggregateID,
+		EventID:       out.EventID,
+		TargetEventID: storeIn.TargetEventID,
+		Replayed:      out.Replayed,
+	}, nil
+}
+
+// loadTarget resolves the timeline entry an amendment refers to.
+func (c eventCorrector) loadTarget(ctx context.Context, animalID, eventID string) (domain.TimelineEntry, error) {
+	if animalID == "" {
+		return domain.TimelineEntry{}, BusinessError{Code: CodeAnimalNotFound, Err: errors.New("animal not found")}
+	}
+	if eventID == "" {
+		return domain.TimelineEntry{}, BusinessError{Code: CodeEventNotFound, Err: errors.New("event not found")}
+	}
+
+	records, err := c.store.ListAggregateEvents(ctx, BarnFromContext(ctx), domain.AnimalAggregateType, animalID)
+	if err != nil {
+		return domain.TimelineEntry{}, fmt.Errorf("list animal events: %w", err)
+	}
+	if len(records) == 0 {
+		return domain.TimelineEntry{}, BusinessError{Code: CodeAnimalNotFound, Err: errors.New("animal not found")}
+	}
+
+	entries, err := buildTimeline(records)
+	if err != nil {
+		return domain.TimelineEntry{}, err
+	}
+	for _, entry := range entries {
+		if entry.Event.ID == eventID {
+			return entry, nil
+		}
+	}
+
+	for _, record := range records {
+		if record.ID == eventID {
+			return domain.TimelineEntry{}, BusinessError{
+				Code: CodeEventNotCorrectable,
+				Err:  fmt.Errorf("%s events cannot be corrected or voided", record.EventType),
+			}
+		}
+	}
+
+	record, found, err := c.store.GetEvent(ctx, BarnFromContext(ctx), eventID)
+	if err != nil {
+		return domain.TimelineEntry{}, fmt.Errorf("get event: %w", err)
+	}
+	if found {
+		return domain.TimelineEntry{}, BusinessError{
+			Code: CodeEventAggregateMismatch,
+			Err:  fmt.Errorf("event belongs to %s %s", record.AggregateType, record.AggregateID),
+		}
+	}
+	return domain.TimelineEntry{}, BusinessError{Code: CodeEventNotFound, Err: errors.New("event not found")}
+}
+
+func validateAmendmentMeta(reason string, meta RequestMeta) error {
+	if reason == "" {
+		return BusinessError{Code: CodeReasonRequired, Err: errors.New("reason is required")}
+	}
+	return validateRequestMeta(meta)
+}
+
+// decodeAnimalCreatedCorrection validates a replacement animal.created payload
+// with the same rules as animal creation.
+func decodeAnimalCreatedCorrection(payload []byte) (domain.AnimalCreated, error) {
+	var created domain.AnimalCreated
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&created); err != nil {
+		return domain.AnimalCreated{}, BusinessError{
+			Code: CodePayloadInvalid,
+			Err:  fmt.Errorf("decode corrected payload: %w", err),
+		}
+	}
+
+	in := normalizeCreateAnimalInput(CreateAnimalInput{
+		Name:      created.Name,
+		Species:   created.Species,
+		Tag:       created.Tag,
+		Birthdate: created.Birthdate,
+		PhotoID:   created.PhotoID,
+	})
+	if err := validateCreateAnimalInput(in); err != nil {
+		return domain.AnimalCreated{}, err
+	}
+
+	return domain.AnimalCreated{
+		Name:      in.Name,
+		Species:   in.Species,
+		Tag:       in.Tag,
+		Birthdate: in.Birthdate,
+		PhotoID:   in.PhotoID,
+	}, nil
+}
+
+// entryPhotoID returns the photo referenced by the effective animal.created payload.
+// Keeping an already referenced photo does not require it to still exist.
+func entryPhotoID(entry domain.TimelineEntry) string {
+	var created domain.AnimalCreated
+	if err := json.Unmarshal(entry.Payload, &created); err != nil {
+		return ""
+	}
+	return created.PhotoID
+}
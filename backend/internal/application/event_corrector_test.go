@@ -0,0 +1,250 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestEventCorrector_CorrectAnimalEvent(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeEventCorrectionStore()
+	c := NewEventCorrector(store, &fakeAnimalWriteStore{photoExists: true}, nil)
+
+	out, err := c.CorrectAnimalEvent(roleContext(domain.RoleOwner), CorrectAnimalEventInput{
+		AnimalID: "a1",
+		EventID:  "e1",
+		Reason:   " wrong tag ",
+		Payload:  []byte(`{"name":" Nanny ","species":"goat","tag":"G-8"}`),
+		Meta:     RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:test"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Replayed || out.TargetEventID != "e1" {
+		t.Fatalf("unexpected output %+v", out)
+	}
+	if len(store.appended) != 1 {
+		t.Fatalf("expected one appended amendment, got %d", len(store.appended))
+	}
+	got := store.appended[0]
+	if got.EventType != domain.EventCorrectedEventType || got.Reason != "wrong tag" || got.PayloadVersion != 1 {
+		t.Fatalf("unexpected amendment %+v", got)
+	}
+	if string(got.Payload) != `{"name":"Nanny","species":"goat","tag":"G-8","birthdate":"","photo_id":""}` {
+		t.Fatalf("unexpected corrected payload %s", got.Payload)
+	}
+}
+
+func TestEventCorrector_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		store   *fakeEventCorrectionStore
+		eventID string
+		reason  string
+		payload string
+		code    BusinessCode
+	}{
+		{name: "reason required", eventID: "e1", reason: " ", payload: `{}`, code: CodeReasonRequired},
+		{name: "payload invalid", eventID: "e1", reason: "r", payload: `{"colour":"red"}`, code: CodePayloadInvalid},
+		{name: "payload validated", eventID: "e1", reason: "r", payload: `{"name":"Nanny","species":"horse"}`, code: CodeSpeciesInvalid},
+		{name: "event not found", eventID: "missing", reason: "r", payload: `{}`, code: CodeEventNotFound},
+		{name: "other aggregate", eventID: "other", reason: "r", payload: `{}`, code: CodeEventAggregateMismatch},
+		{name: "amendment target", eventID: "e2", reason: "r", payload: `{}`, code: CodeEventNotCorrectable},
+		{
+			name: "voided target",
+			store: newFakeEventCorrectionStore(ports.EventRecord{
+				Position:     3,
+				ID:           "e3",
+				EventType:    domain.EventVoidedEventType,
+				EventVersion: 1,
+				PayloadJSON:  `{"target_event_id":"e1","reason":"duplicate"}`,
+			}),
+			eventID: "e1",
+			reason:  "r",
+			payload: `{"name":"Nanny","species":"goat"}`,
+			code:    CodeEventVoided,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := tt.store
+			if store == nil {
+				store = newFakeEventCorrectionStore()
+			}
+			_, err := NewEventCorrector(store, &fakeAnimalWriteStore{photoExists: true}, nil).CorrectAnimalEvent(
+				roleContext(domain.RoleOwner),
+				CorrectAnimalEventInput{
+					AnimalID: "a1",
+					EventID:  tt.eventID,
+					Reason:   tt.reason,
+					Payload:  []byte(tt.payload),
+					Meta:     RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:test"},
+				},
+			)
+			be, ok := AsBusinessError(err)
+			if !ok {
+				t.Fatalf("expected business error, got %v", err)
+			}
+			if be.Code != tt.code {
+				t.Fatalf("expected code %q, got %q", tt.code, be.Code)
+			}
+			if len(store.appended) != 0 {
+				t.Fatalf("expected no appended amendment, got %d", len(store.appended))
+			}
+		})
+	}
+}
+
+func TestEventCorrector_VoidAnimalEventReplay(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeEventCorrectionStore(ports.EventRecord{
+		Position:     3,
+		ID:           "e3",
+		EventType:    domain.EventVoidedEventType,
+		EventVersion: 1,
+		PayloadJSON:  `{"target_event_id":"e1","reason":"duplicate"}`,
+	})
+	store.replay = ports.EventCorrectionRecordOutput{EventID: "e3", Replayed: true}
+	store.replayFound = true
+
+	out, err := NewEventCorrector(store, &fakeAnimalWriteStore{}, nil).VoidAnimalEvent(roleContext(domain.RoleOwner), VoidAnimalEventInput{
+		AnimalID: "a1",
+		EventID:  "e1",
+		Reason:   "duplicate",
+		Meta:     RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:test"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.Replayed || out.EventID != "e3" {
+		t.Fatalf("expected replay of e3, got %+v", out)
+	}
+}
+
+func TestEventCorrector_VoidedWhileAppending(t *testing.T) {
+	t.Parallel()
+
+	// The target was voided after it was loaded; the store notices on write.
+	store := newFakeEventCorrectionStore()
+	store.appendErr = fmt.Errorf("%w", ports.ErrEventVoided)
+
+	_, err := NewEventCorrector(store, &fakeAnimalWriteStore{}, nil).VoidAnimalEvent(roleContext(domain.RoleOwner), VoidAnimalEventInput{
+		AnimalID: "a1",
+		EventID:  "e1",
+		Reason:   "duplicate",
+		Meta:     RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:test"},
+	})
+	if !hasCode(err, CodeEventVoided) {
+		t.Fatalf("expected %q, got %v", CodeEventVoided, err)
+	}
+}
+
+func TestEventCorrector_WorkerCannotVoid(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeEventCorrectionStore()
+	c := NewEventCorrector(store, &fakeAnimalWriteStore{photoExists: true}, nil)
+	ctx := roleContext(domain.RoleWorker)
+	meta := RequestMeta{Source: "test", RequestID: "req-1", Actor: "user:sam"}
+
+	if _, err := c.CorrectAnimalEvent(ctx, CorrectAnimalEventInput{
+		AnimalID: "a1",
+		EventID:  "e1",
+		Reason:   "wrong tag",
+		Payload:  []byte(`{"name":"Nanny","species":"goat","tag":"G-8"}`),
+		Meta:     meta,
+	}); err != nil {
+		t.Fatalf("expected a worker to correct, got %v", err)
+	}
+	meta.RequestID = "req-2"
+	if _, err := c.VoidAnimalEvent(ctx, VoidAnimalEventInput{
+		AnimalID: "a1",
+		EventID:  "e1",
+		Reason:   "duplicate",
+		Meta:     meta,
+	}); !hasCode(err, CodeForbidden) {
+		t.Fatalf("expected %q, got %v", CodeForbidden, err)
+	}
+	if len(store.appended) != 1 {
+		t.Fatalf("expected only the correction to be appended, got %d", len(store.appended))
+	}
+}
+
+type fakeEventCorrectionStore struct {
+	events      []ports.EventRecord
+	other       ports.EventRecord
+	replay      ports.EventCorrectionRecordOutput
+	replayFound bool
+	appendErr   error
+	appended    []ports.EventCorrectionRecordInput
+}
+
+// newFakeEventCorrectionStore seeds animal a1 with a creation event e1 and a
+// correction e2, followed by extra events.
+func newFakeEventCorrectionStore(extra ...ports.EventRecord) *fakeEventCorrectionStore {
+	events := []ports.EventRecord{
+		{
+			Position:     1,
+			ID:           "e1",
+			EventType:    domain.AnimalCreatedEventType,
+			EventVersion: 1,
+			PayloadJSON:  `{"name":"Nanny","species":"goat","tag":"G-7"}`,
+		},
+		{
+			Position:     2,
+			ID:           "e2",
+			EventType:    domain.EventCorrectedEventType,
+			EventVersion: 1,
+			PayloadJSON:  `{"target_event_id":"e1","reason":"typo","payload_version":1,"payload":{"name":"Nanny","species":"goat","tag":"G-77"}}`,
+		},
+	}
+	return &fakeEventCorrectionStore{
+		events: append(events, extra...),
+		other:  ports.EventRecord{ID: "other", AggregateType: domain.AnimalAggregateType, AggregateID: "a2"},
+	}
+}
+
+func (f *fakeEventCorrectionStore) GetEvent(_ context.Context, _, eventID string) (ports.EventRecord, bool, error) {
+	if eventID == f.other.ID {
+		return f.other, true, nil
+	}
+	return ports.EventRecord{}, false, nil
+}
+
+func (f *fakeEventCorrectionStore) ListAggregateEvents(_ context.Context, _, _, aggregateID string) ([]ports.EventRecord, error) {
+	if aggregateID != "a1" {
+		return nil, nil
+	}
+	return f.events, nil
+}
+
+func (f *fakeEventCorrectionStore) FindEventCorrectionReplay(
+	context.Context,
+	ports.EventCorrectionRecordInput,
+) (ports.EventCorrectionRecordOutput, bool, error) {
+	return f.replay, f.replayFound, nil
+}
+
+func (f *fakeEventCorrectionStore) AppendEventCorrection(
+	_ context.Context,
+	in ports.EventCorrectionRecordInput,
+) (ports.EventCorrectionRecordOutput, error) {
+	if f.appendErr != nil {
+		return ports.EventCorrectionRecordOutput{}, f.appendErr
+	}
+	f.appended = append(f.appended, in)
+	return ports.EventCorrectionRecordOutput{EventID: "new"}, nil
+}
+
+var _ ports.EventCorrectionStore = (*fakeEventCorrectionStore)(nil)
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeInvalidCursor indicates a feed cursor that is not a non-negative event position.
+	CodeInvalidCursor BusinessCode = "invalid_cursor"
+
+	defaultEventFeedLimit = 100
+	maxEventFeedLimit     = 1000
+)
+
+// EventFeedQuery selects events appended after a global position.
+// Empty filter fields match every event.
+type EventFeedQuery struct {
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	AfterPosition int64
+	Limit         int
+}
+
+// FeedEvent is one event of the global feed with its payload upcast to the current
+// contract and credentials removed.
+type FeedEvent struct {
+	Position      int64
+	EventID       string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	CreatedBy     string
+	OccurredAt    string
+	RecordedAt    string
+	Payload       json.RawMessage
+}
+
+// EventFeed reads events across aggregates in global append order.
+type EventFeed interface {
+	ListAfter(ctx context.Context, q EventFeedQuery) ([]FeedEvent, error)
+	LatestPosition(ctx context.Context) (int64, error)
+}
+
+type eventFeed struct {
+	store ports.EventFeedStore
+}
+
+// NewEventFeed builds the event feed application service.
+func NewEventFeed(store ports.EventFeedStore) EventFeed {
+	return eventFeed{store: store}
+}
+
+func (f eventFeed) ListAfter(ctx context.Context, q EventFeedQuery) ([]FeedEvent, error) {
+	if err := Authorize(ctx, PermissionReadEvents); err != nil {
+		return nil, err
+	}
+	if q.AfterPosition < 0 {
+		return nil, BusinessError{
+			Code: CodeInvalidCursor,
+			Err:  errors.New("cursor must not be negative"),
+		}
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultEventFeedLimit
+	}
+	q.Limit = min(q.Limit, maxEventFeedLimit)
+
+	records, err := f.store.ListEventsAfter(ctx, BarnFromContext(ctx), ports.EventFeedFilter{
+		AggregateType: strings.TrimSpace(q.AggregateType),
+		AggregateID:   strings.TrimSpace(q.AggregateID),
+		EventType:     strings.TrimSpace(q.EventType),
+	}, q.AfterPosition, q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	events := make([]FeedEvent, 0, len(records))
+	for _, record := range records {
+		event, err := feedEventFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func feedEventFromRecord(record ports.EventRecord) (FeedEvent, error) {
+	payload, err := domain.Upcast(record.EventType, record.EventVersion, []byte(record.PayloadJSON))
+	if err != nil {
+		return FeedEvent{}, fmt.Errorf("upcast event %s: %w", record.ID, err)
+	}
+	payload, err = domain.RedactPayload(record.EventType, payload)
+	if err != nil {
+		return FeedEvent{}, fmt.Errorf("redact event %s: %w", record.ID, err)
+	}
+	return FeedEvent{
+		Position:      record.Position,
+		EventID:       record.ID,
+		AggregateType: record.AggregateType,
+		AggregateID:   record.AggregateID,
+		EventType:     record.EventType,
+		CreatedBy:     record.CreatedBy,
+		OccurredAt:    record.OccurredAt,
+		RecordedAt:    record.RecordedAt,
+		Payload:       payload,
+	}, nil
+}
+
+func (f eventFeed) LatestPosition(ctx context.Context) (int64, error) {
+	if err := Authorize(ctx, PermissionReadEvents); err != nil {
+		return 0, err
+	}
+	position, err := f.store.LatestPosition(ctx, BarnFromContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("latest event position: %w", err)
+	}
+	return position, nil
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestEventFeed_ListAfter(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeEventFeedStore{
+		records: []ports.EventRecord{{
+			Position:     7,
+			ID:           "e7",
+			EventType:    "animal.created",
+			EventVersion: 1,
+			PayloadJSON:  `{"name":"Nanny"}`,
+		}},
+	}
+
+	events, err := NewEventFeed(store).ListAfter(roleContext(domain.RoleOwner), EventFeedQuery{
+		AggregateType: " animal ",
+		AfterPosition: 6,
+		Limit:         5000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].Position != 7 || string(events[0].Payload) != `{"name":"Nanny"}` {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if store.filter.AggregateType != "animal" {
+		t.Fatalf("expected trimmed aggregate type, got %q", store.filter.AggregateType)
+	}
+	if store.after != 6 {
+		t.Fatalf("expected cursor 6, got %d", store.after)
+	}
+	if store.limit != maxEventFeedLimit {
+		t.Fatalf("expected limit capped at %d, got %d", maxEventFeedLimit, store.limit)
+	}
+}
+
+func TestEventFeed_ListAfterRedactsPasswordHashes(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeEventFeedStore{
+		records: []ports.EventRecord{{
+			Position:     3,
+			ID:           "e3",
+			EventType:    "user.created",
+			EventVersion: 1,
+			PayloadJSON:  `{"password_hash":"$argon2id$secret","username":"anna"}`,
+		}},
+	}
+
+	events, err := NewEventFeed(store).ListAfter(roleContext(domain.RoleOwner), EventFeedQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || string(events[0].Payload) != `{"username":"anna"}` {
+		t.Fatalf("expected redacted payload, got %+v", events)
+	}
+}
+
+func TestEventFeed_ListAfterNegativeCursor(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEventFeed(&fakeEventFeedStore{}).ListAfter(roleContext(domain.RoleOwner), EventFeedQuery{AfterPosition: -1})
+	be, ok := AsBusinessError(err)
+	if !ok || be.Code != CodeInvalidCursor {
+		t.Fatalf("expected %q, got %v", CodeInvalidCursor, err)
+	}
+}
+
+type fakeEventFeedStore struct {
+	records []ports.EventRecord
+	latest  int64
+	barnID  string
+	filter  ports.EventFeedFilter
+	after   int64
+	limit   int
+}
+
+func (f *fakeEventFeedStore) ListEventsAfter(
+	_ context.Context,
+	barnID string,
+	filter ports.EventFeedFilter,
+	afterPosition int64,
+	limit int,
+) ([]ports.EventRecord, error) {
+	f.barnID = barnID
+	f.filter = filter
+	f.after = afterPosition
+	f.limit = limit
+	return f.records, nil
+}
+
+func (f *fakeEventFeedStore) LatestPosition(context.Context, string) (int64, error) {
+	return f.latest, nil
+}
+
+var _ ports.EventFeedStore = (*fakeEventFeedStore)(nil)
@@ -0,0 +1,17 @@
+package application
+
+import "barnlog/backend/internal/ports"
+
+type noopEventMetrics struct{}
+
+func (noopEventMetrics) EventAppended(string)     {}
+func (noopEventMetrics) EventReplayed(string)     {}
+func (noopEventMetrics) EventAppendFailed(string) {}
+
+// eventMetricsOrNoop lets services treat a nil EventMetrics as disabled.
+func eventMetricsOrNoop(m ports.EventMetrics) ports.EventMetrics {
+	if m == nil {
+		return noopEventMetrics{}
+	}
+	return m
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"barnlog/backend/internal/ports"
+)
+
+// CodeAnimalNotFound indicates the requested animal has no event stream or was voided.
+const CodeAnimalNotFound BusinessCode = "animal_not_found"
+
+// GetAnimalOutput is the application result for reading an animal.
+type GetAnimalOutput struct {
+	AnimalID  string
+	Name      string
+	Species   string
+	Tag       string
+	Birthdate string
+	PhotoID   string
+	Version   int64
+}
+
+// AnimalReader executes animal read queries in the application layer.
+type AnimalReader interface {
+	Get(ctx context.Context, animalID string) (GetAnimalOutput, error)
+	Timeline(ctx context.Context, animalID string) (AnimalTimelineOutput, error)
+}
+
+type animalReader struct {
+	store ports.AnimalReadStore
+}
+
+// NewAnimalReader builds the animal read application service.
+func NewAnimalReader(store ports.AnimalReadStore) AnimalReader {
+	return animalReader{store: store}
+}
+
+func (r animalReader) Get(ctx context.Context, animalID string) (GetAnimalOutput, error) {
+	if err := Authorize(ctx, PermissionReadAnimals); err != nil {
+		return GetAnimalOutput{}, err
+	}
+	animalID = strings.TrimSpace(animalID)
+	if animalID == "" {
+		return GetAnimalOutput{}, BusinessError{
+			Code: CodeAnimalNotFound,
+			Err:  errors.New("animal not found"),
+		}
+	}
+
+	state, found, err := r.store.LoadAnimal(ctx, BarnFromContext(ctx), animalID)
+	if err != nil {
+		return GetAnimalOutput{}, fmt.Errorf("load animal: %w", err)
+	}
+	if !found || state.Animal.Voided {
+		return GetAnimalOutput{}, BusinessError{
+			Code: CodeAnimalNotFound,
+			Err:  errors.New("animal not found"),
+		}
+	}
+
+	return GetAnimalOutput{
+		AnimalID:  animalID,
+		Name:      state.Animal.Name,
+		Species:   state.Animal.Species,
+		Tag:       state.Animal.Tag,
+		Birthdate: state.Animal.Birthdate,
+		PhotoID:   state.Animal.PhotoID,
+		Version:   state.Version,
+	}, nil
+}
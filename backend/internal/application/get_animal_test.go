@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestAnimalReader_Get(t *testing.T) {
+	t.Parallel()
+
+	r := NewAnimalReader(&fakeAnimalReadStore{
+		found: true,
+		state: ports.AnimalState{
+			Animal: domain.Animal{
+				ID:      "a1",
+				Name:    "Nanny",
+				Species: "goat",
+				Tag:     "G-7",
+			},
+			Version: 3,
+		},
+	})
+
+	out, err := r.Get(roleContext(domain.RoleOwner), " a1 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.AnimalID != "a1" {
+		t.Fatalf("expected trimmed AnimalID, got %q", out.AnimalID)
+	}
+	if out.Name != "Nanny" {
+		t.Fatalf("expected Name=Nanny, got %q", out.Name)
+	}
+	if out.Version != 3 {
+		t.Fatalf("expected Version=3, got %d", out.Version)
+	}
+}
+
+func TestAnimalReader_GetNotFound(t *testing.T) {
+	t.Parallel()
+
+	for _, animalID := range []string{"missing", "  "} {
+		_, err := NewAnimalReader(&fakeAnimalReadStore{}).Get(roleContext(domain.RoleOwner), animalID)
+		be, ok := AsBusinessError(err)
+		if !ok {
+			t.Fatalf("expected business error for %q, got %v", animalID, err)
+		}
+		if be.Code != CodeAnimalNotFound {
+			t.Fatalf("expected code %q, got %q", CodeAnimalNotFound, be.Code)
+		}
+	}
+}
+
+func TestAnimalReader_GetVoided(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAnimalReader(&fakeAnimalReadStore{
+		found: true,
+		state: ports.AnimalState{
+			Animal:  domain.Animal{ID: "a1", Name: "Nanny", Species: "goat", Voided: true},
+			Version: 2,
+		},
+	}).Get(roleContext(domain.RoleOwner), "a1")
+	be, ok := AsBusinessError(err)
+	if !ok || be.Code != CodeAnimalNotFound {
+		t.Fatalf("expected %q, got %v", CodeAnimalNotFound, err)
+	}
+}
+
+func TestAnimalReader_Timeline(t *testing.T) {
+	t.Parallel()
+
+	r := NewAnimalReader(&fakeAnimalReadStore{
+		events: []ports.EventRecord{
+			{
+				Position:     1,
+				ID:           "e1",
+				EventType:    domain.AnimalCreatedEventType,
+				EventVersion: 1,
+				CreatedBy:    "system",
+				PayloadJSON:  `{"name":"Nanny","species":"goat","tag":"G-7"}`,
+			},
+			{
+				Position:     2,
+				ID:           "e2",
+				EventType:    domain.EventCorrectedEventType,
+				EventVersion: 1,
+				CreatedBy:    "system",
+				PayloadJSON:  `{"target_event_id":"e1","reason":"typo","payload_version":1,"payload":{"name":"Nanny","species":"goat","tag":"G-8"}}`,
+			},
+		},
+	})
+
+	out, err := r.Timeline(roleContext(domain.RoleOwner), "a1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(out.Entries))
+	}
+	entry := out.Entries[0]
+	if entry.Status != TimelineStatusCorrected {
+		t.Fatalf("expected status %q, got %q", TimelineStatusCorrected, entry.Status)
+	}
+	if string(entry.Payload) != `{"name":"Nanny","species":"goat","tag":"G-8"}` {
+		t.Fatalf("unexpected effective payload %s", entry.Payload)
+	}
+	if string(entry.OriginalPayload) != `{"name":"Nanny","species":"goat","tag":"G-7"}` {
+		t.Fatalf("unexpected original payload %s", entry.OriginalPayload)
+	}
+	if len(entry.Corrections) != 1 || entry.Corrections[0].Reason != "typo" {
+		t.Fatalf("unexpected corrections %+v", entry.Corrections)
+	}
+}
+
+func TestAnimalReader_TimelineNotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAnimalReader(&fakeAnimalReadStore{}).Timeline(roleContext(domain.RoleOwner), "missing")
+	be, ok := AsBusinessError(err)
+	if !ok || be.Code != CodeAnimalNotFound {
+		t.Fatalf("expected %q, got %v", CodeAnimalNotFound, err)
+	}
+}
+
+func TestAnimalReader_GetStoreError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAnimalReader(&fakeAnimalReadStore{err: errors.New("boom")}).Get(roleContext(domain.RoleOwner), "a1")
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, ok := AsBusinessError(err); ok {
+		t.Fatalf("expected internal error, got business error %v", err)
+	}
+}
+
+type fakeAnimalReadStore struct {
+	state  ports.AnimalState
+	found  bool
+	events []ports.EventRecord
+	err    error
+}
+
+func (f *fakeAnimalReadStore) LoadAnimal(context.Context, string, string) (ports.AnimalState, bool, error) {
+	return f.state, f.found, f.err
+}
+
+func (f *fakeAnimalReadStore) ListAnimalEvents(context.Context, string, string) ([]ports.EventRecord, error) {
+	return f.events, f.err
+}
+
+var _ ports.AnimalReadStore = (*fakeAnimalReadStore)(nil)
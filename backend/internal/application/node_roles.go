@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+// CodeReadOnly indicates a write sent to a follower node.
+const CodeReadOnly BusinessCode = "read_only"
+
+// NodeRoles reads and changes the replication role of this node.
+// A follower rejects writes until it is promoted to primary.
+type NodeRoles interface {
+	Role(ctx context.Context) (ports.NodeRole, error)
+	// RequireWritable returns a CodeReadOnly business error on a follower.
+	RequireWritable(ctx context.Context) error
+	Promote(ctx context.Context) error
+	Follow(ctx context.Context) error
+}
+
+type nodeRoles struct {
+	store ports.NodeRoleStore
+	now   func() time.Time
+}
+
+// NewNodeRoles builds the node role service.
+func NewNodeRoles(store ports.NodeRoleStore) NodeRoles {
+	return nodeRoles{store: store, now: time.Now}
+}
+
+func (n nodeRoles) Role(ctx context.Context) (ports.NodeRole, error) {
+	role, err := n.store.GetNodeRole(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get node role: %w", err)
+	}
+	return role, nil
+}
+
+func (n nodeRoles) RequireWritable(ctx context.Context) error {
+	role, err := n.Role(ctx)
+	if err != nil {
+		return err
+	}
+	if role == ports.NodeRoleFollower {
+		return BusinessError{Code: CodeReadOnly, Err: fmt.Errorf("node is a read-only follower")}
+	}
+	return nil
+}
+
+func (n nodeRoles) Promote(ctx context.Context) error {
+	return n.setRole(ctx, ports.NodeRolePrimary)
+}
+
+func (n nodeRoles) Follow(ctx context.Context) error {
+	return n.setRole(ctx, ports.NodeRoleFollower)
+}
+
+func (n nodeRoles) setRole(ctx context.Context, role ports.NodeRole) error {
+	if err := n.store.SetNodeRole(ctx, role, n.now().UTC()); err != nil {
+		return fmt.Errorf("set node role %s: %w", role, err)
+	}
+	return nil
+}
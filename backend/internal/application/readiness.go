@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"barnlog/backend/internal/ports"
+)
+
+// Names of the checks reported by ReadinessChecker.
+const (
+	ReadinessCheckDatabase      = "database"
+	ReadinessCheckMigrations    = "migrations"
+	ReadinessCheckWebhookOutbox = "webhook_outbox"
+	ReadinessCheckReplication   = "replication"
+)
+
+// ReadinessCheck is the outcome of one dependency check. Error is a fixed
+// message that is safe to serve on the public probe; Cause holds the failure
+// itself for the log. Lag, when set, counts events a background consumer has
+// not processed yet; lag is reported but does not fail a check by itself.
+type ReadinessCheck struct {
+	Name  string
+	OK    bool
+	Error string
+	Cause error
+	Lag   *int64
+}
+
+// readinessFailures are the messages served for the failed checks.
+var readinessFailures = map[string]string{
+	ReadinessCheckDatabase:      "database is unreachable",
+	ReadinessCheckMigrations:    "database schema does not match this build",
+	ReadinessCheckWebhookOutbox: "webhook outbox cannot be read",
+	ReadinessCheckReplication:   "replication progress cannot be read",
+}
+
+// ReadinessConfig describes the state a ready node is expected to be in.
+type ReadinessConfig struct {
+	// SchemaVersion is the latest migration the binary knows.
+	SchemaVersion uint
+	// ReplicationTarget is the configured replication target; empty skips the replication check.
+	ReplicationTarget string
+}
+
+// ReadinessChecker runs the database checks behind the readiness probe.
+type ReadinessChecker interface {
+	CheckReadiness(ctx context.Context) []ReadinessCheck
+}
+
+type readinessChecker struct {
+	store ports.ReadinessStore
+	cfg   ReadinessConfig
+}
+
+// NewReadinessChecker builds the readiness checker.
+func NewReadinessChecker(store ports.ReadinessStore, cfg ReadinessConfig) ReadinessChecker {
+	return readinessChecker{store: store, cfg: cfg}
+}
+
+func (c readinessChecker) CheckReadiness(ctx context.Context) []ReadinessCheck {
+	checks := []ReadinessCheck{
+		newReadinessCheck(ReadinessCheckDatabase, c.store.Ping(ctx)),
+		newReadinessCheck(ReadinessCheckMigrations, c.checkSchemaVersion(ctx)),
+		newLagCheck(ReadinessCheckWebhookOutbox, func() (int64, error) {
+			return c.store.PendingOutbox(ctx)
+		}),
+	}
+	if c.cfg.ReplicationTarget != "" {
+		checks = append(checks, newLagCheck(ReadinessCheckReplication, func() (int64, error) {
+			return c.store.ReplicationLag(ctx, c.cfg.ReplicationTarget)
+		}))
+	}
+	return checks
+}
+
+func (c readinessChecker) checkSchemaVersion(ctx context.Context) error {
+	version, dirty, err := c.store.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed half way and must be fixed", version)
+	}
+	if version != c.cfg.SchemaVersion {
+		return fmt.Errorf("database is at version %d, expected %d", version, c.cfg.SchemaVersion)
+	}
+	return nil
+}
+
+func newReadinessCheck(name string, err error) ReadinessCheck {
+	check := ReadinessCheck{Name: name, OK: err == nil}
+	if err != nil {
+		check.Error = readinessFailures[name]
+		check.Cause = err
+	}
+	return check
+}
+
+func newLagCheck(name string, lag func() (int64, error)) ReadinessCheck {
+	events, err := lag()
+	check := newReadinessCheck(name, err)
+	if err == nil {
+		check.Lag = &events
+	}
+	return check
+}
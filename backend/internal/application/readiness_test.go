@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadinessChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		store      fakeReadinessStore
+		cfg        ReadinessConfig
+		wantNames  []string
+		wantFailed map[string]bool
+	}{
+		{
+			name:      "healthy without replication",
+			store:     fakeReadinessStore{version: 5, pending: 2},
+			cfg:       ReadinessConfig{SchemaVersion: 5},
+			wantNames: []string{ReadinessCheckDatabase, ReadinessCheckMigrations, ReadinessCheckWebhookOutbox},
+		},
+		{
+			name:      "replication lag is reported",
+			store:     fakeReadinessStore{version: 5, replicationLag: 40},
+			cfg:       ReadinessConfig{SchemaVersion: 5, ReplicationTarget: "file:/replica.sqlite3"},
+			wantNames: []string{ReadinessCheckDatabase, ReadinessCheckMigrations, ReadinessCheckWebhookOutbox, ReadinessCheckReplication},
+		},
+		{
+			name:       "schema behind",
+			store:      fakeReadinessStore{version: 4},
+			cfg:        ReadinessConfig{SchemaVersion: 5},
+			wantNames:  []string{ReadinessCheckDatabase, ReadinessCheckMigrations, ReadinessCheckWebhookOutbox},
+			wantFailed: map[string]bool{ReadinessCheckMigrations: true},
+		},
+		{
+			name:       "dirty schema",
+			store:      fakeReadinessStore{version: 5, dirty: true},
+			cfg:        ReadinessConfig{SchemaVersion: 5},
+			wantNames:  []string{ReadinessCheckDatabase, ReadinessCheckMigrations, ReadinessCheckWebhookOutbox},
+			wantFailed: map[string]bool{ReadinessCheckMigrations: true},
+		},
+		{
+			name:      "database down",
+			store:     fakeReadinessStore{err: errors.New("disk I/O error")},
+			cfg:       ReadinessConfig{SchemaVersion: 5},
+			wantNames: []string{ReadinessCheckDatabase, ReadinessCheckMigrations, ReadinessCheckWebhookOutbox},
+			wantFailed: map[string]bool{
+				ReadinessCheckDatabase:      true,
+				ReadinessCheckMigrations:    true,
+				ReadinessCheckWebhookOutbox: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checks := NewReadinessChecker(tt.store, tt.cfg).CheckReadiness(context.Background())
+			if len(checks) != len(tt.wantNames) {
+				t.Fatalf("expected checks %v, got %+v", tt.wantNames, checks)
+			}
+			for i, check := range checks {
+				if check.Name != tt.wantNames[i] {
+					t.Fatalf("check %d: expected %s, got %s", i, tt.wantNames[i], check.Name)
+				}
+				if check.OK == tt.wantFailed[check.Name] {
+					t.Fatalf("check %s: expected failed=%t, got %+v", check.Name, tt.wantFailed[check.Name], check)
+				}
+				if !check.OK && (check.Error == "" || check.Cause == nil) {
+					t.Fatalf("check %s failed without an error", check.Name)
+				}
+				if tt.store.err != nil && strings.Contains(check.Error, tt.store.err.Error()) {
+					t.Fatalf("check %s serves the failure itself: %q", check.Name, check.Error)
+				}
+			}
+			if tt.cfg.ReplicationTarget != "" {
+				if lag := checks[3].Lag; lag == nil || *lag != tt.store.replicationLag {
+					t.Fatalf("expected replication lag %d, got %v", tt.store.replicationLag, lag)
+				}
+			}
+		})
+	}
+}
+
+type fakeReadinessStore struct {
+	err            error
+	version        uint
+	dirty          bool
+	pending        int64
+	replicationLag int64
+}
+
+func (f fakeReadinessStore) Ping(context.Context) error {
+	return f.err
+}
+
+func (f fakeReadinessStore) SchemaVersion(context.Context) (uint, bool, error) {
+	return f.version, f.dirty, f.err
+}
+
+func (f fakeReadinessStore) PendingOutbox(context.Context) (int64, error) {
+	return f.pending, f.err
+}
+
+func (f fakeReadinessStore) ReplicationLag(context.Context, string) (int64, error) {
+	return f.replicationLag, f.err
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+const defaultReplicationBatchSize = 500
+
+// ReplicationResult counts the work done by one replication pass.
+// Position is the checkpoint after the pass.
+type ReplicationResult struct {
+	Shipped  int
+	Imported int
+	Skipped  int
+	Position int64
+}
+
+// Replicator ships events appended since the target's checkpoint to the target,
+// in position order. The checkpoint advances only after a batch is accepted,
+// so a failed pass resends that batch and the target skips what it already has.
+type Replicator struct {
+	store     ports.ReplicationStore
+	target    ports.ReplicationTarget
+	batchSize int
+	now       func() time.Time
+}
+
+// NewReplicator builds a replicator for one target. A batchSize <= 0 uses 500.
+func NewReplicator(store ports.ReplicationStore, target ports.ReplicationTarget, batchSize int) *Replicator {
+	if batchSize <= 0 {
+		batchSize = defaultReplicationBatchSize
+	}
+	return &Replicator{
+		store:     store,
+		target:    target,
+		batchSize: batchSize,
+		now:       time.Now,
+	}
+}
+
+// Target returns the name of the replication target.
+func (r *Replicator) Target() string {
+	return r.target.Name()
+}
+
+// ReplicateOnce ships batches until the target has caught up with the local log.
+func (r *Replicator) ReplicateOnce(ctx context.Context) (ReplicationResult, error) {
+	name := r.target.Name()
+	position, err := r.store.GetReplicationCheckpoint(ctx, name)
+	if err != nil {
+		return ReplicationResult{}, fmt.Errorf("get replication checkpoint: %w", err)
+	}
+
+	result := ReplicationResult{Position: position}
+	for {
+		events, err := r.store.ListEventsSince(ctx, result.Position, r.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("list events since %d: %w", result.Position, err)
+		}
+		if len(events) == 0 {
+			return result, nil
+		}
+
+		imported, err := r.target.ReplicateEvents(ctx, events)
+		if err != nil {
+			return result, fmt.Errorf("replicate events to %s: %w", name, err)
+		}
+		last := events[len(events)-1].Position
+		if err := r.store.SaveReplicationCheckpoint(ctx, name, last, r.now().UTC()); err != nil {
+			return result, fmt.Errorf("save replication checkpoint: %w", err)
+		}
+
+		result.Shipped += len(events)
+		result.Imported += imported.Imported
+		result.Skipped += imported.Skipped
+		result.Position = last
+		if len(events) < r.batchSize {
+			return result, nil
+		}
+	}
+}
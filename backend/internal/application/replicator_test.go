@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestReplicator_ShipsBatchesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeReplicationStore{
+		events:      replicationTestEvents(5),
+		checkpoints: map[string]int64{"peer": 1},
+	}
+	target := &fakeReplicationTarget{name: "peer"}
+
+	result, err := NewReplicator(store, target, 2).ReplicateOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != (ReplicationResult{Shipped: 4, Imported: 4, Position: 5}) {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if len(target.batches) != 2 || target.batches[0][0].Position != 2 || len(target.batches[1]) != 2 {
+		t.Fatalf("expected batches [2 3] [4 5], got %+v", target.batches)
+	}
+	if store.checkpoints["peer"] != 5 {
+		t.Fatalf("expected checkpoint 5, got %d", store.checkpoints["peer"])
+	}
+}
+
+func TestReplicator_KeepsCheckpointWhenTargetFails(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeReplicationStore{events: replicationTestEvents(3), checkpoints: map[string]int64{}}
+	target := &fakeReplicationTarget{name: "peer", err: errors.New("connection refused")}
+
+	result, err := NewReplicator(store, target, 10).ReplicateOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected target error")
+	}
+	if result.Position != 0 || store.checkpoints["peer"] != 0 {
+		t.Fatalf("expected checkpoint to stay at 0, got result %+v, stored %d", result, store.checkpoints["peer"])
+	}
+
+	target.err = nil
+	result, err = NewReplicator(store, target, 10).ReplicateOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if result.Shipped != 3 || store.checkpoints["peer"] != 3 {
+		t.Fatalf("expected retry to ship all 3 events, got %+v", result)
+	}
+}
+
+func TestNodeRoles_FollowerIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeNodeRoleStore{role: ports.NodeRolePrimary}
+	roles := NewNodeRoles(store)
+	ctx := context.Background()
+
+	if err := roles.RequireWritable(ctx); err != nil {
+		t.Fatalf("expected primary to be writable, got %v", err)
+	}
+	if err := roles.Follow(ctx); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	be, ok := AsBusinessError(roles.RequireWritable(ctx))
+	if !ok || be.Code != CodeReadOnly {
+		t.Fatalf("expected %s on a follower, got %v", CodeReadOnly, be)
+	}
+	if err := roles.Promote(ctx); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	if err := roles.RequireWritable(ctx); err != nil {
+		t.Fatalf("expected promoted node to be writable, got %v", err)
+	}
+}
+
+func replicationTestEvents(n int) []ports.ArchivedEvent {
+	events := make([]ports.ArchivedEvent, 0, n)
+	for i := 1; i <= n; i++ {
+		events = append(events, ports.ArchivedEvent{Position: int64(i)})
+	}
+	return events
+}
+
+type fakeReplicationStore struct {
+	events      []ports.ArchivedEvent
+	checkpoints map[string]int64
+}
+
+func (f *fakeReplicationStore) ListEventsSince(_ context.Context, after int64, limit int) ([]ports.ArchivedEvent, error) {
+	var out []ports.ArchivedEvent
+	for _, event := range f.events {
+		if event.Position > after && len(out) < limit {
+			out = append(out, event)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeReplicationStore) GetReplicationCheckpoint(_ context.Context, target string) (int64, error) {
+	return f.checkpoints[target], nil
+}
+
+func (f *fakeReplicationStore) SaveReplicationCheckpoint(_ context.Context, target string, position int64, _ time.Time) error {
+	f.checkpoints[target] = position
+	return nil
+}
+
+func (f *fakeReplicationStore) ListReplicationCheckpoints(context.Context) ([]ports.ReplicationCheckpoint, error) {
+	return nil, nil
+}
+
+type fakeReplicationTarget struct {
+	name    string
+	err     error
+	batches [][]ports.ArchivedEvent
+}
+
+func (f *fakeReplicationTarget) Name() string {
+	return f.name
+}
+
+func (f *fakeReplicationTarget) ReplicateEvents(_ context.Context, events []ports.ArchivedEvent) (ports.ImportEventsResult, error) {
+	if f.err != nil {
+		return ports.ImportEventsResult{}, f.err
+	}
+	f.batches = append(f.batches, events)
+	return ports.ImportEventsResult{Imported: len(events)}, nil
+}
+
+type fakeNodeRoleStore struct {
+	role ports.NodeRole
+}
+
+func (f *fakeNodeRoleStore) GetNodeRole(context.Context) (ports.NodeRole, error) {
+	return f.role, nil
+}
+
+func (f *fakeNodeRoleStore) SetNodeRole(_ context.Context, role ports.NodeRole, _ time.Time) error {
+	f.role = role
+	return nil
+}
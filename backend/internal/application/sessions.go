@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeInvalidCredentials indicates an unknown username or a wrong password.
+	CodeInvalidCredentials BusinessCode = "invalid_credentials"
+	// CodeUnauthenticated indicates a missing, unknown or expired session.
+	CodeUnauthenticated BusinessCode = "unauthenticated"
+
+	// DefaultSessionTTL is how long a login session stays valid.
+	DefaultSessionTTL = 7 * 24 * time.Hour
+
+	secretBytes = 32
+)
+
+// AuthenticatorConfig tunes login sessions. A zero SessionTTL uses DefaultSessionTTL.
+type AuthenticatorConfig struct {
+	SessionTTL time.Duration
+}
+
+// LoginInput carries the credentials of a login attempt.
+type LoginInput struct {
+	Username string
+	Password string
+}
+
+// SessionOutput is a new login session. Token is only returned here; the
+// server keeps a hash of it.
+type SessionOutput struct {
+	Token       string
+	CSRFToken   string
+	UserID      string
+	Username    string
+	Memberships domain.Memberships
+	ExpiresAt   time.Time
+}
+
+// Principal is the user behind an authenticated session, API token or device.
+type Principal struct {
+	UserID      string
+	Username    string
+	Memberships domain.Memberships
+	CSRFToken   string
+	ExpiresAt   time.Time
+	// TokenID and Scopes are set when the request carried an API token
+	// instead of a session cookie.
+	TokenID string
+	// DeviceID is set when the request carried a device secret. Scopes are
+	// then DeviceScopes.
+	DeviceID string
+	Scopes   []string
+}
+
+// Scoped reports whether the principal authenticated with an API token or a
+// device secret rather than a session cookie.
+func (p Principal) Scoped() bool {
+	return p.TokenID != "" || p.DeviceID != ""
+}
+
+// HasScope reports whether the principal may use an operation that requires
+// scope. Sessions are not scoped and may use every operation.
+func (p Principal) HasScope(scope string) bool {
+	if !p.Scoped() {
+		return true
+	}
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Authenticator logs users in and resolves session tokens, API tokens and
+// device secrets to principals.
+type Authenticator interface {
+	Login(ctx context.Context, in LoginInput) (SessionOutput, error)
+	Authenticate(ctx context.Context, token string) (Principal, error)
+	AuthenticateToken(ctx context.Context, token string) (Principal, error)
+	Logout(ctx context.Context, token string) error
+}
+
+type authenticator struct {
+	users    ports.UserStore
+	sessions ports.SessionStore
+	tokens   ports.APITokenStore
+	devices  ports.DeviceStore
+	hasher   ports.PasswordHasher
+	cfg      AuthenticatorConfig
+	now      func() time.Time
+}
+
+// NewAuthenticator builds the password login and token authentication application service.
+func NewAuthenticator(
+	users ports.UserStore,
+	sessions ports.SessionStore,
+	tokens ports.APITokenStore,
+	devices ports.DeviceStore,
+	hasher ports.PasswordHasher,
+	cfg AuthenticatorConfig,
+) Authenticator {
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = DefaultSessionTTL
+	}
+	return authenticator{
+		users:    users,
+		sessions: sessions,
+		tokens:   tokens,
+		devices:  devices,
+		hasher:   hasher,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+func (a authenticator) Login(ctx context.Context, in LoginInput) (SessionOutput, error) {
+	username, err := normalizeUsername(in.Username)
+	if err != nil {
+		return SessionOutput{}, errInvalidCredentials()
+	}
+
+	user, found, err := a.users.FindUserByUsername(ctx, username)
+	if err != nil {
+		return SessionOutput{}, fmt.Errorf("find user: %w", err)
+	}
+	if !found {
+		// Hash anyway so unknown usernames take as long as wrong passwords.
+		_, _ = a.hasher.HashPassword(in.Password)
+		return SessionOutput{}, errInvalidCredentials()
+	}
+	if user.PasswordHash == "" {
+		// Single sign-on users have no password; hash anyway as above.
+		_, _ = a.hasher.HashPassword(in.Password)
+		return SessionOutput{}, errInvalidCredentials()
+	}
+	ok, err := a.hasher.VerifyPassword(user.PasswordHash, in.Password)
+	if err != nil {
+		return SessionOutput{}, fmt.Errorf("verify password of user %s: %w", user.ID, err)
+	}
+	if !ok {
+		return SessionOutput{}, errInvalidCredentials()
+	}
+	return startSession(ctx, a.sessions, user, a.now(), a.cfg.SessionTTL)
+}
+
+// startSession stores a new session of user that expires ttl after now and
+// returns its tokens.
+func startSession(ctx context.Context, sessions ports.SessionStore, user domain.User, now time.Time, ttl time.Duration) (SessionOutput, error) {
+	token, err := newSecret()
+	if err != nil {
+		return SessionOutput{}, err
+	}
+	csrfToken, err := newSecret()
+	if err != nil {
+		return SessionOutput{}, err
+	}
+
+	now = now.UTC().Truncate(time.Second)
+	// Expired sessions are only pruned here; a failure leaves them for the next login.
+	_ = sessions.DeleteExpiredSessions(ctx, now)
+	session := ports.Session{
+		TokenHash: hashSecret(token),
+		UserID:    user.ID,
+		CSRFToken: csrfToken,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := sessions.CreateSession(ctx, session); err != nil {
+		return SessionOutput{}, fmt.Errorf("create session: %w", err)
+	}
+
+	return SessionOutput{
+		Token:       token,
+		CSRFToken:   csrfToken,
+		UserID:      user.ID,
+		Username:    user.Username,
+		Memberships: user.Memberships,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+func (a authenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	if token == "" {
+		return Principal{}, errUnauthenticated()
+	}
+	session, found, err := a.sessions.GetSession(ctx, hashSecret(token))
+	if err != nil {
+		return Principal{}, fmt.Errorf("get session: %w", err)
+	}
+	if !found || !a.now().Before(session.ExpiresAt) {
+		return Principal{}, errUnauthenticated()
+	}
+
+	user, found, err := a.users.GetUser(ctx, session.UserID)
+	if err != nil {
+		return Principal{}, fmt.Errorf("get user %s: %w", session.UserID, err)
+	}
+	if !found {
+		return Principal{}, errUnauthenticated()
+	}
+	return Principal{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Memberships: user.Memberships,
+		CSRFToken:   session.CSRFToken,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+func (a authenticator) Logout(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	if err := a.sessions.DeleteSession(ctx, hashSecret(token)); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	return nil
+}
+
+func errInvalidCredentials() error {
+	return BusinessError{Code: CodeInvalidCredentials, Err: errors.New("invalid username or password")}
+}
+
+func errUnauthenticated() error {
+	return BusinessError{Code: CodeUnauthenticated, Err: errors.New("no valid session")}
+}
+
+// newSecret returns a random URL-safe token for sessions, CSRF and API tokens.
+func newSecret() (string, error) {
+	var buf [secretBytes]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf[:]), nil
+}
+
+// hashSecret is the stored form of a session or API token. The tokens are
+// random, so a fast hash is enough.
+func hashSecret(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
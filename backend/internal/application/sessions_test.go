@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestAuthenticator_LoginAuthenticateLogout(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	user := users.add("anna", "hashed:correct horse")
+	sessions := newFakeSessionStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	auth := newTestAuthenticator(users, sessions, &now)
+	ctx := context.Background()
+
+	session, err := auth.Login(ctx, LoginInput{Username: "Anna", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if session.Token == "" || session.CSRFToken == "" || session.UserID != user.ID {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if !session.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected expiry after the session TTL, got %v", session.ExpiresAt)
+	}
+	if _, stored := sessions.sessions[session.Token]; stored {
+		t.Fatal("expected only a hash of the session token to be stored")
+	}
+
+	principal, err := auth.Authenticate(ctx, session.Token)
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if principal.Username != "anna" || principal.CSRFToken != session.CSRFToken {
+		t.Fatalf("unexpected principal %+v", principal)
+	}
+
+	if err := auth.Logout(ctx, session.Token); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	if _, err := auth.Authenticate(ctx, session.Token); !hasCode(err, CodeUnauthenticated) {
+		t.Fatalf("expected %q after logout, got %v", CodeUnauthenticated, err)
+	}
+}
+
+func TestAuthenticator_LoginRejectsBadCredentials(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	users.add("anna", "hashed:correct horse")
+	now := time.Now()
+	auth := newTestAuthenticator(users, newFakeSessionStore(), &now)
+
+	for _, in := range []LoginInput{
+		{Username: "anna", Password: "wrong horse"},
+		{Username: "bert", Password: "correct horse"},
+		{Username: "", Password: "correct horse"},
+	} {
+		if _, err := auth.Login(context.Background(), in); !hasCode(err, CodeInvalidCredentials) {
+			t.Fatalf("expected %q for %+v, got %v", CodeInvalidCredentials, in, err)
+		}
+	}
+}
+
+func TestAuthenticator_SessionsExpire(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	users.add("anna", "hashed:correct horse")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	auth := newTestAuthenticator(users, newFakeSessionStore(), &now)
+
+	session, err := auth.Login(context.Background(), LoginInput{Username: "anna", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	now = now.Add(time.Hour)
+	if _, err := auth.Authenticate(context.Background(), session.Token); !hasCode(err, CodeUnauthenticated) {
+		t.Fatalf("expected %q once expired, got %v", CodeUnauthenticated, err)
+	}
+}
+
+func newTestAuthenticator(users ports.UserStore, sessions ports.SessionStore, now *time.Time) authenticator {
+	auth := NewAuthenticator(users, sessions, newFakeAPITokenStore(), newFakeDeviceStore(), fakePasswordHasher{}, AuthenticatorConfig{SessionTTL: time.Hour}).(authenticator)
+	auth.now = func() time.Time { return *now }
+	return auth
+}
+
+func hasCode(err error, code BusinessCode) bool {
+	be, ok := AsBusinessError(err)
+	return ok && be.Code == code
+}
+
+type fakeSessionStore struct {
+	sessions map[string]ports.Session
+}
+
+func newFakeSessionStore() *fakeSessionStore {
+	return &fakeSessionStore{sessions: map[string]ports.Session{}}
+}
+
+func (f *fakeSessionStore) CreateSession(_ context.Context, session ports.Session) error {
+	f.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (f *fakeSessionStore) GetSession(_ context.Context, tokenHash string) (ports.Session, bool, error) {
+	session, ok := f.sessions[tokenHash]
+	return session, ok, nil
+}
+
+func (f *fakeSessionStore) DeleteSession(_ context.Context, tokenHash string) error {
+	delete(f.sessions, tokenHash)
+	return nil
+}
+
+func (f *fakeSessionStore) DeleteUserSessions(_ context.Context, userID string) error {
+	for hash, session := range f.sessions {
+		if session.UserID == userID {
+			delete(f.sessions, hash)
+		}
+	}
+	return nil
+}
+
+func (f *fakeSessionStore) DeleteExpiredSessions(_ context.Context, now time.Time) error {
+	for hash, session := range f.sessions {
+		if !session.ExpiresAt.After(now) {
+			delete(f.sessions, hash)
+		}
+	}
+	return nil
+}
+
+var _ ports.SessionStore = (*fakeSessionStore)(nil)
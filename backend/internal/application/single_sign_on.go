@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeSingleSignOnFailed indicates a single sign-on callback whose state,
+	// authorization code or ID token was not accepted.
+	CodeSingleSignOnFailed BusinessCode = "sso_failed"
+
+	// DefaultUsernameClaim is the ID token claim read as the Barn Log username.
+	DefaultUsernameClaim = "preferred_username"
+	// DefaultRolesClaim is the ID token claim read as the user's memberships.
+	DefaultRolesClaim = "barnlog_roles"
+
+	singleSignOnSource = "barnlog.oidc"
+)
+
+// SingleSignOnConfig tunes single sign-on. Empty claims use
+// DefaultUsernameClaim and DefaultRolesClaim; a zero SessionTTL uses
+// DefaultSessionTTL.
+type SingleSignOnConfig struct {
+	UsernameClaim string
+	RolesClaim    string
+	SessionTTL    time.Duration
+}
+
+// SingleSignOnLogin is a login started at the identity provider. The caller
+// sends the browser to AuthURL and keeps State, Nonce and CodeVerifier until
+// the callback.
+type SingleSignOnLogin struct {
+	AuthURL      string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// CompleteSingleSignOnInput carries the provider's callback together with the
+// login it answers.
+type CompleteSingleSignOnInput struct {
+	Code  string
+	State string
+	Login SingleSignOnLogin
+}
+
+// SingleSignOn logs users in through an OpenID Connect provider.
+//
+// The roles claim lists memberships as "BARN:ROLE" strings, such as
+// "north:worker" or "server:owner"; malformed entries are ignored. Each login
+// replaces the user's memberships with the ones the claim grants, and a login
+// that grants none is forbidden. Users are matched by username and bound to
+// the subject of their first login, so a password account or another
+// subject's account is never taken over.
+type SingleSignOn interface {
+	Begin(ctx context.Context) (SingleSignOnLogin, error)
+	Complete(ctx context.Context, in CompleteSingleSignOnInput) (SessionOutput, error)
+}
+
+type singleSignOn struct {
+	users    ports.UserStore
+	sessions ports.SessionStore
+	provider ports.IdentityProvider
+	cfg      SingleSignOnConfig
+	now      func() time.Time
+}
+
+// NewSingleSignOn builds the single sign-on application service.
+func NewSingleSignOn(
+	users ports.UserStore,
+	sessions ports.SessionStore,
+	provider ports.IdentityProvider,
+	cfg SingleSignOnConfig,
+) SingleSignOn {
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = DefaultUsernameClaim
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = DefaultRolesClaim
+	}
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = DefaultSessionTTL
+	}
+	return singleSignOn{
+		users:    users,
+		sessions: sessions,
+		provider: provider,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+func (s singleSignOn) Begin(ctx context.Context) (SingleSignOnLogin, error) {
+	var login SingleSignOnLogin
+	for _, secret := range []*string{&login.State, &login.Nonce, &login.CodeVerifier} {
+		value, err := newSecret()
+		if err != nil {
+			return SingleSignOnLogin{}, err
+		}
+		*secret = value
+	}
+	authURL, err := s.provider.AuthCodeURL(ctx, login.State, login.Nonce, codeChallenge(login.CodeVerifier))
+	if err != nil {
+		return SingleSignOnLogin{}, fmt.Errorf("build authorization url: %w", err)
+	}
+	login.AuthURL = authURL
+	return login, nil
+}
+
+func (s singleSignOn) Complete(ctx context.Context, in CompleteSingleSignOnInput) (SessionOutput, error) {
+	if in.Code == "" || in.Login.State == "" ||
+		subtle.ConstantTimeCompare([]byte(in.State), []byte(in.Login.State)) != 1 {
+		return SessionOutput{}, errSingleSignOnFailed(errors.New("missing code or state mismatch"))
+	}
+	identity, err := s.provider.Exchange(ctx, in.Code, in.Login.CodeVerifier, in.Login.Nonce)
+	if err != nil {
+		if errors.Is(err, ports.ErrIdentityRejected) {
+			return SessionOutput{}, errSingleSignOnFailed(err)
+		}
+		return SessionOutput{}, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	rawUsername, _ := identity.Claims[s.cfg.UsernameClaim].(string)
+	username, err := normalizeUsername(rawUsername)
+	if err != nil {
+		return SessionOutput{}, err
+	}
+	memberships := parseRolesClaim(identity.Claims[s.cfg.RolesClaim])
+	if len(memberships) == 0 {
+		return SessionOutput{}, BusinessError{
+			Code: CodeForbidden,
+			Err:  fmt.Errorf("claim %q grants %q no barn membership", s.cfg.RolesClaim, username),
+		}
+	}
+
+	user, found, err := s.users.FindUserByUsername(ctx, username)
+	if err != nil {
+		return SessionOutput{}, fmt.Errorf("find user: %w", err)
+	}
+	if found && user.OIDCSubject != identity.Subject {
+		return SessionOutput{}, BusinessError{
+			Code: CodeUsernameTaken,
+			Err:  fmt.Errorf("username %q belongs to another account", username),
+		}
+	}
+	if !found {
+		user, err = s.createUser(ctx, username, identity.Subject, memberships)
+		if err != nil {
+			return SessionOutput{}, err
+		}
+	}
+	if err := s.syncMemberships(ctx, user, memberships); err != nil {
+		return SessionOutput{}, err
+	}
+	user.Memberships = memberships
+
+	return startSession(ctx, s.sessions, user, s.now(), s.cfg.SessionTTL)
+}
+
+// createUser registers a user without a password, holding the first of
+// memberships; syncMemberships adds the rest.
+func (s singleSignOn) createUser(ctx context.Context, username, subject string, memberships domain.Memberships) (domain.User, error) {
+	requestID, err := newSecret()
+	if err != nil {
+		return domain.User{}, err
+	}
+	barnID := slices.Sorted(maps.Keys(memberships))[0]
+	user, err := s.users.CreateUser(ctx, ports.CreateUserRecordInput{
+		Username:    username,
+		OIDCSubject: subject,
+		BarnID:      barnID,
+		Role:        memberships[barnID],
+		Source:      singleSignOnSource,
+		RequestID:   requestID,
+		CreatedBy:   ServiceActor("oidc"),
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return domain.User{}, BusinessError{Code: CodeUsernameTaken, Err: err}
+		}
+		return domain.User{}, fmt.Errorf("create user: %w", err)
+	}
+	return user, nil
+}
+
+// syncMemberships changes the roles of user to match memberships, ending the
+// memberships it no longer contains.
+func (s singleSignOn) syncMemberships(ctx context.Context, user domain.User, memberships domain.Memberships) error {
+	barnIDs := slices.AppendSeq(slices.Collect(maps.Keys(user.Memberships)), maps.Keys(memberships))
+	slices.Sort(barnIDs)
+	for _, barnID := range slices.Compact(barnIDs) {
+		current, had := user.Memberships[barnID]
+		role := memberships[barnID]
+		if had && current == role {
+			continue
+		}
+		requestID, err := newSecret()
+		if err != nil {
+			return err
+		}
+		if err := s.users.ChangeRole(ctx, ports.ChangeRoleRecordInput{
+			UserID:    user.ID,
+			BarnID:    barnID,
+			Role:      role,
+			Source:    singleSignOnSource,
+			RequestID: requestID,
+			CreatedBy: ServiceActor("oidc"),
+		}); err != nil {
+			if errors.Is(err, ports.ErrConflict) {
+				return BusinessError{Code: CodeConflict, Err: err}
+			}
+			return fmt.Errorf("change role in barn %s: %w", barnID, err)
+		}
+	}
+	return nil
+}
+
+// parseRolesClaim reads a roles claim holding a "BARN:ROLE" string or a list
+// of them. Malformed entries are skipped; a barn named twice keeps the more
+// privileged role.
+func parseRolesClaim(claim any) domain.Memberships {
+	var entries []string
+	switch value := claim.(type) {
+	case string:
+		entries = strings.Fields(value)
+	case []any:
+		for _, entry := range value {
+			if entry, ok := entry.(string); ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	memberships := domain.Memberships{}
+	for _, entry := range entries {
+		rawBarnID, rawRole, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		barnID, err := parseMembershipBarnID(rawBarnID)
+		if err != nil || strings.TrimSpace(rawBarnID) == "" {
+			continue
+		}
+		role, err := parseRole(rawRole)
+		if err != nil {
+			continue
+		}
+		if current, exists := memberships[barnID]; !exists || role.Includes(current) {
+			memberships[barnID] = role
+		}
+	}
+	return memberships
+}
+
+// codeChallenge is the PKCE S256 challenge of verifier.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func errSingleSignOnFailed(err error) error {
+	return BusinessError{Code: CodeSingleSignOnFailed, Err: fmt.Errorf("single sign-on failed: %w", err)}
+}
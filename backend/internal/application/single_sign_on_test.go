@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestSingleSignOn_BeginAndComplete(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	sessions := newFakeSessionStore()
+	provider := &fakeIdentityProvider{
+		identity: ports.IdentityClaims{Subject: "sub-1", Claims: map[string]any{
+			"preferred_username": "Anna",
+			"barnlog_roles":      []any{"north:worker", "south:viewer", "south:manager", "bogus", 7},
+		}},
+	}
+	sso := NewSingleSignOn(users, sessions, provider, SingleSignOnConfig{SessionTTL: time.Hour})
+	ctx := context.Background()
+
+	login, err := sso.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	authURL, err := url.Parse(login.AuthURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+	query := authURL.Query()
+	if query.Get("state") != login.State || query.Get("nonce") != login.Nonce ||
+		query.Get("code_challenge") != codeChallenge(login.CodeVerifier) {
+		t.Fatalf("auth url does not carry the login: %s", login.AuthURL)
+	}
+
+	session, err := sso.Complete(ctx, CompleteSingleSignOnInput{Code: "code-1", State: login.State, Login: login})
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if provider.verifier != login.CodeVerifier || provider.nonce != login.Nonce {
+		t.Fatalf("expected the login's verifier and nonce in the exchange, got %q and %q", provider.verifier, provider.nonce)
+	}
+	want := domain.Memberships{"north": domain.RoleWorker, "south": domain.RoleManager}
+	if session.Username != "anna" || session.Token == "" || !maps.Equal(session.Memberships, want) {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	user, found, _ := users.FindUserByUsername(ctx, "anna")
+	if !found || user.OIDCSubject != "sub-1" || user.PasswordHash != "" || !maps.Equal(user.Memberships, want) {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	t.Run("later logins replace the memberships", func(t *testing.T) {
+		provider.identity.Claims["barnlog_roles"] = "south:viewer"
+		login, _ := sso.Begin(ctx)
+		if _, err := sso.Complete(ctx, CompleteSingleSignOnInput{Code: "code-2", State: login.State, Login: login}); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+		user, _, _ := users.FindUserByUsername(ctx, "anna")
+		if want := (domain.Memberships{"south": domain.RoleViewer}); !maps.Equal(user.Memberships, want) {
+			t.Fatalf("expected memberships %v, got %v", want, user.Memberships)
+		}
+	})
+}
+
+func TestSingleSignOn_CompleteRejects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cases := []struct {
+		name     string
+		state    string
+		subject  string
+		roles    any
+		username string
+		rejected bool
+		want     BusinessCode
+	}{
+		{name: "state mismatch", state: "other", want: CodeSingleSignOnFailed},
+		{name: "rejected token", rejected: true, want: CodeSingleSignOnFailed},
+		{name: "no memberships", roles: []any{"north:farmer"}, want: CodeForbidden},
+		{name: "invalid username", username: "!", want: CodeUsernameInvalid},
+		{name: "password account", username: "bert", want: CodeUsernameTaken},
+		{name: "other subject", username: "carla", want: CodeUsernameTaken},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := newFakeUserStore()
+			users.add("bert", "hashed:correct horse")
+			if _, err := users.CreateUser(ctx, ports.CreateUserRecordInput{Username: "carla", OIDCSubject: "sub-carla"}); err != nil {
+				t.Fatalf("seed user: %v", err)
+			}
+			provider := &fakeIdentityProvider{identity: ports.IdentityClaims{Subject: "sub-1", Claims: map[string]any{
+				"preferred_username": "anna",
+				"barnlog_roles":      []any{"north:worker"},
+			}}}
+			if tc.username != "" {
+				provider.identity.Claims["preferred_username"] = tc.username
+			}
+			if tc.roles != nil {
+				provider.identity.Claims["barnlog_roles"] = tc.roles
+			}
+			if tc.rejected {
+				provider.err = fmt.Errorf("bad signature: %w", ports.ErrIdentityRejected)
+			}
+			sso := NewSingleSignOn(users, newFakeSessionStore(), provider, SingleSignOnConfig{})
+
+			login, err := sso.Begin(ctx)
+			if err != nil {
+				t.Fatalf("begin: %v", err)
+			}
+			state := login.State
+			if tc.state != "" {
+				state = tc.state
+			}
+			if _, err := sso.Complete(ctx, CompleteSingleSignOnInput{Code: "code", State: state, Login: login}); !hasCode(err, tc.want) {
+				t.Fatalf("expected %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_LoginRejectsSingleSignOnUsers(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUserStore()
+	users.add("anna", "")
+	now := time.Now()
+	auth := newTestAuthenticator(users, newFakeSessionStore(), &now)
+
+	if _, err := auth.Login(context.Background(), LoginInput{Username: "anna", Password: ""}); !hasCode(err, CodeInvalidCredentials) {
+		t.Fatalf("expected %q, got %v", CodeInvalidCredentials, err)
+	}
+}
+
+type fakeIdentityProvider struct {
+	identity ports.IdentityClaims
+	err      error
+	verifier string
+	nonce    string
+}
+
+func (f *fakeIdentityProvider) AuthCodeURL(_ context.Context, state, nonce, codeChallenge string) (string, error) {
+	return "https://idp.example/authorize?" + url.Values{
+		"state":          {state},
+		"nonce":          {nonce},
+		"code_challenge": {codeChallenge},
+	}.Encode(), nil
+}
+
+func (f *fakeIdentityProvider) Exchange(_ context.Context, _, codeVerifier, nonce string) (ports.IdentityClaims, error) {
+	f.verifier, f.nonce = codeVerifier, nonce
+	if f.err != nil {
+		return ports.IdentityClaims{}, f.err
+	}
+	return f.identity, nil
+}
+
+var _ ports.IdentityProvider = (*fakeIdentityProvider)(nil)
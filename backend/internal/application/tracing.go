@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "barnlog/backend/internal/application"
+
+// Span attributes set by application services.
+const (
+	attrBarnID    = attribute.Key("barnlog.barn_id")
+	attrErrorCode = attribute.Key("barnlog.error_code")
+	attrReplayed  = attribute.Key("barnlog.replayed")
+	attrSource    = attribute.Key("barnlog.source")
+	attrRequestID = attribute.Key("barnlog.request_id")
+)
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span, marking it failed for unexpected errors. A
+// BusinessError is an expected outcome, so only its code is recorded.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		if be, ok := AsBusinessError(err); ok {
+			span.SetAttributes(attrErrorCode.String(string(be.Code)))
+		} else {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"barnlog/backend/internal/domain"
+)
+
+// TestCreateAnimalWriterSpans installs a global tracer provider, so it must not run in parallel.
+func TestCreateAnimalWriterSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	_, err := NewCreateAnimalWriter(&fakeAnimalWriteStore{photoExists: true}, nil).Create(roleContext(domain.RoleOwner), CreateAnimalInput{
+		Name:    "Nanny",
+		Species: "goat",
+		PhotoID: "photo_1",
+		Meta: RequestMeta{
+			Source:    "test",
+			RequestID: "req-1",
+			Actor:     "user:test",
+		},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	spans := recorder.Ended()
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	want := []string{"CreateAnimal.FindReplay", "CreateAnimal.PhotoExists", "CreateAnimal.CreateRecord", "CreateAnimal"}
+	if len(names) != len(want) {
+		t.Fatalf("expected spans %v, got %v", want, names)
+	}
+	root := spans[len(spans)-1]
+	for i, span := range spans {
+		if span.Name() != want[i] {
+			t.Fatalf("expected spans %v, got %v", want, names)
+		}
+		if span != root && span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Fatalf("expected %s to be a child of CreateAnimal", span.Name())
+		}
+	}
+
+	_, err = NewCreateAnimalWriter(&fakeAnimalWriteStore{}, nil).Create(roleContext(domain.RoleOwner), CreateAnimalInput{})
+	if _, ok := AsBusinessError(err); !ok {
+		t.Fatalf("expected a business error, got %v", err)
+	}
+	failed := recorder.Ended()[len(spans)]
+	if failed.Status().Code != codes.Unset || !hasSpanAttribute(failed, attrErrorCode, string(CodeNameRequired)) {
+		t.Fatalf("expected an unset status and error code %q, got %v %v", CodeNameRequired, failed.Status(), failed.Attributes())
+	}
+}
+
+func hasSpanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key, value string) bool {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key && attr.Value.AsString() == value {
+			return true
+		}
+	}
+	return false
+}
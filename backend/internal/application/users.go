@@ -0,0 +1,267 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeUsernameInvalid indicates a username outside the allowed character set or length.
+	CodeUsernameInvalid BusinessCode = "username_invalid"
+	// CodeUsernameTaken indicates another user already has the username.
+	CodeUsernameTaken BusinessCode = "username_taken"
+	// CodePasswordTooShort indicates a password below the minimum length.
+	CodePasswordTooShort BusinessCode = "password_too_short"
+	// CodeUserNotFound indicates the user does not exist.
+	CodeUserNotFound BusinessCode = "user_not_found"
+	// CodeRoleInvalid indicates a role other than owner, manager, worker or viewer.
+	CodeRoleInvalid BusinessCode = "role_invalid"
+
+	// MinPasswordLength is the minimum number of characters in a password.
+	MinPasswordLength = 10
+	maxPasswordLength = 256
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,63}$`)
+
+// UserActor returns the actor identity recorded for events appended by a signed-in user.
+func UserActor(username string) string {
+	return "user:" + username
+}
+
+// CreateUserInput registers a user account with Role in BarnID. Role names
+// one of domain.Roles; an empty BarnID means domain.DefaultBarnID and
+// domain.ServerBarnID grants the role in every barn.
+type CreateUserInput struct {
+	Username string
+	Password string
+	BarnID   string
+	Role     string
+	Meta     RequestMeta
+}
+
+// SetPasswordInput replaces the password of an existing user.
+type SetPasswordInput struct {
+	Username string
+	Password string
+	Meta     RequestMeta
+}
+
+// SetRoleInput replaces the role of an existing user in BarnID, adding the
+// membership if the user has none there. BarnID is read as in CreateUserInput.
+type SetRoleInput struct {
+	Username string
+	BarnID   string
+	Role     string
+	Meta     RequestMeta
+}
+
+// UserOutput is a user account without credentials.
+type UserOutput struct {
+	ID          string
+	Username    string
+	Memberships domain.Memberships
+}
+
+// UserManager creates user accounts and manages their passwords and roles.
+// Creating users and setting roles needs PermissionManageUsers in the barn
+// concerned; setting passwords needs it in domain.ServerBarnID. Setting a
+// password signs the user out of every session. Their API tokens and devices
+// keep working: each is a separate credential that never held the password
+// and is revoked on its own.
+type UserManager interface {
+	CreateUser(ctx context.Context, in CreateUserInput) (UserOutput, error)
+	SetPassword(ctx context.Context, in SetPasswordInput) error
+	SetRole(ctx context.Context, in SetRoleInput) error
+}
+
+type userManager struct {
+	store    ports.UserStore
+	sessions ports.SessionStore
+	hasher   ports.PasswordHasher
+}
+
+// NewUserManager builds the user account application service.
+func NewUserManager(store ports.UserStore, sessions ports.SessionStore, hasher ports.PasswordHasher) UserManager {
+	return userManager{store: store, sessions: sessions, hasher: hasher}
+}
+
+func (m userManager) CreateUser(ctx context.Context, in CreateUserInput) (UserOutput, error) {
+	barnID, err := parseMembershipBarnID(in.BarnID)
+	if err != nil {
+		return UserOutput{}, err
+	}
+	if err := Authorize(WithBarn(ctx, barnID), PermissionManageUsers); err != nil {
+		return UserOutput{}, err
+	}
+	username, err := normalizeUsername(in.Username)
+	if err != nil {
+		return UserOutput{}, err
+	}
+	if err := validatePassword(in.Password); err != nil {
+		return UserOutput{}, err
+	}
+	role, err := parseRole(in.Role)
+	if err != nil {
+		return UserOutput{}, err
+	}
+	if err := validateRequestMeta(in.Meta); err != nil {
+		return UserOutput{}, err
+	}
+
+	hash, err := m.hasher.HashPassword(in.Password)
+	if err != nil {
+		return UserOutput{}, fmt.Errorf("hash password: %w", err)
+	}
+	user, err := m.store.CreateUser(ctx, ports.CreateUserRecordInput{
+		Username:     username,
+		PasswordHash: hash,
+		BarnID:       barnID,
+		Role:         role,
+		Source:       in.Meta.Source,
+		RequestID:    in.Meta.RequestID,
+		CreatedBy:    in.Meta.Actor,
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return UserOutput{}, BusinessError{Code: CodeUsernameTaken, Err: err}
+		}
+		return UserOutput{}, fmt.Errorf("create user: %w", err)
+	}
+	return UserOutput{ID: user.ID, Username: user.Username, Memberships: user.Memberships}, nil
+}
+
+func (m userManager) SetPassword(ctx context.Context, in SetPasswordInput) error {
+	if err := Authorize(WithBarn(ctx, domain.ServerBarnID), PermissionManageUsers); err != nil {
+		return err
+	}
+	username, err := normalizeUsername(in.Username)
+	if err != nil {
+		return err
+	}
+	if err := validatePassword(in.Password); err != nil {
+		return err
+	}
+	if err := validateRequestMeta(in.Meta); err != nil {
+		return err
+	}
+
+	user, found, err := m.store.FindUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("find user: %w", err)
+	}
+	if !found {
+		return BusinessError{Code: CodeUserNotFound, Err: fmt.Errorf("user %q not found", username)}
+	}
+
+	hash, err := m.hasher.HashPassword(in.Password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	if err := m.store.ChangePassword(ctx, ports.ChangePasswordRecordInput{
+		UserID:       user.ID,
+		PasswordHash: hash,
+		Source:       in.Meta.Source,
+		RequestID:    in.Meta.RequestID,
+		CreatedBy:    in.Meta.Actor,
+	}); err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return BusinessError{Code: CodeConflict, Err: err}
+		}
+		return fmt.Errorf("change password: %w", err)
+	}
+	if err := m.sessions.DeleteUserSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("sign out user %s: %w", user.ID, err)
+	}
+	return nil
+}
+
+func (m userManager) SetRole(ctx context.Context, in SetRoleInput) error {
+	barnID, err := parseMembershipBarnID(in.BarnID)
+	if err != nil {
+		return err
+	}
+	if err := Authorize(WithBarn(ctx, barnID), PermissionManageUsers); err != nil {
+		return err
+	}
+	username, err := normalizeUsername(in.Username)
+	if err != nil {
+		return err
+	}
+	role, err := parseRole(in.Role)
+	if err != nil {
+		return err
+	}
+	if err := validateRequestMeta(in.Meta); err != nil {
+		return err
+	}
+
+	user, found, err := m.store.FindUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("find user: %w", err)
+	}
+	if !found {
+		return BusinessError{Code: CodeUserNotFound, Err: fmt.Errorf("user %q not found", username)}
+	}
+	if err := m.store.ChangeRole(ctx, ports.ChangeRoleRecordInput{
+		UserID:    user.ID,
+		BarnID:    barnID,
+		Role:      role,
+		Source:    in.Meta.Source,
+		RequestID: in.Meta.RequestID,
+		CreatedBy: in.Meta.Actor,
+	}); err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return BusinessError{Code: CodeConflict, Err: err}
+		}
+		return fmt.Errorf("change role: %w", err)
+	}
+	return nil
+}
+
+// normalizeUsername lower-cases and trims a username and checks it against usernamePattern.
+func normalizeUsername(username string) (string, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if !usernamePattern.MatchString(username) {
+		return "", BusinessError{
+			Code: CodeUsernameInvalid,
+			Err:  errors.New("username must be 2-64 characters of a-z, 0-9, '.', '_' or '-'"),
+		}
+	}
+	return username, nil
+}
+
+func parseRole(name string) (domain.Role, error) {
+	role, ok := domain.ParseRole(strings.ToLower(strings.TrimSpace(name)))
+	if !ok {
+		return "", BusinessError{
+			Code: CodeRoleInvalid,
+			Err:  fmt.Errorf("role must be one of owner, manager, worker or viewer, got %q", name),
+		}
+	}
+	return role, nil
+}
+
+func validatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength {
+		return BusinessError{
+			Code: CodePasswordTooShort,
+			Err:  fmt.Errorf("password must be at least %d characters", MinPasswordLength),
+		}
+	}
+	if length > maxPasswordLength {
+		return BusinessError{
+			Code: CodeInvalidInput,
+			Err:  fmt.Errorf("password must be at most %d characters", maxPasswordLength),
+		}
+	}
+	return nil
+}
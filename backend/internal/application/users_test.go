@@ -0,0 +1,237 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+var testUserMeta = RequestMeta{Source: "cli", RequestID: "req-1", Actor: ServiceActor("cli")}
+
+func TestUserManager_CreateUser(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeUserStore()
+	out, err := NewUserManager(store, newFakeSessionStore(), fakePasswordHasher{}).CreateUser(roleContext(domain.RoleOwner), CreateUserInput{
+		Username: " Anna ",
+		Password: "correct horse",
+		Role:     "Worker",
+		Meta:     testUserMeta,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Username != "anna" || out.ID == "" || out.Memberships[domain.DefaultBarnID] != domain.RoleWorker {
+		t.Fatalf("unexpected output %+v", out)
+	}
+	if got := store.users[out.ID].PasswordHash; got != "hashed:correct horse" {
+		t.Fatalf("expected hashed password to be stored, got %q", got)
+	}
+}
+
+func TestUserManager_CreateUserErrors(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeUserStore()
+	store.add("anna", "hashed:correct horse")
+	manager := NewUserManager(store, newFakeSessionStore(), fakePasswordHasher{})
+
+	tests := []struct {
+		name string
+		in   CreateUserInput
+		code BusinessCode
+	}{
+		{"empty username", CreateUserInput{Password: "correct horse", Role: "viewer", Meta: testUserMeta}, CodeUsernameInvalid},
+		{"username with spaces", CreateUserInput{Username: "an na", Password: "correct horse", Role: "viewer", Meta: testUserMeta}, CodeUsernameInvalid},
+		{"short password", CreateUserInput{Username: "bert", Password: "short", Role: "viewer", Meta: testUserMeta}, CodePasswordTooShort},
+		{"missing role", CreateUserInput{Username: "bert", Password: "correct horse", Meta: testUserMeta}, CodeRoleInvalid},
+		{"unknown role", CreateUserInput{Username: "bert", Password: "correct horse", Role: "admin", Meta: testUserMeta}, CodeRoleInvalid},
+		{"taken username", CreateUserInput{Username: "ANNA", Password: "correct horse", Role: "viewer", Meta: testUserMeta}, CodeUsernameTaken},
+		{"missing meta", CreateUserInput{Username: "bert", Password: "correct horse", Role: "viewer"}, CodeInvalidInput},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := manager.CreateUser(roleContext(domain.RoleOwner), tc.in)
+			be, ok := AsBusinessError(err)
+			if !ok || be.Code != tc.code {
+				t.Fatalf("expected %q, got %v", tc.code, err)
+			}
+		})
+	}
+}
+
+func TestUserManager_SetPassword(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeUserStore()
+	user := store.add("anna", "hashed:old password")
+	other := store.add("bert", "hashed:correct horse")
+	sessions := newFakeSessionStore()
+	sessions.sessions["laptop"] = ports.Session{TokenHash: "laptop", UserID: user.ID}
+	sessions.sessions["phone"] = ports.Session{TokenHash: "phone", UserID: user.ID}
+	sessions.sessions["bert"] = ports.Session{TokenHash: "bert", UserID: other.ID}
+	manager := NewUserManager(store, sessions, fakePasswordHasher{})
+
+	if err := manager.SetPassword(roleContext(domain.RoleOwner), SetPasswordInput{
+		Username: "anna",
+		Password: "new password",
+		Meta:     testUserMeta,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.users[user.ID].PasswordHash; got != "hashed:new password" {
+		t.Fatalf("expected new hash, got %q", got)
+	}
+	if got := slices.Sorted(maps.Keys(sessions.sessions)); !slices.Equal(got, []string{"bert"}) {
+		t.Fatalf("expected only the other user's session to remain, got %v", got)
+	}
+
+	err := manager.SetPassword(roleContext(domain.RoleOwner), SetPasswordInput{
+		Username: "carla",
+		Password: "new password",
+		Meta:     testUserMeta,
+	})
+	if be, ok := AsBusinessError(err); !ok || be.Code != CodeUserNotFound {
+		t.Fatalf("expected %q, got %v", CodeUserNotFound, err)
+	}
+}
+
+func TestUserManager_SetRole(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeUserStore()
+	user := store.add("sam", "hashed:correct horse")
+	manager := NewUserManager(store, newFakeSessionStore(), fakePasswordHasher{})
+
+	if err := manager.SetRole(roleContext(domain.RoleOwner), SetRoleInput{
+		Username: "sam",
+		Role:     "worker",
+		Meta:     testUserMeta,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.users[user.ID].Memberships[domain.DefaultBarnID]; got != domain.RoleWorker {
+		t.Fatalf("expected role %q, got %q", domain.RoleWorker, got)
+	}
+
+	if err := manager.SetRole(barnRoleContext("north", domain.RoleOwner), SetRoleInput{
+		Username: "sam",
+		BarnID:   "north",
+		Role:     "viewer",
+		Meta:     testUserMeta,
+	}); err != nil {
+		t.Fatalf("expected a barn owner to set roles in the barn, got %v", err)
+	}
+	if got := store.users[user.ID].Memberships["north"]; got != domain.RoleViewer {
+		t.Fatalf("expected role %q in north, got %q", domain.RoleViewer, got)
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		in   SetRoleInput
+		code BusinessCode
+	}{
+		{"manager", roleContext(domain.RoleManager), SetRoleInput{Username: "sam", Role: "owner", Meta: testUserMeta}, CodeForbidden},
+		{"owner of another barn", barnRoleContext("north", domain.RoleOwner), SetRoleInput{Username: "sam", BarnID: "south", Role: "owner", Meta: testUserMeta}, CodeForbidden},
+		{"server membership from a barn owner", barnRoleContext("north", domain.RoleOwner), SetRoleInput{Username: "sam", BarnID: domain.ServerBarnID, Role: "owner", Meta: testUserMeta}, CodeForbidden},
+		{"malformed barn", roleContext(domain.RoleOwner), SetRoleInput{Username: "sam", BarnID: "../north", Role: "owner", Meta: testUserMeta}, CodeBarnInvalid},
+		{"unknown role", roleContext(domain.RoleOwner), SetRoleInput{Username: "sam", Role: "admin", Meta: testUserMeta}, CodeRoleInvalid},
+		{"unknown user", roleContext(domain.RoleOwner), SetRoleInput{Username: "bert", Role: "viewer", Meta: testUserMeta}, CodeUserNotFound},
+	} {
+		if err := manager.SetRole(tc.ctx, tc.in); !hasCode(err, tc.code) {
+			t.Fatalf("%s: expected %q, got %v", tc.name, tc.code, err)
+		}
+	}
+	if got := store.users[user.ID].Memberships[domain.DefaultBarnID]; got != domain.RoleWorker {
+		t.Fatalf("expected rejected changes to keep role %q, got %q", domain.RoleWorker, got)
+	}
+}
+
+type fakePasswordHasher struct{}
+
+func (fakePasswordHasher) HashPassword(password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (fakePasswordHasher) VerifyPassword(hash, password string) (bool, error) {
+	if !strings.HasPrefix(hash, "hashed:") {
+		return false, errors.New("malformed hash")
+	}
+	return hash == "hashed:"+password, nil
+}
+
+type fakeUserStore struct {
+	users map[string]domain.User
+}
+
+func newFakeUserStore() *fakeUserStore {
+	return &fakeUserStore{users: map[string]domain.User{}}
+}
+
+func (f *fakeUserStore) add(username, hash string) domain.User {
+	user := domain.User{ID: fmt.Sprintf("u%d", len(f.users)+1), Username: username, PasswordHash: hash}
+	f.users[user.ID] = user
+	return user
+}
+
+func (f *fakeUserStore) CreateUser(_ context.Context, in ports.CreateUserRecordInput) (domain.User, error) {
+	if _, found, _ := f.FindUserByUsername(context.Background(), in.Username); found {
+		return domain.User{}, ports.ErrConflict
+	}
+	user := f.add(in.Username, in.PasswordHash)
+	user.OIDCSubject = in.OIDCSubject
+	user.Memberships = domain.Memberships{in.BarnID: in.Role}
+	f.users[user.ID] = user
+	return user, nil
+}
+
+func (f *fakeUserStore) ChangePassword(_ context.Context, in ports.ChangePasswordRecordInput) error {
+	user := f.users[in.UserID]
+	user.PasswordHash = in.PasswordHash
+	f.users[in.UserID] = user
+	return nil
+}
+
+func (f *fakeUserStore) ChangeRole(_ context.Context, in ports.ChangeRoleRecordInput) error {
+	user := f.users[in.UserID]
+	user.Memberships = maps.Clone(user.Memberships)
+	if user.Memberships == nil {
+		user.Memberships = domain.Memberships{}
+	}
+	if in.Role == "" {
+		delete(user.Memberships, in.BarnID)
+	} else {
+		user.Memberships[in.BarnID] = in.Role
+	}
+	f.users[in.UserID] = user
+	return nil
+}
+
+func (f *fakeUserStore) GetUser(_ context.Context, userID string) (domain.User, bool, error) {
+	user, ok := f.users[userID]
+	return user, ok, nil
+}
+
+func (f *fakeUserStore) FindUserByUsername(_ context.Context, username string) (domain.User, bool, error) {
+	for _, user := range f.users {
+		if user.Username == username {
+			return user, true, nil
+		}
+	}
+	return domain.User{}, false, nil
+}
+
+var (
+	_ ports.UserStore      = (*fakeUserStore)(nil)
+	_ ports.PasswordHasher = fakePasswordHasher{}
+)
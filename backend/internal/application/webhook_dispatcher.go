@@ -0,0 +1,243 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	defaultWebhookBatchSize   = 100
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBaseBackoff = 10 * time.Second
+	defaultWebhookMaxBackoff  = time.Hour
+)
+
+// WebhookDispatcherConfig tunes delivery retries. Zero values use the defaults:
+// 100 per batch, 8 attempts, backoff doubling from 10s up to 1h.
+type WebhookDispatcherConfig struct {
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// WebhookDispatchResult counts the work done by one dispatch pass.
+type WebhookDispatchResult struct {
+	Scheduled int
+	Delivered int
+	Retrying  int
+	Dead      int
+}
+
+// WebhookDispatcher fans outbox events out to matching subscriptions and
+// delivers them with exponential backoff. Deliveries that keep failing after
+// MaxAttempts are moved to the dead state and no longer retried.
+type WebhookDispatcher struct {
+	outbox   ports.WebhookOutboxStore
+	webhooks ports.WebhookStore
+	sender   ports.WebhookSender
+	cfg      WebhookDispatcherConfig
+	now      func() time.Time
+}
+
+// webhookBody is the JSON document POSTed to subscribers.
+type webhookBody struct {
+	DeliveryID int64            `json:"delivery_id"`
+	WebhookID  string           `json:"webhook_id"`
+	Attempt    int              `json:"attempt"`
+	Event      webhookEventBody `json:"event"`
+}
+
+type webhookEventBody struct {
+	Position      int64           `json:"position"`
+	EventID       string          `json:"event_id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	CreatedBy     string          `json:"created_by"`
+	OccurredAt    string          `json:"occurred_at"`
+	RecordedAt    string          `json:"recorded_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// NewWebhookDispatcher builds the webhook dispatcher.
+func NewWebhookDispatcher(
+	outbox ports.WebhookOutboxStore,
+	webhooks ports.WebhookStore,
+	sender ports.WebhookSender,
+	cfg WebhookDispatcherConfig,
+) *WebhookDispatcher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaultWebhookBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	return &WebhookDispatcher{
+		outbox:   outbox,
+		webhooks: webhooks,
+		sender:   sender,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+}
+
+// DispatchOnce schedules deliveries for pending outbox events and attempts
+// every delivery that is due. It stops at the first storage error; failed
+// sends are recorded on the delivery instead of being returned.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (WebhookDispatchResult, error) {
+	var result WebhookDispatchResult
+
+	scheduled, err := d.scheduleOutbox(ctx)
+	result.Scheduled = scheduled
+	if err != nil {
+		return result, err
+	}
+
+	now := d.now()
+	deliveries, err := d.outbox.ListDueWebhookDeliveries(ctx, now, d.cfg.BatchSize)
+	if err != nil {
+		return result, fmt.Errorf("list due webhook deliveries: %w", err)
+	}
+	for _, delivery := range deliveries {
+		status, err := d.deliver(ctx, delivery)
+		if err != nil {
+			return result, err
+		}
+		switch status {
+		case ports.WebhookDeliveryDelivered:
+			result.Delivered++
+		case ports.WebhookDeliveryDead:
+			result.Dead++
+		default:
+			result.Retrying++
+		}
+	}
+	return result, nil
+}
+
+func (d *WebhookDispatcher) scheduleOutbox(ctx context.Context) (int, error) {
+	records, err := d.outbox.ListPendingOutbox(ctx, d.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list pending outbox: %w", err)
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	// Webhooks only see events of their own barn.
+	webhooksByBarn := make(map[string][]ports.Webhook)
+	scheduled := 0
+	for _, record := range records {
+		webhooks, ok := webhooksByBarn[record.BarnID]
+		if !ok {
+			webhooks, err = d.webhooks.ListWebhooks(ctx, record.BarnID)
+			if err != nil {
+				return scheduled, fmt.Errorf("list webhooks of barn %s: %w", record.BarnID, err)
+			}
+			webhooksByBarn[record.BarnID] = webhooks
+		}
+		var webhookIDs []string
+		for _, webhook := range webhooks {
+			if webhookMatches(webhook, record.EventType) {
+				webhookIDs = append(webhookIDs, webhook.ID)
+			}
+		}
+		if err := d.outbox.ScheduleWebhookDeliveries(ctx, record.ID, webhookIDs, d.now()); err != nil {
+			return scheduled, fmt.Errorf("schedule webhook deliveries for event %s: %w", record.ID, err)
+		}
+		scheduled += len(webhookIDs)
+	}
+	return scheduled, nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery ports.WebhookDelivery) (ports.WebhookDeliveryStatus, error) {
+	attempt := delivery.Attempts + 1
+	sendErr := d.send(ctx, delivery, attempt)
+
+	outcome := ports.WebhookDeliveryAttempt{
+		DeliveryID:    delivery.ID,
+		Status:        ports.WebhookDeliveryDelivered,
+		Attempts:      attempt,
+		NextAttemptAt: d.now(),
+	}
+	if sendErr != nil {
+		outcome.LastError = sendErr.Error()
+		if attempt >= d.cfg.MaxAttempts {
+			outcome.Status = ports.WebhookDeliveryDead
+		} else {
+			outcome.Status = ports.WebhookDeliveryPending
+			outcome.NextAttemptAt = outcome.NextAttemptAt.Add(d.backoff(attempt))
+		}
+	}
+
+	if err := d.outbox.RecordWebhookDeliveryAttempt(ctx, outcome); err != nil {
+		return "", fmt.Errorf("record webhook delivery %d: %w", delivery.ID, err)
+	}
+	return outcome.Status, nil
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery ports.WebhookDelivery, attempt int) error {
+	event, err := feedEventFromRecord(delivery.Event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(webhookBody{
+		DeliveryID: delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		Attempt:    attempt,
+		Event: webhookEventBody{
+			Position:      event.Position,
+			EventID:       event.EventID,
+			AggregateType: event.AggregateType,
+			AggregateID:   event.AggregateID,
+			EventType:     event.EventType,
+			CreatedBy:     event.CreatedBy,
+			OccurredAt:    event.OccurredAt,
+			RecordedAt:    event.RecordedAt,
+			Payload:       event.Payload,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook body: %w", err)
+	}
+
+	return d.sender.SendWebhook(ctx, ports.WebhookRequest{
+		URL:        delivery.URL,
+		Secret:     delivery.Secret,
+		DeliveryID: delivery.ID,
+		EventID:    delivery.Event.ID,
+		Body:       body,
+	})
+}
+
+// backoff returns the delay after the given failed attempt: BaseBackoff doubled
+// per earlier attempt, capped at MaxBackoff.
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
+
+func webhookMatches(webhook ports.Webhook, eventType string) bool {
+	if !webhook.Active {
+		return false
+	}
+	return len(webhook.EventTypes) == 0 || slices.Contains(webhook.EventTypes, eventType)
+}
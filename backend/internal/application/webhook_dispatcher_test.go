@@ -0,0 +1,189 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestWebhookDispatcher_SchedulesMatchingSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	outbox := &fakeWebhookOutboxStore{
+		pending: []ports.EventRecord{{ID: "e1", EventType: "animal.created", EventVersion: 1, PayloadJSON: `{}`}},
+	}
+	webhooks := &fakeWebhookStore{webhooks: map[string]ports.Webhook{
+		"all":      {ID: "all", Active: true},
+		"created":  {ID: "created", Active: true, EventTypes: []string{"animal.created"}},
+		"voided":   {ID: "voided", Active: true, EventTypes: []string{"event.voided"}},
+		"inactive": {ID: "inactive", Active: false},
+	}}
+
+	result, err := NewWebhookDispatcher(outbox, webhooks, &fakeWebhookSender{}, WebhookDispatcherConfig{}).
+		DispatchOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Scheduled != 2 {
+		t.Fatalf("expected 2 scheduled deliveries, got %d", result.Scheduled)
+	}
+	if !slices.Equal(outbox.scheduled["e1"], []string{"all", "created"}) {
+		t.Fatalf("unexpected scheduled subscriptions %v", outbox.scheduled["e1"])
+	}
+}
+
+func TestWebhookDispatcher_DeliversSignedBody(t *testing.T) {
+	t.Parallel()
+
+	outbox := &fakeWebhookOutboxStore{
+		due: []ports.WebhookDelivery{{
+			ID:        9,
+			WebhookID: "w1",
+			URL:       "https://example.test/hook",
+			Secret:    "whsec_x",
+			Event: ports.EventRecord{
+				Position:     3,
+				ID:           "e3",
+				EventType:    "animal.created",
+				EventVersion: 1,
+				PayloadJSON:  `{"name":"Nanny"}`,
+			},
+		}},
+	}
+	sender := &fakeWebhookSender{}
+
+	result, err := NewWebhookDispatcher(outbox, &fakeWebhookStore{}, sender, WebhookDispatcherConfig{}).
+		DispatchOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Delivered != 1 {
+		t.Fatalf("expected 1 delivered, got %+v", result)
+	}
+	if len(sender.requests) != 1 || sender.requests[0].Secret != "whsec_x" || sender.requests[0].EventID != "e3" {
+		t.Fatalf("unexpected requests %+v", sender.requests)
+	}
+
+	var body struct {
+		DeliveryID int64 `json:"delivery_id"`
+		Attempt    int   `json:"attempt"`
+		Event      struct {
+			Position int64           `json:"position"`
+			Payload  json.RawMessage `json:"payload"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(sender.requests[0].Body, &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.DeliveryID != 9 || body.Attempt != 1 || body.Event.Position != 3 || string(body.Event.Payload) != `{"name":"Nanny"}` {
+		t.Fatalf("unexpected body %s", sender.requests[0].Body)
+	}
+	if got := outbox.attempts[0]; got.Status != ports.WebhookDeliveryDelivered || got.Attempts != 1 {
+		t.Fatalf("unexpected recorded attempt %+v", got)
+	}
+}
+
+func TestWebhookDispatcher_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name     string
+		attempts int
+		status   ports.WebhookDeliveryStatus
+		next     time.Time
+	}{
+		{name: "first failure", attempts: 0, status: ports.WebhookDeliveryPending, next: now.Add(10 * time.Second)},
+		{name: "third failure", attempts: 2, status: ports.WebhookDeliveryPending, next: now.Add(40 * time.Second)},
+		{name: "backoff is capped", attempts: 6, status: ports.WebhookDeliveryPending, next: now.Add(5 * time.Minute)},
+		{name: "last attempt", attempts: 7, status: ports.WebhookDeliveryDead, next: now},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			outbox := &fakeWebhookOutboxStore{
+				due: []ports.WebhookDelivery{{
+					ID:       1,
+					Attempts: tc.attempts,
+					Event:    ports.EventRecord{ID: "e1", EventType: "animal.created", EventVersion: 1, PayloadJSON: `{}`},
+				}},
+			}
+			d := NewWebhookDispatcher(
+				outbox,
+				&fakeWebhookStore{},
+				&fakeWebhookSender{err: errors.New("connection refused")},
+				WebhookDispatcherConfig{MaxAttempts: 8, BaseBackoff: 10 * time.Second, MaxBackoff: 5 * time.Minute},
+			)
+			d.now = func() time.Time { return now }
+
+			if _, err := d.DispatchOnce(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := outbox.attempts[0]
+			if got.Status != tc.status || got.Attempts != tc.attempts+1 {
+				t.Fatalf("expected %s after attempt %d, got %+v", tc.status, tc.attempts+1, got)
+			}
+			if !got.NextAttemptAt.Equal(tc.next) {
+				t.Fatalf("expected next attempt at %s, got %s", tc.next, got.NextAttemptAt)
+			}
+			if got.LastError != "connection refused" {
+				t.Fatalf("expected last error to be recorded, got %q", got.LastError)
+			}
+		})
+	}
+}
+
+type fakeWebhookOutboxStore struct {
+	pending   []ports.EventRecord
+	due       []ports.WebhookDelivery
+	scheduled map[string][]string
+	attempts  []ports.WebhookDeliveryAttempt
+}
+
+func (f *fakeWebhookOutboxStore) ListPendingOutbox(context.Context, int) ([]ports.EventRecord, error) {
+	return f.pending, nil
+}
+
+func (f *fakeWebhookOutboxStore) ScheduleWebhookDeliveries(
+	_ context.Context,
+	eventID string,
+	webhookIDs []string,
+	_ time.Time,
+) error {
+	if f.scheduled == nil {
+		f.scheduled = map[string][]string{}
+	}
+	f.scheduled[eventID] = webhookIDs
+	return nil
+}
+
+func (f *fakeWebhookOutboxStore) ListDueWebhookDeliveries(context.Context, time.Time, int) ([]ports.WebhookDelivery, error) {
+	return f.due, nil
+}
+
+func (f *fakeWebhookOutboxStore) RecordWebhookDeliveryAttempt(_ context.Context, attempt ports.WebhookDeliveryAttempt) error {
+	f.attempts = append(f.attempts, attempt)
+	return nil
+}
+
+type fakeWebhookSender struct {
+	err      error
+	requests []ports.WebhookRequest
+}
+
+func (f *fakeWebhookSender) SendWebhook(_ context.Context, req ports.WebhookRequest) error {
+	f.requests = append(f.requests, req)
+	return f.err
+}
+
+var (
+	_ ports.WebhookOutboxStore = (*fakeWebhookOutboxStore)(nil)
+	_ ports.WebhookSender      = (*fakeWebhookSender)(nil)
+)
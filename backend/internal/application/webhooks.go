@@ -0,0 +1,229 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// CodeWebhookNotFound indicates the webhook subscription does not exist.
+	CodeWebhookNotFound BusinessCode = "webhook_not_found"
+	// CodeWebhookURLInvalid indicates the webhook URL is not an absolute http(s) URL.
+	CodeWebhookURLInvalid BusinessCode = "webhook_url_invalid"
+	// CodeWebhookEventTypeInvalid indicates an event type filter names an unknown event type.
+	CodeWebhookEventTypeInvalid BusinessCode = "webhook_event_type_invalid"
+
+	webhookSecretBytes = 32
+)
+
+// CreateWebhookInput registers a subscription. Nil Active defaults to true;
+// empty EventTypes subscribes to every event type.
+type CreateWebhookInput struct {
+	URL        string
+	EventTypes []string
+	Active     *bool
+}
+
+// UpdateWebhookInput replaces the URL, filter and active flag of a subscription.
+type UpdateWebhookInput struct {
+	WebhookID  string
+	URL        string
+	EventTypes []string
+	Active     bool
+}
+
+// WebhookOutput is a webhook subscription. Secret is only set when the
+// subscription is created; it is never returned afterwards.
+type WebhookOutput struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	Active     bool
+	Secret     string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// WebhookManager manages webhook subscriptions.
+type WebhookManager interface {
+	CreateWebhook(ctx context.Context, in CreateWebhookInput) (WebhookOutput, error)
+	GetWebhook(ctx context.Context, webhookID string) (WebhookOutput, error)
+	ListWebhooks(ctx context.Context) ([]WebhookOutput, error)
+	UpdateWebhook(ctx context.Context, in UpdateWebhookInput) (WebhookOutput, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+}
+
+type webhookManager struct {
+	store ports.WebhookStore
+}
+
+// NewWebhookManager builds the webhook subscription application service.
+func NewWebhookManager(store ports.WebhookStore) WebhookManager {
+	return webhookManager{store: store}
+}
+
+func (m webhookManager) CreateWebhook(ctx context.Context, in CreateWebhookInput) (WebhookOutput, error) {
+	if err := Authorize(ctx, PermissionManageWebhooks); err != nil {
+		return WebhookOutput{}, err
+	}
+	webhookURL, eventTypes, err := normalizeWebhookInput(in.URL, in.EventTypes)
+	if err != nil {
+		return WebhookOutput{}, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return WebhookOutput{}, err
+	}
+	active := true
+	if in.Active != nil {
+		active = *in.Active
+	}
+
+	webhook, err := m.store.CreateWebhook(ctx, ports.WebhookRecordInput{
+		BarnID:     BarnFromContext(ctx),
+		URL:        webhookURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     active,
+	})
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("create webhook: %w", err)
+	}
+
+	out := webhookOutput(webhook)
+	out.Secret = webhook.Secret
+	return out, nil
+}
+
+func (m webhookManager) GetWebhook(ctx context.Context, webhookID string) (WebhookOutput, error) {
+	if err := Authorize(ctx, PermissionManageWebhooks); err != nil {
+		return WebhookOutput{}, err
+	}
+	webhook, found, err := m.store.GetWebhook(ctx, BarnFromContext(ctx), strings.TrimSpace(webhookID))
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("get webhook: %w", err)
+	}
+	if !found {
+		return WebhookOutput{}, errWebhookNotFound()
+	}
+	return webhookOutput(webhook), nil
+}
+
+func (m webhookManager) ListWebhooks(ctx context.Context) ([]WebhookOutput, error) {
+	if err := Authorize(ctx, PermissionManageWebhooks); err != nil {
+		return nil, err
+	}
+	webhooks, err := m.store.ListWebhooks(ctx, BarnFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+
+	out := make([]WebhookOutput, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		out = append(out, webhookOutput(webhook))
+	}
+	return out, nil
+}
+
+func (m webhookManager) UpdateWebhook(ctx context.Context, in UpdateWebhookInput) (WebhookOutput, error) {
+	if err := Authorize(ctx, PermissionManageWebhooks); err != nil {
+		return WebhookOutput{}, err
+	}
+	webhookURL, eventTypes, err := normalizeWebhookInput(in.URL, in.EventTypes)
+	if err != nil {
+		return WebhookOutput{}, err
+	}
+
+	webhook, found, err := m.store.UpdateWebhook(ctx, strings.TrimSpace(in.WebhookID), ports.WebhookRecordInput{
+		BarnID:     BarnFromContext(ctx),
+		URL:        webhookURL,
+		EventTypes: eventTypes,
+		Active:     in.Active,
+	})
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("update webhook: %w", err)
+	}
+	if !found {
+		return WebhookOutput{}, errWebhookNotFound()
+	}
+	return webhookOutput(webhook), nil
+}
+
+func (m webhookManager) DeleteWebhook(ctx context.Context, webhookID string) error {
+	if err := Authorize(ctx, PermissionManageWebhooks); err != nil {
+		return err
+	}
+	deleted, err := m.store.DeleteWebhook(ctx, BarnFromContext(ctx), strings.TrimSpace(webhookID))
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	if !deleted {
+		return errWebhookNotFound()
+	}
+	return nil
+}
+
+// normalizeWebhookInput validates the URL and returns the trimmed, de-duplicated event type filter.
+func normalizeWebhookInput(rawURL string, eventTypes []string) (string, []string, error) {
+	webhookURL := strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", nil, BusinessError{
+			Code: CodeWebhookURLInvalid,
+			Err:  errors.New("url must be an absolute http or https URL"),
+		}
+	}
+
+	normalized := make([]string, 0, len(eventTypes))
+	seen := make(map[string]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !domain.IsKnownEventType(eventType) {
+			return "", nil, BusinessError{
+				Code: CodeWebhookEventTypeInvalid,
+				Err:  fmt.Errorf("unknown event type %q", eventType),
+			}
+		}
+		if _, ok := seen[eventType]; ok {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		normalized = append(normalized, eventType)
+	}
+	return webhookURL, normalized, nil
+}
+
+func webhookOutput(webhook ports.Webhook) WebhookOutput {
+	eventTypes := webhook.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return WebhookOutput{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		Active:     webhook.Active,
+		CreatedAt:  webhook.CreatedAt,
+		UpdatedAt:  webhook.UpdatedAt,
+	}
+}
+
+func errWebhookNotFound() error {
+	return BusinessError{Code: CodeWebhookNotFound, Err: errors.New("webhook not found")}
+}
+
+func newWebhookSecret() (string, error) {
+	var secret [webhookSecretBytes]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(secret[:]), nil
+}
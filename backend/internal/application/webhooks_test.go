@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestWebhookManager_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeWebhookStore{}
+	out, err := NewWebhookManager(store).CreateWebhook(roleContext(domain.RoleOwner), CreateWebhookInput{
+		URL:        " https://example.test/hook ",
+		EventTypes: []string{"animal.created", " animal.created "},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.created.URL != "https://example.test/hook" {
+		t.Fatalf("expected trimmed URL, got %q", store.created.URL)
+	}
+	if !slices.Equal(store.created.EventTypes, []string{"animal.created"}) {
+		t.Fatalf("expected de-duplicated event types, got %v", store.created.EventTypes)
+	}
+	if !store.created.Active {
+		t.Fatalf("expected new webhook to default to active")
+	}
+	if !strings.HasPrefix(out.Secret, "whsec_") || out.Secret != store.created.Secret {
+		t.Fatalf("expected generated secret in output, got %q", out.Secret)
+	}
+}
+
+func TestWebhookManager_GetWebhookHidesSecret(t *testing.T) {
+	t.Parallel()
+
+	store := &fakeWebhookStore{webhooks: map[string]ports.Webhook{
+		"w1": {ID: "w1", BarnID: domain.DefaultBarnID, URL: "https://example.test/hook", Secret: "whsec_x", Active: true},
+	}}
+	out, err := NewWebhookManager(store).GetWebhook(roleContext(domain.RoleOwner), "w1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Secret != "" {
+		t.Fatalf("expected secret to be omitted, got %q", out.Secret)
+	}
+	if out.EventTypes == nil {
+		t.Fatalf("expected empty, non-nil event types")
+	}
+}
+
+func TestWebhookManager_ValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   CreateWebhookInput
+		code BusinessCode
+	}{
+		{name: "empty url", in: CreateWebhookInput{URL: ""}, code: CodeWebhookURLInvalid},
+		{name: "relative url", in: CreateWebhookInput{URL: "/hook"}, code: CodeWebhookURLInvalid},
+		{name: "unsupported scheme", in: CreateWebhookInput{URL: "ftp://example.test/hook"}, code: CodeWebhookURLInvalid},
+		{
+			name: "unknown event type",
+			in:   CreateWebhookInput{URL: "https://example.test/hook", EventTypes: []string{"animal.sold"}},
+			code: CodeWebhookEventTypeInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewWebhookManager(&fakeWebhookStore{}).CreateWebhook(roleContext(domain.RoleOwner), tc.in)
+			be, ok := AsBusinessError(err)
+			if !ok || be.Code != tc.code {
+				t.Fatalf("expected %q, got %v", tc.code, err)
+			}
+		})
+	}
+}
+
+func TestWebhookManager_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := NewWebhookManager(&fakeWebhookStore{})
+	ctx := roleContext(domain.RoleOwner)
+
+	_, getErr := m.GetWebhook(ctx, "missing")
+	_, updateErr := m.UpdateWebhook(ctx, UpdateWebhookInput{WebhookID: "missing", URL: "https://example.test/hook"})
+	deleteErr := m.DeleteWebhook(ctx, "missing")
+
+	for _, err := range []error{getErr, updateErr, deleteErr} {
+		be, ok := AsBusinessError(err)
+		if !ok || be.Code != CodeWebhookNotFound {
+			t.Fatalf("expected %q, got %v", CodeWebhookNotFound, err)
+		}
+	}
+}
+
+type fakeWebhookStore struct {
+	webhooks map[string]ports.Webhook
+	created  ports.WebhookRecordInput
+}
+
+func (f *fakeWebhookStore) CreateWebhook(_ context.Context, in ports.WebhookRecordInput) (ports.Webhook, error) {
+	f.created = in
+	return ports.Webhook{
+		ID:         "w1",
+		BarnID:     in.BarnID,
+		URL:        in.URL,
+		Secret:     in.Secret,
+		EventTypes: in.EventTypes,
+		Active:     in.Active,
+	}, nil
+}
+
+func (f *fakeWebhookStore) GetWebhook(_ context.Context, barnID, webhookID string) (ports.Webhook, bool, error) {
+	webhook, ok := f.webhooks[webhookID]
+	if !ok || webhook.BarnID != barnID {
+		return ports.Webhook{}, false, nil
+	}
+	return webhook, true, nil
+}
+
+func (f *fakeWebhookStore) ListWebhooks(_ context.Context, barnID string) ([]ports.Webhook, error) {
+	webhooks := make([]ports.Webhook, 0, len(f.webhooks))
+	for _, webhook := range f.webhooks {
+		if webhook.BarnID == barnID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	slices.SortFunc(webhooks, func(a, b ports.Webhook) int { return strings.Compare(a.ID, b.ID) })
+	return webhooks, nil
+}
+
+func (f *fakeWebhookStore) UpdateWebhook(
+	_ context.Context,
+	webhookID string,
+	in ports.WebhookRecordInput,
+) (ports.Webhook, bool, error) {
+	webhook, ok := f.webhooks[webhookID]
+	if !ok || webhook.BarnID != in.BarnID {
+		return ports.Webhook{}, false, nil
+	}
+	webhook.URL = in.URL
+	webhook.EventTypes = in.EventTypes
+	webhook.Active = in.Active
+	f.webhooks[webhookID] = webhook
+	return webhook, true, nil
+}
+
+func (f *fakeWebhookStore) DeleteWebhook(_ context.Context, barnID, webhookID string) (bool, error) {
+	webhook, ok := f.webhooks[webhookID]
+	if !ok || webhook.BarnID != barnID {
+		return false, nil
+	}
+	delete(f.webhooks, webhookID)
+	return true, nil
+}
+
+var _ ports.WebhookStore = (*fakeWebhookStore)(nil)
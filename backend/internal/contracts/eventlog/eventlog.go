@@ -0,0 +1,141 @@
+// Package eventlog defines the newline-delimited JSON format of the event log
+// shared by export, import and replication: one events row per line with every column.
+package eventlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+// ContentType is the media type of an event log stream.
+const ContentType = "application/x-ndjson"
+
+// MaxLineBytes bounds a single line; events are far smaller in practice.
+const MaxLineBytes = 4 << 20
+
+// Line is one event of the log. payload and metadata are embedded as JSON
+// rather than strings so exports stay readable and diffable.
+type Line struct {
+	Position      int64           `json:"position"`
+	ID            string          `json:"id"`
+	BarnID        string          `json:"barn_id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	EventVersion  int64           `json:"event_version"`
+	CreatedBy     string          `json:"created_by"`
+	Source        string          `json:"source"`
+	RequestID     string          `json:"request_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Metadata      json.RawMessage `json:"metadata"`
+	OccurredAt    string          `json:"occurred_at"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+// Encoder writes events as lines.
+type Encoder struct {
+	enc *json.Encoder
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{enc: json.NewEncoder(w)}
+}
+
+// Encode writes event as one line.
+func (e *Encoder) Encode(event ports.ArchivedEvent) error {
+	line := Line{
+		Position:      event.Position,
+		ID:            event.ID,
+		BarnID:        event.BarnID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.EventType,
+		EventVersion:  event.EventVersion,
+		CreatedBy:     event.CreatedBy,
+		Source:        event.Source,
+		RequestID:     event.RequestID,
+		Payload:       json.RawMessage(event.PayloadJSON),
+		Metadata:      json.RawMessage("null"),
+		OccurredAt:    event.OccurredAt,
+		CreatedAt:     event.RecordedAt,
+	}
+	if event.MetadataJSON != "" {
+		line.Metadata = json.RawMessage(event.MetadataJSON)
+	}
+	if err := e.enc.Encode(line); err != nil {
+		return fmt.Errorf("encode event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// Parse decodes and validates one line. Unknown fields are rejected.
+func Parse(raw []byte) (ports.ArchivedEvent, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var line Line
+	if err := dec.Decode(&line); err != nil {
+		return ports.ArchivedEvent{}, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"id", line.ID},
+		{"aggregate_type", line.AggregateType},
+		{"aggregate_id", line.AggregateID},
+		{"event_type", line.EventType},
+		{"created_by", line.CreatedBy},
+		{"source", line.Source},
+		{"request_id", line.RequestID},
+		{"occurred_at", line.OccurredAt},
+		{"created_at", line.CreatedAt},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			return ports.ArchivedEvent{}, fmt.Errorf("%s is required", field.name)
+		}
+	}
+	// Logs exported before barns existed carry no barn_id; the importer
+	// decides which barn such events belong to.
+	if line.BarnID != "" && !domain.ValidBarnID(line.BarnID) {
+		return ports.ArchivedEvent{}, errors.New("barn_id is malformed")
+	}
+	if line.EventVersion < 1 {
+		return ports.ArchivedEvent{}, errors.New("event_version must be at least 1")
+	}
+	// Stored payloads are compact JSON; compacting keeps idempotent re-imports
+	// byte-identical even if the file was pretty-printed or edited by hand.
+	var payload bytes.Buffer
+	if len(line.Payload) == 0 || line.Payload[0] != '{' || json.Compact(&payload, line.Payload) != nil {
+		return ports.ArchivedEvent{}, errors.New("payload must be a JSON object")
+	}
+
+	var metadata bytes.Buffer
+	if len(line.Metadata) > 0 && !bytes.Equal(line.Metadata, []byte("null")) {
+		if line.Metadata[0] != '{' || json.Compact(&metadata, line.Metadata) != nil {
+			return ports.ArchivedEvent{}, errors.New("metadata must be a JSON object or null")
+		}
+	}
+
+	return ports.ArchivedEvent{
+		Position:      line.Position,
+		ID:            line.ID,
+		BarnID:        line.BarnID,
+		AggregateType: line.AggregateType,
+		AggregateID:   line.AggregateID,
+		EventType:     line.EventType,
+		CreatedBy:     line.CreatedBy,
+		Source:        line.Source,
+		RequestID:     line.RequestID,
+		EventVersion:  line.EventVersion,
+		PayloadJSON:   payload.String(),
+		MetadataJSON:  metadata.String(),
+		OccurredAt:    line.OccurredAt,
+		RecordedAt:    line.CreatedAt,
+	}, nil
+}
@@ -7,6 +7,22 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes    = "bearerAuth.Scopes"
+	SessionCookieScopes = "sessionCookie.Scopes"
+)
+
+// Defines values for HttpapiApiTokenResponseScopes.
+const (
+	HttpapiApiTokenResponseScopesAnimalsRead   HttpapiApiTokenResponseScopes = "animals:read"
+	HttpapiApiTokenResponseScopesAnimalsWrite  HttpapiApiTokenResponseScopes = "animals:write"
+	HttpapiApiTokenResponseScopesEventsImport  HttpapiApiTokenResponseScopes = "events:import"
+	HttpapiApiTokenResponseScopesEventsRead    HttpapiApiTokenResponseScopes = "events:read"
+	HttpapiApiTokenResponseScopesUploadsWrite  HttpapiApiTokenResponseScopes = "uploads:write"
+	HttpapiApiTokenResponseScopesWebhooksRead  HttpapiApiTokenResponseScopes = "webhooks:read"
+	HttpapiApiTokenResponseScopesWebhooksWrite HttpapiApiTokenResponseScopes = "webhooks:write"
+)
+
 // Defines values for HttpapiCreateAnimalRequestSpecies.
 const (
 	Cat  HttpapiCreateAnimalRequestSpecies = "cat"
@@ -15,6 +31,101 @@ const (
 	Pig  HttpapiCreateAnimalRequestSpecies = "pig"
 )
 
+// Defines values for HttpapiCreateApiTokenRequestScopes.
+const (
+	HttpapiCreateApiTokenRequestScopesAnimalsRead   HttpapiCreateApiTokenRequestScopes = "animals:read"
+	HttpapiCreateApiTokenRequestScopesAnimalsWrite  HttpapiCreateApiTokenRequestScopes = "animals:write"
+	HttpapiCreateApiTokenRequestScopesEventsImport  HttpapiCreateApiTokenRequestScopes = "events:import"
+	HttpapiCreateApiTokenRequestScopesEventsRead    HttpapiCreateApiTokenRequestScopes = "events:read"
+	HttpapiCreateApiTokenRequestScopesUploadsWrite  HttpapiCreateApiTokenRequestScopes = "uploads:write"
+	HttpapiCreateApiTokenRequestScopesWebhooksRead  HttpapiCreateApiTokenRequestScopes = "webhooks:read"
+	HttpapiCreateApiTokenRequestScopesWebhooksWrite HttpapiCreateApiTokenRequestScopes = "webhooks:write"
+)
+
+// Defines values for HttpapiMembershipResponseRole.
+const (
+	Manager HttpapiMembershipResponseRole = "manager"
+	Owner   HttpapiMembershipResponseRole = "owner"
+	Viewer  HttpapiMembershipResponseRole = "viewer"
+	Worker  HttpapiMembershipResponseRole = "worker"
+)
+
+// Defines values for HttpapiReadyCheckName.
+const (
+	Database      HttpapiReadyCheckName = "database"
+	FileDir       HttpapiReadyCheckName = "file_dir"
+	Migrations    HttpapiReadyCheckName = "migrations"
+	Replication   HttpapiReadyCheckName = "replication"
+	WebhookOutbox HttpapiReadyCheckName = "webhook_outbox"
+)
+
+// Defines values for HttpapiReadyCheckStatus.
+const (
+	Fail HttpapiReadyCheckStatus = "fail"
+	Ok   HttpapiReadyCheckStatus = "ok"
+)
+
+// Defines values for HttpapiReadyResponseStatus.
+const (
+	NotReady HttpapiReadyResponseStatus = "not_ready"
+	Ready    HttpapiReadyResponseStatus = "ready"
+)
+
+// Defines values for HttpapiTimelineCorrectionEventType.
+const (
+	EventCorrected HttpapiTimelineCorrectionEventType = "event.corrected"
+	EventVoided    HttpapiTimelineCorrectionEventType = "event.voided"
+)
+
+// Defines values for HttpapiTimelineEntryStatus.
+const (
+	Active    HttpapiTimelineEntryStatus = "active"
+	Corrected HttpapiTimelineEntryStatus = "corrected"
+	Voided    HttpapiTimelineEntryStatus = "voided"
+)
+
+// HttpapiAnimalResponse defines model for httpapi.animalResponse.
+type HttpapiAnimalResponse struct {
+	AnimalId  string              `json:"animal_id"`
+	Birthdate *openapi_types.Date `json:"birthdate,omitempty"`
+	Name      string              `json:"name"`
+	PhotoId   *string             `json:"photo_id,omitempty"`
+	Species   string              `json:"species"`
+	Tag       *string             `json:"tag,omitempty"`
+
+	// Version Number of events folded into this state
+	Version int `json:"version"`
+}
+
+// HttpapiApiTokenListResponse defines model for httpapi.apiTokenListResponse.
+type HttpapiApiTokenListResponse struct {
+	Items []HttpapiApiTokenResponse `json:"items"`
+}
+
+// HttpapiApiTokenResponse defines model for httpapi.apiTokenResponse.
+type HttpapiApiTokenResponse struct {
+	CreatedAt string `json:"created_at"`
+
+	// ExpiresAt Omitted for tokens that do not expire
+	ExpiresAt *string                         `json:"expires_at,omitempty"`
+	Id        string                          `json:"id"`
+	Name      string                          `json:"name"`
+	Scopes    []HttpapiApiTokenResponseScopes `json:"scopes"`
+
+	// Token Only returned when the token is created. Send it as "Authorization: Bearer <token>".
+	Token *string `json:"token,omitempty"`
+}
+
+// HttpapiApiTokenResponseScopes defines model for HttpapiApiTokenResponse.Scopes.
+type HttpapiApiTokenResponseScopes string
+
+// HttpapiCorrectEventRequest defines model for httpapi.correctEventRequest.
+type HttpapiCorrectEventRequest struct {
+	// Payload Replacement payload of the target event
+	Payload map[string]interface{} `json:"payload"`
+	Reason  string                 `json:"reason"`
+}
+
 // HttpapiCreateAnimalRequest defines model for httpapi.createAnimalRequest.
 type HttpapiCreateAnimalRequest struct {
 	Birthdate *openapi_types.Date               `json:"birthdate,omitempty"`
@@ -38,15 +149,150 @@ type HttpapiCreateAnimalResponse struct {
 	Tag       *string             `json:"tag,omitempty"`
 }
 
-// HttpapiErrorResponse defines model for httpapi.errorResponse.
+// HttpapiCreateApiTokenRequest defines model for httpapi.createApiTokenRequest.
+type HttpapiCreateApiTokenRequest struct {
+	// ExpiresAt RFC 3339 time after which the token stops working; omit for a token that does not expire
+	ExpiresAt *string `json:"expires_at,omitempty"`
+
+	// Name What the token is for, 1-100 characters
+	Name string `json:"name"`
+
+	// Scopes Operations the token may call; at least one
+	Scopes []HttpapiCreateApiTokenRequestScopes `json:"scopes"`
+}
+
+// HttpapiCreateApiTokenRequestScopes defines model for HttpapiCreateApiTokenRequest.Scopes.
+type HttpapiCreateApiTokenRequestScopes string
+
+// HttpapiCreateWebhookRequest defines model for httpapi.createWebhookRequest.
+type HttpapiCreateWebhookRequest struct {
+	// Active Defaults to true
+	Active *bool `json:"active,omitempty"`
+
+	// EventTypes Event types to deliver; empty or omitted delivers every event type
+	EventTypes *[]string `json:"event_types,omitempty"`
+
+	// Url Absolute http or https URL that receives signed POST requests
+	Url string `json:"url"`
+}
+
+// HttpapiDeviceListResponse defines model for httpapi.deviceListResponse.
+type HttpapiDeviceListResponse struct {
+	Items []HttpapiDeviceResponse `json:"items"`
+}
+
+// HttpapiDeviceResponse defines model for httpapi.deviceResponse.
+type HttpapiDeviceResponse struct {
+	CreatedAt string `json:"created_at"`
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+
+	// Secret Only returned when the device is registered. The device sends it as "Authorization: Bearer <secret>".
+	Secret *string `json:"secret,omitempty"`
+}
+
+// HttpapiErrorResponse RFC 9457 problem details, served as application/problem+json.
 type HttpapiErrorResponse struct {
-	Error string `json:"error"`
+	// Code Stable error code; for validation errors the code of the first invalid field
+	Code string `json:"code"`
+
+	// Detail What went wrong in this request, when there is more to say than the title
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Every invalid field of a validation error
+	Errors *[]HttpapiFieldError `json:"errors,omitempty"`
+	Status int                  `json:"status"`
+	Title  string               `json:"title"`
+
+	// Type URI identifying the problem type, urn:barnlog:problem:<code>
+	Type string `json:"type"`
+}
+
+// HttpapiEventAmendmentResponse defines model for httpapi.eventAmendmentResponse.
+type HttpapiEventAmendmentResponse struct {
+	AnimalId string `json:"animal_id"`
+
+	// EventId ID of the appended event.corrected or event.voided event
+	EventId       string `json:"event_id"`
+	TargetEventId string `json:"target_event_id"`
+}
+
+// HttpapiFieldError defines model for httpapi.fieldError.
+type HttpapiFieldError struct {
+	Code   string  `json:"code"`
+	Detail *string `json:"detail,omitempty"`
+
+	// Field Name of the invalid request field
+	Field string `json:"field"`
+}
+
+// HttpapiImportEventsResponse defines model for httpapi.importEventsResponse.
+type HttpapiImportEventsResponse struct {
+	Imported int `json:"imported"`
+
+	// Skipped Events already present under the same ID, idempotency key and payload
+	Skipped int `json:"skipped"`
 }
 
+// HttpapiLoginRequest defines model for httpapi.loginRequest.
+type HttpapiLoginRequest struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// HttpapiMembershipResponse defines model for httpapi.membershipResponse.
+type HttpapiMembershipResponse struct {
+	// BarnId Barn the role applies in; "server" applies in every barn
+	BarnId string                        `json:"barn_id"`
+	Role   HttpapiMembershipResponseRole `json:"role"`
+}
+
+// HttpapiMembershipResponseRole defines model for HttpapiMembershipResponse.Role.
+type HttpapiMembershipResponseRole string
+
+// HttpapiReadyCheck defines model for httpapi.readyCheck.
+type HttpapiReadyCheck struct {
+	// Error Which part of the check failed. The underlying error is only logged.
+	Error *string `json:"error,omitempty"`
+
+	// Lag Events the consumer has not processed yet. Reported by webhook_outbox and replication; lag alone does not fail a check.
+	Lag    *int64                  `json:"lag,omitempty"`
+	Name   HttpapiReadyCheckName   `json:"name"`
+	Status HttpapiReadyCheckStatus `json:"status"`
+}
+
+// HttpapiReadyCheckName defines model for HttpapiReadyCheck.Name.
+type HttpapiReadyCheckName string
+
+// HttpapiReadyCheckStatus defines model for HttpapiReadyCheck.Status.
+type HttpapiReadyCheckStatus string
+
 // HttpapiReadyResponse defines model for httpapi.readyResponse.
 type HttpapiReadyResponse struct {
-	Status    string `json:"status"`
-	Timestamp string `json:"timestamp"`
+	Checks    []HttpapiReadyCheck        `json:"checks"`
+	Status    HttpapiReadyResponseStatus `json:"status"`
+	Timestamp string                     `json:"timestamp"`
+}
+
+// HttpapiReadyResponseStatus defines model for HttpapiReadyResponse.Status.
+type HttpapiReadyResponseStatus string
+
+// HttpapiRegisterDeviceRequest defines model for httpapi.registerDeviceRequest.
+type HttpapiRegisterDeviceRequest struct {
+	// Name What the device is, 1-100 characters
+	Name string `json:"name"`
+}
+
+// HttpapiSessionResponse defines model for httpapi.sessionResponse.
+type HttpapiSessionResponse struct {
+	// CsrfToken Send this value in the X-CSRF-Token header of every POST, PUT and DELETE made with the session cookie
+	CsrfToken string `json:"csrf_token"`
+	ExpiresAt string `json:"expires_at"`
+
+	// Memberships Barns the user belongs to, ordered by barn ID; the role in the request's barn decides which operations the session may use
+	Memberships []HttpapiMembershipResponse `json:"memberships"`
+	UserId      string                      `json:"user_id"`
+	Username    string                      `json:"username"`
 }
 
 // HttpapiStatusResponse defines model for httpapi.statusResponse.
@@ -54,6 +300,58 @@ type HttpapiStatusResponse struct {
 	Status string `json:"status"`
 }
 
+// HttpapiTimelineCorrection defines model for httpapi.timelineCorrection.
+type HttpapiTimelineCorrection struct {
+	CreatedBy  string                             `json:"created_by"`
+	EventId    string                             `json:"event_id"`
+	EventType  HttpapiTimelineCorrectionEventType `json:"event_type"`
+	OccurredAt string                             `json:"occurred_at"`
+
+	// Payload Replacement payload (event.corrected only)
+	Payload    *map[string]interface{} `json:"payload,omitempty"`
+	Reason     string                  `json:"reason"`
+	RecordedAt string                  `json:"recorded_at"`
+}
+
+// HttpapiTimelineCorrectionEventType defines model for HttpapiTimelineCorrection.EventType.
+type HttpapiTimelineCorrectionEventType string
+
+// HttpapiTimelineEntry defines model for httpapi.timelineEntry.
+type HttpapiTimelineEntry struct {
+	// Corrections Corrections and voids applied to this event, oldest first
+	Corrections []HttpapiTimelineCorrection `json:"corrections"`
+	CreatedBy   string                      `json:"created_by"`
+	EventId     string                      `json:"event_id"`
+	EventType   string                      `json:"event_type"`
+	OccurredAt  string                      `json:"occurred_at"`
+
+	// OriginalPayload Payload as originally recorded
+	OriginalPayload map[string]interface{} `json:"original_payload"`
+
+	// Payload Payload with the latest correction applied
+	Payload    map[string]interface{}     `json:"payload"`
+	RecordedAt string                     `json:"recorded_at"`
+	Status     HttpapiTimelineEntryStatus `json:"status"`
+}
+
+// HttpapiTimelineEntryStatus defines model for HttpapiTimelineEntry.Status.
+type HttpapiTimelineEntryStatus string
+
+// HttpapiTimelineResponse defines model for httpapi.timelineResponse.
+type HttpapiTimelineResponse struct {
+	AnimalId string                 `json:"animal_id"`
+	Entries  []HttpapiTimelineEntry `json:"entries"`
+}
+
+// HttpapiUpdateWebhookRequest defines model for httpapi.updateWebhookRequest.
+type HttpapiUpdateWebhookRequest struct {
+	Active bool `json:"active"`
+
+	// EventTypes Event types to deliver; empty or omitted delivers every event type
+	EventTypes *[]string `json:"event_types,omitempty"`
+	Url        string    `json:"url"`
+}
+
 // HttpapiUploadFileResponse defines model for httpapi.uploadFileResponse.
 type HttpapiUploadFileResponse struct {
 	ContentType string `json:"content_type"`
@@ -62,13 +360,93 @@ type HttpapiUploadFileResponse struct {
 	SizeBytes   int    `json:"size_bytes"`
 }
 
+// HttpapiVoidEventRequest defines model for httpapi.voidEventRequest.
+type HttpapiVoidEventRequest struct {
+	Reason string `json:"reason"`
+}
+
+// HttpapiWebhookListResponse defines model for httpapi.webhookListResponse.
+type HttpapiWebhookListResponse struct {
+	Items []HttpapiWebhookResponse `json:"items"`
+}
+
+// HttpapiWebhookResponse defines model for httpapi.webhookResponse.
+type HttpapiWebhookResponse struct {
+	Active     bool     `json:"active"`
+	CreatedAt  string   `json:"created_at"`
+	EventTypes []string `json:"event_types"`
+	Id         string   `json:"id"`
+
+	// Secret HMAC-SHA256 signing secret. Only returned when the webhook is created.
+	Secret    *string `json:"secret,omitempty"`
+	UpdatedAt string  `json:"updated_at"`
+	Url       string  `json:"url"`
+}
+
 // PostAnimalsParams defines parameters for PostAnimals.
 type PostAnimalsParams struct {
 	// XRequestId Idempotency request key (omit to disable idempotency)
 	XRequestId *string `json:"X-Request-Id,omitempty"`
 
-	// XBarnlogSource Request source
+	// XBarnlogSource Request source (ignored for registered devices, which always record device:<device ID>)
+	XBarnlogSource *string `json:"X-Barnlog-Source,omitempty"`
+
+	// XBarnlogClientVersion Client application version (stored in event metadata)
+	XBarnlogClientVersion *string `json:"X-Barnlog-Client-Version,omitempty"`
+}
+
+// PostAnimalsAnimalIdEventsEventIdCorrectionParams defines parameters for PostAnimalsAnimalIdEventsEventIdCorrection.
+type PostAnimalsAnimalIdEventsEventIdCorrectionParams struct {
+	// XRequestId Idempotency request key (omit to disable idempotency)
+	XRequestId *string `json:"X-Request-Id,omitempty"`
+
+	// XBarnlogSource Request source (ignored for registered devices, which always record device:<device ID>)
 	XBarnlogSource *string `json:"X-Barnlog-Source,omitempty"`
+
+	// XBarnlogClientVersion Client application version (stored in event metadata)
+	XBarnlogClientVersion *string `json:"X-Barnlog-Client-Version,omitempty"`
+}
+
+// PostAnimalsAnimalIdEventsEventIdVoidParams defines parameters for PostAnimalsAnimalIdEventsEventIdVoid.
+type PostAnimalsAnimalIdEventsEventIdVoidParams struct {
+	// XRequestId Idempotency request key (omit to disable idempotency)
+	XRequestId *string `json:"X-Request-Id,omitempty"`
+
+	// XBarnlogSource Request source (ignored for registered devices, which always record device:<device ID>)
+	XBarnlogSource *string `json:"X-Barnlog-Source,omitempty"`
+
+	// XBarnlogClientVersion Client application version (stored in event metadata)
+	XBarnlogClientVersion *string `json:"X-Barnlog-Client-Version,omitempty"`
+}
+
+// GetAuthOidcCallbackParams defines parameters for GetAuthOidcCallback.
+type GetAuthOidcCallbackParams struct {
+	// Code Authorization code issued by the provider
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// State State echoed by the provider
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Error reported by the provider instead of a code
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// GetEventsStreamParams defines parameters for GetEventsStream.
+type GetEventsStreamParams struct {
+	// AggregateType Only stream events of this aggregate type
+	AggregateType *string `form:"aggregate_type,omitempty" json:"aggregate_type,omitempty"`
+
+	// AggregateId Only stream events of this aggregate ID
+	AggregateId *string `form:"aggregate_id,omitempty" json:"aggregate_id,omitempty"`
+
+	// EventType Only stream events of this event type
+	EventType *string `form:"event_type,omitempty" json:"event_type,omitempty"`
+
+	// After Stream events after this global position (ignored when Last-Event-ID is sent)
+	After *int64 `form:"after,omitempty" json:"after,omitempty"`
+
+	// LastEventID Global position of the last event the client received
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
 }
 
 // PostUploadsAnimalPhotosMultipartBody defines parameters for PostUploadsAnimalPhotos.
@@ -80,5 +458,26 @@ type PostUploadsAnimalPhotosMultipartBody struct {
 // PostAnimalsJSONRequestBody defines body for PostAnimals for application/json ContentType.
 type PostAnimalsJSONRequestBody = HttpapiCreateAnimalRequest
 
+// PostAnimalsAnimalIdEventsEventIdCorrectionJSONRequestBody defines body for PostAnimalsAnimalIdEventsEventIdCorrection for application/json ContentType.
+type PostAnimalsAnimalIdEventsEventIdCorrectionJSONRequestBody = HttpapiCorrectEventRequest
+
+// PostAnimalsAnimalIdEventsEventIdVoidJSONRequestBody defines body for PostAnimalsAnimalIdEventsEventIdVoid for application/json ContentType.
+type PostAnimalsAnimalIdEventsEventIdVoidJSONRequestBody = HttpapiVoidEventRequest
+
+// PostAuthDevicesJSONRequestBody defines body for PostAuthDevices for application/json ContentType.
+type PostAuthDevicesJSONRequestBody = HttpapiRegisterDeviceRequest
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = HttpapiLoginRequest
+
+// PostAuthTokensJSONRequestBody defines body for PostAuthTokens for application/json ContentType.
+type PostAuthTokensJSONRequestBody = HttpapiCreateApiTokenRequest
+
 // PostUploadsAnimalPhotosMultipartRequestBody defines body for PostUploadsAnimalPhotos for multipart/form-data ContentType.
 type PostUploadsAnimalPhotosMultipartRequestBody PostUploadsAnimalPhotosMultipartBody
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = HttpapiCreateWebhookRequest
+
+// PutWebhooksWebhookIdJSONRequestBody defines body for PutWebhooksWebhookId for application/json ContentType.
+type PutWebhooksWebhookIdJSONRequestBody = HttpapiUpdateWebhookRequest
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// AnimalAggregateType is the aggregate_type stored for animal streams.
+	AnimalAggregateType = "animal"
+	// AnimalCreatedEventType is appended once when an animal stream starts.
+	AnimalCreatedEventType = "animal.created"
+
+	// AnimalFoldVersion identifies the revision of Animal.Apply.
+	// Bump it whenever fold logic changes so persisted snapshots are rebuilt.
+	AnimalFoldVersion = 2
+)
+
+// Animal is the current state of an animal folded from its event stream.
+type Animal struct {
+	ID             string `json:"id"`
+	CreatedEventID string `json:"created_event_id"`
+	Voided         bool   `json:"voided"`
+	Name           string `json:"name"`
+	Species        string `json:"species"`
+	Tag            string `json:"tag"`
+	Birthdate      string `json:"birthdate"`
+	PhotoID        string `json:"photo_id"`
+}
+
+// AnimalCreated is the payload of an animal.created event.
+type AnimalCreated struct {
+	Name      string `json:"name"`
+	Species   string `json:"species"`
+	Tag       string `json:"tag"`
+	Birthdate string `json:"birthdate"`
+	PhotoID   string `json:"photo_id"`
+}
+
+// Apply folds one upcasted event into the animal state.
+// Corrections and voids of the creation event replace or retract the animal details.
+// Event types the animal does not model are ignored so newer streams stay readable.
+func (a Animal) Apply(event Event) (Animal, error) {
+	switch event.Type {
+	case AnimalCreatedEventType:
+		a.CreatedEventID = event.ID
+		return a.applyCreated(event.Type, event.Payload)
+	case EventCorrectedEventType:
+		var corrected EventCorrected
+		if err := json.Unmarshal(event.Payload, &corrected); err != nil {
+			return Animal{}, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		if corrected.TargetEventID != a.CreatedEventID || a.CreatedEventID == "" {
+			return a, nil
+		}
+		payload, err := Upcast(AnimalCreatedEventType, corrected.PayloadVersion, corrected.Payload)
+		if err != nil {
+			return Animal{}, err
+		}
+		return a.applyCreated(AnimalCreatedEventType, payload)
+	case EventVoidedEventType:
+		var voided EventVoided
+		if err := json.Unmarshal(event.Payload, &voided); err != nil {
+			return Animal{}, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		if voided.TargetEventID == a.CreatedEventID && a.CreatedEventID != "" {
+			a.Voided = true
+		}
+	}
+	return a, nil
+}
+
+func (a Animal) applyCreated(eventType string, payload []byte) (Animal, error) {
+	var created AnimalCreated
+	if err := json.Unmarshal(payload, &created); err != nil {
+		return Animal{}, fmt.Errorf("decode %s payload: %w", eventType, err)
+	}
+	a.Name = created.Name
+	a.Species = created.Species
+	a.Tag = created.Tag
+	a.Birthdate = created.Birthdate
+	a.PhotoID = created.PhotoID
+	return a, nil
+}
@@ -0,0 +1,94 @@
+package domain
+
+import "testing"
+
+func TestAnimalApplyCreated(t *testing.T) {
+	t.Parallel()
+
+	animal, err := Animal{ID: "a1"}.Apply(Event{
+		ID:      "e1",
+		Type:    AnimalCreatedEventType,
+		Payload: []byte(`{"name":"Nanny","species":"goat","tag":"G-7"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if animal.ID != "a1" || animal.Name != "Nanny" || animal.Species != "goat" || animal.Tag != "G-7" {
+		t.Fatalf("unexpected animal state: %+v", animal)
+	}
+}
+
+func TestAnimalApplyIgnoresUnknownEventTypes(t *testing.T) {
+	t.Parallel()
+
+	before := Animal{ID: "a1", Name: "Nanny"}
+	after, err := before.Apply(Event{ID: "e2", Type: "animal.fed", Payload: []byte(`not json`)})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if after != before {
+		t.Fatalf("expected unknown event to leave state unchanged, got %+v", after)
+	}
+}
+
+func TestAnimalApplyCorrectionAndVoidOfCreation(t *testing.T) {
+	t.Parallel()
+
+	animal, err := Animal{ID: "a1"}.Apply(Event{
+		ID:      "e1",
+		Type:    AnimalCreatedEventType,
+		Payload: []byte(`{"name":"Nany","species":"goat"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply created: %v", err)
+	}
+
+	animal, err = animal.Apply(Event{
+		ID:      "e2",
+		Type:    EventCorrectedEventType,
+		Payload: []byte(`{"target_event_id":"e1","reason":"typo","payload_version":1,"payload":{"name":"Nanny","species":"goat"}}`),
+	})
+	if err != nil {
+		t.Fatalf("apply corrected: %v", err)
+	}
+	if animal.Name != "Nanny" {
+		t.Fatalf("expected corrected name, got %q", animal.Name)
+	}
+
+	animal, err = animal.Apply(Event{
+		ID:      "e3",
+		Type:    EventVoidedEventType,
+		Payload: []byte(`{"target_event_id":"other","reason":"n/a"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply unrelated void: %v", err)
+	}
+	if animal.Voided {
+		t.Fatalf("expected void of another event to leave animal active")
+	}
+
+	animal, err = animal.Apply(Event{
+		ID:      "e4",
+		Type:    EventVoidedEventType,
+		Payload: []byte(`{"target_event_id":"e1","reason":"duplicate"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply void: %v", err)
+	}
+	if !animal.Voided {
+		t.Fatalf("expected void of creation to retract the animal")
+	}
+}
+
+func TestUpcastRejectsUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Upcast(AnimalCreatedEventType, 1, []byte(`{}`)); err != nil {
+		t.Fatalf("expected current version to upcast, got %v", err)
+	}
+	for _, version := range []int64{0, 2} {
+		if _, err := Upcast(AnimalCreatedEventType, version, []byte(`{}`)); err == nil {
+			t.Fatalf("expected event_version %d to be rejected", version)
+		}
+	}
+}
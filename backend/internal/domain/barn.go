@@ -0,0 +1,34 @@
+package domain
+
+import "regexp"
+
+const (
+	// DefaultBarnID is the barn of requests that name none and of every event
+	// recorded before barns existed.
+	DefaultBarnID = "default"
+	// ServerBarnID holds the streams all barns share, such as users. A
+	// membership in it applies to every barn.
+	ServerBarnID = "server"
+)
+
+// barnIDPattern keeps barn IDs safe to use as directory names.
+var barnIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// ValidBarnID reports whether s is a well-formed barn ID: 1 to 63 lowercase
+// letters, digits and hyphens, starting with a letter or digit.
+func ValidBarnID(s string) bool {
+	return barnIDPattern.MatchString(s)
+}
+
+// Memberships maps a barn ID to the role a user has in that barn.
+type Memberships map[string]Role
+
+// RoleIn returns the role the memberships grant in barnID: the more
+// privileged of the barn's own membership and the ServerBarnID membership.
+func (m Memberships) RoleIn(barnID string) (Role, bool) {
+	role, ok := m[barnID]
+	if server, isMember := m[ServerBarnID]; isMember && (!ok || server.Includes(role)) {
+		return server, true
+	}
+	return role, ok
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "encoding/json"
+
+const (
+	// EventCorrectedEventType replaces the payload of an earlier event in the same aggregate.
+	EventCorrectedEventType = "event.corrected"
+	// EventVoidedEventType marks an earlier event in the same aggregate as void.
+	EventVoidedEventType = "event.voided"
+)
+
+// Event is one upcasted entry of an aggregate stream.
+type Event struct {
+	Position   int64
+	ID         string
+	Type       string
+	Payload    []byte
+	CreatedBy  string
+	OccurredAt string
+	RecordedAt string
+}
+
+// EventCorrected is the payload of an event.corrected event.
+type EventCorrected struct {
+	TargetEventID  string          `json:"target_event_id"`
+	Reason         string          `json:"reason"`
+	PayloadVersion int64           `json:"payload_version"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// EventVoided is the payload of an event.voided event.
+type EventVoided struct {
+	TargetEventID string `json:"target_event_id"`
+	Reason        string `json:"reason"`
+}
+
+// IsAmendment reports whether eventType corrects or voids another event.
+func IsAmendment(eventType string) bool {
+	return eventType == EventCorrectedEventType || eventType == EventVoidedEventType
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TimelineEntry is an event as it reads once corrections and voids are applied.
+type TimelineEntry struct {
+	Event       Event
+	Payload     []byte
+	Voided      bool
+	Corrections []TimelineCorrection
+}
+
+// Corrected reports whether at least one correction replaced the entry payload.
+func (e TimelineEntry) Corrected() bool {
+	for _, correction := range e.Corrections {
+		if correction.Event.Type == EventCorrectedEventType {
+			return true
+		}
+	}
+	return false
+}
+
+// TimelineCorrection records who corrected or voided an entry, when and why.
+type TimelineCorrection struct {
+	Event   Event
+	Reason  string
+	Payload []byte
+}
+
+// BuildTimeline applies corrections and voids to an aggregate stream ordered by position.
+// The original events stay in place; each entry carries its effective payload and the
+// amendments that produced it. Amendments never appear as entries of their own.
+func BuildTimeline(events []Event) ([]TimelineEntry, error) {
+	entries := make([]TimelineEntry, 0, len(events))
+	byID := make(map[string]int, len(events))
+
+	for _, event := range events {
+		switch event.Type {
+		case EventCorrectedEventType:
+			var corrected EventCorrected
+			if err := json.Unmarshal(event.Payload, &corrected); err != nil {
+				return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+			}
+			index, ok := byID[corrected.TargetEventID]
+			if !ok || entries[index].Voided {
+				continue
+			}
+			payload, err := Upcast(entries[index].Event.Type, corrected.PayloadVersion, corrected.Payload)
+			if err != nil {
+				return nil, err
+			}
+			entries[index].Payload = payload
+			entries[index].Corrections = append(entries[index].Corrections, TimelineCorrection{
+				Event:   event,
+				Reason:  corrected.Reason,
+				Payload: payload,
+			})
+		case EventVoidedEventType:
+			var voided EventVoided
+			if err := json.Unmarshal(event.Payload, &voided); err != nil {
+				return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+			}
+			index, ok := byID[voided.TargetEventID]
+			if !ok || entries[index].Voided {
+				continue
+			}
+			entries[index].Voided = true
+			entries[index].Corrections = append(entries[index].Corrections, TimelineCorrection{
+				Event:  event,
+				Reason: voided.Reason,
+			})
+		default:
+			byID[event.ID] = len(entries)
+			entries = append(entries, TimelineEntry{
+				Event:   event,
+				Payload: event.Payload,
+			})
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "testing"
+
+func TestBuildTimelineAppliesCorrectionsAndVoids(t *testing.T) {
+	t.Parallel()
+
+	entries, err := BuildTimeline([]Event{
+		{ID: "e1", Type: AnimalCreatedEventType, Payload: []byte(`{"name":"Nany"}`)},
+		{ID: "e2", Type: "animal.fed", Payload: []byte(`{"feed":"hay"}`)},
+		{ID: "e3", Type: EventCorrectedEventType, CreatedBy: "alice", Payload: []byte(
+			`{"target_event_id":"e1","reason":"typo","payload_version":1,"payload":{"name":"Nanny"}}`,
+		)},
+		{ID: "e4", Type: EventVoidedEventType, CreatedBy: "bob", Payload: []byte(
+			`{"target_event_id":"e2","reason":"wrong goat"}`,
+		)},
+		{ID: "e5", Type: EventCorrectedEventType, Payload: []byte(
+			`{"target_event_id":"e2","reason":"too late","payload_version":1,"payload":{"feed":"grain"}}`,
+		)},
+	})
+	if err != nil {
+		t.Fatalf("build timeline: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected amendments to be folded into 2 entries, got %d", len(entries))
+	}
+
+	created := entries[0]
+	if string(created.Payload) != `{"name":"Nanny"}` {
+		t.Fatalf("expected corrected payload, got %s", created.Payload)
+	}
+	if string(created.Event.Payload) != `{"name":"Nany"}` {
+		t.Fatalf("expected original payload to stay visible, got %s", created.Event.Payload)
+	}
+	if !created.Corrected() || len(created.Corrections) != 1 || created.Corrections[0].Event.CreatedBy != "alice" {
+		t.Fatalf("expected one correction by alice, got %+v", created.Corrections)
+	}
+
+	fed := entries[1]
+	if !fed.Voided {
+		t.Fatalf("expected fed entry to be voided")
+	}
+	if string(fed.Payload) != `{"feed":"hay"}` {
+		t.Fatalf("expected corrections after a void to be ignored, got %s", fed.Payload)
+	}
+	if len(fed.Corrections) != 1 || fed.Corrections[0].Reason != "wrong goat" {
+		t.Fatalf("expected only the void amendment, got %+v", fed.Corrections)
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "fmt"
+
+// UpcasterVersion identifies the revision of Upcast.
+// Bump it whenever an upcaster is added or changed so persisted snapshots are rebuilt.
+const UpcasterVersion = 1
+
+// currentEventVersions lists the newest payload version per event type.
+var currentEventVersions = map[string]int64{
+	AnimalCreatedEventType:       1,
+	EventCorrectedEventType:      1,
+	EventVoidedEventType:         1,
+	UserCreatedEventType:         1,
+	UserPasswordChangedEventType: 1,
+	UserRoleChangedEventType:     1,
+}
+
+// CurrentEventVersion returns the payload version new events of eventType are written with.
+func CurrentEventVersion(eventType string) int64 {
+	if version, ok := currentEventVersions[eventType]; ok {
+		return version
+	}
+	return 1
+}
+
+// IsKnownEventType reports whether eventType is written by this version of the domain.
+func IsKnownEventType(eventType string) bool {
+	_, ok := currentEventVersions[eventType]
+	return ok
+}
+
+// Upcast converts a stored payload to the current contract for its event type.
+func Upcast(eventType string, eventVersion int64, payload []byte) ([]byte, error) {
+	current, ok := currentEventVersions[eventType]
+	if !ok {
+		return payload, nil
+	}
+	if eventVersion < 1 || eventVersion > current {
+		return nil, fmt.Errorf("unsupported %s event_version %d", eventType, eventVersion)
+	}
+	return payload, nil
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+const (
+	// UserAggregateType is the aggregate_type stored for user streams.
+	UserAggregateType = "user"
+	// UserCreatedEventType is appended once when a user stream starts.
+	UserCreatedEventType = "user.created"
+	// UserPasswordChangedEventType replaces the password hash of a user.
+	UserPasswordChangedEventType = "user.password_changed"
+	// UserRoleChangedEventType sets the role of a user in one barn.
+	UserRoleChangedEventType = "user.role_changed"
+)
+
+// Role is the access level of a user. Each role includes the rights of the
+// roles below it: owner, manager, worker, viewer.
+type Role string
+
+const (
+	// RoleOwner may do everything, including managing users.
+	RoleOwner Role = "owner"
+	// RoleManager may void events, move the event log and manage webhooks.
+	RoleManager Role = "manager"
+	// RoleWorker may record animals and their events.
+	RoleWorker Role = "worker"
+	// RoleViewer may only read.
+	RoleViewer Role = "viewer"
+)
+
+// Roles lists every role from the most to the least privileged.
+var Roles = []Role{RoleOwner, RoleManager, RoleWorker, RoleViewer}
+
+// ParseRole returns the role named s.
+func ParseRole(s string) (Role, bool) {
+	for _, role := range Roles {
+		if string(role) == s {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// Includes reports whether r is other or a more privileged role.
+func (r Role) Includes(other Role) bool {
+	for _, role := range Roles {
+		if role == r {
+			return true
+		}
+		if role == other {
+			return false
+		}
+	}
+	return false
+}
+
+// User is the current state of a user account folded from its event stream.
+// Users that sign in through OpenID Connect have an OIDCSubject and usually
+// no PasswordHash.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	OIDCSubject  string
+	Memberships  Memberships
+}
+
+// UserCreated is the payload of a user.created event. The user starts as a
+// member of BarnID with Role. OIDCSubject is the sub claim of the identity
+// provider account the user was created for.
+type UserCreated struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash"`
+	Role         Role   `json:"role,omitempty"`
+	BarnID       string `json:"barn_id,omitempty"`
+	OIDCSubject  string `json:"oidc_subject,omitempty"`
+}
+
+// UserPasswordChanged is the payload of a user.password_changed event.
+type UserPasswordChanged struct {
+	PasswordHash string `json:"password_hash"`
+}
+
+// UserRoleChanged is the payload of a user.role_changed event. An empty Role
+// ends the membership in BarnID.
+type UserRoleChanged struct {
+	Role   Role   `json:"role"`
+	BarnID string `json:"barn_id,omitempty"`
+}
+
+// Apply folds one upcasted event into the user state.
+// Event types the user does not model are ignored so newer streams stay readable.
+func (u User) Apply(event Event) (User, error) {
+	switch event.Type {
+	case UserCreatedEventType:
+		var created UserCreated
+		if err := json.Unmarshal(event.Payload, &created); err != nil {
+			return User{}, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		u.Username = created.Username
+		u.PasswordHash = created.PasswordHash
+		u.OIDCSubject = created.OIDCSubject
+		role := created.Role
+		if role == "" {
+			// Users created before roles existed keep the full access they had.
+			role = RoleOwner
+		}
+		u.Memberships = Memberships{eventBarnID(created.BarnID): role}
+	case UserPasswordChangedEventType:
+		var changed UserPasswordChanged
+		if err := json.Unmarshal(event.Payload, &changed); err != nil {
+			return User{}, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		u.PasswordHash = changed.PasswordHash
+	case UserRoleChangedEventType:
+		var changed UserRoleChanged
+		if err := json.Unmarshal(event.Payload, &changed); err != nil {
+			return User{}, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		memberships := make(Memberships, len(u.Memberships)+1)
+		maps.Copy(memberships, u.Memberships)
+		if changed.Role == "" {
+			delete(memberships, eventBarnID(changed.BarnID))
+		} else {
+			memberships[eventBarnID(changed.BarnID)] = changed.Role
+		}
+		u.Memberships = memberships
+	}
+	return u, nil
+}
+
+// eventBarnID returns the barn a user event names; events recorded before
+// barns existed name none and apply to DefaultBarnID.
+func eventBarnID(barnID string) string {
+	if barnID == "" {
+		return DefaultBarnID
+	}
+	return barnID
+}
+
+// RedactPayload removes credentials from a payload before it leaves the
+// server through the event feed or webhooks. Other payloads pass through.
+func RedactPayload(eventType string, payload []byte) ([]byte, error) {
+	if eventType != UserCreatedEventType && eventType != UserPasswordChangedEventType {
+		return payload, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", eventType, err)
+	}
+	delete(fields, "password_hash")
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", eventType, err)
+	}
+	return redacted, nil
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestUserApplyCreatedAndPasswordChanged(t *testing.T) {
+	t.Parallel()
+
+	user, err := User{ID: "u1"}.Apply(Event{
+		ID:      "e1",
+		Type:    UserCreatedEventType,
+		Payload: []byte(`{"username":"anna","password_hash":"h1"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply created: %v", err)
+	}
+	user, err = user.Apply(Event{
+		ID:      "e2",
+		Type:    UserPasswordChangedEventType,
+		Payload: []byte(`{"password_hash":"h2"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply password changed: %v", err)
+	}
+
+	if user.ID != "u1" || user.Username != "anna" || user.PasswordHash != "h2" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+	if want := (Memberships{DefaultBarnID: RoleOwner}); !maps.Equal(user.Memberships, want) {
+		t.Fatalf("expected memberships %v, got %v", want, user.Memberships)
+	}
+}
+
+func TestUserApplyRole(t *testing.T) {
+	t.Parallel()
+
+	user, err := User{ID: "u1"}.Apply(Event{
+		ID:      "e1",
+		Type:    UserCreatedEventType,
+		Payload: []byte(`{"username":"sam","password_hash":"","role":"worker","barn_id":"north","oidc_subject":"sub-1"}`),
+	})
+	if err != nil {
+		t.Fatalf("apply created: %v", err)
+	}
+	if want := (Memberships{"north": RoleWorker}); !maps.Equal(user.Memberships, want) {
+		t.Fatalf("expected memberships %v, got %v", want, user.Memberships)
+	}
+	if user.OIDCSubject != "sub-1" {
+		t.Fatalf("expected OIDC subject sub-1, got %q", user.OIDCSubject)
+	}
+	before := user
+	for i, payload := range []string{
+		`{"role":"viewer","barn_id":"north"}`,
+		`{"role":"manager"}`,
+		`{"role":"owner","barn_id":"south"}`,
+		`{"role":"","barn_id":"south"}`,
+	} {
+		user, err = user.Apply(Event{ID: "e2", Type: UserRoleChangedEventType, Payload: []byte(payload)})
+		if err != nil {
+			t.Fatalf("apply role changed %d: %v", i, err)
+		}
+	}
+	if want := (Memberships{"north": RoleViewer, DefaultBarnID: RoleManager}); !maps.Equal(user.Memberships, want) {
+		t.Fatalf("expected memberships %v, got %v", want, user.Memberships)
+	}
+	if before.Memberships["north"] != RoleWorker {
+		t.Fatal("expected Apply to leave the earlier state unchanged")
+	}
+
+	if _, ok := ParseRole("admin"); ok {
+		t.Fatal("expected unknown role to be rejected")
+	}
+}
+
+func TestRedactPayload(t *testing.T) {
+	t.Parallel()
+
+	redacted, err := RedactPayload(UserCreatedEventType, []byte(`{"username":"anna","password_hash":"h1"}`))
+	if err != nil {
+		t.Fatalf("redact: %v", err)
+	}
+	if string(redacted) != `{"username":"anna"}` {
+		t.Fatalf("expected password hash removed, got %s", redacted)
+	}
+
+	animal := []byte(`{"name":"Nanny"}`)
+	kept, err := RedactPayload(AnimalCreatedEventType, animal)
+	if err != nil {
+		t.Fatalf("redact animal: %v", err)
+	}
+	if string(kept) != string(animal) {
+		t.Fatalf("expected animal payload unchanged, got %s", kept)
+	}
+}
+
+func TestMembershipsRoleIn(t *testing.T) {
+	t.Parallel()
+
+	memberships := Memberships{"north": RoleWorker, "south": RoleOwner, ServerBarnID: RoleManager}
+	tests := []struct {
+		barnID string
+		role   Role
+		ok     bool
+	}{
+		{barnID: "north", role: RoleManager, ok: true},
+		{barnID: "south", role: RoleOwner, ok: true},
+		{barnID: "east", role: RoleManager, ok: true},
+	}
+	for _, tt := range tests {
+		if role, ok := memberships.RoleIn(tt.barnID); role != tt.role || ok != tt.ok {
+			t.Fatalf("RoleIn(%q) = %q, %t; expected %q, %t", tt.barnID, role, ok, tt.role, tt.ok)
+		}
+	}
+	if _, ok := (Memberships{"north": RoleOwner}).RoleIn("south"); ok {
+		t.Fatal("expected no role in a barn without a membership")
+	}
+}
+
+func TestValidBarnID(t *testing.T) {
+	t.Parallel()
+
+	for _, id := range []string{"default", "north-barn", "b2"} {
+		if !ValidBarnID(id) {
+			t.Fatalf("expected %q to be valid", id)
+		}
+	}
+	for _, id := range []string{"", "North", "-north", "../north", "north/south", "north barn"} {
+		if ValidBarnID(id) {
+			t.Fatalf("expected %q to be invalid", id)
+		}
+	}
+}
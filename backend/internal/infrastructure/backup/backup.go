@@ -0,0 +1,241 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the sqlite driver used to verify backups
+)
+
+const (
+	// DatabaseFile is the name of the database snapshot inside a backup directory.
+	DatabaseFile = "barnlog.sqlite3"
+	// FilesDir is the name of the upload directory copy inside a backup directory.
+	FilesDir = "files"
+
+	namePrefix = "barnlog-"
+	nameLayout = "20060102T150405Z"
+	tmpSuffix  = ".tmp"
+
+	// staleAfter is how old an unfinished backup must be before Prune treats
+	// it as interrupted rather than still being written by another process.
+	staleAfter = 24 * time.Hour
+)
+
+// ErrIntegrity reports a backup whose database fails PRAGMA integrity_check.
+var ErrIntegrity = errors.New("backup integrity check failed")
+
+// Backup is one completed backup directory.
+type Backup struct {
+	Name      string
+	Path      string
+	CreatedAt time.Time
+}
+
+// Options configures a Manager.
+type Options struct {
+	// Dir holds one subdirectory per backup.
+	Dir string
+	// FileDir is the upload directory copied next to the database snapshot.
+	FileDir string
+	// Keep is the number of newest backups retained after each run; 0 keeps all.
+	Keep int
+}
+
+// Manager creates and prunes backups of a live database.
+type Manager struct {
+	db      *sql.DB
+	dir     string
+	fileDir string
+	keep    int
+	now     func() time.Time
+}
+
+// NewManager builds a Manager that snapshots db.
+func NewManager(db *sql.DB, opts Options) *Manager {
+	return &Manager{
+		db:      db,
+		dir:     opts.Dir,
+		fileDir: opts.FileDir,
+		keep:    opts.Keep,
+		now:     time.Now,
+	}
+}
+
+// Create writes a new backup, verifies it, and then prunes old backups.
+// The backup only becomes visible to List once it is complete and verified.
+func (m *Manager) Create(ctx context.Context) (Backup, error) {
+	createdAt := m.now().UTC().Truncate(time.Second)
+	name := namePrefix + createdAt.Format(nameLayout)
+	final := filepath.Join(m.dir, name)
+	if _, err := os.Stat(final); err == nil {
+		return Backup{}, fmt.Errorf("backup %s already exists", name)
+	}
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return Backup{}, fmt.Errorf("create backup directory: %w", err)
+	}
+	tmp := final + tmpSuffix
+	if err := os.RemoveAll(tmp); err != nil {
+		return Backup{}, fmt.Errorf("remove stale backup: %w", err)
+	}
+	if err := os.Mkdir(tmp, 0o750); err != nil {
+		return Backup{}, fmt.Errorf("create backup: %w", err)
+	}
+	complete := false
+	defer func() {
+		if !complete {
+			_ = os.RemoveAll(tmp)
+		}
+	}()
+
+	// VACUUM INTO reads inside one transaction, so the copy is consistent
+	// even while the server keeps appending events.
+	if _, err := m.db.ExecContext(ctx, `VACUUM INTO ?`, filepath.Join(tmp, DatabaseFile)); err != nil {
+		return Backup{}, fmt.Errorf("snapshot database: %w", err)
+	}
+	if err := copyDir(m.fileDir, filepath.Join(tmp, FilesDir)); err != nil {
+		return Backup{}, fmt.Errorf("copy upload directory: %w", err)
+	}
+	if err := Verify(ctx, tmp); err != nil {
+		return Backup{}, err
+	}
+	if err := os.Rename(tmp, final); err != nil {
+		return Backup{}, fmt.Errorf("publish backup: %w", err)
+	}
+	complete = true
+
+	backup := Backup{Name: name, Path: final, CreatedAt: createdAt}
+	if err := m.Prune(); err != nil {
+		return backup, err
+	}
+	return backup, nil
+}
+
+// List returns the completed backups, newest first.
+func (m *Manager) List() ([]Backup, error) {
+	return List(m.dir)
+}
+
+// Prune removes all but the newest Keep backups and any interrupted ones.
+func (m *Manager) Prune() error {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return fmt.Errorf("read backup directory: %w", err)
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), tmpSuffix)
+		if !entry.IsDir() || !ok {
+			continue
+		}
+		startedAt, ok := parseName(name)
+		if !ok || m.now().Sub(startedAt) < staleAfter {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(m.dir, entry.Name())); err != nil {
+			return fmt.Errorf("remove interrupted backup %s: %w", entry.Name(), err)
+		}
+	}
+
+	if m.keep <= 0 {
+		return nil
+	}
+	backups, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, old := range backups[min(m.keep, len(backups)):] {
+		if err := os.RemoveAll(old.Path); err != nil {
+			return fmt.Errorf("remove backup %s: %w", old.Name, err)
+		}
+	}
+	return nil
+}
+
+// List returns the completed backups in dir, newest first.
+// A missing dir has no backups.
+func List(dir string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read backup directory: %w", err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		createdAt, ok := parseName(entry.Name())
+		if !ok {
+			continue
+		}
+		backups = append(backups, Backup{
+			Name:      entry.Name(),
+			Path:      filepath.Join(dir, entry.Name()),
+			CreatedAt: createdAt,
+		})
+	}
+	slices.SortFunc(backups, func(a, b Backup) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return backups, nil
+}
+
+func parseName(name string) (time.Time, bool) {
+	stamp, ok := strings.CutPrefix(name, namePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	createdAt, err := time.Parse(nameLayout, stamp)
+	return createdAt, err == nil
+}
+
+// Verify opens the database of the backup at path read-only and runs
+// PRAGMA integrity_check on it.
+func Verify(ctx context.Context, path string) error {
+	return verifyDatabase(ctx, filepath.Join(path, DatabaseFile))
+}
+
+func verifyDatabase(ctx context.Context, dbPath string) error {
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("%w: %w", ErrIntegrity, err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("open backup: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrIntegrity, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("scan integrity check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrIntegrity, err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrIntegrity, strings.Join(problems, "; "))
+	}
+	return nil
+}
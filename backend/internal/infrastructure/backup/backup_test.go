@@ -0,0 +1,305 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManagerCreateListPrune(t *testing.T) {
+	db, _, fileDir := newTestSource(t)
+	backupDir := filepath.Join(t.TempDir(), "backups")
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	m := NewManager(db, Options{Dir: backupDir, FileDir: fileDir, Keep: 2})
+	m.now = func() time.Time { return now }
+
+	first, err := m.Create(context.Background())
+	if err != nil {
+		t.Fatalf("create backup: %v", err)
+	}
+	if first.Name != "barnlog-20260304T050607Z" || !first.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected backup %+v", first)
+	}
+	assertBackupContents(t, first.Path, "first")
+	if _, err := m.Create(context.Background()); err == nil {
+		t.Fatalf("expected an error for a second backup in the same second")
+	}
+
+	// Writes after the snapshot must not leak into it.
+	if _, err := db.Exec(`INSERT INTO notes (body) VALUES ('second')`); err != nil {
+		t.Fatalf("insert note: %v", err)
+	}
+	assertBackupContents(t, first.Path, "first")
+
+	for range 2 {
+		now = now.Add(time.Hour)
+		if _, err := m.Create(context.Background()); err != nil {
+			t.Fatalf("create backup: %v", err)
+		}
+	}
+
+	backups, err := m.List()
+	if err != nil {
+		t.Fatalf("list backups: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups after pruning, got %+v", backups)
+	}
+	if backups[0].Name != "barnlog-20260304T070607Z" || backups[1].Name != "barnlog-20260304T060607Z" {
+		t.Fatalf("expected newest backups first, got %+v", backups)
+	}
+	if _, err := os.Stat(first.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the oldest backup to be pruned, got %v", err)
+	}
+}
+
+func TestManagerPruneRemovesInterruptedBackups(t *testing.T) {
+	db, _, fileDir := newTestSource(t)
+	backupDir := t.TempDir()
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	m := NewManager(db, Options{Dir: backupDir, FileDir: fileDir})
+	m.now = func() time.Time { return now }
+
+	stale := filepath.Join(backupDir, "barnlog-20260302T000000Z.tmp")
+	running := filepath.Join(backupDir, "barnlog-20260304T050000Z.tmp")
+	for _, dir := range []string{stale, running} {
+		if err := os.Mkdir(dir, 0o750); err != nil {
+			t.Fatalf("create unfinished backup: %v", err)
+		}
+	}
+
+	if err := m.Prune(); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if _, err := os.Stat(stale); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected stale unfinished backup to be removed, got %v", err)
+	}
+	if _, err := os.Stat(running); err != nil {
+		t.Fatalf("expected recent unfinished backup to be kept, got %v", err)
+	}
+	if backups, err := m.List(); err != nil || len(backups) != 0 {
+		t.Fatalf("expected unfinished backups to be hidden from List, got %+v, %v", backups, err)
+	}
+}
+
+func TestVerifyRejectsCorruptBackup(t *testing.T) {
+	dir := t.TempDir()
+	if err := Verify(context.Background(), dir); !errors.Is(err, ErrIntegrity) {
+		t.Fatalf("expected ErrIntegrity for a missing database, got %v", err)
+	}
+
+	garbage := make([]byte, 8192)
+	for i := range garbage {
+		garbage[i] = byte(i)
+	}
+	if err := os.WriteFile(filepath.Join(dir, DatabaseFile), garbage, 0o600); err != nil {
+		t.Fatalf("write corrupt database: %v", err)
+	}
+	if err := Verify(context.Background(), dir); !errors.Is(err, ErrIntegrity) {
+		t.Fatalf("expected ErrIntegrity for a corrupt database, got %v", err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	db, dbPath, fileDir := newTestSource(t)
+	m := NewManager(db, Options{Dir: t.TempDir(), FileDir: fileDir})
+	backup, err := m.Create(context.Background())
+	if err != nil {
+		t.Fatalf("create backup: %v", err)
+	}
+
+	if _, err := db.Exec(`INSERT INTO notes (body) VALUES ('after backup')`); err != nil {
+		t.Fatalf("insert note: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(fileDir, "late.jpg"), []byte("late"), 0o600); err != nil {
+		t.Fatalf("write upload: %v", err)
+	}
+	if err := os.WriteFile(dbPath+"-journal", []byte("hot journal"), 0o600); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	_ = db.Close()
+
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	result, err := Restore(context.Background(), backup.Path, RestoreTarget{DBPath: dbPath, FileDir: fileDir}, now)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	suffix := ".pre-restore-20260304T050607Z"
+	if result.PreviousDB != dbPath+suffix || result.PreviousFiles != fileDir+suffix {
+		t.Fatalf("unexpected restore result %+v", result)
+	}
+
+	assertNotes(t, dbPath, "first")
+	assertNotes(t, result.PreviousDB, "first", "after backup")
+	if _, err := os.Stat(dbPath + "-journal"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the old journal to be moved aside, got %v", err)
+	}
+	if _, err := os.Stat(dbPath + "-journal" + suffix); err != nil {
+		t.Fatalf("expected the old journal next to the old database: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(fileDir, "late.jpg")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected uploads after the backup to be gone, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(result.PreviousFiles, "late.jpg")); err != nil {
+		t.Fatalf("expected previous uploads to be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(backup.Path, DatabaseFile)); err != nil {
+		t.Fatalf("expected the backup to stay intact: %v", err)
+	}
+}
+
+func TestRestoreRefusesCorruptBackup(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, DatabaseFile), []byte("not a database"), 0o600); err != nil {
+		t.Fatalf("write corrupt database: %v", err)
+	}
+	dbPath := filepath.Join(t.TempDir(), "barnlog.sqlite3")
+	if err := os.WriteFile(dbPath, []byte("current"), 0o600); err != nil {
+		t.Fatalf("write current database: %v", err)
+	}
+
+	_, err := Restore(context.Background(), dir, RestoreTarget{DBPath: dbPath, FileDir: t.TempDir()}, time.Now())
+	if !errors.Is(err, ErrIntegrity) {
+		t.Fatalf("expected ErrIntegrity, got %v", err)
+	}
+	if body, _ := os.ReadFile(dbPath); string(body) != "current" {
+		t.Fatalf("expected the current database to be untouched, got %q", body)
+	}
+}
+
+func TestRestoreRefusesDatabaseInUse(t *testing.T) {
+	db, dbPath, fileDir := newTestSource(t)
+	backup, err := NewManager(db, Options{Dir: t.TempDir(), FileDir: fileDir}).Create(context.Background())
+	if err != nil {
+		t.Fatalf("create backup: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO notes (body) VALUES ('after backup')`); err != nil {
+		t.Fatalf("insert note: %v", err)
+	}
+	_ = db.Close()
+	if err := os.WriteFile(dbPath+"-shm", nil, 0o600); err != nil {
+		t.Fatalf("write shm: %v", err)
+	}
+
+	_, err = Restore(context.Background(), backup.Path, RestoreTarget{DBPath: dbPath, FileDir: fileDir}, time.Now())
+	if !errors.Is(err, ErrInUse) {
+		t.Fatalf("expected ErrInUse, got %v", err)
+	}
+	assertNotes(t, dbPath, "first", "after backup")
+}
+
+func TestRestoreRollsBackFailedSwap(t *testing.T) {
+	db, dbPath, fileDir := newTestSource(t)
+	backup, err := NewManager(db, Options{Dir: t.TempDir(), FileDir: fileDir}).Create(context.Background())
+	if err != nil {
+		t.Fatalf("create backup: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO notes (body) VALUES ('after backup')`); err != nil {
+		t.Fatalf("insert note: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(fileDir, "late.jpg"), []byte("late"), 0o600); err != nil {
+		t.Fatalf("write upload: %v", err)
+	}
+	_ = db.Close()
+
+	// Fail the last step, installing the restored upload directory.
+	rename = func(oldpath, newpath string) error {
+		if oldpath == fileDir+".restore" {
+			return errors.New("disk full")
+		}
+		return os.Rename(oldpath, newpath)
+	}
+	t.Cleanup(func() { rename = os.Rename })
+
+	result, err := Restore(context.Background(), backup.Path, RestoreTarget{DBPath: dbPath, FileDir: fileDir}, time.Now())
+	if err == nil {
+		t.Fatal("expected the failed install to fail the restore")
+	}
+	if result != (RestoreResult{}) {
+		t.Fatalf("expected nothing to be left aside after the roll back, got %+v", result)
+	}
+	assertNotes(t, dbPath, "first", "after backup")
+	if _, err := os.Stat(filepath.Join(fileDir, "late.jpg")); err != nil {
+		t.Fatalf("expected the current uploads to be back: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(dbPath))
+	if err != nil {
+		t.Fatalf("read db dir: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".pre-restore-") || strings.HasSuffix(entry.Name(), ".restore") {
+			t.Fatalf("expected the roll back to clean up, found %s", entry.Name())
+		}
+	}
+}
+
+func newTestSource(t *testing.T) (*sql.DB, string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "barnlog.sqlite3")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if _, err := db.Exec(`CREATE TABLE notes (body TEXT NOT NULL); INSERT INTO notes (body) VALUES ('first')`); err != nil {
+		t.Fatalf("seed sqlite: %v", err)
+	}
+
+	fileDir := filepath.Join(dir, "files")
+	if err := os.MkdirAll(filepath.Join(fileDir, "animals"), 0o750); err != nil {
+		t.Fatalf("create upload dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(fileDir, "animals", "nanny.jpg"), []byte("jpeg"), 0o600); err != nil {
+		t.Fatalf("write upload: %v", err)
+	}
+	return db, dbPath, fileDir
+}
+
+func assertBackupContents(t *testing.T, path string, wantNotes ...string) {
+	t.Helper()
+
+	if err := Verify(context.Background(), path); err != nil {
+		t.Fatalf("verify backup: %v", err)
+	}
+	assertNotes(t, filepath.Join(path, DatabaseFile), wantNotes...)
+	body, err := os.ReadFile(filepath.Join(path, FilesDir, "animals", "nanny.jpg"))
+	if err != nil || string(body) != "jpeg" {
+		t.Fatalf("expected copied upload, got %q, %v", body, err)
+	}
+}
+
+func assertNotes(t *testing.T, dbPath string, want ...string) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open %s: %v", dbPath, err)
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query(`SELECT body FROM notes ORDER BY rowid`)
+	if err != nil {
+		t.Fatalf("query notes in %s: %v", dbPath, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var got []string
+	for rows.Next() {
+		var body string
+		if err := rows.Scan(&body); err != nil {
+			t.Fatalf("scan note: %v", err)
+		}
+		got = append(got, body)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected notes %q in %s, got %q", want, dbPath, got)
+	}
+}
@@ -0,0 +1,3 @@
+// Package backup writes verified online snapshots of the SQLite database and
+// the upload directory, prunes old ones, and restores them.
+package backup
@@ -0,0 +1,155 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// RestoreTarget is where a backup is restored to.
+type RestoreTarget struct {
+	DBPath  string
+	FileDir string
+}
+
+// RestoreResult reports where the replaced data was kept.
+type RestoreResult struct {
+	// PreviousDB is the moved-aside database, empty when there was none.
+	PreviousDB string
+	// PreviousFiles is the moved-aside upload directory, empty when there was none.
+	PreviousFiles string
+}
+
+// sqliteSidecars are the files SQLite keeps next to a database. They belong to
+// the database they were written for and must move with it.
+var sqliteSidecars = []string{"-wal", "-shm", "-journal"}
+
+// ErrInUse reports a database that may still be open: SQLite keeps its -wal and
+// -shm files while a connection is open in WAL mode and only a clean close
+// removes them.
+var ErrInUse = errors.New("database is in use; stop the server before restoring")
+
+// rename is os.Rename, replaced in tests to fail a step of the swap.
+var rename = os.Rename
+
+// Restore replaces the database and upload directory of target with the
+// backup at path. The server must not be running; Restore refuses with
+// ErrInUse while the database has -wal or -shm files.
+//
+// The backup is verified and staged next to the target before anything is
+// replaced. Both the current database and upload directory are then moved
+// aside with a ".pre-restore-<time>" suffix rather than deleted, and only then
+// is the restore installed. When a step of that swap fails, whatever was
+// installed is removed and the moved-aside data is renamed back.
+func Restore(ctx context.Context, path string, target RestoreTarget, now time.Time) (RestoreResult, error) {
+	for _, sidecar := range []string{"-wal", "-shm"} {
+		if _, err := os.Stat(target.DBPath + sidecar); err == nil {
+			return RestoreResult{}, fmt.Errorf("%w: %s exists", ErrInUse, target.DBPath+sidecar)
+		}
+	}
+	if err := Verify(ctx, path); err != nil {
+		return RestoreResult{}, err
+	}
+
+	stagedDB := target.DBPath + ".restore"
+	stagedFiles := target.FileDir + ".restore"
+	for _, staged := range []string{stagedDB, stagedFiles} {
+		if err := os.RemoveAll(staged); err != nil {
+			return RestoreResult{}, fmt.Errorf("remove stale restore: %w", err)
+		}
+	}
+	defer func() {
+		_ = os.RemoveAll(stagedDB)
+		_ = os.RemoveAll(stagedFiles)
+	}()
+
+	if err := os.MkdirAll(filepath.Dir(target.DBPath), 0o750); err != nil {
+		return RestoreResult{}, fmt.Errorf("create db directory: %w", err)
+	}
+	if err := copyFile(filepath.Join(path, DatabaseFile), stagedDB); err != nil {
+		return RestoreResult{}, fmt.Errorf("stage database: %w", err)
+	}
+	if err := verifyDatabase(ctx, stagedDB); err != nil {
+		return RestoreResult{}, err
+	}
+	if err := copyDir(filepath.Join(path, FilesDir), stagedFiles); err != nil {
+		return RestoreResult{}, fmt.Errorf("stage upload directory: %w", err)
+	}
+
+	swap := restoreSwap{suffix: ".pre-restore-" + now.UTC().Format(nameLayout)}
+	current := []string{target.DBPath}
+	for _, sidecar := range sqliteSidecars {
+		current = append(current, target.DBPath+sidecar)
+	}
+	current = append(current, target.FileDir)
+	for _, path := range current {
+		if err := swap.moveAside(path); err != nil {
+			return swap.rollBack(target, fmt.Errorf("move %s aside: %w", path, err))
+		}
+	}
+	for _, install := range []struct{ staged, path string }{
+		{staged: stagedDB, path: target.DBPath},
+		{staged: stagedFiles, path: target.FileDir},
+	} {
+		if err := rename(install.staged, install.path); err != nil {
+			return swap.rollBack(target, fmt.Errorf("install restored %s: %w", install.path, err))
+		}
+		swap.installed = append(swap.installed, install.path)
+	}
+	return swap.result(target), nil
+}
+
+// restoreSwap tracks the paths a restore has moved aside and installed, so a
+// failed swap can be undone.
+type restoreSwap struct {
+	suffix    string
+	aside     []string
+	installed []string
+}
+
+// moveAside renames path to path+suffix; a missing path is skipped.
+func (s *restoreSwap) moveAside(path string) error {
+	if err := rename(path, path+s.suffix); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	s.aside = append(s.aside, path)
+	return nil
+}
+
+// rollBack removes what was installed and moves the current data back. When
+// that fails too, the result names where the current data was left.
+func (s *restoreSwap) rollBack(target RestoreTarget, cause error) (RestoreResult, error) {
+	var errs []error
+	for _, path := range s.installed {
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(s.aside) - 1; i >= 0; i-- {
+		if err := rename(s.aside[i]+s.suffix, s.aside[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return s.result(target), errors.Join(cause, fmt.Errorf("roll back restore: %w", errors.Join(errs...)))
+	}
+	return RestoreResult{}, cause
+}
+
+func (s *restoreSwap) result(target RestoreTarget) RestoreResult {
+	var result RestoreResult
+	if slices.Contains(s.aside, target.DBPath) {
+		result.PreviousDB = target.DBPath + s.suffix
+	}
+	if slices.Contains(s.aside, target.FileDir) {
+		result.PreviousFiles = target.FileDir + s.suffix
+	}
+	return result
+}
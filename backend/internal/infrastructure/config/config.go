@@ -4,34 +4,142 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Config contains server and infrastructure settings sourced from environment variables.
 type Config struct {
-	Env             string
-	HTTPAddr        string
-	DBPath          string
-	MigrationsPath  string
-	FileDir         string
-	AutoMigrate     bool
-	LogLevel        slog.Level
-	ShutdownTimeout time.Duration
+	Env                 string
+	HTTPAddr            string
+	DBPath              string
+	DBBusyTimeout       time.Duration
+	DBJournalMode       string
+	DBSynchronous       string
+	DBMaxReadConns      int
+	MigrationsPath      string
+	FileDir             string
+	AutoMigrate         bool
+	LogLevel            slog.Level
+	ShutdownTimeout     time.Duration
+	SnapshotEvery       int
+	WebhookPollInterval time.Duration
+	WebhookTimeout      time.Duration
+	WebhookMaxAttempts  int
+	BackupDir           string
+	BackupInterval      time.Duration
+	BackupKeep          int
+	ReplicationTarget   string
+	ReplicationToken    string
+	ReplicationInterval time.Duration
+	TraceExporter       string
+	TraceOTLPEndpoint   string
+	SessionTTL          time.Duration
+	// OIDCIssuer enables single sign-on with the OpenID Connect provider at
+	// this issuer URL; empty disables it.
+	OIDCIssuer        string
+	OIDCClientID      string
+	OIDCClientSecret  string
+	OIDCRedirectURL   string
+	OIDCScopes        []string
+	OIDCUsernameClaim string
+	OIDCRolesClaim    string
+	// CORSAllowedOrigins may call the API from a browser; empty disables CORS
+	// and "*" allows any origin.
+	CORSAllowedOrigins   []string
+	CORSAllowCredentials bool
+	CORSMaxAge           time.Duration
+	// HSTSMaxAge is announced in Strict-Transport-Security on TLS requests;
+	// zero leaves the header out.
+	HSTSMaxAge time.Duration
+	// TLSMode is "off", "files" to serve TLSCertFile and TLSKeyFile, or
+	// "self-signed" to serve a certificate from a local CA kept in TLSDir.
+	TLSMode     string
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSDir      string
+	// TLSHosts are names and addresses added to the self-signed certificate.
+	TLSHosts []string
+	// TLSRedirectAddr serves redirects from plain HTTP to HTTPS; empty disables it.
+	TLSRedirectAddr string
+	// MetricsAddr serves /metrics over plain HTTP on a listener of its own,
+	// without authentication. It defaults to 127.0.0.1:9090 so only local
+	// scrapers reach it, must differ from HTTPAddr and TLSRedirectAddr, and
+	// empty disables it.
+	MetricsAddr string
+	// RateLimitPerMinute refills each client's request budget of
+	// RateLimitBurst requests; zero disables request limiting.
+	RateLimitPerMinute int
+	RateLimitBurst     int
+	// UploadBytesPerHour caps each client's uploads; zero disables the cap.
+	UploadBytesPerHour int64
+	// AuthFailuresPerMinute is how many 401 answers each IP address may get
+	// per minute before all its requests are refused; zero disables the cap.
+	AuthFailuresPerMinute int
+}
+
+// TLS modes.
+const (
+	TLSModeOff        = "off"
+	TLSModeFiles      = "files"
+	TLSModeSelfSigned = "self-signed"
+)
+
+// devOrigins are the SvelteKit dev and preview servers, allowed by default
+// in local and dev.
+const devOrigins = "http://localhost:5173 http://127.0.0.1:5173 http://localhost:4173 http://127.0.0.1:4173"
+
+// Development reports whether the server runs on a developer machine.
+func (c Config) Development() bool {
+	return c.Env == "local" || c.Env == "dev"
 }
 
 // LoadFromEnv builds Config from environment variables and defaults.
 func LoadFromEnv() (Config, error) {
 	cfg := Config{
-		Env:             getenv("BARNLOG_ENV", "dev"),
-		HTTPAddr:        getenv("BARNLOG_HTTP_ADDR", ":8080"),
-		DBPath:          getenv("BARNLOG_DB_PATH", "backend/db/dev.sqlite3"),
-		MigrationsPath:  getenv("BARNLOG_MIGRATIONS_PATH", "backend/db/migrations"),
-		FileDir:         getenv("BARNLOG_FILE_DIR", "backend/uploads/files"),
-		AutoMigrate:     true,
-		ShutdownTimeout: 10 * time.Second,
+		Env:                  getenv("BARNLOG_ENV", "dev"),
+		HTTPAddr:             getenv("BARNLOG_HTTP_ADDR", ":8080"),
+		DBPath:               getenv("BARNLOG_DB_PATH", "backend/db/dev.sqlite3"),
+		DBBusyTimeout:        5 * time.Second,
+		DBMaxReadConns:       4,
+		MigrationsPath:       getenv("BARNLOG_MIGRATIONS_PATH", ""),
+		FileDir:              getenv("BARNLOG_FILE_DIR", "backend/uploads/files"),
+		AutoMigrate:          true,
+		ShutdownTimeout:      10 * time.Second,
+		SnapshotEvery:        100,
+		WebhookPollInterval:  2 * time.Second,
+		WebhookTimeout:       10 * time.Second,
+		WebhookMaxAttempts:   8,
+		BackupDir:            getenv("BARNLOG_BACKUP_DIR", "backend/backups"),
+		BackupInterval:       24 * time.Hour,
+		BackupKeep:           7,
+		ReplicationTarget:    getenv("BARNLOG_REPLICATION_TARGET", ""),
+		ReplicationToken:     getenv("BARNLOG_REPLICATION_TOKEN", ""),
+		ReplicationInterval:  5 * time.Second,
+		TraceExporter:        strings.ToLower(getenv("BARNLOG_TRACE_EXPORTER", "none")),
+		TraceOTLPEndpoint:    strings.TrimRight(getenv("BARNLOG_TRACE_OTLP_ENDPOINT", "http://localhost:4318"), "/"),
+		SessionTTL:           7 * 24 * time.Hour,
+		OIDCIssuer:           getenv("BARNLOG_OIDC_ISSUER", ""),
+		OIDCClientID:         getenv("BARNLOG_OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:     getenv("BARNLOG_OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:      getenv("BARNLOG_OIDC_REDIRECT_URL", ""),
+		OIDCScopes:           strings.Fields(getenv("BARNLOG_OIDC_SCOPES", "openid profile")),
+		OIDCUsernameClaim:    getenv("BARNLOG_OIDC_USERNAME_CLAIM", "preferred_username"),
+		OIDCRolesClaim:       getenv("BARNLOG_OIDC_ROLES_CLAIM", "barnlog_roles"),
+		CORSAllowCredentials: true,
+		TLSMode:              strings.ToLower(getenv("BARNLOG_TLS_MODE", TLSModeOff)),
+		TLSCertFile:          getenv("BARNLOG_TLS_CERT_FILE", ""),
+		TLSKeyFile:           getenv("BARNLOG_TLS_KEY_FILE", ""),
+		TLSDir:               getenv("BARNLOG_TLS_DIR", "backend/tls"),
+		TLSHosts:             splitList(getenv("BARNLOG_TLS_HOSTS", "")),
+		TLSRedirectAddr:      getenv("BARNLOG_TLS_REDIRECT_ADDR", ""),
+		MetricsAddr:          getenv("BARNLOG_METRICS_ADDR", "127.0.0.1:9090"),
+		CORSMaxAge:           10 * time.Minute,
 	}
 
 	logLevel, err := parseLogLevel(getenv("BARNLOG_LOG_LEVEL", "info"))
@@ -48,6 +156,28 @@ func LoadFromEnv() (Config, error) {
 		cfg.ShutdownTimeout = dur
 	}
 
+	if cfg.DBBusyTimeout, err = positiveDurationEnv("BARNLOG_DB_BUSY_TIMEOUT", cfg.DBBusyTimeout); err != nil {
+		return Config{}, err
+	}
+	if cfg.DBJournalMode, err = enumEnv(
+		"BARNLOG_DB_JOURNAL_MODE", "WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF",
+	); err != nil {
+		return Config{}, err
+	}
+	if cfg.DBSynchronous, err = enumEnv("BARNLOG_DB_SYNCHRONOUS", "NORMAL", "OFF", "NORMAL", "FULL", "EXTRA"); err != nil {
+		return Config{}, err
+	}
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_DB_MAX_READ_CONNS")); raw != "" {
+		conns, err := strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse BARNLOG_DB_MAX_READ_CONNS: %w", err)
+		}
+		if conns < 1 {
+			return Config{}, fmt.Errorf("parse BARNLOG_DB_MAX_READ_CONNS: must be at least 1, got %d", conns)
+		}
+		cfg.DBMaxReadConns = conns
+	}
+
 	if raw := strings.TrimSpace(os.Getenv("BARNLOG_AUTO_MIGRATE")); raw != "" {
 		enabled, err := strconv.ParseBool(raw)
 		if err != nil {
@@ -56,9 +186,267 @@ func LoadFromEnv() (Config, error) {
 		cfg.AutoMigrate = enabled
 	}
 
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_SNAPSHOT_EVERY")); raw != "" {
+		every, err := strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse BARNLOG_SNAPSHOT_EVERY: %w", err)
+		}
+		if every < 0 {
+			return Config{}, fmt.Errorf("parse BARNLOG_SNAPSHOT_EVERY: must not be negative, got %d", every)
+		}
+		cfg.SnapshotEvery = every
+	}
+
+	if cfg.WebhookPollInterval, err = positiveDurationEnv("BARNLOG_WEBHOOK_POLL_INTERVAL", cfg.WebhookPollInterval); err != nil {
+		return Config{}, err
+	}
+	if cfg.WebhookTimeout, err = positiveDurationEnv("BARNLOG_WEBHOOK_TIMEOUT", cfg.WebhookTimeout); err != nil {
+		return Config{}, err
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_WEBHOOK_MAX_ATTEMPTS")); raw != "" {
+		attempts, err := strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse BARNLOG_WEBHOOK_MAX_ATTEMPTS: %w", err)
+		}
+		if attempts < 1 {
+			return Config{}, fmt.Errorf("parse BARNLOG_WEBHOOK_MAX_ATTEMPTS: must be at least 1, got %d", attempts)
+		}
+		cfg.WebhookMaxAttempts = attempts
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_BACKUP_INTERVAL")); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse BARNLOG_BACKUP_INTERVAL: %w", err)
+		}
+		if interval < 0 {
+			return Config{}, fmt.Errorf("parse BARNLOG_BACKUP_INTERVAL: must not be negative, got %s", interval)
+		}
+		cfg.BackupInterval = interval
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_BACKUP_KEEP")); raw != "" {
+		keep, err := strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse BARNLOG_BACKUP_KEEP: %w", err)
+		}
+		if keep < 0 {
+			return Config{}, fmt.Errorf("parse BARNLOG_BACKUP_KEEP: must not be negative, got %d", keep)
+		}
+		cfg.BackupKeep = keep
+	}
+
+	if err := validateReplicationTarget(cfg.ReplicationTarget); err != nil {
+		return Config{}, err
+	}
+	if cfg.ReplicationInterval, err = positiveDurationEnv("BARNLOG_REPLICATION_INTERVAL", cfg.ReplicationInterval); err != nil {
+		return Config{}, err
+	}
+
+	if !slices.Contains([]string{"none", "otlp", "stdout"}, cfg.TraceExporter) {
+		return Config{}, fmt.Errorf("parse BARNLOG_TRACE_EXPORTER: must be one of none, otlp, stdout, got %q", cfg.TraceExporter)
+	}
+	if u, err := url.Parse(cfg.TraceOTLPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Config{}, fmt.Errorf("parse BARNLOG_TRACE_OTLP_ENDPOINT: must be an http(s) URL, got %q", cfg.TraceOTLPEndpoint)
+	}
+
+	if cfg.SessionTTL, err = positiveDurationEnv("BARNLOG_SESSION_TTL", cfg.SessionTTL); err != nil {
+		return Config{}, err
+	}
+
+	if err := validateOIDC(cfg); err != nil {
+		return Config{}, err
+	}
+
+	if err := loadHTTPSecurity(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := validateTLS(cfg); err != nil {
+		return Config{}, err
+	}
+	if cfg.MetricsAddr != "" && (cfg.MetricsAddr == cfg.HTTPAddr || cfg.MetricsAddr == cfg.TLSRedirectAddr) {
+		return Config{}, fmt.Errorf("parse BARNLOG_METRICS_ADDR: must differ from BARNLOG_HTTP_ADDR and BARNLOG_TLS_REDIRECT_ADDR")
+	}
+
+	if cfg.RateLimitPerMinute, err = minIntEnv("BARNLOG_RATE_LIMIT_PER_MINUTE", 600, 0); err != nil {
+		return Config{}, err
+	}
+	if cfg.RateLimitBurst, err = minIntEnv("BARNLOG_RATE_LIMIT_BURST", 120, 1); err != nil {
+		return Config{}, err
+	}
+	uploadMiB, err := minIntEnv("BARNLOG_UPLOAD_MIB_PER_HOUR", 1024, 0)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.UploadBytesPerHour = int64(uploadMiB) << 20
+	if cfg.AuthFailuresPerMinute, err = minIntEnv("BARNLOG_AUTH_FAILURES_PER_MINUTE", 10, 0); err != nil {
+		return Config{}, err
+	}
+
 	return cfg, nil
 }
 
+// loadHTTPSecurity reads the CORS and HSTS settings, whose defaults depend on
+// BARNLOG_ENV: dev and local allow the SvelteKit dev server and skip HSTS,
+// test skips HSTS too, and every other environment allows no foreign
+// origin and asks for HSTS.
+func loadHTTPSecurity(cfg *Config) error {
+	origins := ""
+	if cfg.Development() {
+		origins = devOrigins
+	}
+	if !cfg.Development() && cfg.Env != "test" {
+		cfg.HSTSMaxAge = 365 * 24 * time.Hour
+	}
+
+	cfg.CORSAllowedOrigins = splitList(getenv("BARNLOG_CORS_ALLOWED_ORIGINS", origins))
+	for _, origin := range cfg.CORSAllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); !isHTTPURL(origin) || err != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("parse BARNLOG_CORS_ALLOWED_ORIGINS: %q is not an http(s) origin", origin)
+		}
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_CORS_ALLOW_CREDENTIALS")); raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("parse BARNLOG_CORS_ALLOW_CREDENTIALS: %w", err)
+		}
+		cfg.CORSAllowCredentials = enabled
+	}
+	if cfg.CORSAllowCredentials && slices.Contains(cfg.CORSAllowedOrigins, "*") {
+		return fmt.Errorf("parse BARNLOG_CORS_ALLOWED_ORIGINS: \"*\" cannot be combined with BARNLOG_CORS_ALLOW_CREDENTIALS")
+	}
+
+	var err error
+	if cfg.CORSMaxAge, err = positiveDurationEnv("BARNLOG_CORS_MAX_AGE", cfg.CORSMaxAge); err != nil {
+		return err
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("BARNLOG_HSTS_MAX_AGE")); raw != "" {
+		maxAge, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("parse BARNLOG_HSTS_MAX_AGE: %w", err)
+		}
+		if maxAge < 0 {
+			return fmt.Errorf("parse BARNLOG_HSTS_MAX_AGE: must not be negative, got %s", maxAge)
+		}
+		cfg.HSTSMaxAge = maxAge
+	}
+	return nil
+}
+
+// validateOIDC checks the single sign-on settings when BARNLOG_OIDC_ISSUER is set.
+func validateOIDC(cfg Config) error {
+	if cfg.OIDCIssuer == "" {
+		return nil
+	}
+	if !isHTTPURL(cfg.OIDCIssuer) {
+		return fmt.Errorf("parse BARNLOG_OIDC_ISSUER: must be an http(s) URL, got %q", cfg.OIDCIssuer)
+	}
+	if cfg.OIDCClientID == "" {
+		return fmt.Errorf("parse BARNLOG_OIDC_CLIENT_ID: required when BARNLOG_OIDC_ISSUER is set")
+	}
+	if !isHTTPURL(cfg.OIDCRedirectURL) {
+		return fmt.Errorf("parse BARNLOG_OIDC_REDIRECT_URL: must be the http(s) URL of /auth/oidc/callback, got %q", cfg.OIDCRedirectURL)
+	}
+	return nil
+}
+
+// validateTLS checks that the chosen TLS mode has what it needs.
+func validateTLS(cfg Config) error {
+	switch cfg.TLSMode {
+	case TLSModeOff:
+		if cfg.TLSRedirectAddr != "" {
+			return fmt.Errorf("parse BARNLOG_TLS_REDIRECT_ADDR: needs BARNLOG_TLS_MODE %s or %s", TLSModeFiles, TLSModeSelfSigned)
+		}
+	case TLSModeFiles:
+		if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			return fmt.Errorf("parse BARNLOG_TLS_MODE: %s needs BARNLOG_TLS_CERT_FILE and BARNLOG_TLS_KEY_FILE", TLSModeFiles)
+		}
+	case TLSModeSelfSigned:
+	default:
+		return fmt.Errorf("parse BARNLOG_TLS_MODE: must be one of %s, %s, %s, got %q",
+			TLSModeOff, TLSModeFiles, TLSModeSelfSigned, cfg.TLSMode)
+	}
+	if cfg.TLSRedirectAddr != "" && cfg.TLSRedirectAddr == cfg.HTTPAddr {
+		return fmt.Errorf("parse BARNLOG_TLS_REDIRECT_ADDR: must differ from BARNLOG_HTTP_ADDR")
+	}
+	return nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ReplicationFilePrefix marks a BARNLOG_REPLICATION_TARGET that is a local SQLite file.
+const ReplicationFilePrefix = "file:"
+
+// validateReplicationTarget accepts "", "file:<path>" or an absolute http(s) URL.
+func validateReplicationTarget(target string) error {
+	if target == "" {
+		return nil
+	}
+	if path, ok := strings.CutPrefix(target, ReplicationFilePrefix); ok {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("parse BARNLOG_REPLICATION_TARGET: file target needs a path")
+		}
+		return nil
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("parse BARNLOG_REPLICATION_TARGET: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("parse BARNLOG_REPLICATION_TARGET: must be file:<path> or an http(s) URL, got %q", target)
+	}
+	return nil
+}
+
+func positiveDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback, nil
+	}
+	dur, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	if dur <= 0 {
+		return 0, fmt.Errorf("parse %s: must be positive, got %s", key, dur)
+	}
+	return dur, nil
+}
+
+// minIntEnv returns the integer value of key, which must be at least lowest.
+func minIntEnv(key string, fallback, lowest int) (int, error) {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	if value < lowest {
+		return 0, fmt.Errorf("parse %s: must be at least %d, got %d", key, lowest, value)
+	}
+	return value, nil
+}
+
+// enumEnv returns the upper-cased value of key, which must be one of allowed.
+func enumEnv(key, fallback string, allowed ...string) (string, error) {
+	value := strings.ToUpper(getenv(key, fallback))
+	if !slices.Contains(allowed, value) {
+		return "", fmt.Errorf("parse %s: must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+	}
+	return value, nil
+}
+
 func parseLogLevel(raw string) (slog.Level, error) {
 	var level slog.Level
 	if err := level.UnmarshalText([]byte(strings.TrimSpace(strings.ToLower(raw)))); err != nil {
@@ -67,6 +455,13 @@ func parseLogLevel(raw string) (slog.Level, error) {
 	return level, nil
 }
 
+// splitList splits a comma- or space-separated list.
+func splitList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
 func getenv(key, fallback string) string {
 	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
 		return value
@@ -2,6 +2,7 @@ package config
 
 import (
 	"log/slog"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -11,11 +12,50 @@ func TestLoadFromEnvDefaults(t *testing.T) {
 	t.Setenv("BARNLOG_ENV", "")
 	t.Setenv("BARNLOG_HTTP_ADDR", "")
 	t.Setenv("BARNLOG_DB_PATH", "")
+	t.Setenv("BARNLOG_DB_BUSY_TIMEOUT", "")
+	t.Setenv("BARNLOG_DB_JOURNAL_MODE", "")
+	t.Setenv("BARNLOG_DB_SYNCHRONOUS", "")
+	t.Setenv("BARNLOG_DB_MAX_READ_CONNS", "")
 	t.Setenv("BARNLOG_MIGRATIONS_PATH", "")
 	t.Setenv("BARNLOG_FILE_DIR", "")
 	t.Setenv("BARNLOG_AUTO_MIGRATE", "")
 	t.Setenv("BARNLOG_LOG_LEVEL", "")
 	t.Setenv("BARNLOG_SHUTDOWN_TIMEOUT", "")
+	t.Setenv("BARNLOG_SNAPSHOT_EVERY", "")
+	t.Setenv("BARNLOG_WEBHOOK_POLL_INTERVAL", "")
+	t.Setenv("BARNLOG_WEBHOOK_TIMEOUT", "")
+	t.Setenv("BARNLOG_WEBHOOK_MAX_ATTEMPTS", "")
+	t.Setenv("BARNLOG_BACKUP_DIR", "")
+	t.Setenv("BARNLOG_BACKUP_INTERVAL", "")
+	t.Setenv("BARNLOG_BACKUP_KEEP", "")
+	t.Setenv("BARNLOG_REPLICATION_TARGET", "")
+	t.Setenv("BARNLOG_REPLICATION_TOKEN", "")
+	t.Setenv("BARNLOG_REPLICATION_INTERVAL", "")
+	t.Setenv("BARNLOG_TRACE_EXPORTER", "")
+	t.Setenv("BARNLOG_TRACE_OTLP_ENDPOINT", "")
+	t.Setenv("BARNLOG_SESSION_TTL", "")
+	t.Setenv("BARNLOG_OIDC_ISSUER", "")
+	t.Setenv("BARNLOG_OIDC_CLIENT_ID", "")
+	t.Setenv("BARNLOG_OIDC_CLIENT_SECRET", "")
+	t.Setenv("BARNLOG_OIDC_REDIRECT_URL", "")
+	t.Setenv("BARNLOG_OIDC_SCOPES", "")
+	t.Setenv("BARNLOG_OIDC_USERNAME_CLAIM", "")
+	t.Setenv("BARNLOG_OIDC_ROLES_CLAIM", "")
+	t.Setenv("BARNLOG_CORS_ALLOWED_ORIGINS", "")
+	t.Setenv("BARNLOG_CORS_ALLOW_CREDENTIALS", "")
+	t.Setenv("BARNLOG_CORS_MAX_AGE", "")
+	t.Setenv("BARNLOG_HSTS_MAX_AGE", "")
+	t.Setenv("BARNLOG_TLS_MODE", "")
+	t.Setenv("BARNLOG_TLS_CERT_FILE", "")
+	t.Setenv("BARNLOG_TLS_KEY_FILE", "")
+	t.Setenv("BARNLOG_TLS_DIR", "")
+	t.Setenv("BARNLOG_TLS_HOSTS", "")
+	t.Setenv("BARNLOG_TLS_REDIRECT_ADDR", "")
+	t.Setenv("BARNLOG_METRICS_ADDR", "")
+	t.Setenv("BARNLOG_RATE_LIMIT_PER_MINUTE", "")
+	t.Setenv("BARNLOG_RATE_LIMIT_BURST", "")
+	t.Setenv("BARNLOG_UPLOAD_MIB_PER_HOUR", "")
+	t.Setenv("BARNLOG_AUTH_FAILURES_PER_MINUTE", "")
 
 	cfg, err := LoadFromEnv()
 	if err != nil {
@@ -31,8 +71,20 @@ func TestLoadFromEnvDefaults(t *testing.T) {
 	if cfg.DBPath != "backend/db/dev.sqlite3" {
 		t.Fatalf("expected DBPath=backend/db/dev.sqlite3, got %q", cfg.DBPath)
 	}
-	if cfg.MigrationsPath != "backend/db/migrations" {
-		t.Fatalf("expected MigrationsPath=backend/db/migrations, got %q", cfg.MigrationsPath)
+	if cfg.DBBusyTimeout != 5*time.Second {
+		t.Fatalf("expected DBBusyTimeout=5s, got %s", cfg.DBBusyTimeout)
+	}
+	if cfg.DBJournalMode != "WAL" {
+		t.Fatalf("expected DBJournalMode=WAL, got %q", cfg.DBJournalMode)
+	}
+	if cfg.DBSynchronous != "NORMAL" {
+		t.Fatalf("expected DBSynchronous=NORMAL, got %q", cfg.DBSynchronous)
+	}
+	if cfg.DBMaxReadConns != 4 {
+		t.Fatalf("expected DBMaxReadConns=4, got %d", cfg.DBMaxReadConns)
+	}
+	if cfg.MigrationsPath != "" {
+		t.Fatalf("expected embedded migrations by default, got MigrationsPath=%q", cfg.MigrationsPath)
 	}
 	if cfg.FileDir != "backend/uploads/files" {
 		t.Fatalf("expected FileDir=backend/uploads/files, got %q", cfg.FileDir)
@@ -46,17 +98,126 @@ func TestLoadFromEnvDefaults(t *testing.T) {
 	if cfg.ShutdownTimeout != 10*time.Second {
 		t.Fatalf("expected ShutdownTimeout=10s, got %s", cfg.ShutdownTimeout)
 	}
+	if cfg.SnapshotEvery != 100 {
+		t.Fatalf("expected SnapshotEvery=100, got %d", cfg.SnapshotEvery)
+	}
+	if cfg.WebhookPollInterval != 2*time.Second {
+		t.Fatalf("expected WebhookPollInterval=2s, got %s", cfg.WebhookPollInterval)
+	}
+	if cfg.WebhookTimeout != 10*time.Second {
+		t.Fatalf("expected WebhookTimeout=10s, got %s", cfg.WebhookTimeout)
+	}
+	if cfg.WebhookMaxAttempts != 8 {
+		t.Fatalf("expected WebhookMaxAttempts=8, got %d", cfg.WebhookMaxAttempts)
+	}
+	if cfg.BackupDir != "backend/backups" {
+		t.Fatalf("expected BackupDir=backend/backups, got %q", cfg.BackupDir)
+	}
+	if cfg.BackupInterval != 24*time.Hour {
+		t.Fatalf("expected BackupInterval=24h, got %s", cfg.BackupInterval)
+	}
+	if cfg.BackupKeep != 7 {
+		t.Fatalf("expected BackupKeep=7, got %d", cfg.BackupKeep)
+	}
+	if cfg.ReplicationTarget != "" {
+		t.Fatalf("expected replication disabled, got target %q", cfg.ReplicationTarget)
+	}
+	if cfg.ReplicationToken != "" {
+		t.Fatalf("expected no replication token, got %q", cfg.ReplicationToken)
+	}
+	if cfg.ReplicationInterval != 5*time.Second {
+		t.Fatalf("expected ReplicationInterval=5s, got %s", cfg.ReplicationInterval)
+	}
+	if cfg.TraceExporter != "none" {
+		t.Fatalf("expected TraceExporter=none, got %q", cfg.TraceExporter)
+	}
+	if cfg.TraceOTLPEndpoint != "http://localhost:4318" {
+		t.Fatalf("expected TraceOTLPEndpoint=http://localhost:4318, got %q", cfg.TraceOTLPEndpoint)
+	}
+	if cfg.SessionTTL != 7*24*time.Hour {
+		t.Fatalf("expected SessionTTL=168h, got %s", cfg.SessionTTL)
+	}
+	if cfg.OIDCIssuer != "" {
+		t.Fatalf("expected single sign-on disabled, got issuer %q", cfg.OIDCIssuer)
+	}
+	if !slices.Equal(cfg.OIDCScopes, []string{"openid", "profile"}) {
+		t.Fatalf("expected OIDCScopes=[openid profile], got %v", cfg.OIDCScopes)
+	}
+	if cfg.OIDCUsernameClaim != "preferred_username" || cfg.OIDCRolesClaim != "barnlog_roles" {
+		t.Fatalf("expected default claims, got %q and %q", cfg.OIDCUsernameClaim, cfg.OIDCRolesClaim)
+	}
+	if !slices.Contains(cfg.CORSAllowedOrigins, "http://localhost:5173") || !cfg.CORSAllowCredentials ||
+		cfg.CORSMaxAge != 10*time.Minute {
+		t.Fatalf("expected the dev server to be allowed by CORS, got %v, %t, %s",
+			cfg.CORSAllowedOrigins, cfg.CORSAllowCredentials, cfg.CORSMaxAge)
+	}
+	if cfg.HSTSMaxAge != 0 {
+		t.Fatalf("expected HSTS disabled in dev, got %s", cfg.HSTSMaxAge)
+	}
+	if cfg.TLSMode != TLSModeOff || cfg.TLSDir != "backend/tls" || len(cfg.TLSHosts) != 0 || cfg.TLSRedirectAddr != "" {
+		t.Fatalf("expected TLS off by default, got mode %q, dir %q, hosts %v, redirect %q",
+			cfg.TLSMode, cfg.TLSDir, cfg.TLSHosts, cfg.TLSRedirectAddr)
+	}
+	if cfg.RateLimitPerMinute != 600 || cfg.RateLimitBurst != 120 || cfg.UploadBytesPerHour != 1<<30 {
+		t.Fatalf("expected default rate limits, got %d/min, burst %d, %d upload bytes/h",
+			cfg.RateLimitPerMinute, cfg.RateLimitBurst, cfg.UploadBytesPerHour)
+	}
+	if cfg.MetricsAddr != "127.0.0.1:9090" {
+		t.Fatalf("expected metrics on 127.0.0.1:9090 by default, got %q", cfg.MetricsAddr)
+	}
+	if cfg.AuthFailuresPerMinute != 10 {
+		t.Fatalf("expected 10 authentication failures per minute, got %d", cfg.AuthFailuresPerMinute)
+	}
 }
 
 func TestLoadFromEnvCustomValues(t *testing.T) {
 	t.Setenv("BARNLOG_ENV", "prod")
 	t.Setenv("BARNLOG_HTTP_ADDR", ":9090")
 	t.Setenv("BARNLOG_DB_PATH", "backend/db/custom.sqlite3")
+	t.Setenv("BARNLOG_DB_BUSY_TIMEOUT", "250ms")
+	t.Setenv("BARNLOG_DB_JOURNAL_MODE", "delete")
+	t.Setenv("BARNLOG_DB_SYNCHRONOUS", "full")
+	t.Setenv("BARNLOG_DB_MAX_READ_CONNS", "2")
 	t.Setenv("BARNLOG_MIGRATIONS_PATH", "backend/db/custom-migrations")
 	t.Setenv("BARNLOG_FILE_DIR", "backend/uploads/custom-files")
 	t.Setenv("BARNLOG_AUTO_MIGRATE", "false")
 	t.Setenv("BARNLOG_LOG_LEVEL", "debug")
 	t.Setenv("BARNLOG_SHUTDOWN_TIMEOUT", "3s")
+	t.Setenv("BARNLOG_SNAPSHOT_EVERY", "0")
+	t.Setenv("BARNLOG_WEBHOOK_POLL_INTERVAL", "500ms")
+	t.Setenv("BARNLOG_WEBHOOK_TIMEOUT", "4s")
+	t.Setenv("BARNLOG_WEBHOOK_MAX_ATTEMPTS", "3")
+	t.Setenv("BARNLOG_BACKUP_DIR", "/var/backups/barnlog")
+	t.Setenv("BARNLOG_BACKUP_INTERVAL", "0")
+	t.Setenv("BARNLOG_BACKUP_KEEP", "0")
+	t.Setenv("BARNLOG_REPLICATION_TARGET", "https://replica.example.com")
+	t.Setenv("BARNLOG_REPLICATION_TOKEN", " blt_replica ")
+	t.Setenv("BARNLOG_REPLICATION_INTERVAL", "30s")
+	t.Setenv("BARNLOG_TRACE_EXPORTER", "OTLP")
+	t.Setenv("BARNLOG_TRACE_OTLP_ENDPOINT", "https://otel.example.com:4318/")
+	t.Setenv("BARNLOG_SESSION_TTL", "12h")
+	t.Setenv("BARNLOG_OIDC_ISSUER", "https://idp.example.com/realms/farm")
+	t.Setenv("BARNLOG_OIDC_CLIENT_ID", "barnlog")
+	t.Setenv("BARNLOG_OIDC_CLIENT_SECRET", "s3cret")
+	t.Setenv("BARNLOG_OIDC_REDIRECT_URL", "https://barnlog.example.com/auth/oidc/callback")
+	t.Setenv("BARNLOG_OIDC_SCOPES", "openid email groups")
+	t.Setenv("BARNLOG_OIDC_USERNAME_CLAIM", "email")
+	t.Setenv("BARNLOG_OIDC_ROLES_CLAIM", "groups")
+	t.Setenv("BARNLOG_CORS_ALLOWED_ORIGINS", "https://app.example.com, https://farm.example.com:8443")
+	t.Setenv("BARNLOG_CORS_ALLOW_CREDENTIALS", "false")
+	t.Setenv("BARNLOG_CORS_MAX_AGE", "1h")
+	t.Setenv("BARNLOG_HSTS_MAX_AGE", "720h")
+	t.Setenv("BARNLOG_TLS_MODE", "Files")
+	t.Setenv("BARNLOG_TLS_CERT_FILE", "/etc/barnlog/tls.crt")
+	t.Setenv("BARNLOG_TLS_KEY_FILE", "/etc/barnlog/tls.key")
+	t.Setenv("BARNLOG_TLS_DIR", "/var/lib/barnlog/tls")
+	t.Setenv("BARNLOG_TLS_HOSTS", "barn.example.com,10.0.0.5")
+	t.Setenv("BARNLOG_TLS_REDIRECT_ADDR", ":80")
+	t.Setenv("BARNLOG_METRICS_ADDR", "10.0.0.5:9090")
+	t.Setenv("BARNLOG_RATE_LIMIT_PER_MINUTE", "0")
+	t.Setenv("BARNLOG_RATE_LIMIT_BURST", "10")
+	t.Setenv("BARNLOG_UPLOAD_MIB_PER_HOUR", "50")
+	t.Setenv("BARNLOG_AUTH_FAILURES_PER_MINUTE", "0")
 
 	cfg, err := LoadFromEnv()
 	if err != nil {
@@ -72,6 +233,18 @@ func TestLoadFromEnvCustomValues(t *testing.T) {
 	if cfg.DBPath != "backend/db/custom.sqlite3" {
 		t.Fatalf("expected DBPath=backend/db/custom.sqlite3, got %q", cfg.DBPath)
 	}
+	if cfg.DBBusyTimeout != 250*time.Millisecond {
+		t.Fatalf("expected DBBusyTimeout=250ms, got %s", cfg.DBBusyTimeout)
+	}
+	if cfg.DBJournalMode != "DELETE" {
+		t.Fatalf("expected DBJournalMode=DELETE, got %q", cfg.DBJournalMode)
+	}
+	if cfg.DBSynchronous != "FULL" {
+		t.Fatalf("expected DBSynchronous=FULL, got %q", cfg.DBSynchronous)
+	}
+	if cfg.DBMaxReadConns != 2 {
+		t.Fatalf("expected DBMaxReadConns=2, got %d", cfg.DBMaxReadConns)
+	}
 	if cfg.MigrationsPath != "backend/db/custom-migrations" {
 		t.Fatalf("expected MigrationsPath=backend/db/custom-migrations, got %q", cfg.MigrationsPath)
 	}
@@ -87,6 +260,115 @@ func TestLoadFromEnvCustomValues(t *testing.T) {
 	if cfg.ShutdownTimeout != 3*time.Second {
 		t.Fatalf("expected ShutdownTimeout=3s, got %s", cfg.ShutdownTimeout)
 	}
+	if cfg.SnapshotEvery != 0 {
+		t.Fatalf("expected SnapshotEvery=0, got %d", cfg.SnapshotEvery)
+	}
+	if cfg.WebhookPollInterval != 500*time.Millisecond {
+		t.Fatalf("expected WebhookPollInterval=500ms, got %s", cfg.WebhookPollInterval)
+	}
+	if cfg.WebhookTimeout != 4*time.Second {
+		t.Fatalf("expected WebhookTimeout=4s, got %s", cfg.WebhookTimeout)
+	}
+	if cfg.WebhookMaxAttempts != 3 {
+		t.Fatalf("expected WebhookMaxAttempts=3, got %d", cfg.WebhookMaxAttempts)
+	}
+	if cfg.BackupDir != "/var/backups/barnlog" {
+		t.Fatalf("expected BackupDir=/var/backups/barnlog, got %q", cfg.BackupDir)
+	}
+	if cfg.BackupInterval != 0 {
+		t.Fatalf("expected BackupInterval=0, got %s", cfg.BackupInterval)
+	}
+	if cfg.BackupKeep != 0 {
+		t.Fatalf("expected BackupKeep=0, got %d", cfg.BackupKeep)
+	}
+	if cfg.ReplicationTarget != "https://replica.example.com" {
+		t.Fatalf("expected ReplicationTarget=https://replica.example.com, got %q", cfg.ReplicationTarget)
+	}
+	if cfg.ReplicationToken != "blt_replica" {
+		t.Fatalf("expected ReplicationToken=blt_replica, got %q", cfg.ReplicationToken)
+	}
+	if cfg.ReplicationInterval != 30*time.Second {
+		t.Fatalf("expected ReplicationInterval=30s, got %s", cfg.ReplicationInterval)
+	}
+	if cfg.TraceExporter != "otlp" {
+		t.Fatalf("expected TraceExporter=otlp, got %q", cfg.TraceExporter)
+	}
+	if cfg.TraceOTLPEndpoint != "https://otel.example.com:4318" {
+		t.Fatalf("expected TraceOTLPEndpoint=https://otel.example.com:4318, got %q", cfg.TraceOTLPEndpoint)
+	}
+	if cfg.SessionTTL != 12*time.Hour {
+		t.Fatalf("expected SessionTTL=12h, got %s", cfg.SessionTTL)
+	}
+	if cfg.OIDCIssuer != "https://idp.example.com/realms/farm" || cfg.OIDCClientID != "barnlog" ||
+		cfg.OIDCClientSecret != "s3cret" || cfg.OIDCRedirectURL != "https://barnlog.example.com/auth/oidc/callback" {
+		t.Fatalf("unexpected OIDC client settings %+v", cfg)
+	}
+	if !slices.Equal(cfg.OIDCScopes, []string{"openid", "email", "groups"}) {
+		t.Fatalf("expected OIDCScopes=[openid email groups], got %v", cfg.OIDCScopes)
+	}
+	if cfg.OIDCUsernameClaim != "email" || cfg.OIDCRolesClaim != "groups" {
+		t.Fatalf("expected custom claims, got %q and %q", cfg.OIDCUsernameClaim, cfg.OIDCRolesClaim)
+	}
+	if !slices.Equal(cfg.CORSAllowedOrigins, []string{"https://app.example.com", "https://farm.example.com:8443"}) ||
+		cfg.CORSAllowCredentials || cfg.CORSMaxAge != time.Hour {
+		t.Fatalf("unexpected CORS settings %v, %t, %s", cfg.CORSAllowedOrigins, cfg.CORSAllowCredentials, cfg.CORSMaxAge)
+	}
+	if cfg.HSTSMaxAge != 720*time.Hour {
+		t.Fatalf("expected HSTSMaxAge=720h, got %s", cfg.HSTSMaxAge)
+	}
+	if cfg.TLSMode != TLSModeFiles || cfg.TLSCertFile != "/etc/barnlog/tls.crt" || cfg.TLSKeyFile != "/etc/barnlog/tls.key" ||
+		cfg.TLSDir != "/var/lib/barnlog/tls" || cfg.TLSRedirectAddr != ":80" {
+		t.Fatalf("unexpected TLS settings %+v", cfg)
+	}
+	if !slices.Equal(cfg.TLSHosts, []string{"barn.example.com", "10.0.0.5"}) {
+		t.Fatalf("expected TLSHosts=[barn.example.com 10.0.0.5], got %v", cfg.TLSHosts)
+	}
+	if cfg.RateLimitPerMinute != 0 || cfg.RateLimitBurst != 10 || cfg.UploadBytesPerHour != 50<<20 {
+		t.Fatalf("unexpected rate limits %d/min, burst %d, %d upload bytes/h",
+			cfg.RateLimitPerMinute, cfg.RateLimitBurst, cfg.UploadBytesPerHour)
+	}
+	if cfg.MetricsAddr != "10.0.0.5:9090" {
+		t.Fatalf("expected MetricsAddr=10.0.0.5:9090, got %q", cfg.MetricsAddr)
+	}
+	if cfg.AuthFailuresPerMinute != 0 {
+		t.Fatalf("expected authentication failures to be unlimited, got %d/min", cfg.AuthFailuresPerMinute)
+	}
+}
+
+func TestLoadFromEnvHTTPSecurityPerEnv(t *testing.T) {
+	tests := []struct {
+		env         string
+		development bool
+		corsEnabled bool
+		hsts        time.Duration
+	}{
+		{env: "local", development: true, corsEnabled: true},
+		{env: "dev", development: true, corsEnabled: true},
+		{env: "test"},
+		{env: "prod", hsts: 365 * 24 * time.Hour},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.env, func(t *testing.T) {
+			t.Setenv("BARNLOG_ENV", tc.env)
+			t.Setenv("BARNLOG_CORS_ALLOWED_ORIGINS", "")
+			t.Setenv("BARNLOG_HSTS_MAX_AGE", "")
+
+			cfg, err := LoadFromEnv()
+			if err != nil {
+				t.Fatalf("LoadFromEnv() error = %v", err)
+			}
+			if cfg.Development() != tc.development {
+				t.Fatalf("expected Development()=%t, got %t", tc.development, cfg.Development())
+			}
+			if (len(cfg.CORSAllowedOrigins) > 0) != tc.corsEnabled {
+				t.Fatalf("expected CORS enabled=%t, got origins %v", tc.corsEnabled, cfg.CORSAllowedOrigins)
+			}
+			if cfg.HSTSMaxAge != tc.hsts {
+				t.Fatalf("expected HSTSMaxAge=%s, got %s", tc.hsts, cfg.HSTSMaxAge)
+			}
+		})
+	}
 }
 
 func TestLoadFromEnvInvalidLogLevel(t *testing.T) {
@@ -124,3 +406,285 @@ func TestLoadFromEnvInvalidAutoMigrate(t *testing.T) {
 		t.Fatalf("expected BARNLOG_AUTO_MIGRATE in error, got %q", err.Error())
 	}
 }
+
+func TestLoadFromEnvInvalidSnapshotEvery(t *testing.T) {
+	for _, raw := range []string{"often", "-1"} {
+		t.Setenv("BARNLOG_SNAPSHOT_EVERY", raw)
+
+		_, err := LoadFromEnv()
+		if err == nil {
+			t.Fatalf("expected error for snapshot interval %q", raw)
+		}
+		if !strings.Contains(err.Error(), "BARNLOG_SNAPSHOT_EVERY") {
+			t.Fatalf("expected BARNLOG_SNAPSHOT_EVERY in error, got %q", err.Error())
+		}
+	}
+}
+
+func TestLoadFromEnvInvalidWebhookSettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_WEBHOOK_POLL_INTERVAL", raw: "soon"},
+		{key: "BARNLOG_WEBHOOK_POLL_INTERVAL", raw: "0s"},
+		{key: "BARNLOG_WEBHOOK_TIMEOUT", raw: "-1s"},
+		{key: "BARNLOG_WEBHOOK_MAX_ATTEMPTS", raw: "many"},
+		{key: "BARNLOG_WEBHOOK_MAX_ATTEMPTS", raw: "0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidBackupSettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_BACKUP_INTERVAL", raw: "daily"},
+		{key: "BARNLOG_BACKUP_INTERVAL", raw: "-1h"},
+		{key: "BARNLOG_BACKUP_KEEP", raw: "all"},
+		{key: "BARNLOG_BACKUP_KEEP", raw: "-1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidReplicationSettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_REPLICATION_TARGET", raw: "file:"},
+		{key: "BARNLOG_REPLICATION_TARGET", raw: "ftp://replica.example.com"},
+		{key: "BARNLOG_REPLICATION_TARGET", raw: "replica.sqlite3"},
+		{key: "BARNLOG_REPLICATION_INTERVAL", raw: "0s"},
+		{key: "BARNLOG_REPLICATION_INTERVAL", raw: "often"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidTraceSettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_TRACE_EXPORTER", raw: "jaeger"},
+		{key: "BARNLOG_TRACE_OTLP_ENDPOINT", raw: "localhost:4318"},
+		{key: "BARNLOG_TRACE_OTLP_ENDPOINT", raw: "grpc://collector:4317"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidSessionTTL(t *testing.T) {
+	for _, raw := range []string{"forever", "0s", "-1h"} {
+		t.Run(raw, func(t *testing.T) {
+			t.Setenv("BARNLOG_SESSION_TTL", raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for BARNLOG_SESSION_TTL=%q", raw)
+			}
+			if !strings.Contains(err.Error(), "BARNLOG_SESSION_TTL") {
+				t.Fatalf("expected BARNLOG_SESSION_TTL in error, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidOIDCSettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_OIDC_ISSUER", raw: "idp.example.com"},
+		{key: "BARNLOG_OIDC_CLIENT_ID", raw: ""},
+		{key: "BARNLOG_OIDC_REDIRECT_URL", raw: ""},
+		{key: "BARNLOG_OIDC_REDIRECT_URL", raw: "/auth/oidc/callback"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv("BARNLOG_OIDC_ISSUER", "https://idp.example.com")
+			t.Setenv("BARNLOG_OIDC_CLIENT_ID", "barnlog")
+			t.Setenv("BARNLOG_OIDC_REDIRECT_URL", "https://barnlog.example.com/auth/oidc/callback")
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidDBSettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_DB_BUSY_TIMEOUT", raw: "forever"},
+		{key: "BARNLOG_DB_BUSY_TIMEOUT", raw: "0s"},
+		{key: "BARNLOG_DB_JOURNAL_MODE", raw: "wal2"},
+		{key: "BARNLOG_DB_SYNCHRONOUS", raw: "sometimes"},
+		{key: "BARNLOG_DB_MAX_READ_CONNS", raw: "lots"},
+		{key: "BARNLOG_DB_MAX_READ_CONNS", raw: "0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidHTTPSecuritySettings(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_CORS_ALLOWED_ORIGINS", raw: "localhost:5173"},
+		{key: "BARNLOG_CORS_ALLOWED_ORIGINS", raw: "https://app.example.com/ui"},
+		{key: "BARNLOG_CORS_ALLOWED_ORIGINS", raw: "*"},
+		{key: "BARNLOG_CORS_ALLOW_CREDENTIALS", raw: "maybe"},
+		{key: "BARNLOG_CORS_MAX_AGE", raw: "0s"},
+		{key: "BARNLOG_HSTS_MAX_AGE", raw: "-1h"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidTLSSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		key  string
+	}{
+		{"unknown mode", map[string]string{"BARNLOG_TLS_MODE": "acme"}, "BARNLOG_TLS_MODE"},
+		{"files without key", map[string]string{"BARNLOG_TLS_MODE": "files", "BARNLOG_TLS_CERT_FILE": "tls.crt"}, "BARNLOG_TLS_MODE"},
+		{"redirect without tls", map[string]string{"BARNLOG_TLS_REDIRECT_ADDR": ":80"}, "BARNLOG_TLS_REDIRECT_ADDR"},
+		{"redirect on the https address", map[string]string{
+			"BARNLOG_TLS_MODE": "self-signed", "BARNLOG_HTTP_ADDR": ":8443", "BARNLOG_TLS_REDIRECT_ADDR": ":8443",
+		}, "BARNLOG_TLS_REDIRECT_ADDR"},
+		{"metrics on the api address", map[string]string{"BARNLOG_HTTP_ADDR": ":8080", "BARNLOG_METRICS_ADDR": ":8080"}, "BARNLOG_METRICS_ADDR"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"BARNLOG_TLS_MODE", "BARNLOG_TLS_CERT_FILE", "BARNLOG_TLS_KEY_FILE", "BARNLOG_TLS_REDIRECT_ADDR"} {
+				t.Setenv(key, "")
+			}
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %v", tc.env)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvInvalidRateLimits(t *testing.T) {
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: "BARNLOG_RATE_LIMIT_PER_MINUTE", raw: "fast"},
+		{key: "BARNLOG_RATE_LIMIT_PER_MINUTE", raw: "-1"},
+		{key: "BARNLOG_RATE_LIMIT_BURST", raw: "0"},
+		{key: "BARNLOG_UPLOAD_MIB_PER_HOUR", raw: "-5"},
+		{key: "BARNLOG_AUTH_FAILURES_PER_MINUTE", raw: "-1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key+"="+tc.raw, func(t *testing.T) {
+			t.Setenv(tc.key, tc.raw)
+
+			_, err := LoadFromEnv()
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", tc.key, tc.raw)
+			}
+			if !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected %s in error, got %q", tc.key, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package metrics collects process metrics and serves them in the Prometheus
+// text exposition format.
+package metrics
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+// Metrics holds the barnlog metric families.
+type Metrics struct {
+	registry         *Registry
+	httpRequests     *CounterVec
+	httpDuration     *HistogramVec
+	eventsAppended   *CounterVec
+	eventFailures    *CounterVec
+	eventReplays     *CounterVec
+	uploads          *CounterVec
+	uploadBytes      *CounterVec
+	uploadRejections *CounterVec
+}
+
+var _ ports.EventMetrics = (*Metrics)(nil)
+
+// NewMetrics registers the barnlog metric families in a new registry.
+func NewMetrics() *Metrics {
+	r := NewRegistry()
+	return &Metrics{
+		registry: r,
+		httpRequests: r.NewCounterVec(
+			"barnlog_http_requests_total",
+			"HTTP requests by method, chi route pattern and status code.",
+			"method", "route", "status",
+		),
+		httpDuration: r.NewHistogramVec(
+			"barnlog_http_request_duration_seconds",
+			"HTTP request latency by method and chi route pattern.",
+			DefaultBuckets,
+			"method", "route",
+		),
+		eventsAppended: r.NewCounterVec(
+			"barnlog_events_appended_total",
+			"Events appended to the event log by event type.",
+			"event_type",
+		),
+		eventFailures: r.NewCounterVec(
+			"barnlog_event_append_failures_total",
+			"Event appends that failed in storage by event type.",
+			"event_type",
+		),
+		eventReplays: r.NewCounterVec(
+			"barnlog_idempotent_replays_total",
+			"Requests answered from an earlier append with the same idempotency key.",
+			"event_type",
+		),
+		uploads: r.NewCounterVec(
+			"barnlog_uploads_total",
+			"Accepted file uploads.",
+		),
+		uploadBytes: r.NewCounterVec(
+			"barnlog_upload_bytes_total",
+			"Bytes stored by accepted file uploads.",
+		),
+		uploadRejections: r.NewCounterVec(
+			"barnlog_upload_rejections_total",
+			"Rejected file uploads by error code.",
+			"code",
+		),
+	}
+}
+
+// ObserveHTTPRequest records one served request.
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	m.httpRequests.Inc(method, route, strconv.Itoa(status))
+	m.httpDuration.Observe(elapsed.Seconds(), method, route)
+}
+
+// EventAppended counts a new event of eventType.
+func (m *Metrics) EventAppended(eventType string) {
+	m.eventsAppended.Inc(eventType)
+}
+
+// EventReplayed counts an idempotent replay of eventType.
+func (m *Metrics) EventReplayed(eventType string) {
+	m.eventReplays.Inc(eventType)
+}
+
+// EventAppendFailed counts a failed append of eventType.
+func (m *Metrics) EventAppendFailed(eventType string) {
+	m.eventFailures.Inc(eventType)
+}
+
+// UploadAccepted counts a stored upload of size bytes.
+func (m *Metrics) UploadAccepted(size int64) {
+	m.uploads.Inc()
+	m.uploadBytes.Add(float64(size))
+}
+
+// UploadRejected counts an upload rejected with the given error code.
+func (m *Metrics) UploadRejected(code string) {
+	m.uploadRejections.Inc(code)
+}
+
+// Handler serves every metric in the text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return m.registry.Handler()
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "Requests.\nBy code.", "code")
+	latency := r.NewHistogramVec("test_latency_seconds", "Latency.", []float64{1, 0.1}, "route")
+	bytes := r.NewCounterVec("test_bytes_total", "Bytes.")
+
+	requests.Inc("b")
+	requests.Add(2, `a"\`+"\n")
+	requests.Add(-1, "b")
+	latency.Observe(0.05, "/x")
+	latency.Observe(0.5, "/x")
+	latency.Observe(3, "/x")
+	bytes.Add(1024)
+
+	var out strings.Builder
+	if err := r.WriteText(&out); err != nil {
+		t.Fatalf("write text: %v", err)
+	}
+
+	want := `# HELP test_requests_total Requests.\nBy code.
+# TYPE test_requests_total counter
+test_requests_total{code="a\"\\\n"} 2
+test_requests_total{code="b"} 1
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{route="/x",le="0.1"} 1
+test_latency_seconds_bucket{route="/x",le="1"} 2
+test_latency_seconds_bucket{route="/x",le="+Inf"} 3
+test_latency_seconds_sum{route="/x"} 3.55
+test_latency_seconds_count{route="/x"} 3
+# HELP test_bytes_total Bytes.
+# TYPE test_bytes_total counter
+test_bytes_total 1024
+`
+	if out.String() != want {
+		t.Fatalf("unexpected exposition:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if got := latency.Count("/x"); got != 3 {
+		t.Fatalf("expected 3 observations, got %d", got)
+	}
+}
+
+func TestRegistryPanicsOnLabelMismatch(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a missing label value")
+		}
+	}()
+	NewRegistry().NewCounterVec("test_total", "Test.", "code").Inc()
+}
+
+func TestMetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	m := NewMetrics()
+	m.EventAppended("animal.created")
+	m.EventReplayed("animal.created")
+	m.UploadAccepted(42)
+	m.UploadRejected("file_too_large")
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Fatalf("expected content-type %q, got %q", ContentType, got)
+	}
+	for _, want := range []string{
+		`barnlog_events_appended_total{event_type="animal.created"} 1`,
+		`barnlog_idempotent_replays_total{event_type="animal.created"} 1`,
+		"barnlog_uploads_total 1",
+		"barnlog_upload_bytes_total 42",
+		`barnlog_upload_rejections_total{code="file_too_large"} 1`,
+		"# TYPE barnlog_event_append_failures_total counter",
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Fatalf("expected %s in:\n%s", want, rec.Body.String())
+		}
+	}
+}
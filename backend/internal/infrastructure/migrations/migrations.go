@@ -0,0 +1,252 @@
+// Package migrations applies the SQL migrations in backend/db/migrations to a
+// SQLite database and snapshots the resulting schema. The migrations are
+// embedded in the binary; a directory on disk can replace them.
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	gomigrate "github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite" // registers the sqlite:// database driver
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"barnlog/backend/db"
+)
+
+// EmbeddedSource names the migrations compiled into the binary.
+const EmbeddedSource = "embedded"
+
+var (
+	// ErrNoChange is returned by Up when the database is already at the latest version.
+	ErrNoChange = gomigrate.ErrNoChange
+	// ErrDatabaseNewer reports a database migrated by a newer build than this one.
+	// Running against it could misread or corrupt data the binary does not understand.
+	ErrDatabaseNewer = errors.New("database schema is newer than this binary")
+)
+
+// Migration is one versioned migration known to the source.
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// Status describes how far a database has been migrated.
+type Status struct {
+	// Version is the applied version, or 0 when no migration has run.
+	Version uint
+	// Dirty reports a migration that failed half way; it must be fixed and forced.
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Latest returns the highest version known to the source.
+func (s Status) Latest() uint {
+	if len(s.Migrations) == 0 {
+		return 0
+	}
+	return s.Migrations[len(s.Migrations)-1].Version
+}
+
+// Pending returns the migrations above the applied version.
+func (s Status) Pending() []Migration {
+	var pending []Migration
+	for _, migration := range s.Migrations {
+		if migration.Version > s.Version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// Migrator runs migrations against one SQLite database.
+type Migrator struct {
+	migrate    *gomigrate.Migrate
+	migrations []Migration
+	dbURL      string
+	source     string
+}
+
+// Open prepares migrations for the database at dbPath, creating the database
+// file and its directory if they do not exist. An empty migrationsPath uses
+// the embedded migrations.
+func Open(dbPath, migrationsPath string) (*Migrator, error) {
+	absDBPath, err := filepath.Abs(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve db path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(absDBPath), 0o750); err != nil {
+		return nil, fmt.Errorf("create db directory: %w", err)
+	}
+
+	src, sourceName, err := openSource(migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := listMigrations(src)
+	if err != nil {
+		_ = src.Close()
+		return nil, err
+	}
+
+	dbURL := (&url.URL{Scheme: "sqlite", Path: absDBPath}).String()
+	m, err := gomigrate.NewWithSourceInstance("iofs", src, dbURL)
+	if err != nil {
+		_ = src.Close()
+		return nil, fmt.Errorf("initialize migrate: %w", err)
+	}
+	return &Migrator{migrate: m, migrations: migrations, dbURL: dbURL, source: sourceName}, nil
+}
+
+// Latest returns the highest migration version in migrationsPath, or in the
+// embedded migrations when it is empty. A fully migrated database is at this version.
+func Latest(migrationsPath string) (uint, error) {
+	src, _, err := openSource(migrationsPath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = src.Close() }()
+
+	migrations, err := listMigrations(src)
+	if err != nil {
+		return 0, err
+	}
+	return Status{Migrations: migrations}.Latest(), nil
+}
+
+func openSource(migrationsPath string) (source.Driver, string, error) {
+	fsys, dir, sourceName := fs.FS(db.Migrations), "migrations", EmbeddedSource
+	if migrationsPath != "" {
+		absMigrationsPath, err := filepath.Abs(migrationsPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("resolve migrations path: %w", err)
+		}
+		fsys, dir, sourceName = os.DirFS(absMigrationsPath), ".", absMigrationsPath
+	}
+	src, err := iofs.New(fsys, dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("open migrations %s: %w", sourceName, err)
+	}
+	return src, sourceName, nil
+}
+
+// DatabaseURL returns the migrate URL of the target database, for logging.
+func (m *Migrator) DatabaseURL() string {
+	return m.dbURL
+}
+
+// Source returns EmbeddedSource or the migrations directory, for logging.
+func (m *Migrator) Source() string {
+	return m.source
+}
+
+// CheckVersion returns ErrDatabaseNewer when the database is at a version
+// above the latest migration this binary knows.
+func (m *Migrator) CheckVersion() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	if latest := status.Latest(); status.Version > latest {
+		return fmt.Errorf("%w: database is at version %d, latest known migration is %d",
+			ErrDatabaseNewer, status.Version, latest)
+	}
+	return nil
+}
+
+// Up applies every pending migration. It returns ErrNoChange when there is none
+// and ErrDatabaseNewer, without touching the database, when it is ahead of the binary.
+func (m *Migrator) Up() error {
+	if err := m.CheckVersion(); err != nil {
+		return err
+	}
+	if err := m.migrate.Up(); err != nil {
+		if errors.Is(err, gomigrate.ErrNoChange) {
+			return ErrNoChange
+		}
+		return fmt.Errorf("run migrations: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the last n applied migrations.
+func (m *Migrator) Down(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("rollback steps must be positive, got %d", n)
+	}
+	if err := m.migrate.Steps(-n); err != nil {
+		return fmt.Errorf("roll back %d migrations: %w", n, err)
+	}
+	return nil
+}
+
+// Force records version as applied and clears the dirty flag without running
+// any SQL. Version -1 marks the database as never migrated.
+func (m *Migrator) Force(version int) error {
+	if version < -1 {
+		return fmt.Errorf("force version must be -1 or greater, got %d", version)
+	}
+	if version > 0 && !m.known(uint(version)) {
+		return fmt.Errorf("force version %d: no such migration", version)
+	}
+	if err := m.migrate.Force(version); err != nil {
+		return fmt.Errorf("force version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Status reports the applied version and every migration known to the source.
+func (m *Migrator) Status() (Status, error) {
+	status := Status{Migrations: m.migrations}
+	version, dirty, err := m.migrate.Version()
+	if err != nil && !errors.Is(err, gomigrate.ErrNilVersion) {
+		return Status{}, fmt.Errorf("read migration version: %w", err)
+	}
+	status.Version = version
+	status.Dirty = dirty
+	return status, nil
+}
+
+// Close releases the source and database handles.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.migrate.Close()
+	if srcErr != nil {
+		return fmt.Errorf("close migration source: %w", srcErr)
+	}
+	if dbErr != nil {
+		return fmt.Errorf("close migration db: %w", dbErr)
+	}
+	return nil
+}
+
+func (m *Migrator) known(version uint) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func listMigrations(src source.Driver) ([]Migration, error) {
+	var migrations []Migration
+	version, err := src.First()
+	for err == nil {
+		r, name, readErr := src.ReadUp(version)
+		if readErr != nil {
+			return nil, fmt.Errorf("read migration %d: %w", version, readErr)
+		}
+		_ = r.Close()
+		migrations = append(migrations, Migration{Version: version, Name: name})
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+	return migrations, nil
+}
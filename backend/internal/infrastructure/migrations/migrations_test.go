@@ -0,0 +1,197 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "nested", "test.sqlite3")
+	m := openTestMigrator(t, dbPath)
+
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("status before up: %v", err)
+	}
+	if status.Version != 0 || status.Dirty {
+		t.Fatalf("expected an unmigrated database, got %+v", status)
+	}
+	if len(status.Migrations) == 0 || status.Migrations[0] != (Migration{Version: 1, Name: "init"}) {
+		t.Fatalf("expected 000001_init first, got %+v", status.Migrations)
+	}
+	if len(status.Pending()) != len(status.Migrations) {
+		t.Fatalf("expected every migration pending, got %+v", status.Pending())
+	}
+	latest := status.Latest()
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if err := m.Up(); !errors.Is(err, ErrNoChange) {
+		t.Fatalf("expected ErrNoChange on second up, got %v", err)
+	}
+	status = mustStatus(t, m)
+	if status.Version != latest || len(status.Pending()) != 0 {
+		t.Fatalf("expected version %d with nothing pending, got %+v", latest, status)
+	}
+
+	if err := m.Down(1); err != nil {
+		t.Fatalf("down 1: %v", err)
+	}
+	status = mustStatus(t, m)
+	if status.Version != latest-1 || len(status.Pending()) != 1 {
+		t.Fatalf("expected version %d with one pending, got %+v", latest-1, status)
+	}
+	if err := m.Down(0); err == nil {
+		t.Fatalf("expected error for zero rollback steps")
+	}
+}
+
+func TestMigratorForce(t *testing.T) {
+	m := openTestMigrator(t, filepath.Join(t.TempDir(), "test.sqlite3"))
+
+	if err := m.Force(2); err != nil {
+		t.Fatalf("force 2: %v", err)
+	}
+	if status := mustStatus(t, m); status.Version != 2 || status.Dirty {
+		t.Fatalf("expected clean version 2, got %+v", status)
+	}
+	if err := m.Force(-1); err != nil {
+		t.Fatalf("force -1: %v", err)
+	}
+	if status := mustStatus(t, m); status.Version != 0 {
+		t.Fatalf("expected no version after force -1, got %+v", status)
+	}
+	for _, version := range []int{-2, 999} {
+		if err := m.Force(version); err == nil {
+			t.Fatalf("expected error forcing version %d", version)
+		}
+	}
+}
+
+func TestDumpSchemaMatchesSnapshot(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.sqlite3")
+	m := openTestMigrator(t, dbPath)
+	if err := m.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	var got bytes.Buffer
+	if err := DumpSchema(context.Background(), db, &got); err != nil {
+		t.Fatalf("dump schema: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(testBackendDir(t), "db", "schema.sql"))
+	if err != nil {
+		t.Fatalf("read schema snapshot: %v", err)
+	}
+	if got.String() != string(want) {
+		t.Fatalf("backend/db/schema.sql is stale; run make db-schema\n got:\n%s", got.String())
+	}
+}
+
+func TestOpenEmbeddedMatchesDirectory(t *testing.T) {
+	embedded, err := Open(filepath.Join(t.TempDir(), "embedded.sqlite3"), "")
+	if err != nil {
+		t.Fatalf("open embedded migrations: %v", err)
+	}
+	t.Cleanup(func() { _ = embedded.Close() })
+	if embedded.Source() != EmbeddedSource {
+		t.Fatalf("expected embedded source, got %q", embedded.Source())
+	}
+
+	fromDisk := openTestMigrator(t, filepath.Join(t.TempDir(), "disk.sqlite3"))
+	if got, want := mustStatus(t, embedded).Migrations, mustStatus(t, fromDisk).Migrations; !slices.Equal(got, want) {
+		t.Fatalf("embedded migrations differ from backend/db/migrations:\n got %+v\nwant %+v", got, want)
+	}
+	if err := embedded.Up(); err != nil {
+		t.Fatalf("up from embedded migrations: %v", err)
+	}
+
+	latest, err := Latest("")
+	if err != nil {
+		t.Fatalf("latest embedded migration: %v", err)
+	}
+	if want := mustStatus(t, embedded).Version; latest != want {
+		t.Fatalf("expected Latest() = %d, got %d", want, latest)
+	}
+}
+
+func TestMigratorRefusesNewerDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.sqlite3")
+	m := openTestMigrator(t, dbPath)
+	if err := m.CheckVersion(); err != nil {
+		t.Fatalf("check version of a new database: %v", err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if err := m.CheckVersion(); err != nil {
+		t.Fatalf("check version at latest: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if _, err := db.Exec(`UPDATE schema_migrations SET version = 999`); err != nil {
+		t.Fatalf("simulate newer database: %v", err)
+	}
+
+	if err := m.CheckVersion(); !errors.Is(err, ErrDatabaseNewer) {
+		t.Fatalf("expected ErrDatabaseNewer from CheckVersion, got %v", err)
+	}
+	if err := m.Up(); !errors.Is(err, ErrDatabaseNewer) {
+		t.Fatalf("expected ErrDatabaseNewer from Up, got %v", err)
+	}
+}
+
+func openTestMigrator(t *testing.T, dbPath string) *Migrator {
+	t.Helper()
+
+	m, err := Open(dbPath, filepath.Join(testBackendDir(t), "db", "migrations"))
+	if err != nil {
+		t.Fatalf("open migrator: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := m.Close(); err != nil {
+			t.Errorf("close migrator: %v", err)
+		}
+	})
+	return m
+}
+
+func mustStatus(t *testing.T, m *Migrator) Status {
+	t.Helper()
+
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	return status
+}
+
+func testBackendDir(t *testing.T) string {
+	t.Helper()
+
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatalf("resolve caller path")
+	}
+	return filepath.Clean(filepath.Join(filepath.Dir(thisFile), "..", "..", ".."))
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+)
+
+// schemaQuery lists the user-visible schema in a stable order: tables,
+// indexes, triggers, then views, each sorted by name.
+const schemaQuery = `
+SELECT sql || ';'
+FROM sqlite_master
+WHERE sql IS NOT NULL
+  AND type IN ('table', 'index', 'trigger', 'view')
+  AND name NOT LIKE 'sqlite_%'
+  AND name != 'schema_migrations'
+ORDER BY
+  CASE type
+    WHEN 'table' THEN 1
+    WHEN 'index' THEN 2
+    WHEN 'trigger' THEN 3
+    WHEN 'view' THEN 4
+    ELSE 5
+  END,
+  name`
+
+// DumpSchema writes the CREATE statements of db, one per line, in the format
+// of backend/db/schema.sql.
+func DumpSchema(ctx context.Context, db *sql.DB, w io.Writer) error {
+	rows, err := db.QueryContext(ctx, schemaQuery)
+	if err != nil {
+		return fmt.Errorf("query schema: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			return fmt.Errorf("scan schema: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, statement); err != nil {
+			return fmt.Errorf("write schema: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package oidc provides the OpenID Connect client that signs users in with the
+// authorization code flow and PKCE.
+package oidc
@@ -0,0 +1,162 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// minRSABits rejects RSA keys too short to trust.
+const minRSABits = 2048
+
+// jws is a compact JSON Web Signature as used by ID tokens.
+type jws struct {
+	header       jwsHeader
+	payload      []byte
+	signingInput string
+	signature    []byte
+}
+
+type jwsHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// publicKey is a verification key with the one algorithm it may be used for.
+type publicKey struct {
+	algorithm string
+	key       crypto.PublicKey
+}
+
+func parseJWS(raw string) (jws, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return jws{}, errors.New("token is not a compact JWS")
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return jws{}, fmt.Errorf("decode token header: %w", err)
+	}
+	var token jws
+	if err := json.Unmarshal(rawHeader, &token.header); err != nil {
+		return jws{}, fmt.Errorf("decode token header: %w", err)
+	}
+	if token.payload, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+		return jws{}, fmt.Errorf("decode token payload: %w", err)
+	}
+	if token.signature, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return jws{}, fmt.Errorf("decode token signature: %w", err)
+	}
+	token.signingInput = parts[0] + "." + parts[1]
+	return token, nil
+}
+
+// verify checks the signature with key. The token's alg must be the key's;
+// in particular "none" and HMAC algorithms are never accepted.
+func (t jws) verify(key publicKey) error {
+	if t.header.Algorithm != key.algorithm {
+		return fmt.Errorf("token algorithm %q does not match key algorithm %q", t.header.Algorithm, key.algorithm)
+	}
+	digest := sha256.Sum256([]byte(t.signingInput))
+	switch pub := key.key.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], t.signature); err != nil {
+			return fmt.Errorf("verify signature: %w", err)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if len(t.signature) != 64 {
+			return errors.New("verify signature: malformed ES256 signature")
+		}
+		r := new(big.Int).SetBytes(t.signature[:32])
+		s := new(big.Int).SetBytes(t.signature[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return errors.New("verify signature: invalid ES256 signature")
+		}
+		return nil
+	default:
+		return errors.New("verify signature: unsupported key")
+	}
+}
+
+// jsonWebKeySet is a JWKS document.
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+// publicKeys returns the RS256 and ES256 signing keys of the set by key ID.
+// Encryption keys and keys of other types are skipped.
+func (s jsonWebKeySet) publicKeys() map[string]publicKey {
+	keys := map[string]publicKey{}
+	for _, jwk := range s.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys
+}
+
+func (k jsonWebKey) publicKey() (publicKey, error) {
+	switch {
+	case k.KeyType == "RSA" && (k.Algorithm == "" || k.Algorithm == "RS256"):
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return publicKey{}, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return publicKey{}, err
+		}
+		if n.BitLen() < minRSABits || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return publicKey{}, errors.New("weak or malformed RSA key")
+		}
+		return publicKey{algorithm: "RS256", key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+	case k.KeyType == "EC" && k.Curve == "P-256" && (k.Algorithm == "" || k.Algorithm == "ES256"):
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return publicKey{}, errors.New("malformed EC key")
+		}
+		point := append(append([]byte{4}, x...), y...)
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("parse EC key: %w", err)
+		}
+		return publicKey{algorithm: "ES256", key: pub}, nil
+	default:
+		return publicKey{}, fmt.Errorf("unsupported key type %q with algorithm %q", k.KeyType, k.Algorithm)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(raw) == 0 {
+		return nil, errors.New("malformed key parameter")
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
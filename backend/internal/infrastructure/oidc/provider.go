@@ -0,0 +1,371 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"barnlog/backend/internal/ports"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DiscoveryPath is appended to the issuer to find its provider metadata.
+	DiscoveryPath = "/.well-known/openid-configuration"
+
+	// clockSkew is how far the provider's clock may be ahead of or behind ours.
+	clockSkew = time.Minute
+	// keysMaxAge is how long fetched signing keys are used before refetching.
+	keysMaxAge = 24 * time.Hour
+	// keyRefetchInterval limits refetches for tokens signed with an unknown
+	// key, so forged key IDs cannot make every login hit the provider.
+	keyRefetchInterval = 30 * time.Second
+
+	maxResponseBytes = 1 << 20
+)
+
+// Options configures a Provider.
+type Options struct {
+	// Issuer is the provider's issuer URL, which must match the discovery
+	// document and the iss claim exactly.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback URL registered with the provider.
+	RedirectURL string
+	// Scopes are requested in addition to "openid".
+	Scopes  []string
+	Timeout time.Duration
+}
+
+// Provider is an OpenID Connect relying party for one provider. The discovery
+// document is fetched on first use and kept; signing keys are cached and
+// refetched when they grow old or a token names a key ID the cache lacks,
+// which picks up key rotation.
+type Provider struct {
+	opts   Options
+	client *http.Client
+	now    func() time.Time
+
+	// fetches collapses concurrent fetches of the same document; mu guards
+	// the cache only and is never held across a request to the provider.
+	fetches       singleflight.Group
+	mu            sync.Mutex
+	metadata      *metadata
+	keys          map[string]publicKey
+	keysFetchedAt time.Time
+}
+
+var _ ports.IdentityProvider = (*Provider)(nil)
+
+// metadata is the part of the discovery document the flow uses.
+type metadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	JWKSURI                       string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// NewProvider builds a Provider whose requests to the provider time out after
+// opts.Timeout.
+func NewProvider(opts Options) *Provider {
+	if !slices.Contains(opts.Scopes, "openid") {
+		opts.Scopes = append([]string{"openid"}, opts.Scopes...)
+	}
+	return &Provider{
+		opts: opts,
+		client: &http.Client{
+			Timeout: opts.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// AuthCodeURL returns the authorization endpoint URL that starts a login.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	authURL, err := url.Parse(md.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.opts.ClientID)
+	query.Set("redirect_uri", p.opts.RedirectURL)
+	query.Set("scope", strings.Join(p.opts.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange redeems code at the token endpoint and verifies the returned ID token.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (ports.IdentityClaims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return ports.IdentityClaims{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.opts.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if p.opts.ClientSecret == "" {
+		form.Set("client_id", p.opts.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return ports.IdentityClaims{}, fmt.Errorf("build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.opts.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.opts.ClientID), url.QueryEscape(p.opts.ClientSecret))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	// #nosec G704 -- the token endpoint comes from the configured issuer's discovery document.
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return ports.IdentityClaims{}, fmt.Errorf("post token request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		// invalid_grant and friends: a stale, reused or forged code.
+		return ports.IdentityClaims{}, fmt.Errorf("token endpoint responded with status %d: %w", resp.StatusCode, ports.ErrIdentityRejected)
+	}
+	if err := decodeResponse(resp, &token); err != nil {
+		return ports.IdentityClaims{}, fmt.Errorf("token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return ports.IdentityClaims{}, fmt.Errorf("token response has no id_token: %w", ports.ErrIdentityRejected)
+	}
+	return p.verifyIDToken(ctx, md, token.IDToken, nonce)
+}
+
+// verifyIDToken checks the signature and claims of an ID token.
+func (p *Provider) verifyIDToken(ctx context.Context, md metadata, rawToken, nonce string) (ports.IdentityClaims, error) {
+	token, err := parseJWS(rawToken)
+	if err != nil {
+		return ports.IdentityClaims{}, rejected(err)
+	}
+	key, err := p.signingKey(ctx, md, token.header.KeyID)
+	if err != nil {
+		return ports.IdentityClaims{}, err
+	}
+	if err := token.verify(key); err != nil {
+		return ports.IdentityClaims{}, rejected(err)
+	}
+
+	claims := map[string]any{}
+	if err := json.Unmarshal(token.payload, &claims); err != nil {
+		return ports.IdentityClaims{}, rejected(fmt.Errorf("decode claims: %w", err))
+	}
+	if err := p.checkClaims(md, claims, nonce); err != nil {
+		return ports.IdentityClaims{}, rejected(err)
+	}
+	subject, _ := claims["sub"].(string)
+	return ports.IdentityClaims{Subject: subject, Claims: claims}, nil
+}
+
+func (p *Provider) checkClaims(md metadata, claims map[string]any, nonce string) error {
+	if iss, _ := claims["iss"].(string); iss != md.Issuer {
+		return fmt.Errorf("issuer %q is not %q", iss, md.Issuer)
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return errors.New("subject is missing")
+	}
+
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []any:
+		for _, entry := range aud {
+			if entry, ok := entry.(string); ok {
+				audiences = append(audiences, entry)
+			}
+		}
+	}
+	if !slices.Contains(audiences, p.opts.ClientID) {
+		return fmt.Errorf("audience %v does not include client %q", audiences, p.opts.ClientID)
+	}
+	if azp, ok := claims["azp"].(string); (ok || len(audiences) > 1) && azp != p.opts.ClientID {
+		return fmt.Errorf("authorized party %q is not client %q", azp, p.opts.ClientID)
+	}
+
+	now := p.now()
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return errors.New("expiry is missing")
+	}
+	if !now.Before(exp.Add(clockSkew)) {
+		return fmt.Errorf("token expired at %s", exp.Format(time.RFC3339))
+	}
+	if iat, ok := numericDate(claims["iat"]); ok && iat.After(now.Add(clockSkew)) {
+		return fmt.Errorf("token issued in the future at %s", iat.Format(time.RFC3339))
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && nbf.After(now.Add(clockSkew)) {
+		return fmt.Errorf("token not valid before %s", nbf.Format(time.RFC3339))
+	}
+	if got, _ := claims["nonce"].(string); got == "" || got != nonce {
+		return errors.New("nonce does not match the login")
+	}
+	return nil
+}
+
+// discover returns the cached provider metadata, fetching it on first use.
+// Failed fetches are not cached.
+func (p *Provider) discover(ctx context.Context) (metadata, error) {
+	p.mu.Lock()
+	cached := p.metadata
+	p.mu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	md, err, _ := p.fetches.Do("discovery", func() (any, error) {
+		return p.fetchMetadata(ctx)
+	})
+	if err != nil {
+		return metadata{}, err
+	}
+	return md.(metadata), nil
+}
+
+// fetchMetadata fetches and checks the discovery document and caches it.
+func (p *Provider) fetchMetadata(ctx context.Context) (metadata, error) {
+	var md metadata
+	if err := p.getJSON(ctx, strings.TrimRight(p.opts.Issuer, "/")+DiscoveryPath, &md); err != nil {
+		return metadata{}, fmt.Errorf("discover provider: %w", err)
+	}
+	if md.Issuer != p.opts.Issuer {
+		return metadata{}, fmt.Errorf("discovery document names issuer %q instead of %q", md.Issuer, p.opts.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return metadata{}, errors.New("discovery document lacks an authorization, token or jwks endpoint")
+	}
+	if len(md.CodeChallengeMethodsSupported) > 0 && !slices.Contains(md.CodeChallengeMethodsSupported, "S256") {
+		return metadata{}, errors.New("provider does not support S256 PKCE challenges")
+	}
+	p.mu.Lock()
+	p.metadata = &md
+	p.mu.Unlock()
+	return md, nil
+}
+
+// signingKey returns the key with keyID, refetching the key set when the
+// cache is old or lacks the key. An empty keyID matches a key set of one key.
+func (p *Provider) signingKey(ctx context.Context, md metadata, keyID string) (publicKey, error) {
+	now := p.now()
+	p.mu.Lock()
+	key, found := p.lookupKey(keyID)
+	fetchedAt := p.keysFetchedAt
+	p.mu.Unlock()
+
+	stale := now.Sub(fetchedAt) >= keysMaxAge
+	if found && !stale {
+		return key, nil
+	}
+	if !found && !stale && now.Sub(fetchedAt) < keyRefetchInterval {
+		return publicKey{}, rejected(fmt.Errorf("unknown signing key %q", keyID))
+	}
+
+	_, err, _ := p.fetches.Do("jwks", func() (any, error) {
+		var set jsonWebKeySet
+		if err := p.getJSON(ctx, md.JWKSURI, &set); err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		p.keys = set.publicKeys()
+		p.keysFetchedAt = now
+		p.mu.Unlock()
+		return nil, nil
+	})
+	if err != nil {
+		if found {
+			// Keep using the old keys until the provider is reachable again.
+			return key, nil
+		}
+		return publicKey{}, fmt.Errorf("fetch signing keys: %w", err)
+	}
+
+	p.mu.Lock()
+	key, found = p.lookupKey(keyID)
+	p.mu.Unlock()
+	if !found {
+		return publicKey{}, rejected(fmt.Errorf("unknown signing key %q", keyID))
+	}
+	return key, nil
+}
+
+func (p *Provider) lookupKey(keyID string) (publicKey, bool) {
+	if keyID == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[keyID]
+	return key, ok
+}
+
+func (p *Provider) getJSON(ctx context.Context, target string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	// #nosec G704 -- the URL is the configured issuer or comes from its discovery document.
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return decodeResponse(resp, v)
+}
+
+func decodeResponse(resp *http.Response, v any) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// numericDate reads a JWT NumericDate claim.
+func numericDate(claim any) (time.Time, bool) {
+	seconds, ok := claim.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+func rejected(err error) error {
+	return fmt.Errorf("%w: %w", ports.ErrIdentityRejected, err)
+}
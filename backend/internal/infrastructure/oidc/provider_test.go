@@ -0,0 +1,196 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/oidc/oidctest"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	testRedirectURL = "https://barnlog.example/auth/oidc/callback"
+	testVerifier    = "verifier-verifier-verifier-verifier-verifier"
+)
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	t.Parallel()
+
+	for _, secret := range []string{"client-secret", ""} {
+		idp := oidctest.NewProvider(t, "barnlog", secret)
+		idp.SetClaims(map[string]any{"sub": "sub-1", "preferred_username": "anna"})
+		provider := newTestProvider(idp, nil)
+
+		identity, err := login(t, idp, provider, testVerifier, "nonce-1")
+		if err != nil {
+			t.Fatalf("secret %q: exchange: %v", secret, err)
+		}
+		if identity.Subject != "sub-1" || identity.Claims["preferred_username"] != "anna" {
+			t.Fatalf("secret %q: unexpected identity %+v", secret, identity)
+		}
+	}
+}
+
+func TestProvider_ExchangeRejects(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewProvider(t, "barnlog", "client-secret")
+	provider := newTestProvider(idp, nil)
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Hour).Unix()
+
+	cases := []struct {
+		name   string
+		claims map[string]any
+	}{
+		{name: "wrong issuer", claims: map[string]any{"sub": "sub-1", "iss": "https://evil.example"}},
+		{name: "wrong audience", claims: map[string]any{"sub": "sub-1", "aud": "other"}},
+		{name: "foreign authorized party", claims: map[string]any{"sub": "sub-1", "aud": []string{"barnlog", "other"}, "azp": "other"}},
+		{name: "expired", claims: map[string]any{"sub": "sub-1", "exp": expired}},
+		{name: "wrong nonce", claims: map[string]any{"sub": "sub-1", "nonce": "replayed"}},
+		{name: "no subject", claims: map[string]any{"sub": ""}},
+	}
+	for _, tc := range cases {
+		idp.SetClaims(tc.claims)
+		if _, err := login(t, idp, provider, testVerifier, "nonce-1"); !errors.Is(err, ports.ErrIdentityRejected) {
+			t.Fatalf("%s: expected rejection, got %v", tc.name, err)
+		}
+	}
+
+	idp.SetClaims(map[string]any{"sub": "sub-1"})
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce-1", challenge(testVerifier))
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+	code := idp.Authorize(t, authURL).Query().Get("code")
+	if _, err := provider.Exchange(ctx, code, "some-other-verifier", "nonce-1"); !errors.Is(err, ports.ErrIdentityRejected) {
+		t.Fatalf("expected a wrong PKCE verifier to be rejected, got %v", err)
+	}
+	if _, err := provider.Exchange(ctx, code, testVerifier, "nonce-1"); !errors.Is(err, ports.ErrIdentityRejected) {
+		t.Fatalf("expected a used code to be rejected, got %v", err)
+	}
+}
+
+func TestProvider_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewProvider(t, "barnlog", "client-secret")
+	now := time.Now()
+	provider := newTestProvider(idp, &now)
+	// The test clock runs past the real expiry of the fake's tokens.
+	idp.SetClaims(map[string]any{"sub": "sub-1", "exp": now.Add(2 * keysMaxAge).Unix()})
+
+	if _, err := login(t, idp, provider, testVerifier, "nonce-1"); err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if _, err := login(t, idp, provider, testVerifier, "nonce-2"); err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	if got := idp.JWKSRequests(); got != 1 {
+		t.Fatalf("expected the key set to be cached, got %d fetches", got)
+	}
+
+	idp.RotateKey(t, "ES256")
+	if _, err := login(t, idp, provider, testVerifier, "nonce-3"); !errors.Is(err, ports.ErrIdentityRejected) {
+		t.Fatalf("expected an unknown key right after a fetch to be rejected, got %v", err)
+	}
+	now = now.Add(keyRefetchInterval)
+	if _, err := login(t, idp, provider, testVerifier, "nonce-4"); err != nil {
+		t.Fatalf("login after rotation: %v", err)
+	}
+	if got := idp.JWKSRequests(); got != 2 {
+		t.Fatalf("expected one refetch for the rotated key, got %d fetches", got)
+	}
+
+	now = now.Add(keysMaxAge)
+	if _, err := login(t, idp, provider, testVerifier, "nonce-5"); err != nil {
+		t.Fatalf("login after the keys aged: %v", err)
+	}
+	if got := idp.JWKSRequests(); got != 3 {
+		t.Fatalf("expected old keys to be refetched, got %d fetches", got)
+	}
+}
+
+func TestProvider_ConcurrentExchanges(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewProvider(t, "barnlog", "client-secret")
+	idp.SetClaims(map[string]any{"sub": "sub-1"})
+	provider := newTestProvider(idp, nil)
+	ctx := context.Background()
+
+	// Codes come from the fake directly, so the exchanges below are the
+	// provider's first look at the discovery document and the key set.
+	discovery := newTestProvider(idp, nil)
+	codes := make([]string, 8)
+	for i := range codes {
+		authURL, err := discovery.AuthCodeURL(ctx, "state", "nonce-1", challenge(testVerifier))
+		if err != nil {
+			t.Fatalf("auth code url: %v", err)
+		}
+		codes[i] = idp.Authorize(t, authURL).Query().Get("code")
+	}
+
+	errs := make(chan error, len(codes))
+	for _, code := range codes {
+		go func() {
+			_, err := provider.Exchange(ctx, code, testVerifier, "nonce-1")
+			errs <- err
+		}()
+	}
+	for range codes {
+		if err := <-errs; err != nil {
+			t.Fatalf("concurrent exchange: %v", err)
+		}
+	}
+}
+
+func TestProvider_DiscoveryRejectsIssuerMismatch(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewProvider(t, "barnlog", "")
+	provider := NewProvider(Options{Issuer: idp.Issuer + "/", ClientID: "barnlog", RedirectURL: testRedirectURL})
+	if _, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge"); err == nil {
+		t.Fatal("expected an issuer that differs from the discovery document to fail")
+	}
+}
+
+func newTestProvider(idp *oidctest.Provider, now *time.Time) *Provider {
+	provider := NewProvider(Options{
+		Issuer:       idp.Issuer,
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"profile"},
+		Timeout:      5 * time.Second,
+	})
+	if now != nil {
+		provider.now = func() time.Time { return *now }
+	}
+	return provider
+}
+
+// login runs a whole authorization code flow against idp.
+func login(t *testing.T, idp *oidctest.Provider, provider *Provider, verifier, nonce string) (ports.IdentityClaims, error) {
+	t.Helper()
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", nonce, challenge(verifier))
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+	callback := idp.Authorize(t, authURL)
+	if !strings.HasPrefix(callback.String(), testRedirectURL+"?") || callback.Query().Get("state") != "state-1" {
+		t.Fatalf("unexpected callback %s", callback)
+	}
+	return provider.Exchange(ctx, callback.Query().Get("code"), verifier, nonce)
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
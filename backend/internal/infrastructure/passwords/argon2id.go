@@ -0,0 +1,102 @@
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"barnlog/backend/internal/ports"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Params are the argon2id cost parameters. Hashes record the parameters they
+// were made with, so raising them only affects new hashes.
+type Params struct {
+	MemoryKiB   uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltBytes   uint32
+	KeyBytes    uint32
+}
+
+// DefaultParams follow the RFC 9106 second recommended option.
+var DefaultParams = Params{
+	MemoryKiB:   64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltBytes:   16,
+	KeyBytes:    32,
+}
+
+// ErrMalformedHash reports a stored hash that is not in the argon2id PHC format.
+var ErrMalformedHash = errors.New("malformed argon2id hash")
+
+type argon2idHasher struct {
+	params Params
+}
+
+// NewArgon2id builds a ports.PasswordHasher that writes PHC-formatted
+// argon2id hashes, e.g. $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>.
+func NewArgon2id(params Params) ports.PasswordHasher {
+	return argon2idHasher{params: params}
+}
+
+func (h argon2idHasher) HashPassword(password string) (string, error) {
+	salt := make([]byte, h.params.SaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.MemoryKiB, h.params.Parallelism, h.params.KeyBytes)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.MemoryKiB,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h argon2idHasher) VerifyPassword(hash, password string) (bool, error) {
+	params, salt, key, err := decodeHash(hash)
+	if err != nil {
+		return false, err
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.MemoryKiB, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
+
+func decodeHash(hash string) (Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("%w: unsupported version %q", ErrMalformedHash, parts[2])
+	}
+
+	var params Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.MemoryKiB, &params.Iterations, &params.Parallelism); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: parameters %q", ErrMalformedHash, parts[3])
+	}
+	if params.MemoryKiB == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return Params{}, nil, nil, fmt.Errorf("%w: parameters %q", ErrMalformedHash, parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: salt", ErrMalformedHash)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, fmt.Errorf("%w: key", ErrMalformedHash)
+	}
+	return params, salt, key, nil
+}
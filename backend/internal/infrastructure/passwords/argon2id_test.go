@@ -0,0 +1,69 @@
+package passwords
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testParams = Params{MemoryKiB: 64, Iterations: 1, Parallelism: 1, SaltBytes: 16, KeyBytes: 32}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hasher := NewArgon2id(testParams)
+	hash, err := hasher.HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Fatalf("unexpected hash encoding %q", hash)
+	}
+
+	if ok, err := hasher.VerifyPassword(hash, "correct horse"); err != nil || !ok {
+		t.Fatalf("expected password to verify: ok=%v err=%v", ok, err)
+	}
+	if ok, err := hasher.VerifyPassword(hash, "wrong horse"); err != nil || ok {
+		t.Fatalf("expected wrong password to fail: ok=%v err=%v", ok, err)
+	}
+
+	again, err := hasher.HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("hash again: %v", err)
+	}
+	if again == hash {
+		t.Fatal("expected a fresh salt per hash")
+	}
+}
+
+func TestArgon2idVerifyUsesStoredParams(t *testing.T) {
+	t.Parallel()
+
+	hash, err := NewArgon2id(testParams).HashPassword("pw")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	stronger := testParams
+	stronger.Iterations = 2
+	if ok, err := NewArgon2id(stronger).VerifyPassword(hash, "pw"); err != nil || !ok {
+		t.Fatalf("expected hash made with older params to verify: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestArgon2idRejectsMalformedHashes(t *testing.T) {
+	t.Parallel()
+
+	hasher := NewArgon2id(testParams)
+	for _, hash := range []string{
+		"",
+		"plaintext",
+		"$2a$10$bcrypthashbcrypthashbcrypthashbcrypthashbcrypthash",
+		"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$!!$a2V5",
+	} {
+		if _, err := hasher.VerifyPassword(hash, "pw"); !errors.Is(err, ErrMalformedHash) {
+			t.Fatalf("expected ErrMalformedHash for %q, got %v", hash, err)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package passwords hashes and verifies user passwords with argon2id.
+package passwords
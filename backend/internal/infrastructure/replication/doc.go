@@ -0,0 +1,2 @@
+// Package replication provides the HTTP target that ships events to another Barn Log instance.
+package replication
@@ -0,0 +1,111 @@
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"barnlog/backend/internal/contracts/eventlog"
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// importPath is the peer's NDJSON import endpoint (httpapi.EventImportPath).
+	importPath = "/events/import"
+
+	maxResponseBytes = 64 << 10
+)
+
+// PeerTarget replicates events by POSTing them as NDJSON to another
+// instance's import endpoint, which skips events it already holds.
+type PeerTarget struct {
+	name    string
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+var _ ports.ReplicationTarget = (*PeerTarget)(nil)
+
+// NewPeerTarget builds a target for the instance at baseURL whose requests time
+// out after timeout. token is an API token of the peer with the events:import
+// scope, sent as a bearer token; an empty token sends no credentials.
+func NewPeerTarget(baseURL string, token string, timeout time.Duration) *PeerTarget {
+	return &PeerTarget{
+		name:    baseURL,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Name returns the peer base URL as configured.
+func (p *PeerTarget) Name() string {
+	return p.name
+}
+
+// ReplicateEvents POSTs events in one import request.
+func (p *PeerTarget) ReplicateEvents(
+	ctx context.Context,
+	events []ports.ArchivedEvent,
+) (ports.ImportEventsResult, error) {
+	var body bytes.Buffer
+	enc := eventlog.NewEncoder(&body)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return ports.ImportEventsResult{}, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+importPath, &body)
+	if err != nil {
+		return ports.ImportEventsResult{}, fmt.Errorf("build import request: %w", err)
+	}
+	req.Header.Set("Content-Type", eventlog.ContentType)
+	req.Header.Set("User-Agent", "barnlog-replication/1")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	// #nosec G704 -- the URL is the operator-configured replication peer.
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return ports.ImportEventsResult{}, fmt.Errorf("post events to peer: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return ports.ImportEventsResult{}, fmt.Errorf("read peer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Code string `json:"code"`
+		}
+		_ = json.Unmarshal(raw, &failure)
+		err := fmt.Errorf("peer responded with status %d (%s)", resp.StatusCode, failure.Code)
+		if resp.StatusCode == http.StatusConflict {
+			err = fmt.Errorf("%w: %w", ports.ErrConflict, err)
+		}
+		return ports.ImportEventsResult{}, err
+	}
+
+	var result struct {
+		Imported int `json:"imported"`
+		Skipped  int `json:"skipped"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return ports.ImportEventsResult{}, fmt.Errorf("decode peer response: %w", err)
+	}
+	return ports.ImportEventsResult{Imported: result.Imported, Skipped: result.Skipped}, nil
+}
@@ -0,0 +1,102 @@
+package replication
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/contracts/eventlog"
+	"barnlog/backend/internal/ports"
+)
+
+func TestPeerTarget_PostsNDJSONToImport(t *testing.T) {
+	t.Parallel()
+
+	type received struct {
+		path          string
+		contentType   string
+		authorization string
+		events        []ports.ArchivedEvent
+	}
+	got := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := received{
+			path:          r.URL.Path,
+			contentType:   r.Header.Get("Content-Type"),
+			authorization: r.Header.Get("Authorization"),
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			event, err := eventlog.Parse(scanner.Bytes())
+			if err != nil {
+				t.Errorf("parse line: %v", err)
+			}
+			rec.events = append(rec.events, event)
+		}
+		got <- rec
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"imported":1,"skipped":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	events := []ports.ArchivedEvent{testEvent("e1", 1), testEvent("e2", 2)}
+	result, err := NewPeerTarget(server.URL+"/", "blt_replica", time.Second).ReplicateEvents(context.Background(), events)
+	if err != nil {
+		t.Fatalf("replicate: %v", err)
+	}
+	if result != (ports.ImportEventsResult{Imported: 1, Skipped: 1}) {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	r := <-got
+	if r.path != importPath {
+		t.Fatalf("expected POST to %s, got %s", importPath, r.path)
+	}
+	if r.contentType != eventlog.ContentType {
+		t.Fatalf("unexpected content type %q", r.contentType)
+	}
+	if r.authorization != "Bearer blt_replica" {
+		t.Fatalf("expected the replication token as bearer credentials, got %q", r.authorization)
+	}
+	if len(r.events) != 2 || r.events[0].ID != "e1" || r.events[1].ID != "e2" {
+		t.Fatalf("unexpected events received %+v", r.events)
+	}
+}
+
+func TestPeerTarget_ReportsConflict(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"type":"urn:barnlog:problem:conflict","title":"Request conflicts with an earlier one","status":409,"code":"conflict"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := NewPeerTarget(server.URL, "", time.Second).
+		ReplicateEvents(context.Background(), []ports.ArchivedEvent{testEvent("e1", 1)})
+	if !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func testEvent(id string, position int64) ports.ArchivedEvent {
+	return ports.ArchivedEvent{
+		Position:      position,
+		ID:            id,
+		AggregateType: "animal",
+		AggregateID:   "a1",
+		EventType:     "animal.created",
+		CreatedBy:     "anonymous",
+		Source:        "http.api",
+		RequestID:     "req-" + id,
+		EventVersion:  1,
+		PayloadJSON:   `{"name":"Bella"}`,
+		OccurredAt:    "2026-03-04T05:06:07Z",
+		RecordedAt:    "2026-03-04 05:06:07",
+	}
+}
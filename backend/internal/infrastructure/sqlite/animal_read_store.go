@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+// pendingSnapshots is how many snapshots may wait for the snapshot writer.
+// Reads that find the queue full drop theirs; a later read queues it again.
+const pendingSnapshots = 64
+
+// AnimalReadStore is a ports.AnimalReadStore with a background snapshot
+// writer, which Close stops.
+type AnimalReadStore interface {
+	ports.AnimalReadStore
+	// Close saves the snapshots still queued and stops the snapshot writer.
+	// Call it before closing the write pool; later reads write no snapshots.
+	Close()
+}
+
+type animalReadStore struct {
+	queries       *sqlc.Queries
+	snapshots     *snapshotWriter
+	snapshotEvery int
+}
+
+// NewAnimalReadStore builds the SQLite implementation of ports.AnimalReadStore.
+// Events and snapshots are read from read. Whenever a read replays at least
+// snapshotEvery events past the latest snapshot, it queues a new snapshot for
+// a background writer on write, so reads never wait for the write connection;
+// zero or negative values disable snapshots. onSnapshotError receives failed
+// snapshot writes, which only mean later reads replay further.
+func NewAnimalReadStore(read, write *sql.DB, snapshotEvery int, onSnapshotError func(error)) AnimalReadStore {
+	store := animalReadStore{
+		queries:       newQueries(read),
+		snapshotEvery: snapshotEvery,
+	}
+	if snapshotEvery > 0 {
+		store.snapshots = newSnapshotWriter(newQueries(write), onSnapshotError)
+	}
+	return store
+}
+
+func (s animalReadStore) Close() {
+	if s.snapshots != nil {
+		s.snapshots.close()
+	}
+}
+
+func (s animalReadStore) LoadAnimal(ctx context.Context, barnID, animalID string) (ports.AnimalState, bool, error) {
+	state, version, position, err := s.loadAnimalSnapshot(ctx, barnID, animalID)
+	if err != nil {
+		return ports.AnimalState{}, false, err
+	}
+
+	events, err := s.queries.ListAggregateEventsAfterPosition(ctx, sqlc.ListAggregateEventsAfterPositionParams{
+		BarnID:        barnID,
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   animalID,
+		Position:      position,
+	})
+	if err != nil {
+		return ports.AnimalState{}, false, fmt.Errorf("list animal events: %w", err)
+	}
+
+	for _, event := range events {
+		payload, err := domain.Upcast(event.EventType, event.EventVersion, []byte(event.PayloadJson))
+		if err != nil {
+			return ports.AnimalState{}, false, fmt.Errorf("upcast event %s: %w", event.ID, err)
+		}
+		state, err = state.Apply(domain.Event{
+			Position: event.Position,
+			ID:       event.ID,
+			Type:     event.EventType,
+			Payload:  payload,
+		})
+		if err != nil {
+			return ports.AnimalState{}, false, fmt.Errorf("apply event %s: %w", event.ID, err)
+		}
+		version++
+		position = event.Position
+	}
+
+	if version == 0 {
+		return ports.AnimalState{}, false, nil
+	}
+
+	if s.snapshots != nil && len(events) >= s.snapshotEvery {
+		s.snapshots.queue(animalSnapshot{barnID: barnID, state: state, version: version, position: position})
+	}
+
+	return ports.AnimalState{Animal: state, Version: version}, true, nil
+}
+
+func (s animalReadStore) ListAnimalEvents(ctx context.Context, barnID, animalID string) ([]ports.EventRecord, error) {
+	return listAggregateEventRecords(ctx, s.queries, barnID, domain.AnimalAggregateType, animalID)
+}
+
+// loadAnimalSnapshot returns the replay starting point for an animal stream.
+// Snapshots written by another fold or upcaster revision are ignored.
+func (s animalReadStore) loadAnimalSnapshot(
+	ctx context.Context,
+	barnID, animalID string,
+) (state domain.Animal, version, position int64, err error) {
+	empty := domain.Animal{ID: animalID}
+	if s.snapshotEvery <= 0 {
+		return empty, 0, 0, nil
+	}
+
+	snapshot, err := s.queries.GetSnapshot(ctx, sqlc.GetSnapshotParams{
+		BarnID:        barnID,
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   animalID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return empty, 0, 0, nil
+		}
+		return domain.Animal{}, 0, 0, fmt.Errorf("load animal snapshot: %w", err)
+	}
+
+	if snapshot.FoldVersion != domain.AnimalFoldVersion || snapshot.UpcasterVersion != domain.UpcasterVersion {
+		return empty, 0, 0, nil
+	}
+
+	if err := json.Unmarshal([]byte(snapshot.StateJson), &state); err != nil {
+		return empty, 0, 0, nil
+	}
+	return state, snapshot.StreamVersion, snapshot.LastPosition, nil
+}
+
+// animalSnapshot is an animal folded up to stream version and event position.
+type animalSnapshot struct {
+	barnID   string
+	state    domain.Animal
+	version  int64
+	position int64
+}
+
+// snapshotWriter saves queued snapshots one at a time in the background.
+type snapshotWriter struct {
+	queries *sqlc.Queries
+	onError func(error)
+	// mu guards pending against sends after close.
+	mu      sync.Mutex
+	closed  bool
+	pending chan animalSnapshot
+	// inFlight counts queued snapshots that are not yet saved.
+	inFlight sync.WaitGroup
+}
+
+func newSnapshotWriter(queries *sqlc.Queries, onError func(error)) *snapshotWriter {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	w := &snapshotWriter{
+		queries: queries,
+		onError: onError,
+		pending: make(chan animalSnapshot, pendingSnapshots),
+	}
+	go w.run()
+	return w
+}
+
+// queue hands snapshot to the writer without waiting, or drops it when the
+// queue is full or the writer is closed.
+func (w *snapshotWriter) queue(snapshot animalSnapshot) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	w.inFlight.Add(1)
+	select {
+	case w.pending <- snapshot:
+	default:
+		w.inFlight.Done()
+	}
+}
+
+// close stops accepting snapshots and waits until the queued ones are saved.
+func (w *snapshotWriter) close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.pending)
+	}
+	w.mu.Unlock()
+	w.inFlight.Wait()
+}
+
+func (w *snapshotWriter) run() {
+	for snapshot := range w.pending {
+		if err := w.save(context.Background(), snapshot); err != nil {
+			w.onError(err)
+		}
+		w.inFlight.Done()
+	}
+}
+
+func (w *snapshotWriter) save(ctx context.Context, snapshot animalSnapshot) error {
+	stateJSON, err := json.Marshal(snapshot.state)
+	if err != nil {
+		return fmt.Errorf("marshal snapshot of animal %s: %w", snapshot.state.ID, err)
+	}
+
+	if err := w.queries.UpsertSnapshot(ctx, sqlc.UpsertSnapshotParams{
+		BarnID:          snapshot.barnID,
+		AggregateType:   domain.AnimalAggregateType,
+		AggregateID:     snapshot.state.ID,
+		StreamVersion:   snapshot.version,
+		LastPosition:    snapshot.position,
+		FoldVersion:     domain.AnimalFoldVersion,
+		UpcasterVersion: domain.UpcasterVersion,
+		StateJson:       string(stateJSON),
+	}); err != nil {
+		return fmt.Errorf("upsert snapshot of animal %s: %w", snapshot.state.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+func TestAnimalReadStore_LoadAnimal_NotFound(t *testing.T) {
+	db := openTestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, found, err := newTestAnimalReadStore(t, db, 100).LoadAnimal(context.Background(), domain.DefaultBarnID, "missing")
+	if err != nil {
+		t.Fatalf("load animal: %v", err)
+	}
+	if found {
+		t.Fatalf("expected missing animal not to be found")
+	}
+}
+
+func TestAnimalReadStore_LoadAnimal_ResumesFromSnapshot(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	animalID := seedAnimalStream(t, writer, db, 3)
+	store := newTestAnimalReadStore(t, db, 2)
+
+	first, found, err := store.LoadAnimal(context.Background(), domain.DefaultBarnID, animalID)
+	if err != nil {
+		t.Fatalf("first load: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected animal to be found")
+	}
+	if first.Version != 3 {
+		t.Fatalf("expected version=3, got %d", first.Version)
+	}
+	if first.Animal.Name != "Nanny" {
+		t.Fatalf("expected name=Nanny, got %q", first.Animal.Name)
+	}
+	// Closing saves the queued snapshot; the second load only reads it.
+	store.Close()
+
+	snapshot, err := sqlc.New(db).GetSnapshot(context.Background(), sqlc.GetSnapshotParams{
+		BarnID:        domain.DefaultBarnID,
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   animalID,
+	})
+	if err != nil {
+		t.Fatalf("expected snapshot after replaying past threshold: %v", err)
+	}
+	if snapshot.StreamVersion != 3 {
+		t.Fatalf("expected snapshot stream_version=3, got %d", snapshot.StreamVersion)
+	}
+
+	// Rewrite the snapshot state so a resumed read is distinguishable from a full replay.
+	overwriteSnapshotState(t, db, animalID, snapshot.FoldVersion, `{"id":"`+animalID+`","name":"From Snapshot"}`)
+	appendAnimalFeeding(t, db, animalID, 99)
+
+	second, _, err := store.LoadAnimal(context.Background(), domain.DefaultBarnID, animalID)
+	if err != nil {
+		t.Fatalf("second load: %v", err)
+	}
+	if second.Animal.Name != "From Snapshot" {
+		t.Fatalf("expected read to resume from snapshot, got name %q", second.Animal.Name)
+	}
+	if second.Version != 4 {
+		t.Fatalf("expected version=4, got %d", second.Version)
+	}
+}
+
+func TestAnimalReadStore_LoadAnimal_IgnoresStaleSnapshot(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	animalID := seedAnimalStream(t, writer, db, 1)
+	overwriteSnapshotState(t, db, animalID, domain.AnimalFoldVersion-1, `{"id":"`+animalID+`","name":"Stale"}`)
+
+	state, found, err := newTestAnimalReadStore(t, db, 100).LoadAnimal(context.Background(), domain.DefaultBarnID, animalID)
+	if err != nil {
+		t.Fatalf("load animal: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected animal to be found")
+	}
+	if state.Animal.Name != "Nanny" {
+		t.Fatalf("expected stale snapshot to be ignored, got name %q", state.Animal.Name)
+	}
+	if state.Version != 1 {
+		t.Fatalf("expected version=1, got %d", state.Version)
+	}
+}
+
+func TestAnimalReadStore_LoadAnimal_ReportsFailedSnapshots(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	animalID := seedAnimalStream(t, writer, db, 2)
+
+	write := openTestDB(t)
+	if err := write.Close(); err != nil {
+		t.Fatalf("close write pool: %v", err)
+	}
+	var failures []error
+	store := NewAnimalReadStore(db, write, 2, func(err error) { failures = append(failures, err) })
+
+	state, found, err := store.LoadAnimal(context.Background(), domain.DefaultBarnID, animalID)
+	if err != nil || !found {
+		t.Fatalf("expected the read to succeed without its snapshot: found=%v err=%v", found, err)
+	}
+	if state.Version != 2 {
+		t.Fatalf("expected version=2, got %d", state.Version)
+	}
+	store.Close()
+	if len(failures) != 1 {
+		t.Fatalf("expected one reported snapshot failure, got %v", failures)
+	}
+}
+
+// newTestAnimalReadStore builds a read store on db whose snapshot writer is
+// closed before db is.
+func newTestAnimalReadStore(t testing.TB, db *sql.DB, snapshotEvery int) AnimalReadStore {
+	t.Helper()
+
+	store := NewAnimalReadStore(db, db, snapshotEvery, func(err error) { t.Errorf("save snapshot: %v", err) })
+	t.Cleanup(store.Close)
+	return store
+}
+
+// BenchmarkAnimalReadStore_LoadAnimal compares a full replay of a long-lived
+// animal stream (a goat fed twice daily for eight years) with a snapshot read.
+func BenchmarkAnimalReadStore_LoadAnimal(b *testing.B) {
+	writer, db := newTestAnimalWriteStore(b)
+	b.Cleanup(func() { _ = db.Close() })
+
+	const streamLength = 2 * 365 * 8
+	animalID := seedAnimalStream(b, writer, db, streamLength)
+
+	b.Run("full_replay", func(b *testing.B) {
+		benchmarkLoadAnimal(b, newTestAnimalReadStore(b, db, 0), animalID)
+	})
+	b.Run("from_snapshot", func(b *testing.B) {
+		benchmarkLoadAnimal(b, newTestAnimalReadStore(b, db, 100), animalID)
+	})
+}
+
+func benchmarkLoadAnimal(b *testing.B, store AnimalReadStore, animalID string) {
+	b.Helper()
+
+	if _, _, err := store.LoadAnimal(context.Background(), domain.DefaultBarnID, animalID); err != nil {
+		b.Fatalf("warm up load: %v", err)
+	}
+	// Closing saves the warm-up snapshot before the timed reads.
+	store.Close()
+
+	b.ResetTimer()
+	for b.Loop() {
+		if _, _, err := store.LoadAnimal(context.Background(), domain.DefaultBarnID, animalID); err != nil {
+			b.Fatalf("load animal: %v", err)
+		}
+	}
+}
+
+// seedAnimalStream creates an animal and pads its stream with feedings until it holds streamLength events.
+func seedAnimalStream(t testing.TB, writer animalWriteStore, db *sql.DB, streamLength int) string {
+	t.Helper()
+
+	out, err := writer.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Nanny",
+		Species:   "goat",
+		Source:    "test.api",
+		RequestID: "req-create",
+		CreatedBy: "user:test",
+	})
+	if err != nil {
+		t.Fatalf("create animal: %v", err)
+	}
+
+	for i := 1; i < streamLength; i++ {
+		appendAnimalFeeding(t, db, out.AnimalID, i)
+	}
+	return out.AnimalID
+}
+
+func appendAnimalFeeding(t testing.TB, db *sql.DB, animalID string, seq int) {
+	t.Helper()
+
+	err := sqlc.New(db).CreateEvent(context.Background(), sqlc.CreateEventParams{
+		BarnID:        domain.DefaultBarnID,
+		ID:            fmt.Sprintf("%s-fed-%d", animalID, seq),
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   animalID,
+		EventType:     "animal.fed",
+		CreatedBy:     "system",
+		Source:        "test.api",
+		RequestID:     fmt.Sprintf("req-fed-%d", seq),
+		EventVersion:  1,
+		PayloadJson:   `{"feed":"hay"}`,
+		OccurredAt:    time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("append animal feeding: %v", err)
+	}
+}
+
+func overwriteSnapshotState(t testing.TB, db *sql.DB, animalID string, foldVersion int64, stateJSON string) {
+	t.Helper()
+
+	var version, position int64
+	err := db.QueryRowContext(
+		context.Background(),
+		`SELECT count(*), max(position) FROM events WHERE aggregate_type = ? AND aggregate_id = ?`,
+		domain.AnimalAggregateType,
+		animalID,
+	).Scan(&version, &position)
+	if err != nil {
+		t.Fatalf("load stream head: %v", err)
+	}
+
+	if err := sqlc.New(db).UpsertSnapshot(context.Background(), sqlc.UpsertSnapshotParams{
+		BarnID:          domain.DefaultBarnID,
+		AggregateType:   domain.AnimalAggregateType,
+		AggregateID:     animalID,
+		StreamVersion:   version,
+		LastPosition:    position,
+		FoldVersion:     foldVersion,
+		UpcasterVersion: domain.UpcasterVersion,
+		StateJson:       stateJSON,
+	}); err != nil {
+		t.Fatalf("overwrite snapshot: %v", err)
+	}
+}
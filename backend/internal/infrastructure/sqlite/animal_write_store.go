@@ -9,9 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"barnlog/backend/internal/domain"
 	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
 	"barnlog/backend/internal/ports"
 
@@ -19,12 +21,16 @@ import (
 )
 
 const (
-	createAnimalAggregateType = "animal"
-	createAnimalEventType     = "animal.created"
-	createAnimalCreatedBy     = "system"
+	createAnimalAggregateType = domain.AnimalAggregateType
+	createAnimalEventType     = domain.AnimalCreatedEventType
 )
 
+// eventIdempotencyConstraint is how SQLite names a violation of the
+// (barn_id, source, request_id) index in its error message.
+const eventIdempotencyConstraint = "unique constraint failed: events.barn_id, events.source, events.request_id"
+
 type animalWriteStore struct {
+	db       *sql.DB
 	queries  *sqlc.Queries
 	photoDir string
 	now      func() time.Time
@@ -33,7 +39,8 @@ type animalWriteStore struct {
 // NewAnimalWriteStore builds the SQLite implementation of ports.AnimalWriteStore.
 func NewAnimalWriteStore(db *sql.DB, photoDir string) ports.AnimalWriteStore {
 	return animalWriteStore{
-		queries:  sqlc.New(db),
+		db:       db,
+		queries:  newQueries(db),
 		photoDir: photoDir,
 		now:      time.Now,
 	}
@@ -54,21 +61,19 @@ func (s animalWriteStore) CreateAnimalRecord(ctx context.Context, in ports.Creat
 		return ports.CreateAnimalRecordOutput{}, err
 	}
 
-	metadataJSON, err := json.Marshal(map[string]string{
-		"source":     in.Source,
-		"request_id": in.RequestID,
-	})
+	metadataJSON, err := eventMetadataJSON(in.Source, in.RequestID, in.CreatedBy, in.DeviceID, in.ClientVersion)
 	if err != nil {
-		return ports.CreateAnimalRecordOutput{}, fmt.Errorf("marshal metadata: %w", err)
+		return ports.CreateAnimalRecordOutput{}, err
 	}
 
 	occurredAt := s.now().UTC().Format(time.RFC3339)
-	if err := s.queries.CreateEvent(ctx, sqlc.CreateEventParams{
+	if err := appendEvent(ctx, s.db, sqlc.CreateEventParams{
 		ID:            eventID,
+		BarnID:        in.BarnID,
 		AggregateType: createAnimalAggregateType,
 		AggregateID:   animalID,
 		EventType:     createAnimalEventType,
-		CreatedBy:     createAnimalCreatedBy,
+		CreatedBy:     in.CreatedBy,
 		Source:        in.Source,
 		RequestID:     in.RequestID,
 		EventVersion:  1,
@@ -105,28 +110,18 @@ func (s animalWriteStore) FindCreateAnimalReplay(ctx context.Context, in ports.C
 		return ports.CreateAnimalRecordOutput{}, false, err
 	}
 
-	existing, err := s.queries.GetEventBySourceRequestID(ctx, sqlc.GetEventBySourceRequestIDParams{
-		Source:    in.Source,
-		RequestID: in.RequestID,
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return ports.CreateAnimalRecordOutput{}, false, nil
-		}
-		return ports.CreateAnimalRecordOutput{}, false, fmt.Errorf("load existing event by idempotency key: %w", err)
-	}
-
-	if existing.AggregateType != createAnimalAggregateType || existing.EventType != createAnimalEventType {
-		return ports.CreateAnimalRecordOutput{}, false, fmt.Errorf(
-			"%w: %s/%s",
-			ports.ErrIdempotencyEventTypeMismatch,
-			existing.AggregateType,
-			existing.EventType,
-		)
-	}
-
-	if existing.PayloadJson != string(payloadJSON) {
-		return ports.CreateAnimalRecordOutput{}, false, fmt.Errorf("%w", ports.ErrIdempotencyPayloadMismatch)
+	existing, found, err := findIdempotentEvent(
+		ctx,
+		s.queries,
+		in.BarnID,
+		in.Source,
+		in.RequestID,
+		createAnimalAggregateType,
+		createAnimalEventType,
+		payloadJSON,
+	)
+	if err != nil || !found {
+		return ports.CreateAnimalRecordOutput{}, false, err
 	}
 
 	return ports.CreateAnimalRecordOutput{
@@ -150,8 +145,10 @@ func createAnimalPayloadJSON(in ports.CreateAnimalRecordInput) ([]byte, error) {
 	return payloadJSON, nil
 }
 
-func (s animalWriteStore) PhotoExists(_ context.Context, photoID string) (exists bool, err error) {
-	root, err := os.OpenRoot(s.photoDir)
+// PhotoExists looks for photoID in the upload directory of barnID. The lookup
+// cannot leave that directory, so a barn never sees another barn's photos.
+func (s animalWriteStore) PhotoExists(_ context.Context, barnID, photoID string) (exists bool, err error) {
+	root, err := os.OpenRoot(filepath.Join(s.photoDir, barnID))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return false, nil
@@ -177,14 +174,14 @@ func (s animalWriteStore) PhotoExists(_ context.Context, photoID string) (exists
 
 func isUniqueConstraint(err error) bool {
 	msg := strings.ToLower(err.Error())
-	if strings.Contains(msg, "unique constraint failed: events.source, events.request_id") {
+	if strings.Contains(msg, eventIdempotencyConstraint) {
 		return true
 	}
 	if sqliteErr, ok := errors.AsType[*modernsqlite.Error](err); ok {
 		if sqliteErr.Code() != 19 {
 			return false
 		}
-		return strings.Contains(strings.ToLower(sqliteErr.Error()), "unique constraint failed: events.source, events.request_id")
+		return strings.Contains(strings.ToLower(sqliteErr.Error()), eventIdempotencyConstraint)
 	}
 	return false
 }
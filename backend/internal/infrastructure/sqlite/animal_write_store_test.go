@@ -4,9 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"barnlog/backend/internal/domain"
 	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
 	"barnlog/backend/internal/ports"
 )
@@ -16,6 +21,7 @@ func TestAnimalWriteStore_CreateAnimalRecord_IdempotentReplay(t *testing.T) {
 	t.Cleanup(func() { _ = db.Close() })
 
 	in := ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
 		Name:      "Nanny",
 		Species:   "goat",
 		Tag:       "G-7",
@@ -23,6 +29,7 @@ func TestAnimalWriteStore_CreateAnimalRecord_IdempotentReplay(t *testing.T) {
 		PhotoID:   "photo_1",
 		Source:    "test.api",
 		RequestID: "req-1",
+		CreatedBy: "user:test",
 	}
 
 	first, err := store.CreateAnimalRecord(context.Background(), in)
@@ -53,6 +60,7 @@ func TestAnimalWriteStore_CreateAnimalRecord_PayloadMismatch(t *testing.T) {
 	t.Cleanup(func() { _ = db.Close() })
 
 	_, err := store.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
 		Name:      "Nanny",
 		Species:   "goat",
 		Tag:       "G-7",
@@ -60,12 +68,14 @@ func TestAnimalWriteStore_CreateAnimalRecord_PayloadMismatch(t *testing.T) {
 		PhotoID:   "photo_1",
 		Source:    "test.api",
 		RequestID: "req-1",
+		CreatedBy: "user:test",
 	})
 	if err != nil {
 		t.Fatalf("seed create: %v", err)
 	}
 
 	_, err = store.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
 		Name:      "Nanny",
 		Species:   "goat",
 		Tag:       "G-8",
@@ -73,6 +83,7 @@ func TestAnimalWriteStore_CreateAnimalRecord_PayloadMismatch(t *testing.T) {
 		PhotoID:   "photo_1",
 		Source:    "test.api",
 		RequestID: "req-1",
+		CreatedBy: "user:test",
 	})
 	if !errors.Is(err, ports.ErrIdempotencyPayloadMismatch) {
 		t.Fatalf("expected ErrIdempotencyPayloadMismatch, got %v", err)
@@ -85,6 +96,7 @@ func TestAnimalWriteStore_CreateAnimalRecord_EventTypeMismatch(t *testing.T) {
 
 	queries := sqlc.New(db)
 	err := queries.CreateEvent(context.Background(), sqlc.CreateEventParams{
+		BarnID:        domain.DefaultBarnID,
 		ID:            "event_existing",
 		AggregateType: "photo",
 		AggregateID:   "photo_1",
@@ -105,6 +117,7 @@ func TestAnimalWriteStore_CreateAnimalRecord_EventTypeMismatch(t *testing.T) {
 	}
 
 	_, err = store.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
 		Name:      "Nanny",
 		Species:   "goat",
 		Tag:       "G-7",
@@ -112,8 +125,192 @@ func TestAnimalWriteStore_CreateAnimalRecord_EventTypeMismatch(t *testing.T) {
 		PhotoID:   "photo_1",
 		Source:    "test.api",
 		RequestID: "req-1",
+		CreatedBy: "user:test",
 	})
 	if !errors.Is(err, ports.ErrIdempotencyEventTypeMismatch) {
 		t.Fatalf("expected ErrIdempotencyEventTypeMismatch, got %v", err)
 	}
 }
+
+func TestAnimalWriteStore_CreateAnimalRecord_RecordsActor(t *testing.T) {
+	store, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	out, err := store.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:        domain.DefaultBarnID,
+		Name:          "Nanny",
+		Species:       "goat",
+		Source:        "test.api",
+		RequestID:     "req-1",
+		CreatedBy:     "user:anna",
+		DeviceID:      "device-7",
+		ClientVersion: "web/1.4.0",
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var createdBy, metadataJSON string
+	if err := db.QueryRowContext(
+		context.Background(),
+		`SELECT created_by, metadata_json FROM events WHERE id = ?`,
+		out.EventID,
+	).Scan(&createdBy, &metadataJSON); err != nil {
+		t.Fatalf("load event: %v", err)
+	}
+	if createdBy != "user:anna" {
+		t.Fatalf("expected created_by=user:anna, got %q", createdBy)
+	}
+
+	want := `{"actor":"user:anna","client_version":"web/1.4.0","device_id":"device-7","request_id":"req-1","source":"test.api"}`
+	if metadataJSON != want {
+		t.Fatalf("expected metadata %s, got %s", want, metadataJSON)
+	}
+}
+
+func TestAnimalWriteStore_ConcurrentAppends(t *testing.T) {
+	const (
+		writers         = 8
+		createsPerWrite = 25
+	)
+	db := openTestPools(t, Options{})
+	store := NewAnimalWriteStore(db.Write, t.TempDir())
+	feed := NewEventFeedStore(db.Read)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	readErrs := make(chan error, 1)
+	go func() {
+		defer close(readErrs)
+		for ctx.Err() == nil {
+			if _, err := feed.ListEventsAfter(ctx, domain.DefaultBarnID, ports.EventFeedFilter{}, 0, 50); err != nil && ctx.Err() == nil {
+				readErrs <- err
+				return
+			}
+		}
+	}()
+
+	shared := make(chan string, writers)
+	errs := make(chan error, writers*(createsPerWrite+1))
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Go(func() {
+			for i := range createsPerWrite {
+				_, err := store.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+					BarnID:    domain.DefaultBarnID,
+					Name:      fmt.Sprintf("Goat %d-%d", w, i),
+					Species:   "goat",
+					Source:    "test.stress",
+					RequestID: fmt.Sprintf("req-%d-%d", w, i),
+					CreatedBy: "user:test",
+				})
+				if err != nil {
+					errs <- err
+				}
+			}
+			// Every writer also retries the same request; exactly one event may result.
+			out, err := store.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+				BarnID:    domain.DefaultBarnID,
+				Name:      "Shared",
+				Species:   "goat",
+				Source:    "test.stress",
+				RequestID: "req-shared",
+				CreatedBy: "user:test",
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			shared <- out.AnimalID
+		})
+	}
+	wg.Wait()
+	cancel()
+	close(errs)
+	close(shared)
+
+	for err := range errs {
+		t.Fatalf("concurrent append failed: %v", err)
+	}
+	if err := <-readErrs; err != nil {
+		t.Fatalf("concurrent read failed: %v", err)
+	}
+
+	var sharedID string
+	for id := range shared {
+		if sharedID == "" {
+			sharedID = id
+		}
+		if id != sharedID {
+			t.Fatalf("expected every retry of the shared request to return %s, got %s", sharedID, id)
+		}
+	}
+
+	want := writers*createsPerWrite + 1
+	var events, outbox, positions int
+	if err := db.Read.QueryRow(`SELECT COUNT(*), COUNT(DISTINCT position) FROM events`).Scan(&events, &positions); err != nil {
+		t.Fatalf("count events: %v", err)
+	}
+	if err := db.Read.QueryRow(`SELECT COUNT(*) FROM outbox`).Scan(&outbox); err != nil {
+		t.Fatalf("count outbox: %v", err)
+	}
+	if events != want || positions != want || outbox != want {
+		t.Fatalf("expected %d events, positions and outbox rows, got %d, %d, %d", want, events, positions, outbox)
+	}
+}
+
+func TestAnimalWriteStore_BarnsAreIsolated(t *testing.T) {
+	store, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	ids := map[string]string{}
+	for _, barnID := range []string{"north", "south"} {
+		out, err := store.CreateAnimalRecord(ctx, ports.CreateAnimalRecordInput{
+			BarnID:    barnID,
+			Name:      "Nanny",
+			Species:   "goat",
+			Source:    "test.api",
+			RequestID: "req-1",
+			CreatedBy: "user:test",
+		})
+		if err != nil {
+			t.Fatalf("create in %s: %v", barnID, err)
+		}
+		if out.Replayed {
+			t.Fatalf("expected the idempotency key of %s not to replay another barn's event", barnID)
+		}
+		ids[barnID] = out.AnimalID
+	}
+
+	reads := newTestAnimalReadStore(t, db, 100)
+	if _, found, err := reads.LoadAnimal(ctx, "south", ids["north"]); err != nil || found {
+		t.Fatalf("expected north's animal to be missing from south: found=%v err=%v", found, err)
+	}
+	if _, found, err := reads.LoadAnimal(ctx, "north", ids["north"]); err != nil || !found {
+		t.Fatalf("expected north's animal in north: found=%v err=%v", found, err)
+	}
+
+	exported := collectArchivedEvents(t, NewEventArchiveStore(db, db).ExportEvents(ctx, "south"))
+	if len(exported) != 1 || exported[0].BarnID != "south" || exported[0].AggregateID != ids["south"] {
+		t.Fatalf("expected only south's event in its export, got %+v", exported)
+	}
+
+	for barnID := range ids {
+		if err := os.MkdirAll(filepath.Join(store.photoDir, barnID), 0o750); err != nil {
+			t.Fatalf("create upload directory of %s: %v", barnID, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(store.photoDir, "north", "photo_1"), []byte("jpeg"), 0o600); err != nil {
+		t.Fatalf("write photo: %v", err)
+	}
+	for barnID, photoID := range map[string]string{"north": "photo_1", "south": "../north/photo_1"} {
+		exists, err := store.PhotoExists(ctx, barnID, photoID)
+		if err != nil && barnID == "north" {
+			t.Fatalf("photo exists in %s: %v", barnID, err)
+		}
+		if exists != (barnID == "north") {
+			t.Fatalf("expected photo %q in %s to exist=%t", photoID, barnID, barnID == "north")
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+type apiTokenStore struct {
+	queries *sqlc.Queries
+	reads   *sqlc.Queries
+}
+
+// NewAPITokenStore builds the SQLite implementation of ports.APITokenStore.
+// Like sessions, API tokens are node-local and are not part of the event log;
+// lookups and listings run on read.
+func NewAPITokenStore(read, write *sql.DB) ports.APITokenStore {
+	return apiTokenStore{queries: newQueries(write), reads: newQueries(read)}
+}
+
+func (s apiTokenStore) CreateAPIToken(ctx context.Context, in ports.APITokenRecordInput) (ports.APIToken, error) {
+	tokenID, err := newID()
+	if err != nil {
+		return ports.APIToken{}, fmt.Errorf("generate api token id: %w", err)
+	}
+	if in.Scopes == nil {
+		in.Scopes = []string{}
+	}
+	scopesJSON, err := json.Marshal(in.Scopes)
+	if err != nil {
+		return ports.APIToken{}, fmt.Errorf("marshal api token scopes: %w", err)
+	}
+	var expiresAt sql.NullString
+	if !in.ExpiresAt.IsZero() {
+		expiresAt = sql.NullString{String: formatTimestamp(in.ExpiresAt), Valid: true}
+	}
+
+	if err := s.queries.CreateApiToken(ctx, sqlc.CreateApiTokenParams{
+		ID:         tokenID,
+		UserID:     in.UserID,
+		Name:       in.Name,
+		TokenHash:  in.TokenHash,
+		ScopesJson: string(scopesJSON),
+		CreatedAt:  formatTimestamp(in.CreatedAt),
+		ExpiresAt:  expiresAt,
+	}); err != nil {
+		return ports.APIToken{}, fmt.Errorf("create api token: %w", err)
+	}
+	return ports.APIToken{
+		ID:        tokenID,
+		UserID:    in.UserID,
+		Name:      in.Name,
+		TokenHash: in.TokenHash,
+		Scopes:    in.Scopes,
+		CreatedAt: in.CreatedAt,
+		ExpiresAt: in.ExpiresAt,
+	}, nil
+}
+
+func (s apiTokenStore) GetAPITokenByHash(ctx context.Context, tokenHash string) (ports.APIToken, bool, error) {
+	row, err := s.reads.GetApiTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.APIToken{}, false, nil
+		}
+		return ports.APIToken{}, false, fmt.Errorf("get api token: %w", err)
+	}
+	token, err := apiTokenFromRow(row)
+	if err != nil {
+		return ports.APIToken{}, false, err
+	}
+	return token, true, nil
+}
+
+func (s apiTokenStore) ListAPITokens(ctx context.Context, userID string) ([]ports.APIToken, error) {
+	rows, err := s.reads.ListApiTokensByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+
+	tokens := make([]ports.APIToken, 0, len(rows))
+	for _, row := range rows {
+		token, err := apiTokenFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (s apiTokenStore) DeleteAPIToken(ctx context.Context, userID string, tokenID string) (bool, error) {
+	removed, err := s.queries.DeleteApiToken(ctx, sqlc.DeleteApiTokenParams{ID: tokenID, UserID: userID})
+	if err != nil {
+		return false, fmt.Errorf("delete api token: %w", err)
+	}
+	return removed > 0, nil
+}
+
+func apiTokenFromRow(row sqlc.ApiToken) (ports.APIToken, error) {
+	var scopes []string
+	if err := json.Unmarshal([]byte(row.ScopesJson), &scopes); err != nil {
+		return ports.APIToken{}, fmt.Errorf("decode api token %s scopes: %w", row.ID, err)
+	}
+	createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+	if err != nil {
+		return ports.APIToken{}, fmt.Errorf("parse api token %s created_at: %w", row.ID, err)
+	}
+	var expiresAt time.Time
+	if row.ExpiresAt.Valid {
+		expiresAt, err = time.Parse(time.RFC3339, row.ExpiresAt.String)
+		if err != nil {
+			return ports.APIToken{}, fmt.Errorf("parse api token %s expires_at: %w", row.ID, err)
+		}
+	}
+	return ports.APIToken{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Name:      row.Name,
+		TokenHash: row.TokenHash,
+		Scopes:    scopes,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestAPITokenStore_Lifecycle(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewAPITokenStore(db, db)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	scale, err := store.CreateAPIToken(ctx, ports.APITokenRecordInput{
+		UserID:    "u1",
+		Name:      "scale",
+		TokenHash: "hash-scale",
+		Scopes:    []string{"animals:read", "animals:write"},
+		CreatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("create scale token: %v", err)
+	}
+	if _, err := store.CreateAPIToken(ctx, ports.APITokenRecordInput{
+		UserID:    "u1",
+		Name:      "backup script",
+		TokenHash: "hash-backup",
+		Scopes:    []string{"events:read"},
+		CreatedAt: now.Add(time.Minute),
+		ExpiresAt: now.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("create backup token: %v", err)
+	}
+	if _, err := store.CreateAPIToken(ctx, ports.APITokenRecordInput{
+		UserID:    "u2",
+		Name:      "other",
+		TokenHash: "hash-other",
+		Scopes:    []string{"animals:read"},
+		CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("create token of another user: %v", err)
+	}
+
+	got, found, err := store.GetAPITokenByHash(ctx, "hash-scale")
+	if err != nil || !found {
+		t.Fatalf("get api token: found=%v err=%v", found, err)
+	}
+	if got.ID != scale.ID || got.UserID != "u1" || got.Name != "scale" ||
+		!slices.Equal(got.Scopes, []string{"animals:read", "animals:write"}) ||
+		!got.CreatedAt.Equal(now) || !got.ExpiresAt.IsZero() {
+		t.Fatalf("unexpected api token %+v", got)
+	}
+
+	listed, err := store.ListAPITokens(ctx, "u1")
+	if err != nil {
+		t.Fatalf("list api tokens: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Name != "scale" || listed[1].Name != "backup script" ||
+		!listed[1].ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("unexpected api tokens %+v", listed)
+	}
+
+	if deleted, err := store.DeleteAPIToken(ctx, "u2", scale.ID); err != nil || deleted {
+		t.Fatalf("expected another user's delete to miss: deleted=%v err=%v", deleted, err)
+	}
+	if deleted, err := store.DeleteAPIToken(ctx, "u1", scale.ID); err != nil || !deleted {
+		t.Fatalf("delete api token: deleted=%v err=%v", deleted, err)
+	}
+	if _, found, err := store.GetAPITokenByHash(ctx, "hash-scale"); err != nil || found {
+		t.Fatalf("expected deleted api token to be gone: found=%v err=%v", found, err)
+	}
+}
+
+func TestAPITokenStore_LookupDoesNotWaitForWriter(t *testing.T) {
+	db := openTestPools(t, Options{})
+	store := NewAPITokenStore(db.Read, db.Write)
+	ctx := context.Background()
+
+	if _, err := store.CreateAPIToken(ctx, ports.APITokenRecordInput{
+		UserID: "u1", Name: "scale", TokenHash: "hash-scale", CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("create api token: %v", err)
+	}
+
+	tx, err := db.Write.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin write transaction: %v", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	lookupCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, found, err := store.GetAPITokenByHash(lookupCtx, "hash-scale"); err != nil || !found {
+		t.Fatalf("get api token while the writer is busy: found=%v err=%v", found, err)
+	}
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+type deviceStore struct {
+	queries *sqlc.Queries
+	reads   *sqlc.Queries
+}
+
+// NewDeviceStore builds the SQLite implementation of ports.DeviceStore.
+// Like API tokens, devices are node-local and are not part of the event log;
+// lookups and listings run on read.
+func NewDeviceStore(read, write *sql.DB) ports.DeviceStore {
+	return deviceStore{queries: newQueries(write), reads: newQueries(read)}
+}
+
+func (s deviceStore) CreateDevice(ctx context.Context, in ports.DeviceRecordInput) (ports.Device, error) {
+	deviceID, err := newID()
+	if err != nil {
+		return ports.Device{}, fmt.Errorf("generate device id: %w", err)
+	}
+	if err := s.queries.CreateDevice(ctx, sqlc.CreateDeviceParams{
+		ID:         deviceID,
+		UserID:     in.UserID,
+		Name:       in.Name,
+		SecretHash: in.SecretHash,
+		CreatedAt:  formatTimestamp(in.CreatedAt),
+	}); err != nil {
+		return ports.Device{}, fmt.Errorf("create device: %w", err)
+	}
+	return ports.Device{
+		ID:         deviceID,
+		UserID:     in.UserID,
+		Name:       in.Name,
+		SecretHash: in.SecretHash,
+		CreatedAt:  in.CreatedAt,
+	}, nil
+}
+
+func (s deviceStore) GetDeviceBySecretHash(ctx context.Context, secretHash string) (ports.Device, bool, error) {
+	row, err := s.reads.GetDeviceBySecretHash(ctx, secretHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.Device{}, false, nil
+		}
+		return ports.Device{}, false, fmt.Errorf("get device: %w", err)
+	}
+	device, err := deviceFromRow(row)
+	if err != nil {
+		return ports.Device{}, false, err
+	}
+	return device, true, nil
+}
+
+func (s deviceStore) ListDevices(ctx context.Context, userID string) ([]ports.Device, error) {
+	rows, err := s.reads.ListDevicesByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list devices: %w", err)
+	}
+
+	devices := make([]ports.Device, 0, len(rows))
+	for _, row := range rows {
+		device, err := deviceFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func (s deviceStore) DeleteDevice(ctx context.Context, userID string, deviceID string) (bool, error) {
+	removed, err := s.queries.DeleteDevice(ctx, sqlc.DeleteDeviceParams{ID: deviceID, UserID: userID})
+	if err != nil {
+		return false, fmt.Errorf("delete device: %w", err)
+	}
+	return removed > 0, nil
+}
+
+func deviceFromRow(row sqlc.Device) (ports.Device, error) {
+	createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+	if err != nil {
+		return ports.Device{}, fmt.Errorf("parse device %s created_at: %w", row.ID, err)
+	}
+	return ports.Device{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		Name:       row.Name,
+		SecretHash: row.SecretHash,
+		CreatedAt:  createdAt,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestDeviceStore_Lifecycle(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewDeviceStore(db, db)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	phone, err := store.CreateDevice(ctx, ports.DeviceRecordInput{
+		UserID:     "u1",
+		Name:       "barn phone",
+		SecretHash: "hash-phone",
+		CreatedAt:  now,
+	})
+	if err != nil {
+		t.Fatalf("create phone: %v", err)
+	}
+	if _, err := store.CreateDevice(ctx, ports.DeviceRecordInput{
+		UserID:     "u1",
+		Name:       "tablet",
+		SecretHash: "hash-tablet",
+		CreatedAt:  now.Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("create tablet: %v", err)
+	}
+	if _, err := store.CreateDevice(ctx, ports.DeviceRecordInput{
+		UserID:     "u2",
+		Name:       "other",
+		SecretHash: "hash-other",
+		CreatedAt:  now,
+	}); err != nil {
+		t.Fatalf("create device of another user: %v", err)
+	}
+
+	got, found, err := store.GetDeviceBySecretHash(ctx, "hash-phone")
+	if err != nil || !found {
+		t.Fatalf("get device: found=%v err=%v", found, err)
+	}
+	if got.ID != phone.ID || got.UserID != "u1" || got.Name != "barn phone" || !got.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected device %+v", got)
+	}
+
+	listed, err := store.ListDevices(ctx, "u1")
+	if err != nil {
+		t.Fatalf("list devices: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Name != "barn phone" || listed[1].Name != "tablet" {
+		t.Fatalf("unexpected devices %+v", listed)
+	}
+
+	if deleted, err := store.DeleteDevice(ctx, "u2", phone.ID); err != nil || deleted {
+		t.Fatalf("expected another user's delete to miss: deleted=%v err=%v", deleted, err)
+	}
+	if deleted, err := store.DeleteDevice(ctx, "u1", phone.ID); err != nil || !deleted {
+		t.Fatalf("delete device: deleted=%v err=%v", deleted, err)
+	}
+	if _, found, err := store.GetDeviceBySecretHash(ctx, "hash-phone"); err != nil || found {
+		t.Fatalf("expected revoked device to be gone: found=%v err=%v", found, err)
+	}
+}
+
+func TestDeviceStore_LookupDoesNotWaitForWriter(t *testing.T) {
+	db := openTestPools(t, Options{})
+	store := NewDeviceStore(db.Read, db.Write)
+	ctx := context.Background()
+
+	if _, err := store.CreateDevice(ctx, ports.DeviceRecordInput{
+		UserID: "u1", Name: "barn phone", SecretHash: "hash-phone", CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("create device: %v", err)
+	}
+
+	tx, err := db.Write.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin write transaction: %v", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	lookupCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, found, err := store.GetDeviceBySecretHash(lookupCtx, "hash-phone"); err != nil || !found {
+		t.Fatalf("get device while the writer is busy: found=%v err=%v", found, err)
+	}
+}
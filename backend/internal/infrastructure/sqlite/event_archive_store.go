@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+const eventExportPageSize = 500
+
+type eventArchiveStore struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+	reads   *sqlc.Queries
+}
+
+// NewEventArchiveStore builds the SQLite implementation of ports.EventArchiveStore.
+// Exports page through read; imports run in one transaction on write.
+func NewEventArchiveStore(read, write *sql.DB) ports.EventArchiveStore {
+	return eventArchiveStore{
+		db:      write,
+		queries: newQueries(write),
+		reads:   newQueries(read),
+	}
+}
+
+// ExportEvents pages through events by position so the export never holds a
+// read transaction open for the whole log.
+func (s eventArchiveStore) ExportEvents(ctx context.Context, barnID string) iter.Seq2[ports.ArchivedEvent, error] {
+	return func(yield func(ports.ArchivedEvent, error) bool) {
+		var after int64
+		for {
+			rows, err := s.reads.ListEventsForExport(ctx, sqlc.ListEventsForExportParams{
+				AfterPosition: after,
+				BarnID:        barnID,
+				RowLimit:      eventExportPageSize,
+			})
+			if err != nil {
+				yield(ports.ArchivedEvent{}, fmt.Errorf("list events for export: %w", err))
+				return
+			}
+			for _, row := range rows {
+				if !yield(archivedEventFromRow(row), nil) {
+					return
+				}
+				after = row.Position
+			}
+			if len(rows) < eventExportPageSize {
+				return
+			}
+		}
+	}
+}
+
+func (s eventArchiveStore) ImportEvents(
+	ctx context.Context,
+	events iter.Seq2[ports.ArchivedEvent, error],
+) (result ports.ImportEventsResult, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ports.ImportEventsResult{}, fmt.Errorf("begin import transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	queries := newQueries(tx)
+	for event, err := range events {
+		if err != nil {
+			return ports.ImportEventsResult{}, err
+		}
+		inserted, err := queries.ImportEvent(ctx, sqlc.ImportEventParams{
+			ID:            event.ID,
+			BarnID:        event.BarnID,
+			AggregateType: event.AggregateType,
+			AggregateID:   event.AggregateID,
+			EventType:     event.EventType,
+			CreatedBy:     event.CreatedBy,
+			Source:        event.Source,
+			RequestID:     event.RequestID,
+			EventVersion:  event.EventVersion,
+			PayloadJson:   event.PayloadJSON,
+			MetadataJson: sql.NullString{
+				String: event.MetadataJSON,
+				Valid:  event.MetadataJSON != "",
+			},
+			OccurredAt: event.OccurredAt,
+			CreatedAt:  event.RecordedAt,
+		})
+		if err != nil {
+			return ports.ImportEventsResult{}, fmt.Errorf("import event %s: %w", event.ID, err)
+		}
+		if inserted > 0 {
+			result.Imported++
+			continue
+		}
+		if err := checkImportDuplicate(ctx, queries, event); err != nil {
+			return ports.ImportEventsResult{}, err
+		}
+		result.Skipped++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ports.ImportEventsResult{}, fmt.Errorf("commit import transaction: %w", err)
+	}
+	return result, nil
+}
+
+// checkImportDuplicate accepts a rejected insert only when the stored event is
+// the same event: same ID under the same idempotency key with the same payload.
+func checkImportDuplicate(ctx context.Context, queries *sqlc.Queries, event ports.ArchivedEvent) error {
+	existing, err := queries.GetEventBySourceRequestID(ctx, sqlc.GetEventBySourceRequestIDParams{
+		BarnID:    event.BarnID,
+		Source:    event.Source,
+		RequestID: event.RequestID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: event id %s already used by another request", ports.ErrConflict, event.ID)
+		}
+		return fmt.Errorf("load existing event by idempotency key: %w", err)
+	}
+	if existing.ID != event.ID || existing.PayloadJson != event.PayloadJSON {
+		return fmt.Errorf(
+			"%w: %s/%s/%s already recorded as event %s",
+			ports.ErrConflict,
+			event.BarnID,
+			event.Source,
+			event.RequestID,
+			existing.ID,
+		)
+	}
+	return nil
+}
+
+func archivedEventFromRow(row sqlc.Event) ports.ArchivedEvent {
+	return ports.ArchivedEvent{
+		Position:      row.Position,
+		ID:            row.ID,
+		BarnID:        row.BarnID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		CreatedBy:     row.CreatedBy,
+		Source:        row.Source,
+		RequestID:     row.RequestID,
+		EventVersion:  row.EventVersion,
+		PayloadJSON:   row.PayloadJson,
+		MetadataJSON:  row.MetadataJson.String,
+		OccurredAt:    row.OccurredAt,
+		RecordedAt:    row.CreatedAt,
+	}
+}
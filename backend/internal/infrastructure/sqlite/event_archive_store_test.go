@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestEventArchiveStore_ExportImportRoundTrip(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	seedAnimalStream(t, writer, db, 3)
+
+	exported := collectArchivedEvents(t, NewEventArchiveStore(db, db).ExportEvents(context.Background(), ""))
+	if len(exported) != 3 {
+		t.Fatalf("expected 3 exported events, got %d", len(exported))
+	}
+
+	target := openTestDB(t)
+	t.Cleanup(func() { _ = target.Close() })
+	targetStore := NewEventArchiveStore(target, target)
+
+	result, err := targetStore.ImportEvents(context.Background(), archivedEvents(exported))
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result != (ports.ImportEventsResult{Imported: 3}) {
+		t.Fatalf("expected 3 imported, got %+v", result)
+	}
+
+	reimported := collectArchivedEvents(t, targetStore.ExportEvents(context.Background(), ""))
+	if !slices.Equal(reimported, exported) {
+		t.Fatalf("imported log differs from source:\n got %+v\nwant %+v", reimported, exported)
+	}
+
+	var outbox int
+	if err := target.QueryRow(`SELECT COUNT(*) FROM outbox`).Scan(&outbox); err != nil {
+		t.Fatalf("count outbox: %v", err)
+	}
+	if outbox != 0 {
+		t.Fatalf("expected imported events to skip the outbox, got %d entries", outbox)
+	}
+
+	result, err = targetStore.ImportEvents(context.Background(), archivedEvents(exported))
+	if err != nil {
+		t.Fatalf("re-import: %v", err)
+	}
+	if result != (ports.ImportEventsResult{Skipped: 3}) {
+		t.Fatalf("expected 3 skipped on re-import, got %+v", result)
+	}
+}
+
+func TestEventArchiveStore_ImportConflict(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	seedAnimalStream(t, writer, db, 1)
+	store := NewEventArchiveStore(db, db)
+
+	exported := collectArchivedEvents(t, store.ExportEvents(context.Background(), ""))
+	changed := exported[0]
+	changed.PayloadJSON = `{"name":"Other","species":"goat"}`
+	reused := exported[0]
+	reused.Source = "other.source"
+
+	for name, event := range map[string]ports.ArchivedEvent{
+		"same idempotency key, different payload":  changed,
+		"same event id, different idempotency key": reused,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := store.ImportEvents(context.Background(), archivedEvents([]ports.ArchivedEvent{event}))
+			if !errors.Is(err, ports.ErrConflict) {
+				t.Fatalf("expected ErrConflict, got %v", err)
+			}
+		})
+	}
+
+	after := collectArchivedEvents(t, store.ExportEvents(context.Background(), ""))
+	if !slices.Equal(after, exported) {
+		t.Fatalf("expected failed imports to leave the log untouched, got %+v", after)
+	}
+}
+
+func collectArchivedEvents(t testing.TB, events iter.Seq2[ports.ArchivedEvent, error]) []ports.ArchivedEvent {
+	t.Helper()
+
+	var out []ports.ArchivedEvent
+	for event, err := range events {
+		if err != nil {
+			t.Fatalf("export: %v", err)
+		}
+		out = append(out, event)
+	}
+	return out
+}
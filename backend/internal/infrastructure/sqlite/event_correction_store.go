@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+type eventCorrectionStore struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+	now     func() time.Time
+}
+
+// NewEventCorrectionStore builds the SQLite implementation of ports.EventCorrectionStore.
+func NewEventCorrectionStore(db *sql.DB) ports.EventCorrectionStore {
+	return eventCorrectionStore{
+		db:      db,
+		queries: newQueries(db),
+		now:     time.Now,
+	}
+}
+
+func (s eventCorrectionStore) GetEvent(ctx context.Context, barnID, eventID string) (ports.EventRecord, bool, error) {
+	row, err := s.queries.GetEventByID(ctx, sqlc.GetEventByIDParams{BarnID: barnID, ID: eventID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.EventRecord{}, false, nil
+		}
+		return ports.EventRecord{}, false, fmt.Errorf("get event: %w", err)
+	}
+	return ports.EventRecord{
+		Position:      row.Position,
+		ID:            row.ID,
+		BarnID:        row.BarnID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		EventVersion:  row.EventVersion,
+		CreatedBy:     row.CreatedBy,
+		PayloadJSON:   row.PayloadJson,
+		OccurredAt:    row.OccurredAt,
+		RecordedAt:    row.CreatedAt,
+	}, true, nil
+}
+
+func (s eventCorrectionStore) ListAggregateEvents(
+	ctx context.Context,
+	barnID, aggregateType, aggregateID string,
+) ([]ports.EventRecord, error) {
+	return listAggregateEventRecords(ctx, s.queries, barnID, aggregateType, aggregateID)
+}
+
+func (s eventCorrectionStore) AppendEventCorrection(
+	ctx context.Context,
+	in ports.EventCorrectionRecordInput,
+) (ports.EventCorrectionRecordOutput, error) {
+	eventID, err := newID()
+	if err != nil {
+		return ports.EventCorrectionRecordOutput{}, fmt.Errorf("generate event id: %w", err)
+	}
+
+	payloadJSON, err := eventCorrectionPayloadJSON(in)
+	if err != nil {
+		return ports.EventCorrectionRecordOutput{}, err
+	}
+
+	metadataJSON, err := eventMetadataJSON(in.Source, in.RequestID, in.CreatedBy, in.DeviceID, in.ClientVersion)
+	if err != nil {
+		return ports.EventCorrectionRecordOutput{}, err
+	}
+
+	// The target is checked inside the write transaction, so two amendments
+	// racing a void cannot both be written after it.
+	err = appendCheckedEvent(ctx, s.db, sqlc.CreateEventParams{
+		ID:            eventID,
+		BarnID:        in.BarnID,
+		AggregateType: in.AggregateType,
+		AggregateID:   in.AggregateID,
+		EventType:     in.EventType,
+		CreatedBy:     in.CreatedBy,
+		Source:        in.Source,
+		RequestID:     in.RequestID,
+		EventVersion:  domain.CurrentEventVersion(in.EventType),
+		PayloadJson:   string(payloadJSON),
+		MetadataJson: sql.NullString{
+			String: string(metadataJSON),
+			Valid:  true,
+		},
+		OccurredAt: s.now().UTC().Format(time.RFC3339),
+	}, func(queries *sqlc.Queries) error {
+		voids, err := queries.CountEventVoids(ctx, sqlc.CountEventVoidsParams{
+			BarnID:        in.BarnID,
+			AggregateType: in.AggregateType,
+			AggregateID:   in.AggregateID,
+			TargetEventID: in.TargetEventID,
+		})
+		if err != nil {
+			return fmt.Errorf("count event voids: %w", err)
+		}
+		if voids > 0 {
+			return fmt.Errorf("%w", ports.ErrEventVoided)
+		}
+		return nil
+	})
+	if err != nil {
+		voided := errors.Is(err, ports.ErrEventVoided)
+		if voided || isUniqueConstraint(err) {
+			// A retry of the request that voided the target replays it.
+			out, found, replayErr := s.FindEventCorrectionReplay(ctx, in)
+			if replayErr != nil {
+				return ports.EventCorrectionRecordOutput{}, replayErr
+			}
+			if found {
+				return out, nil
+			}
+			if voided {
+				return ports.EventCorrectionRecordOutput{}, err
+			}
+			return ports.EventCorrectionRecordOutput{}, fmt.Errorf("%w", ports.ErrConflict)
+		}
+		return ports.EventCorrectionRecordOutput{}, fmt.Errorf("create event: %w", err)
+	}
+
+	return ports.EventCorrectionRecordOutput{EventID: eventID}, nil
+}
+
+func (s eventCorrectionStore) FindEventCorrectionReplay(
+	ctx context.Context,
+	in ports.EventCorrectionRecordInput,
+) (ports.EventCorrectionRecordOutput, bool, error) {
+	payloadJSON, err := eventCorrectionPayloadJSON(in)
+	if err != nil {
+		return ports.EventCorrectionRecordOutput{}, false, err
+	}
+
+	existing, found, err := findIdempotentEvent(
+		ctx,
+		s.queries,
+		in.BarnID,
+		in.Source,
+		in.RequestID,
+		in.AggregateType,
+		in.EventType,
+		payloadJSON,
+	)
+	if err != nil || !found {
+		return ports.EventCorrectionRecordOutput{}, false, err
+	}
+	if existing.AggregateID != in.AggregateID {
+		return ports.EventCorrectionRecordOutput{}, false, fmt.Errorf("%w", ports.ErrIdempotencyPayloadMismatch)
+	}
+
+	return ports.EventCorrectionRecordOutput{
+		EventID:  existing.ID,
+		Replayed: true,
+	}, true, nil
+}
+
+func eventCorrectionPayloadJSON(in ports.EventCorrectionRecordInput) ([]byte, error) {
+	var payload any
+	switch in.EventType {
+	case domain.EventCorrectedEventType:
+		payload = domain.EventCorrected{
+			TargetEventID:  in.TargetEventID,
+			Reason:         in.Reason,
+			PayloadVersion: in.PayloadVersion,
+			Payload:        json.RawMessage(in.Payload),
+		}
+	case domain.EventVoidedEventType:
+		payload = domain.EventVoided{
+			TargetEventID: in.TargetEventID,
+			Reason:        in.Reason,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported amendment event type %q", in.EventType)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+	return payloadJSON, nil
+}
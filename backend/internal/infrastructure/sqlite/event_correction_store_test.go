@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestEventCorrectionStore_AppendEventCorrection_FoldsIntoAnimal(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewEventCorrectionStore(db)
+	reader := newTestAnimalReadStore(t, db, 100)
+
+	created, err := writer.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Nanny",
+		Species:   "goat",
+		Tag:       "G-7",
+		Source:    "test.api",
+		RequestID: "req-create",
+		CreatedBy: "user:test",
+	})
+	if err != nil {
+		t.Fatalf("create animal: %v", err)
+	}
+
+	in := ports.EventCorrectionRecordInput{
+		BarnID:         domain.DefaultBarnID,
+		AggregateType:  domain.AnimalAggregateType,
+		AggregateID:    created.AnimalID,
+		EventType:      domain.EventCorrectedEventType,
+		TargetEventID:  created.EventID,
+		Reason:         "typo in tag",
+		PayloadVersion: 1,
+		Payload:        []byte(`{"birthdate":"","name":"Nanny","photo_id":"","species":"goat","tag":"G-8"}`),
+		Source:         "test.api",
+		RequestID:      "req-correct",
+		CreatedBy:      "user:test",
+	}
+	first, err := store.AppendEventCorrection(context.Background(), in)
+	if err != nil {
+		t.Fatalf("append correction: %v", err)
+	}
+	if first.Replayed {
+		t.Fatalf("expected first correction not replayed")
+	}
+
+	second, err := store.AppendEventCorrection(context.Background(), in)
+	if err != nil {
+		t.Fatalf("append correction (replay): %v", err)
+	}
+	if !second.Replayed || second.EventID != first.EventID {
+		t.Fatalf("expected replay of %q, got %+v", first.EventID, second)
+	}
+
+	state, found, err := reader.LoadAnimal(context.Background(), domain.DefaultBarnID, created.AnimalID)
+	if err != nil || !found {
+		t.Fatalf("load animal: found=%v err=%v", found, err)
+	}
+	if state.Animal.Tag != "G-8" {
+		t.Fatalf("expected corrected tag G-8, got %q", state.Animal.Tag)
+	}
+	if state.Version != 2 {
+		t.Fatalf("expected version 2, got %d", state.Version)
+	}
+
+	events, err := reader.ListAnimalEvents(context.Background(), domain.DefaultBarnID, created.AnimalID)
+	if err != nil {
+		t.Fatalf("list animal events: %v", err)
+	}
+	if len(events) != 2 || events[1].EventType != domain.EventCorrectedEventType {
+		t.Fatalf("expected creation followed by correction, got %+v", events)
+	}
+
+	record, found, err := store.GetEvent(context.Background(), domain.DefaultBarnID, first.EventID)
+	if err != nil || !found {
+		t.Fatalf("get correction event: found=%v err=%v", found, err)
+	}
+	if record.AggregateID != created.AnimalID || record.EventVersion != 1 {
+		t.Fatalf("unexpected correction record: %+v", record)
+	}
+}
+
+func TestEventCorrectionStore_AppendEventCorrection_PayloadMismatch(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewEventCorrectionStore(db)
+
+	created, err := writer.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Nanny",
+		Species:   "goat",
+		Source:    "test.api",
+		RequestID: "req-create",
+		CreatedBy: "user:test",
+	})
+	if err != nil {
+		t.Fatalf("create animal: %v", err)
+	}
+
+	in := ports.EventCorrectionRecordInput{
+		BarnID:        domain.DefaultBarnID,
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   created.AnimalID,
+		EventType:     domain.EventVoidedEventType,
+		TargetEventID: created.EventID,
+		Reason:        "duplicate entry",
+		Source:        "test.api",
+		RequestID:     "req-void",
+		CreatedBy:     "user:test",
+	}
+	if _, err := store.AppendEventCorrection(context.Background(), in); err != nil {
+		t.Fatalf("append void: %v", err)
+	}
+
+	in.Reason = "entered twice"
+	_, err = store.AppendEventCorrection(context.Background(), in)
+	if !errors.Is(err, ports.ErrIdempotencyPayloadMismatch) {
+		t.Fatalf("expected ErrIdempotencyPayloadMismatch, got %v", err)
+	}
+}
+
+func TestEventCorrectionStore_AppendEventCorrection_RejectsVoidedTarget(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewEventCorrectionStore(db)
+
+	created, err := writer.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Nanny",
+		Species:   "goat",
+		Source:    "test.api",
+		RequestID: "req-create",
+		CreatedBy: "user:test",
+	})
+	if err != nil {
+		t.Fatalf("create animal: %v", err)
+	}
+
+	void := ports.EventCorrectionRecordInput{
+		BarnID:        domain.DefaultBarnID,
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   created.AnimalID,
+		EventType:     domain.EventVoidedEventType,
+		TargetEventID: created.EventID,
+		Reason:        "duplicate entry",
+		Source:        "test.api",
+		RequestID:     "req-void",
+		CreatedBy:     "user:test",
+	}
+	first, err := store.AppendEventCorrection(context.Background(), void)
+	if err != nil {
+		t.Fatalf("append void: %v", err)
+	}
+
+	// A void or correction that loaded the target before the void was written.
+	second := void
+	second.RequestID = "req-void-2"
+	if _, err := store.AppendEventCorrection(context.Background(), second); !errors.Is(err, ports.ErrEventVoided) {
+		t.Fatalf("expected a second void to fail with ErrEventVoided, got %v", err)
+	}
+	correction := void
+	correction.EventType = domain.EventCorrectedEventType
+	correction.RequestID = "req-correct"
+	correction.PayloadVersion = 1
+	correction.Payload = []byte(`{"birthdate":"","name":"Nanny","photo_id":"","species":"goat","tag":"G-8"}`)
+	if _, err := store.AppendEventCorrection(context.Background(), correction); !errors.Is(err, ports.ErrEventVoided) {
+		t.Fatalf("expected a correction of a voided event to fail with ErrEventVoided, got %v", err)
+	}
+
+	retried, err := store.AppendEventCorrection(context.Background(), void)
+	if err != nil {
+		t.Fatalf("retry void: %v", err)
+	}
+	if !retried.Replayed || retried.EventID != first.EventID {
+		t.Fatalf("expected the retried void to replay %q, got %+v", first.EventID, retried)
+	}
+}
+
+func TestEventCorrectionStore_GetEvent_NotFound(t *testing.T) {
+	db := openTestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, found, err := NewEventCorrectionStore(db).GetEvent(context.Background(), domain.DefaultBarnID, "missing")
+	if err != nil {
+		t.Fatalf("get event: %v", err)
+	}
+	if found {
+		t.Fatalf("expected missing event not found")
+	}
+}
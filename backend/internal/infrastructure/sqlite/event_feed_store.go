@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+type eventFeedStore struct {
+	queries *sqlc.Queries
+}
+
+// NewEventFeedStore builds the SQLite implementation of ports.EventFeedStore.
+func NewEventFeedStore(db *sql.DB) ports.EventFeedStore {
+	return eventFeedStore{queries: newQueries(db)}
+}
+
+func (s eventFeedStore) ListEventsAfter(
+	ctx context.Context,
+	barnID string,
+	filter ports.EventFeedFilter,
+	afterPosition int64,
+	limit int,
+) ([]ports.EventRecord, error) {
+	rows, err := s.queries.ListEventsAfterPosition(ctx, sqlc.ListEventsAfterPositionParams{
+		BarnID:        barnID,
+		AfterPosition: afterPosition,
+		AggregateType: filter.AggregateType,
+		AggregateID:   filter.AggregateID,
+		EventType:     filter.EventType,
+		RowLimit:      int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list events after position: %w", err)
+	}
+
+	records := make([]ports.EventRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, ports.EventRecord{
+			Position:      row.Position,
+			ID:            row.ID,
+			BarnID:        row.BarnID,
+			AggregateType: row.AggregateType,
+			AggregateID:   row.AggregateID,
+			EventType:     row.EventType,
+			EventVersion:  row.EventVersion,
+			CreatedBy:     row.CreatedBy,
+			PayloadJSON:   row.PayloadJson,
+			OccurredAt:    row.OccurredAt,
+			RecordedAt:    row.CreatedAt,
+		})
+	}
+	return records, nil
+}
+
+func (s eventFeedStore) LatestPosition(ctx context.Context, barnID string) (int64, error) {
+	position, err := s.queries.GetLatestEventPosition(ctx, barnID)
+	if err != nil {
+		return 0, fmt.Errorf("get latest event position: %w", err)
+	}
+	return position, nil
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestEventFeedStore_ListEventsAfter(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewEventFeedStore(db)
+
+	latest, err := store.LatestPosition(context.Background(), domain.DefaultBarnID)
+	if err != nil {
+		t.Fatalf("latest position of empty log: %v", err)
+	}
+	if latest != 0 {
+		t.Fatalf("expected latest position 0, got %d", latest)
+	}
+
+	first := seedAnimalStream(t, writer, db, 3)
+	created, err := writer.CreateAnimalRecord(context.Background(), ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Pepper",
+		Species:   "pig",
+		Source:    "test.api",
+		RequestID: "req-create-second",
+		CreatedBy: "user:test",
+	})
+	if err != nil {
+		t.Fatalf("create second animal: %v", err)
+	}
+	second := created.AnimalID
+
+	all, err := store.ListEventsAfter(context.Background(), domain.DefaultBarnID, ports.EventFeedFilter{}, 0, 10)
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Position <= all[i-1].Position {
+			t.Fatalf("expected ascending positions, got %d after %d", all[i].Position, all[i-1].Position)
+		}
+	}
+
+	page, err := store.ListEventsAfter(context.Background(), domain.DefaultBarnID, ports.EventFeedFilter{}, all[1].Position, 1)
+	if err != nil {
+		t.Fatalf("list page: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != all[2].ID {
+		t.Fatalf("expected single event %s after cursor, got %+v", all[2].ID, page)
+	}
+
+	byAggregate, err := store.ListEventsAfter(context.Background(), domain.DefaultBarnID, ports.EventFeedFilter{
+		AggregateType: domain.AnimalAggregateType,
+		AggregateID:   second,
+	}, 0, 10)
+	if err != nil {
+		t.Fatalf("list by aggregate: %v", err)
+	}
+	if len(byAggregate) != 1 || byAggregate[0].AggregateID != second {
+		t.Fatalf("expected only %s events, got %+v", second, byAggregate)
+	}
+
+	byType, err := store.ListEventsAfter(context.Background(), domain.DefaultBarnID, ports.EventFeedFilter{EventType: "animal.fed"}, 0, 10)
+	if err != nil {
+		t.Fatalf("list by type: %v", err)
+	}
+	if len(byType) != 2 || byType[0].AggregateID != first {
+		t.Fatalf("expected two animal.fed events of %s, got %+v", first, byType)
+	}
+
+	latest, err = store.LatestPosition(context.Background(), domain.DefaultBarnID)
+	if err != nil {
+		t.Fatalf("latest position: %v", err)
+	}
+	if latest != all[3].Position {
+		t.Fatalf("expected latest position %d, got %d", all[3].Position, latest)
+	}
+}
@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+// appendEvent inserts an event together with its outbox entry in one transaction,
+// so every persisted event is eventually offered to webhook subscribers.
+// Errors from the event insert are returned unwrapped for isUniqueConstraint.
+func appendEvent(ctx context.Context, db *sql.DB, params sqlc.CreateEventParams) error {
+	return appendCheckedEvent(ctx, db, params, nil)
+}
+
+// appendCheckedEvent is appendEvent with check run first in the same write
+// transaction, so what check sees cannot change before the event is written.
+// A nil check is skipped.
+func appendCheckedEvent(
+	ctx context.Context,
+	db *sql.DB,
+	params sqlc.CreateEventParams,
+	check func(queries *sqlc.Queries) error,
+) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin event transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	queries := newQueries(tx)
+	if check != nil {
+		if err := check(queries); err != nil {
+			return err
+		}
+	}
+	if err := queries.CreateEvent(ctx, params); err != nil {
+		return err
+	}
+	if err := queries.CreateOutboxEntry(ctx, params.ID); err != nil {
+		return fmt.Errorf("create outbox entry: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit event transaction: %w", err)
+	}
+	return nil
+}
+
+// findIdempotentEvent loads the event appended earlier to barnID under (source, requestID)
+// and checks that it records the same aggregate type, event type and payload.
+func findIdempotentEvent(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	barnID, source, requestID, aggregateType, eventType string,
+	payloadJSON []byte,
+) (sqlc.GetEventBySourceRequestIDRow, bool, error) {
+	existing, err := queries.GetEventBySourceRequestID(ctx, sqlc.GetEventBySourceRequestIDParams{
+		BarnID:    barnID,
+		Source:    source,
+		RequestID: requestID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.GetEventBySourceRequestIDRow{}, false, nil
+		}
+		return sqlc.GetEventBySourceRequestIDRow{}, false, fmt.Errorf("load existing event by idempotency key: %w", err)
+	}
+
+	if existing.AggregateType != aggregateType || existing.EventType != eventType {
+		return sqlc.GetEventBySourceRequestIDRow{}, false, fmt.Errorf(
+			"%w: %s/%s",
+			ports.ErrIdempotencyEventTypeMismatch,
+			existing.AggregateType,
+			existing.EventType,
+		)
+	}
+
+	if existing.PayloadJson != string(payloadJSON) {
+		return sqlc.GetEventBySourceRequestIDRow{}, false, fmt.Errorf("%w", ports.ErrIdempotencyPayloadMismatch)
+	}
+	return existing, true, nil
+}
+
+func listAggregateEventRecords(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	barnID, aggregateType, aggregateID string,
+) ([]ports.EventRecord, error) {
+	rows, err := queries.ListAggregateEvents(ctx, sqlc.ListAggregateEventsParams{
+		BarnID:        barnID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list aggregate events: %w", err)
+	}
+
+	records := make([]ports.EventRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, ports.EventRecord{
+			Position:      row.Position,
+			ID:            row.ID,
+			BarnID:        row.BarnID,
+			AggregateType: row.AggregateType,
+			AggregateID:   row.AggregateID,
+			EventType:     row.EventType,
+			EventVersion:  row.EventVersion,
+			CreatedBy:     row.CreatedBy,
+			PayloadJSON:   row.PayloadJson,
+			OccurredAt:    row.OccurredAt,
+			RecordedAt:    row.CreatedAt,
+		})
+	}
+	return records, nil
+}
+
+// eventMetadataJSON builds the metadata_json column: request context plus the
+// actor, device and client version that produced the event when known.
+func eventMetadataJSON(source, requestID, actor, deviceID, clientVersion string) ([]byte, error) {
+	metadata := map[string]string{
+		"source":     source,
+		"request_id": requestID,
+		"actor":      actor,
+	}
+	if deviceID != "" {
+		metadata["device_id"] = deviceID
+	}
+	if clientVersion != "" {
+		metadata["client_version"] = clientVersion
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %w", err)
+	}
+	return metadataJSON, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the sqlite driver
+)
+
+// Connection defaults used when Options leaves a field empty.
+const (
+	DefaultBusyTimeout  = 5 * time.Second
+	DefaultJournalMode  = "WAL"
+	DefaultSynchronous  = "NORMAL"
+	DefaultMaxReadConns = 4
+)
+
+// Options tunes the connections opened by Open.
+type Options struct {
+	// BusyTimeout is how long a connection waits for a lock before failing with SQLITE_BUSY.
+	BusyTimeout time.Duration
+	// JournalMode is the PRAGMA journal_mode value, for example WAL or DELETE.
+	JournalMode string
+	// Synchronous is the PRAGMA synchronous value, for example NORMAL or FULL.
+	Synchronous string
+	// MaxReadConns caps the read pool.
+	MaxReadConns int
+}
+
+// DB is a SQLite database opened as two pools. SQLite allows one writer at a
+// time, so Write holds a single connection and begins transactions with
+// BEGIN IMMEDIATE: writers queue in Go instead of failing with SQLITE_BUSY, and
+// a read-then-write transaction never deadlocks on lock upgrade. Read serves
+// queries concurrently; under WAL they do not block, and are not blocked by, the writer.
+type DB struct {
+	Read  *sql.DB
+	Write *sql.DB
+}
+
+// Open opens the database at path with foreign keys enforced and the pragmas
+// from opts applied to every connection of both pools.
+func Open(ctx context.Context, path string, opts Options) (*DB, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve db path: %w", err)
+	}
+	opts = opts.withDefaults()
+
+	write, err := openPool(ctx, absPath, opts, "immediate", 1)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite write pool: %w", err)
+	}
+	read, err := openPool(ctx, absPath, opts, "deferred", opts.MaxReadConns)
+	if err != nil {
+		_ = write.Close()
+		return nil, fmt.Errorf("open sqlite read pool: %w", err)
+	}
+	return &DB{Read: read, Write: write}, nil
+}
+
+// Close closes both pools.
+func (db *DB) Close() error {
+	return errors.Join(db.Read.Close(), db.Write.Close())
+}
+
+func (o Options) withDefaults() Options {
+	if o.BusyTimeout <= 0 {
+		o.BusyTimeout = DefaultBusyTimeout
+	}
+	if o.JournalMode == "" {
+		o.JournalMode = DefaultJournalMode
+	}
+	if o.Synchronous == "" {
+		o.Synchronous = DefaultSynchronous
+	}
+	if o.MaxReadConns <= 0 {
+		o.MaxReadConns = DefaultMaxReadConns
+	}
+	return o
+}
+
+func openPool(ctx context.Context, path string, opts Options, txLock string, maxConns int) (*sql.DB, error) {
+	query := url.Values{}
+	// busy_timeout comes first so the journal_mode switch can wait for other connections.
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", opts.BusyTimeout.Milliseconds()))
+	query.Add("_pragma", "journal_mode("+strings.ToUpper(opts.JournalMode)+")")
+	query.Add("_pragma", "synchronous("+strings.ToUpper(opts.Synchronous)+")")
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Set("_txlock", txLock)
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(maxConns)
+	db.SetMaxIdleConns(maxConns)
+	db.SetConnMaxIdleTime(0)
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
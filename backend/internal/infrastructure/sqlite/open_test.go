@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenAppliesPragmasToBothPools(t *testing.T) {
+	db := openTestPools(t, Options{})
+
+	if got := db.Write.Stats().MaxOpenConnections; got != 1 {
+		t.Fatalf("expected a single write connection, got %d", got)
+	}
+	if got := db.Read.Stats().MaxOpenConnections; got != DefaultMaxReadConns {
+		t.Fatalf("expected %d read connections, got %d", DefaultMaxReadConns, got)
+	}
+
+	for name, pool := range map[string]*sql.DB{"read": db.Read, "write": db.Write} {
+		assertPragma(t, pool, name, "journal_mode", "wal")
+		assertPragma(t, pool, name, "busy_timeout", "5000")
+		assertPragma(t, pool, name, "foreign_keys", "1")
+		assertPragma(t, pool, name, "synchronous", "1")
+	}
+}
+
+func TestOpenCustomOptions(t *testing.T) {
+	db := openTestPools(t, Options{
+		BusyTimeout:  250 * time.Millisecond,
+		JournalMode:  "delete",
+		Synchronous:  "FULL",
+		MaxReadConns: 2,
+	})
+
+	if got := db.Read.Stats().MaxOpenConnections; got != 2 {
+		t.Fatalf("expected 2 read connections, got %d", got)
+	}
+	assertPragma(t, db.Read, "read", "journal_mode", "delete")
+	assertPragma(t, db.Read, "read", "busy_timeout", "250")
+	assertPragma(t, db.Write, "write", "synchronous", "2")
+}
+
+func TestOpenEnforcesForeignKeys(t *testing.T) {
+	db := openTestPools(t, Options{})
+
+	_, err := db.Write.Exec(`INSERT INTO outbox (event_id) VALUES ('missing-event')`)
+	if err == nil {
+		t.Fatalf("expected a foreign key violation for an outbox row without its event")
+	}
+}
+
+func openTestPools(t testing.TB, opts Options) *DB {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "test.sqlite3")
+	applyTestMigrations(t, dbPath)
+
+	db, err := Open(context.Background(), dbPath, opts)
+	if err != nil {
+		t.Fatalf("open sqlite pools: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func assertPragma(t *testing.T, db *sql.DB, pool, pragma, want string) {
+	t.Helper()
+
+	var got string
+	if err := db.QueryRow(`PRAGMA ` + pragma).Scan(&got); err != nil {
+		t.Fatalf("%s pool: read %s: %v", pool, pragma, err)
+	}
+	if got != want {
+		t.Fatalf("%s pool: expected %s=%s, got %s", pool, pragma, want, got)
+	}
+}
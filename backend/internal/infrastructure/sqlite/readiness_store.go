@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+// schemaVersionQuery reads the table golang-migrate keeps its state in.
+const schemaVersionQuery = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+type readinessStore struct {
+	db    *sql.DB
+	reads *sqlc.Queries
+}
+
+// NewReadinessStore builds the SQLite implementation of ports.ReadinessStore.
+// Every check runs on the read pool so a queued write does not hold up the probe.
+func NewReadinessStore(read *sql.DB) ports.ReadinessStore {
+	return readinessStore{
+		db:    read,
+		reads: newQueries(read),
+	}
+}
+
+func (s readinessStore) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping sqlite: %w", err)
+	}
+	return nil
+}
+
+func (s readinessStore) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	if err := s.db.QueryRowContext(ctx, schemaVersionQuery).Scan(&version, &dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+	if version < 0 {
+		return 0, dirty, nil
+	}
+	return uint(version), dirty, nil
+}
+
+func (s readinessStore) PendingOutbox(ctx context.Context) (int64, error) {
+	count, err := s.reads.CountPendingOutbox(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count pending outbox: %w", err)
+	}
+	return count, nil
+}
+
+func (s readinessStore) ReplicationLag(ctx context.Context, target string) (int64, error) {
+	position, err := s.reads.GetReplicationCheckpoint(ctx, target)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("get replication checkpoint %s: %w", target, err)
+	}
+	behind, err := s.reads.CountEventsAfterPosition(ctx, position)
+	if err != nil {
+		return 0, fmt.Errorf("count events after %d: %w", position, err)
+	}
+	return behind, nil
+}
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadinessStore(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	seedAnimalStream(t, writer, db, 3)
+	ctx := context.Background()
+	store := NewReadinessStore(db)
+
+	if err := store.Ping(ctx); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+
+	version, dirty, err := store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("schema version: %v", err)
+	}
+	if want := latestTestMigration(t); version != want || dirty {
+		t.Fatalf("expected clean version %d, got %d (dirty=%t)", want, version, dirty)
+	}
+
+	pending, err := store.PendingOutbox(ctx)
+	if err != nil {
+		t.Fatalf("pending outbox: %v", err)
+	}
+	// Only the creation goes through the write store; seeded feedings skip the outbox.
+	if pending != 1 {
+		t.Fatalf("expected 1 undispatched outbox entry, got %d", pending)
+	}
+
+	lag, err := store.ReplicationLag(ctx, "peer")
+	if err != nil {
+		t.Fatalf("replication lag: %v", err)
+	}
+	if lag != 3 {
+		t.Fatalf("expected a target without a checkpoint to lag by every event, got %d", lag)
+	}
+	events, err := NewReplicationStore(db, db).ListEventsSince(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if err := NewReplicationStore(db, db).SaveReplicationCheckpoint(ctx, "peer", events[1].Position, time.Now()); err != nil {
+		t.Fatalf("save checkpoint: %v", err)
+	}
+	if lag, err = store.ReplicationLag(ctx, "peer"); err != nil || lag != 1 {
+		t.Fatalf("expected lag 1 after checkpoint, got %d (%v)", lag, err)
+	}
+}
+
+func latestTestMigration(t *testing.T) uint {
+	t.Helper()
+
+	entries, err := os.ReadDir(testMigrationsPath(t))
+	if err != nil {
+		t.Fatalf("read migrations: %v", err)
+	}
+	var latest uint
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".up.sql") {
+			latest++
+		}
+	}
+	return latest
+}
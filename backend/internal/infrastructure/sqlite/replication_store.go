@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+type replicationStore struct {
+	queries *sqlc.Queries
+	reads   *sqlc.Queries
+}
+
+// NewReplicationStore builds the SQLite implementation of ports.ReplicationStore.
+func NewReplicationStore(read, write *sql.DB) ports.ReplicationStore {
+	return replicationStore{
+		queries: newQueries(write),
+		reads:   newQueries(read),
+	}
+}
+
+func (s replicationStore) ListEventsSince(ctx context.Context, after int64, limit int) ([]ports.ArchivedEvent, error) {
+	rows, err := s.reads.ListEventsForExport(ctx, sqlc.ListEventsForExportParams{
+		AfterPosition: after,
+		RowLimit:      int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list events since %d: %w", after, err)
+	}
+	events := make([]ports.ArchivedEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, archivedEventFromRow(row))
+	}
+	return events, nil
+}
+
+func (s replicationStore) GetReplicationCheckpoint(ctx context.Context, target string) (int64, error) {
+	position, err := s.reads.GetReplicationCheckpoint(ctx, target)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get replication checkpoint %s: %w", target, err)
+	}
+	return position, nil
+}
+
+func (s replicationStore) SaveReplicationCheckpoint(
+	ctx context.Context,
+	target string,
+	position int64,
+	at time.Time,
+) error {
+	if err := s.queries.UpsertReplicationCheckpoint(ctx, sqlc.UpsertReplicationCheckpointParams{
+		Target:    target,
+		Position:  position,
+		UpdatedAt: formatTimestamp(at),
+	}); err != nil {
+		return fmt.Errorf("save replication checkpoint %s: %w", target, err)
+	}
+	return nil
+}
+
+func (s replicationStore) ListReplicationCheckpoints(ctx context.Context) ([]ports.ReplicationCheckpoint, error) {
+	rows, err := s.reads.ListReplicationCheckpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list replication checkpoints: %w", err)
+	}
+	checkpoints := make([]ports.ReplicationCheckpoint, 0, len(rows))
+	for _, row := range rows {
+		checkpoints = append(checkpoints, ports.ReplicationCheckpoint{
+			Target:    row.Target,
+			Position:  row.Position,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+	return checkpoints, nil
+}
+
+type nodeRoleStore struct {
+	queries *sqlc.Queries
+	reads   *sqlc.Queries
+}
+
+// NewNodeRoleStore builds the SQLite implementation of ports.NodeRoleStore.
+func NewNodeRoleStore(read, write *sql.DB) ports.NodeRoleStore {
+	return nodeRoleStore{
+		queries: newQueries(write),
+		reads:   newQueries(read),
+	}
+}
+
+// GetNodeRole reports primary until a role has been recorded.
+func (s nodeRoleStore) GetNodeRole(ctx context.Context) (ports.NodeRole, error) {
+	role, err := s.reads.GetNodeRole(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.NodeRolePrimary, nil
+		}
+		return "", fmt.Errorf("get node role: %w", err)
+	}
+	return ports.NodeRole(role), nil
+}
+
+func (s nodeRoleStore) SetNodeRole(ctx context.Context, role ports.NodeRole, at time.Time) error {
+	if err := s.queries.SetNodeRole(ctx, sqlc.SetNodeRoleParams{
+		Role:      string(role),
+		UpdatedAt: formatTimestamp(at),
+	}); err != nil {
+		return fmt.Errorf("set node role: %w", err)
+	}
+	return nil
+}
+
+type replicationTarget struct {
+	name    string
+	archive ports.EventArchiveStore
+}
+
+// NewReplicationTarget builds a ports.ReplicationTarget that imports events
+// into another, already migrated, SQLite database.
+func NewReplicationTarget(name string, read, write *sql.DB) ports.ReplicationTarget {
+	return replicationTarget{
+		name:    name,
+		archive: NewEventArchiveStore(read, write),
+	}
+}
+
+func (t replicationTarget) Name() string {
+	return t.name
+}
+
+func (t replicationTarget) ReplicateEvents(
+	ctx context.Context,
+	events []ports.ArchivedEvent,
+) (ports.ImportEventsResult, error) {
+	return t.archive.ImportEvents(ctx, archivedEvents(events))
+}
+
+func archivedEvents(events []ports.ArchivedEvent) iter.Seq2[ports.ArchivedEvent, error] {
+	return func(yield func(ports.ArchivedEvent, error) bool) {
+		for _, event := range events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
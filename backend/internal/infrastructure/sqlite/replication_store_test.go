@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestReplicationStore_TailsEventsAndCheckpoints(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	seedAnimalStream(t, writer, db, 3)
+	ctx := context.Background()
+	store := NewReplicationStore(db, db)
+
+	position, err := store.GetReplicationCheckpoint(ctx, "file:/tmp/secondary.sqlite3")
+	if err != nil {
+		t.Fatalf("get checkpoint: %v", err)
+	}
+	if position != 0 {
+		t.Fatalf("expected no checkpoint to read as 0, got %d", position)
+	}
+
+	first, err := store.ListEventsSince(ctx, 0, 2)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("expected a batch of 2, got %d", len(first))
+	}
+	rest, err := store.ListEventsSince(ctx, first[1].Position, 2)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if len(rest) != 1 || rest[0].Position <= first[1].Position {
+		t.Fatalf("expected the third event after position %d, got %+v", first[1].Position, rest)
+	}
+
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	for _, position := range []int64{first[1].Position, rest[0].Position} {
+		if err := store.SaveReplicationCheckpoint(ctx, "file:/tmp/secondary.sqlite3", position, at); err != nil {
+			t.Fatalf("save checkpoint: %v", err)
+		}
+	}
+	position, err = store.GetReplicationCheckpoint(ctx, "file:/tmp/secondary.sqlite3")
+	if err != nil {
+		t.Fatalf("get checkpoint: %v", err)
+	}
+	if position != rest[0].Position {
+		t.Fatalf("expected checkpoint %d, got %d", rest[0].Position, position)
+	}
+
+	checkpoints, err := store.ListReplicationCheckpoints(ctx)
+	if err != nil {
+		t.Fatalf("list checkpoints: %v", err)
+	}
+	want := ports.ReplicationCheckpoint{
+		Target:    "file:/tmp/secondary.sqlite3",
+		Position:  rest[0].Position,
+		UpdatedAt: "2026-03-04T05:06:07Z",
+	}
+	if len(checkpoints) != 1 || checkpoints[0] != want {
+		t.Fatalf("expected checkpoints [%+v], got %+v", want, checkpoints)
+	}
+}
+
+func TestReplicationTarget_ImportsIdempotently(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	seedAnimalStream(t, writer, db, 2)
+	ctx := context.Background()
+
+	events, err := NewReplicationStore(db, db).ListEventsSince(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+
+	secondary := openTestDB(t)
+	t.Cleanup(func() { _ = secondary.Close() })
+	target := NewReplicationTarget("secondary", secondary, secondary)
+
+	result, err := target.ReplicateEvents(ctx, events)
+	if err != nil {
+		t.Fatalf("replicate: %v", err)
+	}
+	if result != (ports.ImportEventsResult{Imported: 2}) {
+		t.Fatalf("expected 2 imported, got %+v", result)
+	}
+	result, err = target.ReplicateEvents(ctx, events)
+	if err != nil {
+		t.Fatalf("replicate again: %v", err)
+	}
+	if result != (ports.ImportEventsResult{Skipped: 2}) {
+		t.Fatalf("expected a resent batch to be skipped, got %+v", result)
+	}
+}
+
+func TestNodeRoleStore_DefaultsToPrimary(t *testing.T) {
+	db := openTestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+	store := NewNodeRoleStore(db, db)
+
+	role, err := store.GetNodeRole(ctx)
+	if err != nil {
+		t.Fatalf("get role: %v", err)
+	}
+	if role != ports.NodeRolePrimary {
+		t.Fatalf("expected a fresh database to be primary, got %q", role)
+	}
+
+	for _, want := range []ports.NodeRole{ports.NodeRoleFollower, ports.NodeRolePrimary} {
+		if err := store.SetNodeRole(ctx, want, time.Now()); err != nil {
+			t.Fatalf("set role %s: %v", want, err)
+		}
+		got, err := store.GetNodeRole(ctx)
+		if err != nil {
+			t.Fatalf("get role: %v", err)
+		}
+		if got != want {
+			t.Fatalf("expected role %q, got %q", want, got)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+type sessionStore struct {
+	queries *sqlc.Queries
+	reads   *sqlc.Queries
+}
+
+// NewSessionStore builds the SQLite implementation of ports.SessionStore.
+// Sessions are node-local state and are not part of the event log. Lookups,
+// which every authenticated request makes, run on read.
+func NewSessionStore(read, write *sql.DB) ports.SessionStore {
+	return sessionStore{queries: newQueries(write), reads: newQueries(read)}
+}
+
+func (s sessionStore) CreateSession(ctx context.Context, session ports.Session) error {
+	if err := s.queries.CreateSession(ctx, sqlc.CreateSessionParams{
+		TokenHash: session.TokenHash,
+		UserID:    session.UserID,
+		CsrfToken: session.CSRFToken,
+		CreatedAt: formatTimestamp(session.CreatedAt),
+		ExpiresAt: formatTimestamp(session.ExpiresAt),
+	}); err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+	return nil
+}
+
+func (s sessionStore) GetSession(ctx context.Context, tokenHash string) (ports.Session, bool, error) {
+	row, err := s.reads.GetSession(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.Session{}, false, nil
+		}
+		return ports.Session{}, false, fmt.Errorf("get session: %w", err)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+	if err != nil {
+		return ports.Session{}, false, fmt.Errorf("parse session created_at: %w", err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, row.ExpiresAt)
+	if err != nil {
+		return ports.Session{}, false, fmt.Errorf("parse session expires_at: %w", err)
+	}
+	return ports.Session{
+		TokenHash: row.TokenHash,
+		UserID:    row.UserID,
+		CSRFToken: row.CsrfToken,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}, true, nil
+}
+
+func (s sessionStore) DeleteSession(ctx context.Context, tokenHash string) error {
+	if err := s.queries.DeleteSession(ctx, tokenHash); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	return nil
+}
+
+func (s sessionStore) DeleteUserSessions(ctx context.Context, userID string) error {
+	if err := s.queries.DeleteUserSessions(ctx, userID); err != nil {
+		return fmt.Errorf("delete user sessions: %w", err)
+	}
+	return nil
+}
+
+func (s sessionStore) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	if err := s.queries.DeleteExpiredSessions(ctx, formatTimestamp(now)); err != nil {
+		return fmt.Errorf("delete expired sessions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestSessionStore_Lifecycle(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewSessionStore(db, db)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, s := range []ports.Session{
+		{TokenHash: "live", UserID: "u1", CSRFToken: "c1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{TokenHash: "stale", UserID: "u1", CSRFToken: "c2", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now},
+	} {
+		if err := store.CreateSession(ctx, s); err != nil {
+			t.Fatalf("create session %s: %v", s.TokenHash, err)
+		}
+	}
+
+	got, found, err := store.GetSession(ctx, "live")
+	if err != nil || !found {
+		t.Fatalf("get session: found=%v err=%v", found, err)
+	}
+	if got.UserID != "u1" || got.CSRFToken != "c1" || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected session %+v", got)
+	}
+
+	if err := store.DeleteExpiredSessions(ctx, now); err != nil {
+		t.Fatalf("delete expired sessions: %v", err)
+	}
+	if _, found, err := store.GetSession(ctx, "stale"); err != nil || found {
+		t.Fatalf("expected expired session to be gone: found=%v err=%v", found, err)
+	}
+
+	if err := store.DeleteSession(ctx, "live"); err != nil {
+		t.Fatalf("delete session: %v", err)
+	}
+	if _, found, err := store.GetSession(ctx, "live"); err != nil || found {
+		t.Fatalf("expected deleted session to be gone: found=%v err=%v", found, err)
+	}
+
+	for _, s := range []ports.Session{
+		{TokenHash: "laptop", UserID: "u1", CSRFToken: "c3", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{TokenHash: "phone", UserID: "u1", CSRFToken: "c4", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{TokenHash: "other", UserID: "u2", CSRFToken: "c5", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+	} {
+		if err := store.CreateSession(ctx, s); err != nil {
+			t.Fatalf("create session %s: %v", s.TokenHash, err)
+		}
+	}
+	if err := store.DeleteUserSessions(ctx, "u1"); err != nil {
+		t.Fatalf("delete user sessions: %v", err)
+	}
+	for hash, want := range map[string]bool{"laptop": false, "phone": false, "other": true} {
+		if _, found, err := store.GetSession(ctx, hash); err != nil || found != want {
+			t.Fatalf("session %s: expected found=%v, got found=%v err=%v", hash, want, found, err)
+		}
+	}
+}
+
+func TestSessionStore_GetSessionDoesNotWaitForWriter(t *testing.T) {
+	db := openTestPools(t, Options{})
+	store := NewSessionStore(db.Read, db.Write)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	if err := store.CreateSession(ctx, ports.Session{
+		TokenHash: "live", UserID: "u1", CSRFToken: "c1", CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	tx, err := db.Write.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin write transaction: %v", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	lookupCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, found, err := store.GetSession(lookupCtx, "live"); err != nil || !found {
+		t.Fatalf("get session while the writer is busy: found=%v err=%v", found, err)
+	}
+}
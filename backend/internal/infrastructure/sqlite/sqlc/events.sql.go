@@ -13,6 +13,7 @@ import (
 const createEvent = `-- name: CreateEvent :exec
 INSERT INTO events (
     id,
+    barn_id,
     aggregate_type,
     aggregate_id,
     event_type,
@@ -24,12 +25,13 @@ INSERT INTO events (
     metadata_json,
     occurred_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateEventParams struct {
 	ID            string         `json:"id"`
+	BarnID        string         `json:"barn_id"`
 	AggregateType string         `json:"aggregate_type"`
 	AggregateID   string         `json:"aggregate_id"`
 	EventType     string         `json:"event_type"`
@@ -45,6 +47,7 @@ type CreateEventParams struct {
 func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
 	_, err := q.db.ExecContext(ctx, createEvent,
 		arg.ID,
+		arg.BarnID,
 		arg.AggregateType,
 		arg.AggregateID,
 		arg.EventType,
@@ -67,11 +70,12 @@ SELECT
     event_type,
     payload_json
 FROM events
-WHERE source = ? AND request_id = ?
+WHERE barn_id = ? AND source = ? AND request_id = ?
 LIMIT 1
 `
 
 type GetEventBySourceRequestIDParams struct {
+	BarnID    string `json:"barn_id"`
 	Source    string `json:"source"`
 	RequestID string `json:"request_id"`
 }
@@ -85,7 +89,7 @@ type GetEventBySourceRequestIDRow struct {
 }
 
 func (q *Queries) GetEventBySourceRequestID(ctx context.Context, arg GetEventBySourceRequestIDParams) (GetEventBySourceRequestIDRow, error) {
-	row := q.db.QueryRowContext(ctx, getEventBySourceRequestID, arg.Source, arg.RequestID)
+	row := q.db.QueryRowContext(ctx, getEventBySourceRequestID, arg.BarnID, arg.Source, arg.RequestID)
 	var i GetEventBySourceRequestIDRow
 	err := row.Scan(
 		&i.ID,
@@ -96,3 +100,462 @@ func (q *Queries) GetEventBySourceRequestID(ctx context.Context, arg GetEventByS
 	)
 	return i, err
 }
+
+const listAggregateEventsAfterPosition = `-- name: ListAggregateEventsAfterPosition :many
+SELECT
+    position,
+    id,
+    event_type,
+    event_version,
+    payload_json
+FROM events
+WHERE barn_id = ? AND aggregate_type = ? AND aggregate_id = ? AND position > ?
+ORDER BY position
+`
+
+type ListAggregateEventsAfterPositionParams struct {
+	BarnID        string `json:"barn_id"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	Position      int64  `json:"position"`
+}
+
+type ListAggregateEventsAfterPositionRow struct {
+	Position     int64  `json:"position"`
+	ID           string `json:"id"`
+	EventType    string `json:"event_type"`
+	EventVersion int64  `json:"event_version"`
+	PayloadJson  string `json:"payload_json"`
+}
+
+func (q *Queries) ListAggregateEventsAfterPosition(ctx context.Context, arg ListAggregateEventsAfterPositionParams) ([]ListAggregateEventsAfterPositionRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAggregateEventsAfterPosition,
+		arg.BarnID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.Position,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAggregateEventsAfterPositionRow
+	for rows.Next() {
+		var i ListAggregateEventsAfterPositionRow
+		if err := rows.Scan(
+			&i.Position,
+			&i.ID,
+			&i.EventType,
+			&i.EventVersion,
+			&i.PayloadJson,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEventByID = `-- name: GetEventByID :one
+SELECT
+    position,
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    event_version,
+    created_by,
+    payload_json,
+    occurred_at,
+    created_at
+FROM events
+WHERE barn_id = ? AND id = ?
+LIMIT 1
+`
+
+type GetEventByIDRow struct {
+	Position      int64  `json:"position"`
+	ID            string `json:"id"`
+	BarnID        string `json:"barn_id"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	EventType     string `json:"event_type"`
+	EventVersion  int64  `json:"event_version"`
+	CreatedBy     string `json:"created_by"`
+	PayloadJson   string `json:"payload_json"`
+	OccurredAt    string `json:"occurred_at"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type GetEventByIDParams struct {
+	BarnID string `json:"barn_id"`
+	ID     string `json:"id"`
+}
+
+func (q *Queries) GetEventByID(ctx context.Context, arg GetEventByIDParams) (GetEventByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getEventByID, arg.BarnID, arg.ID)
+	var i GetEventByIDRow
+	err := row.Scan(
+		&i.Position,
+		&i.ID,
+		&i.BarnID,
+		&i.AggregateType,
+		&i.AggregateID,
+		&i.EventType,
+		&i.EventVersion,
+		&i.CreatedBy,
+		&i.PayloadJson,
+		&i.OccurredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAggregateEvents = `-- name: ListAggregateEvents :many
+SELECT
+    position,
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    event_version,
+    created_by,
+    payload_json,
+    occurred_at,
+    created_at
+FROM events
+WHERE barn_id = ? AND aggregate_type = ? AND aggregate_id = ?
+ORDER BY position
+`
+
+type ListAggregateEventsParams struct {
+	BarnID        string `json:"barn_id"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+}
+
+type ListAggregateEventsRow struct {
+	Position      int64  `json:"position"`
+	ID            string `json:"id"`
+	BarnID        string `json:"barn_id"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	EventType     string `json:"event_type"`
+	EventVersion  int64  `json:"event_version"`
+	CreatedBy     string `json:"created_by"`
+	PayloadJson   string `json:"payload_json"`
+	OccurredAt    string `json:"occurred_at"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func (q *Queries) ListAggregateEvents(ctx context.Context, arg ListAggregateEventsParams) ([]ListAggregateEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAggregateEvents, arg.BarnID, arg.AggregateType, arg.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAggregateEventsRow
+	for rows.Next() {
+		var i ListAggregateEventsRow
+		if err := rows.Scan(
+			&i.Position,
+			&i.ID,
+			&i.BarnID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.EventVersion,
+			&i.CreatedBy,
+			&i.PayloadJson,
+			&i.OccurredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsAfterPosition = `-- name: ListEventsAfterPosition :many
+SELECT
+    position,
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    event_version,
+    created_by,
+    payload_json,
+    occurred_at,
+    created_at
+FROM events
+WHERE barn_id = ?1
+    AND position > ?2
+    AND (?3 = '' OR aggregate_type = ?3)
+    AND (?4 = '' OR aggregate_id = ?4)
+    AND (?5 = '' OR event_type = ?5)
+ORDER BY position
+LIMIT ?6
+`
+
+type ListEventsAfterPositionParams struct {
+	BarnID        string `json:"barn_id"`
+	AfterPosition int64  `json:"after_position"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	EventType     string `json:"event_type"`
+	RowLimit      int64  `json:"row_limit"`
+}
+
+type ListEventsAfterPositionRow struct {
+	Position      int64  `json:"position"`
+	ID            string `json:"id"`
+	BarnID        string `json:"barn_id"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	EventType     string `json:"event_type"`
+	EventVersion  int64  `json:"event_version"`
+	CreatedBy     string `json:"created_by"`
+	PayloadJson   string `json:"payload_json"`
+	OccurredAt    string `json:"occurred_at"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func (q *Queries) ListEventsAfterPosition(ctx context.Context, arg ListEventsAfterPositionParams) ([]ListEventsAfterPositionRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsAfterPosition,
+		arg.BarnID,
+		arg.AfterPosition,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventsAfterPositionRow
+	for rows.Next() {
+		var i ListEventsAfterPositionRow
+		if err := rows.Scan(
+			&i.Position,
+			&i.ID,
+			&i.BarnID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.EventVersion,
+			&i.CreatedBy,
+			&i.PayloadJson,
+			&i.OccurredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestEventPosition = `-- name: GetLatestEventPosition :one
+SELECT CAST(COALESCE(MAX(position), 0) AS INTEGER) AS position
+FROM events
+WHERE barn_id = ?
+`
+
+func (q *Queries) GetLatestEventPosition(ctx context.Context, barnID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLatestEventPosition, barnID)
+	var position int64
+	err := row.Scan(&position)
+	return position, err
+}
+
+const listEventsForExport = `-- name: ListEventsForExport :many
+SELECT
+    position,
+    id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    created_by,
+    source,
+    request_id,
+    event_version,
+    payload_json,
+    metadata_json,
+    occurred_at,
+    created_at,
+    barn_id
+FROM events
+WHERE position > ?1
+    AND (?2 = '' OR barn_id = ?2)
+ORDER BY position
+LIMIT ?3
+`
+
+type ListEventsForExportParams struct {
+	AfterPosition int64  `json:"after_position"`
+	BarnID        string `json:"barn_id"`
+	RowLimit      int64  `json:"row_limit"`
+}
+
+func (q *Queries) ListEventsForExport(ctx context.Context, arg ListEventsForExportParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsForExport, arg.AfterPosition, arg.BarnID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.Position,
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.EventType,
+			&i.CreatedBy,
+			&i.Source,
+			&i.RequestID,
+			&i.EventVersion,
+			&i.PayloadJson,
+			&i.MetadataJson,
+			&i.OccurredAt,
+			&i.CreatedAt,
+			&i.BarnID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const importEvent = `-- name: ImportEvent :execrows
+INSERT INTO events (
+    id,
+    barn_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    created_by,
+    source,
+    request_id,
+    event_version,
+    payload_json,
+    metadata_json,
+    occurred_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT DO NOTHING
+`
+
+type ImportEventParams struct {
+	ID            string         `json:"id"`
+	BarnID        string         `json:"barn_id"`
+	AggregateType string         `json:"aggregate_type"`
+	AggregateID   string         `json:"aggregate_id"`
+	EventType     string         `json:"event_type"`
+	CreatedBy     string         `json:"created_by"`
+	Source        string         `json:"source"`
+	RequestID     string         `json:"request_id"`
+	EventVersion  int64          `json:"event_version"`
+	PayloadJson   string         `json:"payload_json"`
+	MetadataJson  sql.NullString `json:"metadata_json"`
+	OccurredAt    string         `json:"occurred_at"`
+	CreatedAt     string         `json:"created_at"`
+}
+
+func (q *Queries) ImportEvent(ctx context.Context, arg ImportEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, importEvent,
+		arg.ID,
+		arg.BarnID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.EventType,
+		arg.CreatedBy,
+		arg.Source,
+		arg.RequestID,
+		arg.EventVersion,
+		arg.PayloadJson,
+		arg.MetadataJson,
+		arg.OccurredAt,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countEventVoids = `-- name: CountEventVoids :one
+SELECT COUNT(*)
+FROM events
+WHERE barn_id = ?
+  AND aggregate_type = ?
+  AND aggregate_id = ?
+  AND event_type = 'event.voided'
+  AND json_extract(payload_json, '$.target_event_id') = ?
+`
+
+type CountEventVoidsParams struct {
+	BarnID        string `json:"barn_id"`
+	AggregateType string `json:"aggregate_type"`
+	AggregateID   string `json:"aggregate_id"`
+	TargetEventID string `json:"target_event_id"`
+}
+
+func (q *Queries) CountEventVoids(ctx context.Context, arg CountEventVoidsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEventVoids,
+		arg.BarnID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.TargetEventID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEventsAfterPosition = `-- name: CountEventsAfterPosition :one
+SELECT COUNT(*)
+FROM events
+WHERE position > ?
+`
+
+func (q *Queries) CountEventsAfterPosition(ctx context.Context, position int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEventsAfterPosition, position)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
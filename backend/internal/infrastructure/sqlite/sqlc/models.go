@@ -8,7 +8,26 @@ import (
 	"database/sql"
 )
 
+type ApiToken struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	Name       string         `json:"name"`
+	TokenHash  string         `json:"token_hash"`
+	ScopesJson string         `json:"scopes_json"`
+	CreatedAt  string         `json:"created_at"`
+	ExpiresAt  sql.NullString `json:"expires_at"`
+}
+
+type Device struct {
+	ID         string `json:"id"`
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	SecretHash string `json:"secret_hash"`
+	CreatedAt  string `json:"created_at"`
+}
+
 type Event struct {
+	Position      int64          `json:"position"`
 	ID            string         `json:"id"`
 	AggregateType string         `json:"aggregate_type"`
 	AggregateID   string         `json:"aggregate_id"`
@@ -21,4 +40,66 @@ type Event struct {
 	MetadataJson  sql.NullString `json:"metadata_json"`
 	OccurredAt    string         `json:"occurred_at"`
 	CreatedAt     string         `json:"created_at"`
+	BarnID        string         `json:"barn_id"`
+}
+
+type NodeRole struct {
+	ID        int64  `json:"id"`
+	Role      string `json:"role"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type Outbox struct {
+	EventID      string         `json:"event_id"`
+	CreatedAt    string         `json:"created_at"`
+	DispatchedAt sql.NullString `json:"dispatched_at"`
+}
+
+type ReplicationCheckpoint struct {
+	Target    string `json:"target"`
+	Position  int64  `json:"position"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type Session struct {
+	TokenHash string `json:"token_hash"`
+	UserID    string `json:"user_id"`
+	CsrfToken string `json:"csrf_token"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type Snapshot struct {
+	BarnID          string `json:"barn_id"`
+	AggregateType   string `json:"aggregate_type"`
+	AggregateID     string `json:"aggregate_id"`
+	StreamVersion   int64  `json:"stream_version"`
+	LastPosition    int64  `json:"last_position"`
+	FoldVersion     int64  `json:"fold_version"`
+	UpcasterVersion int64  `json:"upcaster_version"`
+	StateJson       string `json:"state_json"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type Webhook struct {
+	ID             string `json:"id"`
+	Url            string `json:"url"`
+	Secret         string `json:"secret"`
+	EventTypesJson string `json:"event_types_json"`
+	Active         int64  `json:"active"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+	BarnID         string `json:"barn_id"`
+}
+
+type WebhookDelivery struct {
+	ID            int64  `json:"id"`
+	WebhookID     string `json:"webhook_id"`
+	EventID       string `json:"event_id"`
+	Status        string `json:"status"`
+	Attempts      int64  `json:"attempts"`
+	NextAttemptAt string `json:"next_attempt_at"`
+	LastError     string `json:"last_error"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
 }
@@ -10,14 +10,24 @@ import (
 	"testing"
 	"time"
 
-	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
-
 	gomigrate "github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
-func newTestAnimalWriteStore(t *testing.T) (animalWriteStore, *sql.DB) {
+func newTestAnimalWriteStore(t testing.TB) (animalWriteStore, *sql.DB) {
+	t.Helper()
+
+	db := openTestDB(t)
+	return animalWriteStore{
+		db:       db,
+		queries:  newQueries(db),
+		photoDir: t.TempDir(),
+		now:      time.Now,
+	}, db
+}
+
+func openTestDB(t testing.TB) *sql.DB {
 	t.Helper()
 
 	dbPath := filepath.Join(t.TempDir(), "test.sqlite3")
@@ -27,15 +37,10 @@ func newTestAnimalWriteStore(t *testing.T) (animalWriteStore, *sql.DB) {
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
 	}
-
-	return animalWriteStore{
-		queries:  sqlc.New(db),
-		photoDir: t.TempDir(),
-		now:      time.Now,
-	}, db
+	return db
 }
 
-func applyTestMigrations(t *testing.T, dbPath string) {
+func applyTestMigrations(t testing.TB, dbPath string) {
 	t.Helper()
 
 	migrationsPath := testMigrationsPath(t)
@@ -61,7 +66,7 @@ func applyTestMigrations(t *testing.T, dbPath string) {
 	}
 }
 
-func testMigrationsPath(t *testing.T) string {
+func testMigrationsPath(t testing.TB) string {
 	t.Helper()
 
 	if path := os.Getenv("BARNLOG_TEST_MIGRATIONS_PATH"); path != "" {
@@ -74,7 +79,7 @@ func testMigrationsPath(t *testing.T) string {
 	return filepath.Join(testRepoRoot(t), "backend", "db", "migrations")
 }
 
-func testRepoRoot(t *testing.T) string {
+func testRepoRoot(t testing.TB) string {
 	t.Helper()
 
 	_, thisFile, _, ok := runtime.Caller(0)
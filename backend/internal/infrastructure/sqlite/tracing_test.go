@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestQueriesRecordSpans installs a global tracer provider, so it must not run in parallel.
+func TestQueriesRecordSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	db := openTestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx, root := provider.Tracer("test").Start(context.Background(), "request")
+	_, err := NewAnimalWriteStore(db, t.TempDir()).CreateAnimalRecord(ctx, ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Nanny",
+		Species:   "goat",
+		Source:    "test.api",
+		RequestID: "req-1",
+		CreatedBy: "user:test",
+	})
+	root.End()
+	if err != nil {
+		t.Fatalf("create animal record: %v", err)
+	}
+
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		byName[span.Name()] = span
+	}
+	for _, name := range []string{"CreateEvent", "CreateOutboxEntry"} {
+		span, ok := byName[name]
+		if !ok {
+			t.Fatalf("expected a %s span, got %v", name, byName)
+		}
+		if span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Fatalf("expected %s to be a child of the caller's span", name)
+		}
+		attrs := attribute.NewSet(span.Attributes()...)
+		if v, _ := attrs.Value("db.system.name"); v.AsString() != "sqlite" {
+			t.Fatalf("expected db.system.name=sqlite on %s, got %v", name, span.Attributes())
+		}
+		if v, _ := attrs.Value("db.operation.name"); v.AsString() != name {
+			t.Fatalf("expected db.operation.name=%s, got %v", name, span.Attributes())
+		}
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	t.Parallel()
+
+	for query, want := range map[string]string{
+		"-- name: CreateEvent :exec\nINSERT INTO events": "CreateEvent",
+		"-- name: GetSnapshot :one\nSELECT 1":            "GetSnapshot",
+		"SELECT version, dirty FROM schema_migrations":   "sqlite.query",
+		"-- name: \nSELECT 1":                            "sqlite.query",
+	} {
+		if got := queryName(query); got != want {
+			t.Fatalf("queryName(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+// userStreamSource is the source of every user.created event. Together with a
+// request ID derived from the username it makes the events idempotency index
+// enforce unique usernames, including for users that arrive by import.
+const userStreamSource = "barnlog.users"
+
+type userStore struct {
+	db    *sql.DB
+	reads *sqlc.Queries
+	now   func() time.Time
+}
+
+// NewUserStore builds the SQLite implementation of ports.UserStore. User
+// streams are appended on write and folded from read.
+func NewUserStore(read, write *sql.DB) ports.UserStore {
+	return userStore{
+		db:    write,
+		reads: newQueries(read),
+		now:   time.Now,
+	}
+}
+
+func (s userStore) CreateUser(ctx context.Context, in ports.CreateUserRecordInput) (domain.User, error) {
+	userID, err := newID()
+	if err != nil {
+		return domain.User{}, fmt.Errorf("generate user id: %w", err)
+	}
+	if err := s.append(ctx, userEvent{
+		userID:    userID,
+		eventType: domain.UserCreatedEventType,
+		payload: domain.UserCreated{
+			Username:     in.Username,
+			PasswordHash: in.PasswordHash,
+			Role:         in.Role,
+			BarnID:       in.BarnID,
+			OIDCSubject:  in.OIDCSubject,
+		},
+		keySource:    userStreamSource,
+		keyRequestID: userStreamRequestID(in.Username),
+		source:       in.Source,
+		requestID:    in.RequestID,
+		createdBy:    in.CreatedBy,
+	}); err != nil {
+		if isUniqueConstraint(err) {
+			return domain.User{}, fmt.Errorf("%w: username %q is taken", ports.ErrConflict, in.Username)
+		}
+		return domain.User{}, fmt.Errorf("create event: %w", err)
+	}
+
+	return domain.User{
+		ID:           userID,
+		Username:     in.Username,
+		PasswordHash: in.PasswordHash,
+		OIDCSubject:  in.OIDCSubject,
+		Memberships:  domain.Memberships{in.BarnID: in.Role},
+	}, nil
+}
+
+func (s userStore) ChangePassword(ctx context.Context, in ports.ChangePasswordRecordInput) error {
+	if err := s.append(ctx, userEvent{
+		userID:       in.UserID,
+		eventType:    domain.UserPasswordChangedEventType,
+		payload:      domain.UserPasswordChanged{PasswordHash: in.PasswordHash},
+		keySource:    in.Source,
+		keyRequestID: in.RequestID,
+		source:       in.Source,
+		requestID:    in.RequestID,
+		createdBy:    in.CreatedBy,
+	}); err != nil {
+		if isUniqueConstraint(err) {
+			return fmt.Errorf("%w", ports.ErrConflict)
+		}
+		return fmt.Errorf("create event: %w", err)
+	}
+	return nil
+}
+
+func (s userStore) ChangeRole(ctx context.Context, in ports.ChangeRoleRecordInput) error {
+	if err := s.append(ctx, userEvent{
+		userID:       in.UserID,
+		eventType:    domain.UserRoleChangedEventType,
+		payload:      domain.UserRoleChanged{Role: in.Role, BarnID: in.BarnID},
+		keySource:    in.Source,
+		keyRequestID: in.RequestID,
+		source:       in.Source,
+		requestID:    in.RequestID,
+		createdBy:    in.CreatedBy,
+	}); err != nil {
+		if isUniqueConstraint(err) {
+			return fmt.Errorf("%w", ports.ErrConflict)
+		}
+		return fmt.Errorf("create event: %w", err)
+	}
+	return nil
+}
+
+// userEvent is one event of a user stream. keySource and keyRequestID form
+// its idempotency key; source and requestID describe the request in metadata.
+type userEvent struct {
+	userID       string
+	eventType    string
+	payload      any
+	keySource    string
+	keyRequestID string
+	source       string
+	requestID    string
+	createdBy    string
+}
+
+func (s userStore) append(ctx context.Context, e userEvent) error {
+	eventID, err := newID()
+	if err != nil {
+		return fmt.Errorf("generate event id: %w", err)
+	}
+	payloadJSON, err := json.Marshal(e.payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	metadataJSON, err := eventMetadataJSON(e.source, e.requestID, e.createdBy, "", "")
+	if err != nil {
+		return err
+	}
+
+	return appendEvent(ctx, s.db, sqlc.CreateEventParams{
+		ID:            eventID,
+		BarnID:        domain.ServerBarnID,
+		AggregateType: domain.UserAggregateType,
+		AggregateID:   e.userID,
+		EventType:     e.eventType,
+		CreatedBy:     e.createdBy,
+		Source:        e.keySource,
+		RequestID:     e.keyRequestID,
+		EventVersion:  domain.CurrentEventVersion(e.eventType),
+		PayloadJson:   string(payloadJSON),
+		MetadataJson: sql.NullString{
+			String: string(metadataJSON),
+			Valid:  true,
+		},
+		OccurredAt: s.now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (s userStore) GetUser(ctx context.Context, userID string) (domain.User, bool, error) {
+	events, err := s.reads.ListAggregateEventsAfterPosition(ctx, sqlc.ListAggregateEventsAfterPositionParams{
+		BarnID:        domain.ServerBarnID,
+		AggregateType: domain.UserAggregateType,
+		AggregateID:   userID,
+		Position:      0,
+	})
+	if err != nil {
+		return domain.User{}, false, fmt.Errorf("list user events: %w", err)
+	}
+	if len(events) == 0 {
+		return domain.User{}, false, nil
+	}
+
+	user := domain.User{ID: userID}
+	for _, event := range events {
+		payload, err := domain.Upcast(event.EventType, event.EventVersion, []byte(event.PayloadJson))
+		if err != nil {
+			return domain.User{}, false, fmt.Errorf("upcast event %s: %w", event.ID, err)
+		}
+		user, err = user.Apply(domain.Event{
+			Position: event.Position,
+			ID:       event.ID,
+			Type:     event.EventType,
+			Payload:  payload,
+		})
+		if err != nil {
+			return domain.User{}, false, fmt.Errorf("apply event %s: %w", event.ID, err)
+		}
+	}
+	return user, true, nil
+}
+
+func (s userStore) FindUserByUsername(ctx context.Context, username string) (domain.User, bool, error) {
+	created, err := s.reads.GetEventBySourceRequestID(ctx, sqlc.GetEventBySourceRequestIDParams{
+		BarnID:    domain.ServerBarnID,
+		Source:    userStreamSource,
+		RequestID: userStreamRequestID(username),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, false, nil
+		}
+		return domain.User{}, false, fmt.Errorf("find user stream: %w", err)
+	}
+	if created.AggregateType != domain.UserAggregateType || created.EventType != domain.UserCreatedEventType {
+		return domain.User{}, false, fmt.Errorf(
+			"%w: %s/%s",
+			ports.ErrIdempotencyEventTypeMismatch,
+			created.AggregateType,
+			created.EventType,
+		)
+	}
+	return s.GetUser(ctx, created.AggregateID)
+}
+
+func userStreamRequestID(username string) string {
+	return "username:" + username
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestUserStore_CreateFindAndChangePassword(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewUserStore(db, db)
+	ctx := context.Background()
+
+	created, err := store.CreateUser(ctx, ports.CreateUserRecordInput{
+		Username:     "anna",
+		PasswordHash: "hash-1",
+		BarnID:       "north",
+		Role:         domain.RoleWorker,
+		Source:       "cli",
+		RequestID:    "req-1",
+		CreatedBy:    "service:cli",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	if err := store.ChangePassword(ctx, ports.ChangePasswordRecordInput{
+		UserID:       created.ID,
+		PasswordHash: "hash-2",
+		Source:       "cli",
+		RequestID:    "req-2",
+		CreatedBy:    "service:cli",
+	}); err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+	if err := store.ChangeRole(ctx, ports.ChangeRoleRecordInput{
+		UserID:    created.ID,
+		BarnID:    "south",
+		Role:      domain.RoleManager,
+		Source:    "cli",
+		RequestID: "req-3",
+		CreatedBy: "service:cli",
+	}); err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+
+	found, ok, err := store.FindUserByUsername(ctx, "anna")
+	if err != nil || !ok {
+		t.Fatalf("find user: ok=%v err=%v", ok, err)
+	}
+	if found.ID != created.ID || found.Username != "anna" || found.PasswordHash != "hash-2" {
+		t.Fatalf("unexpected folded user %+v", found)
+	}
+	want := domain.Memberships{"north": domain.RoleWorker, "south": domain.RoleManager}
+	if !maps.Equal(found.Memberships, want) {
+		t.Fatalf("expected memberships %v, got %v", want, found.Memberships)
+	}
+
+	if _, ok, err := store.FindUserByUsername(ctx, "bert"); err != nil || ok {
+		t.Fatalf("expected unknown user to be missing: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestUserStore_CreateRejectsTakenUsername(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewUserStore(db, db)
+	ctx := context.Background()
+
+	in := ports.CreateUserRecordInput{Username: "anna", PasswordHash: "hash", Source: "cli", RequestID: "req-1", CreatedBy: "service:cli"}
+	if _, err := store.CreateUser(ctx, in); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	in.RequestID = "req-2"
+	if _, err := store.CreateUser(ctx, in); !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+}
+
+func TestUserStore_OIDCSubjectAndEndedMembership(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewUserStore(db, db)
+	ctx := context.Background()
+
+	created, err := store.CreateUser(ctx, ports.CreateUserRecordInput{
+		Username:    "anna",
+		OIDCSubject: "sub-1",
+		BarnID:      "north",
+		Role:        domain.RoleViewer,
+		Source:      "barnlog.oidc",
+		RequestID:   "req-1",
+		CreatedBy:   "service:oidc",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if created.OIDCSubject != "sub-1" {
+		t.Fatalf("expected subject on created user, got %+v", created)
+	}
+	if err := store.ChangeRole(ctx, ports.ChangeRoleRecordInput{
+		UserID:    created.ID,
+		BarnID:    "north",
+		Source:    "barnlog.oidc",
+		RequestID: "req-2",
+		CreatedBy: "service:oidc",
+	}); err != nil {
+		t.Fatalf("end membership: %v", err)
+	}
+
+	found, ok, err := store.FindUserByUsername(ctx, "anna")
+	if err != nil || !ok {
+		t.Fatalf("find user: ok=%v err=%v", ok, err)
+	}
+	if found.OIDCSubject != "sub-1" || found.PasswordHash != "" || len(found.Memberships) != 0 {
+		t.Fatalf("unexpected folded user %+v", found)
+	}
+}
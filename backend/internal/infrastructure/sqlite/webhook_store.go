@@ -0,0 +1,310 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"barnlog/backend/internal/infrastructure/sqlite/sqlc"
+	"barnlog/backend/internal/ports"
+)
+
+// maxWebhookErrorLength bounds the last_error column of a delivery.
+const maxWebhookErrorLength = 500
+
+type webhookStore struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+// NewWebhookStore builds the SQLite implementation of ports.WebhookStore.
+func NewWebhookStore(db *sql.DB) ports.WebhookStore {
+	return newWebhookStore(db)
+}
+
+// NewWebhookOutboxStore builds the SQLite implementation of ports.WebhookOutboxStore.
+func NewWebhookOutboxStore(db *sql.DB) ports.WebhookOutboxStore {
+	return newWebhookStore(db)
+}
+
+func newWebhookStore(db *sql.DB) webhookStore {
+	return webhookStore{
+		db:      db,
+		queries: newQueries(db),
+	}
+}
+
+func (s webhookStore) CreateWebhook(ctx context.Context, in ports.WebhookRecordInput) (ports.Webhook, error) {
+	webhookID, err := newID()
+	if err != nil {
+		return ports.Webhook{}, fmt.Errorf("generate webhook id: %w", err)
+	}
+	eventTypesJSON, err := webhookEventTypesJSON(in.EventTypes)
+	if err != nil {
+		return ports.Webhook{}, err
+	}
+
+	if err := s.queries.CreateWebhook(ctx, sqlc.CreateWebhookParams{
+		ID:             webhookID,
+		Url:            in.URL,
+		Secret:         in.Secret,
+		EventTypesJson: eventTypesJSON,
+		Active:         boolToInt(in.Active),
+		BarnID:         in.BarnID,
+	}); err != nil {
+		return ports.Webhook{}, fmt.Errorf("create webhook: %w", err)
+	}
+
+	webhook, _, err := s.GetWebhook(ctx, in.BarnID, webhookID)
+	return webhook, err
+}
+
+func (s webhookStore) GetWebhook(ctx context.Context, barnID, webhookID string) (ports.Webhook, bool, error) {
+	row, err := s.queries.GetWebhook(ctx, sqlc.GetWebhookParams{BarnID: barnID, ID: webhookID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.Webhook{}, false, nil
+		}
+		return ports.Webhook{}, false, fmt.Errorf("get webhook: %w", err)
+	}
+	webhook, err := webhookFromRow(row)
+	if err != nil {
+		return ports.Webhook{}, false, err
+	}
+	return webhook, true, nil
+}
+
+func (s webhookStore) ListWebhooks(ctx context.Context, barnID string) ([]ports.Webhook, error) {
+	rows, err := s.queries.ListWebhooks(ctx, barnID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+
+	webhooks := make([]ports.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhook, err := webhookFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (s webhookStore) UpdateWebhook(
+	ctx context.Context,
+	webhookID string,
+	in ports.WebhookRecordInput,
+) (ports.Webhook, bool, error) {
+	eventTypesJSON, err := webhookEventTypesJSON(in.EventTypes)
+	if err != nil {
+		return ports.Webhook{}, false, err
+	}
+
+	updated, err := s.queries.UpdateWebhook(ctx, sqlc.UpdateWebhookParams{
+		Url:            in.URL,
+		EventTypesJson: eventTypesJSON,
+		Active:         boolToInt(in.Active),
+		BarnID:         in.BarnID,
+		ID:             webhookID,
+	})
+	if err != nil {
+		return ports.Webhook{}, false, fmt.Errorf("update webhook: %w", err)
+	}
+	if updated == 0 {
+		return ports.Webhook{}, false, nil
+	}
+	return s.GetWebhook(ctx, in.BarnID, webhookID)
+}
+
+// DeleteWebhook removes the subscription first so that the deliveries of a
+// subscription in another barn are never touched.
+func (s webhookStore) DeleteWebhook(ctx context.Context, barnID, webhookID string) (deleted bool, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin webhook transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	queries := newQueries(tx)
+	removed, err := queries.DeleteWebhook(ctx, sqlc.DeleteWebhookParams{BarnID: barnID, ID: webhookID})
+	if err != nil {
+		return false, fmt.Errorf("delete webhook: %w", err)
+	}
+	if removed > 0 {
+		if err := queries.DeleteWebhookDeliveries(ctx, webhookID); err != nil {
+			return false, fmt.Errorf("delete webhook deliveries: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit webhook transaction: %w", err)
+	}
+	return removed > 0, nil
+}
+
+func (s webhookStore) ListPendingOutbox(ctx context.Context, limit int) ([]ports.EventRecord, error) {
+	rows, err := s.queries.ListPendingOutboxEvents(ctx, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list pending outbox events: %w", err)
+	}
+
+	records := make([]ports.EventRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, ports.EventRecord{
+			Position:      row.Position,
+			ID:            row.ID,
+			BarnID:        row.BarnID,
+			AggregateType: row.AggregateType,
+			AggregateID:   row.AggregateID,
+			EventType:     row.EventType,
+			EventVersion:  row.EventVersion,
+			CreatedBy:     row.CreatedBy,
+			PayloadJSON:   row.PayloadJson,
+			OccurredAt:    row.OccurredAt,
+			RecordedAt:    row.CreatedAt,
+		})
+	}
+	return records, nil
+}
+
+func (s webhookStore) ScheduleWebhookDeliveries(
+	ctx context.Context,
+	eventID string,
+	webhookIDs []string,
+	at time.Time,
+) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin outbox transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	queries := newQueries(tx)
+	scheduledAt := formatTimestamp(at)
+	for _, webhookID := range webhookIDs {
+		if err := queries.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+			WebhookID:     webhookID,
+			EventID:       eventID,
+			NextAttemptAt: scheduledAt,
+		}); err != nil {
+			return fmt.Errorf("create webhook delivery: %w", err)
+		}
+	}
+	if err := queries.MarkOutboxDispatched(ctx, sqlc.MarkOutboxDispatchedParams{
+		DispatchedAt: sql.NullString{String: scheduledAt, Valid: true},
+		EventID:      eventID,
+	}); err != nil {
+		return fmt.Errorf("mark outbox dispatched: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit outbox transaction: %w", err)
+	}
+	return nil
+}
+
+func (s webhookStore) ListDueWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]ports.WebhookDelivery, error) {
+	rows, err := s.queries.ListDueWebhookDeliveries(ctx, sqlc.ListDueWebhookDeliveriesParams{
+		NextAttemptAt: formatTimestamp(now),
+		Limit:         int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list due webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]ports.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, ports.WebhookDelivery{
+			ID:        row.DeliveryID,
+			WebhookID: row.WebhookID,
+			URL:       row.Url,
+			Secret:    row.Secret,
+			Attempts:  int(row.Attempts),
+			Event: ports.EventRecord{
+				Position:      row.Position,
+				ID:            row.EventID,
+				BarnID:        row.BarnID,
+				AggregateType: row.AggregateType,
+				AggregateID:   row.AggregateID,
+				EventType:     row.EventType,
+				EventVersion:  row.EventVersion,
+				CreatedBy:     row.CreatedBy,
+				PayloadJSON:   row.PayloadJson,
+				OccurredAt:    row.OccurredAt,
+				RecordedAt:    row.CreatedAt,
+			},
+		})
+	}
+	return deliveries, nil
+}
+
+func (s webhookStore) RecordWebhookDeliveryAttempt(ctx context.Context, attempt ports.WebhookDeliveryAttempt) error {
+	lastError := attempt.LastError
+	if len(lastError) > maxWebhookErrorLength {
+		lastError = lastError[:maxWebhookErrorLength]
+	}
+	if err := s.queries.UpdateWebhookDelivery(ctx, sqlc.UpdateWebhookDeliveryParams{
+		Status:        string(attempt.Status),
+		Attempts:      int64(attempt.Attempts),
+		NextAttemptAt: formatTimestamp(attempt.NextAttemptAt),
+		LastError:     lastError,
+		ID:            attempt.DeliveryID,
+	}); err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func webhookFromRow(row sqlc.Webhook) (ports.Webhook, error) {
+	var eventTypes []string
+	if err := json.Unmarshal([]byte(row.EventTypesJson), &eventTypes); err != nil {
+		return ports.Webhook{}, fmt.Errorf("decode webhook %s event types: %w", row.ID, err)
+	}
+	return ports.Webhook{
+		ID:         row.ID,
+		BarnID:     row.BarnID,
+		URL:        row.Url,
+		Secret:     row.Secret,
+		EventTypes: eventTypes,
+		Active:     row.Active == 1,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}, nil
+}
+
+func webhookEventTypesJSON(eventTypes []string) (string, error) {
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	encoded, err := json.Marshal(eventTypes)
+	if err != nil {
+		return "", fmt.Errorf("marshal webhook event types: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// formatTimestamp renders t so that text comparison in SQL matches time order.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/domain"
+	"barnlog/backend/internal/ports"
+)
+
+func TestWebhookStore_CRUD(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewWebhookStore(db)
+	ctx := context.Background()
+
+	created, err := store.CreateWebhook(ctx, ports.WebhookRecordInput{
+		BarnID:     domain.DefaultBarnID,
+		URL:        "https://example.test/hook",
+		Secret:     "whsec_test",
+		EventTypes: []string{"animal.created"},
+		Active:     true,
+	})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	if created.ID == "" || created.Secret != "whsec_test" || !created.Active || created.CreatedAt == "" {
+		t.Fatalf("unexpected created webhook %+v", created)
+	}
+
+	updated, found, err := store.UpdateWebhook(ctx, created.ID, ports.WebhookRecordInput{
+		BarnID: domain.DefaultBarnID,
+		URL:    "https://example.test/other",
+		Secret: "ignored",
+		Active: false,
+	})
+	if err != nil || !found {
+		t.Fatalf("update webhook: found=%v err=%v", found, err)
+	}
+	if updated.URL != "https://example.test/other" || updated.Active || len(updated.EventTypes) != 0 {
+		t.Fatalf("unexpected updated webhook %+v", updated)
+	}
+	if updated.Secret != "whsec_test" {
+		t.Fatalf("expected secret to survive update, got %q", updated.Secret)
+	}
+
+	list, err := store.ListWebhooks(ctx, domain.DefaultBarnID)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("list webhooks: %v %+v", err, list)
+	}
+
+	deleted, err := store.DeleteWebhook(ctx, domain.DefaultBarnID, created.ID)
+	if err != nil || !deleted {
+		t.Fatalf("delete webhook: deleted=%v err=%v", deleted, err)
+	}
+	if _, found, err := store.GetWebhook(ctx, domain.DefaultBarnID, created.ID); err != nil || found {
+		t.Fatalf("expected deleted webhook to be gone: found=%v err=%v", found, err)
+	}
+	if _, found, err := store.UpdateWebhook(ctx, created.ID, ports.WebhookRecordInput{URL: "https://x.test"}); err != nil || found {
+		t.Fatalf("expected update of missing webhook to report not found: found=%v err=%v", found, err)
+	}
+}
+
+func TestWebhookOutboxStore_DeliveryLifecycle(t *testing.T) {
+	writer, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	webhooks := NewWebhookStore(db)
+	outbox := NewWebhookOutboxStore(db)
+	ctx := context.Background()
+
+	created, err := writer.CreateAnimalRecord(ctx, ports.CreateAnimalRecordInput{
+		BarnID:    domain.DefaultBarnID,
+		Name:      "Nanny",
+		Species:   "goat",
+		Source:    "test.api",
+		RequestID: "req-1",
+		CreatedBy: "user:test",
+	})
+	if err != nil {
+		t.Fatalf("create animal: %v", err)
+	}
+
+	pending, err := outbox.ListPendingOutbox(ctx, 10)
+	if err != nil {
+		t.Fatalf("list pending outbox: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != created.EventID {
+		t.Fatalf("expected the appended event in the outbox, got %+v", pending)
+	}
+
+	webhook, err := webhooks.CreateWebhook(ctx, ports.WebhookRecordInput{
+		BarnID: domain.DefaultBarnID,
+		URL:    "https://example.test/hook",
+		Secret: "whsec_test",
+		Active: true,
+	})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if err := outbox.ScheduleWebhookDeliveries(ctx, created.EventID, []string{webhook.ID}, now); err != nil {
+		t.Fatalf("schedule deliveries: %v", err)
+	}
+	if pending, err := outbox.ListPendingOutbox(ctx, 10); err != nil || len(pending) != 0 {
+		t.Fatalf("expected outbox to be drained: %v %+v", err, pending)
+	}
+
+	due, err := outbox.ListDueWebhookDeliveries(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("list due deliveries: %v", err)
+	}
+	if len(due) != 1 || due[0].URL != webhook.URL || due[0].Secret != "whsec_test" || due[0].Event.ID != created.EventID {
+		t.Fatalf("unexpected due deliveries %+v", due)
+	}
+
+	if err := outbox.RecordWebhookDeliveryAttempt(ctx, ports.WebhookDeliveryAttempt{
+		DeliveryID:    due[0].ID,
+		Status:        ports.WebhookDeliveryPending,
+		Attempts:      1,
+		NextAttemptAt: now.Add(time.Minute),
+		LastError:     "connection refused",
+	}); err != nil {
+		t.Fatalf("record retry: %v", err)
+	}
+	if due, err := outbox.ListDueWebhookDeliveries(ctx, now, 10); err != nil || len(due) != 0 {
+		t.Fatalf("expected retry to be deferred: %v %+v", err, due)
+	}
+	due, err = outbox.ListDueWebhookDeliveries(ctx, now.Add(time.Minute), 10)
+	if err != nil || len(due) != 1 || due[0].Attempts != 1 {
+		t.Fatalf("expected retry to be due after backoff: %v %+v", err, due)
+	}
+
+	deliveryID := due[0].ID
+	if err := outbox.RecordWebhookDeliveryAttempt(ctx, ports.WebhookDeliveryAttempt{
+		DeliveryID:    deliveryID,
+		Status:        ports.WebhookDeliveryDead,
+		Attempts:      2,
+		NextAttemptAt: now.Add(time.Minute),
+		LastError:     "connection refused",
+	}); err != nil {
+		t.Fatalf("record dead letter: %v", err)
+	}
+	if due, err := outbox.ListDueWebhookDeliveries(ctx, now.Add(time.Hour), 10); err != nil || len(due) != 0 {
+		t.Fatalf("expected dead delivery to stop retrying: %v %+v", err, due)
+	}
+
+	var status string
+	if err := db.QueryRowContext(ctx, "SELECT status FROM webhook_deliveries WHERE id = ?", deliveryID).Scan(&status); err != nil {
+		t.Fatalf("load delivery status: %v", err)
+	}
+	if status != string(ports.WebhookDeliveryDead) {
+		t.Fatalf("expected dead status, got %q", status)
+	}
+}
+
+func TestWebhookStore_ScopedToBarn(t *testing.T) {
+	_, db := newTestAnimalWriteStore(t)
+	t.Cleanup(func() { _ = db.Close() })
+	store := NewWebhookStore(db)
+	ctx := context.Background()
+
+	created, err := store.CreateWebhook(ctx, ports.WebhookRecordInput{
+		BarnID: "north",
+		URL:    "https://example.test/hook",
+		Secret: "whsec_test",
+		Active: true,
+	})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	if created.BarnID != "north" {
+		t.Fatalf("expected webhook in north, got %q", created.BarnID)
+	}
+
+	if list, err := store.ListWebhooks(ctx, "south"); err != nil || len(list) != 0 {
+		t.Fatalf("expected no webhooks in south: %v %+v", err, list)
+	}
+	if _, found, err := store.GetWebhook(ctx, "south", created.ID); err != nil || found {
+		t.Fatalf("expected north's webhook to be missing from south: found=%v err=%v", found, err)
+	}
+	if _, found, err := store.UpdateWebhook(ctx, created.ID, ports.WebhookRecordInput{
+		BarnID: "south",
+		URL:    "https://attacker.test",
+	}); err != nil || found {
+		t.Fatalf("expected update from south to miss: found=%v err=%v", found, err)
+	}
+	if deleted, err := store.DeleteWebhook(ctx, "south", created.ID); err != nil || deleted {
+		t.Fatalf("expected delete from south to miss: deleted=%v err=%v", deleted, err)
+	}
+	if _, found, err := store.GetWebhook(ctx, "north", created.ID); err != nil || !found {
+		t.Fatalf("expected webhook to survive in north: found=%v err=%v", found, err)
+	}
+}
@@ -0,0 +1,4 @@
+// Package tlscert provides the HTTPS server certificate: it serves a
+// certificate and key from files, rereading them when they change, and can
+// issue a local CA and a server certificate for installs that have none.
+package tlscert
@@ -0,0 +1,79 @@
+package tlscert
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval limits how often handshakes stat the certificate files.
+const checkInterval = time.Second
+
+// Reloader serves a certificate and key from files and rereads them once
+// either file's modification time changes, so a renewed certificate is picked
+// up without a restart. A renewal that fails to load keeps the previous
+// certificate in service and is reported to onError.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	onError  func(error)
+	now      func() time.Time
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	checkedAt time.Time
+}
+
+// NewReloader loads certFile and keyFile, which must hold a PEM certificate
+// chain and its private key. onError may be nil.
+func NewReloader(certFile, keyFile string, onError func(error)) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, onError: onError, now: time.Now}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	r.checkedAt = r.now()
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := r.now(); now.Sub(r.checkedAt) >= checkInterval {
+		r.checkedAt = now
+		if err := r.reload(); err != nil && r.onError != nil {
+			r.onError(err)
+		}
+	}
+	return r.cert, nil
+}
+
+// reload rereads the files when their modification times differ from the
+// last attempt. The times are remembered even when loading fails, so a broken
+// pair is reported once rather than on every handshake.
+func (r *Reloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("stat TLS certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("stat TLS key: %w", err)
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return nil
+	}
+	r.certMod, r.keyMod = certInfo.ModTime(), keyInfo.ModTime()
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS certificate %s: %w", r.certFile, err)
+	}
+	r.cert = &cert
+	return nil
+}
@@ -0,0 +1,78 @@
+package tlscert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReloaderPicksUpRenewedCertificate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Now()
+	certFile, keyFile, err := EnsureSelfSigned(dir, []string{"barn.local"}, now)
+	if err != nil {
+		t.Fatalf("EnsureSelfSigned() error = %v", err)
+	}
+	var reloadErrs []error
+	reloader, err := NewReloader(certFile, keyFile, func(err error) { reloadErrs = append(reloadErrs, err) })
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+	clock := reloader.checkedAt
+	reloader.now = func() time.Time { return clock }
+
+	first, err := reloader.GetCertificate(nil)
+	if err != nil || first.Leaf.VerifyHostname("barn.local") != nil {
+		t.Fatalf("expected the barn.local certificate, got %v", err)
+	}
+
+	if _, _, err := EnsureSelfSigned(dir, []string{"barn.local", "10.0.0.5"}, now); err != nil {
+		t.Fatalf("EnsureSelfSigned() error = %v", err)
+	}
+	touch(t, now.Add(time.Minute), certFile, keyFile)
+	if cert, _ := reloader.GetCertificate(nil); cert != first {
+		t.Fatal("expected the files not to be checked again within a second")
+	}
+
+	clock = clock.Add(checkInterval)
+	renewed, _ := reloader.GetCertificate(nil)
+	if renewed == first || renewed.Leaf.VerifyHostname("10.0.0.5") != nil {
+		t.Fatal("expected the renewed certificate to be served")
+	}
+
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, now.Add(2*time.Minute), certFile)
+	clock = clock.Add(checkInterval)
+	if cert, _ := reloader.GetCertificate(nil); cert != renewed {
+		t.Fatal("expected a broken renewal to keep the previous certificate")
+	}
+	clock = clock.Add(checkInterval)
+	_, _ = reloader.GetCertificate(nil)
+	if len(reloadErrs) != 1 {
+		t.Fatalf("expected the broken renewal to be reported once, got %v", reloadErrs)
+	}
+}
+
+func TestNewReloaderRejectsMissingFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if _, err := NewReloader(filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile), nil); err == nil {
+		t.Fatal("expected an error for missing files")
+	}
+}
+
+func touch(t *testing.T, at time.Time, paths ...string) {
+	t.Helper()
+
+	for _, path := range paths {
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package tlscert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// CAFile is the local CA certificate that devices install to trust the server.
+	CAFile = "ca.pem"
+	// CertFile and KeyFile are the server certificate and key signed by the CA.
+	CertFile = "server.pem"
+	KeyFile  = "server-key.pem"
+
+	caKeyFile = "ca-key.pem"
+
+	caValidity = 10 * 365 * 24 * time.Hour
+	// serverValidity stays below the 398 days Apple devices accept.
+	serverValidity = 397 * 24 * time.Hour
+	// renewBefore reissues the server certificate this long before it expires.
+	renewBefore = 30 * 24 * time.Hour
+	// backdate covers clocks on phones that run slightly behind the server.
+	backdate = time.Hour
+)
+
+// EnsureSelfSigned makes sure dir holds a local CA and a server certificate
+// it signed for hosts, and returns the server certificate and key paths. The
+// CA is created on first run and kept until it expires, so a device that
+// trusts CAFile once keeps trusting the server; the server certificate is
+// reissued whenever it is about to expire or does not name every host.
+func EnsureSelfSigned(dir string, hosts []string, now time.Time) (certFile, keyFile string, err error) {
+	if len(hosts) == 0 {
+		return "", "", errors.New("issue TLS certificate: no hosts")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", "", fmt.Errorf("create TLS directory: %w", err)
+	}
+	ca, caKey, err := loadOrCreateCA(dir, now)
+	if err != nil {
+		return "", "", err
+	}
+
+	certFile, keyFile = filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile)
+	if current, err := readCertificate(certFile); err == nil && current.CheckSignatureFrom(ca) == nil &&
+		current.NotAfter.Sub(now) > renewBefore && coversHosts(current, hosts) {
+		if _, err := os.Stat(keyFile); err == nil {
+			return certFile, keyFile, nil
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generate TLS key: %w", err)
+	}
+	template, err := newTemplate(hosts[0], now, serverValidity)
+	if err != nil {
+		return "", "", err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return "", "", fmt.Errorf("issue TLS certificate: %w", err)
+	}
+	if err := writeKey(keyFile, key); err != nil {
+		return "", "", err
+	}
+	if err := writePEM(certFile, "CERTIFICATE", der, 0o644); err != nil {
+		return "", "", err
+	}
+	return certFile, keyFile, nil
+}
+
+// LocalHosts returns the names and addresses other machines on the local
+// network may use to reach this one: localhost, the hostname with and without
+// ".local", and the address of every interface. Link-local addresses are left
+// out because they only work with a zone.
+func LocalHosts() []string {
+	hosts := []string{"localhost"}
+	if name, err := os.Hostname(); err == nil && name != "" && name != "localhost" {
+		hosts = append(hosts, name)
+		if !strings.Contains(name, ".") {
+			hosts = append(hosts, name+".local")
+		}
+	}
+	addrs, _ := net.InterfaceAddrs()
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		hosts = append(hosts, ipNet.IP.String())
+	}
+	for _, loopback := range []string{"127.0.0.1", "::1"} {
+		if !slices.Contains(hosts, loopback) {
+			hosts = append(hosts, loopback)
+		}
+	}
+	return hosts
+}
+
+func loadOrCreateCA(dir string, now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certFile, keyFile := filepath.Join(dir, CAFile), filepath.Join(dir, caKeyFile)
+	ca, err := readCertificate(certFile)
+	if err == nil && now.Before(ca.NotAfter) {
+		key, err := readKey(keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ca, key, nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate CA key: %w", err)
+	}
+	template, err := newTemplate("Barn Log local CA", now, caValidity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.MaxPathLenZero = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create CA certificate: %w", err)
+	}
+	if ca, err = x509.ParseCertificate(der); err != nil {
+		return nil, nil, fmt.Errorf("parse CA certificate: %w", err)
+	}
+	if err := writeKey(keyFile, key); err != nil {
+		return nil, nil, err
+	}
+	if err := writePEM(certFile, "CERTIFICATE", der, 0o644); err != nil {
+		return nil, nil, err
+	}
+	return ca, key, nil
+}
+
+func newTemplate(commonName string, now time.Time, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generate certificate serial: %w", err)
+	}
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Barn Log"}, CommonName: commonName},
+		NotBefore:    now.Add(-backdate),
+		NotAfter:     now.Add(validity),
+	}, nil
+}
+
+// coversHosts reports whether cert is valid for every host.
+func coversHosts(cert *x509.Certificate, hosts []string) bool {
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func readCertificate(path string) (*x509.Certificate, error) {
+	block, err := readPEM(path, "CERTIFICATE")
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cert, nil
+}
+
+func readKey(path string) (*ecdsa.PrivateKey, error) {
+	block, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("parse %s: not an ECDSA key", path)
+	}
+	return key, nil
+}
+
+func readPEM(path, blockType string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("parse %s: no %s PEM block", path, blockType)
+	}
+	return block, nil
+}
+
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", path, err)
+	}
+	return writePEM(path, "PRIVATE KEY", der, 0o600)
+}
+
+// writePEM replaces path atomically, so a Reloader never reads half a file.
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), perm); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package tlscert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnsureSelfSigned(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "tls")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	hosts := []string{"barn.local", "192.168.1.20"}
+
+	certFile, keyFile, err := EnsureSelfSigned(dir, hosts, now)
+	if err != nil {
+		t.Fatalf("EnsureSelfSigned() error = %v", err)
+	}
+	ca := mustReadCertificate(t, filepath.Join(dir, CAFile))
+	server := mustReadCertificate(t, certFile)
+	if !ca.IsCA {
+		t.Fatal("expected ca.pem to be a CA certificate")
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	for _, host := range hosts {
+		if _, err := server.Verify(x509.VerifyOptions{DNSName: host, Roots: roots, CurrentTime: now}); err != nil {
+			t.Fatalf("expected the server certificate to verify for %s, got %v", host, err)
+		}
+	}
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		t.Fatalf("expected a usable key pair, got %v", err)
+	}
+	info, err := os.Stat(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected the key to be private, got mode %v", info.Mode().Perm())
+	}
+
+	if _, _, err := EnsureSelfSigned(dir, hosts, now.Add(24*time.Hour)); err != nil {
+		t.Fatalf("EnsureSelfSigned() error = %v", err)
+	}
+	if again := mustReadCertificate(t, certFile); !again.Equal(server) {
+		t.Fatal("expected a valid server certificate to be kept")
+	}
+
+	if _, _, err := EnsureSelfSigned(dir, append(hosts, "10.0.0.5"), now); err != nil {
+		t.Fatalf("EnsureSelfSigned() error = %v", err)
+	}
+	reissued := mustReadCertificate(t, certFile)
+	if reissued.Equal(server) || reissued.VerifyHostname("10.0.0.5") != nil {
+		t.Fatal("expected the server certificate to be reissued for the new host")
+	}
+	if !mustReadCertificate(t, filepath.Join(dir, CAFile)).Equal(ca) {
+		t.Fatal("expected the CA to be kept")
+	}
+
+	if _, _, err := EnsureSelfSigned(dir, hosts, reissued.NotAfter.Add(-renewBefore)); err != nil {
+		t.Fatalf("EnsureSelfSigned() error = %v", err)
+	}
+	if mustReadCertificate(t, certFile).Equal(reissued) {
+		t.Fatal("expected a certificate close to expiry to be renewed")
+	}
+}
+
+func TestLocalHostsIncludesLoopback(t *testing.T) {
+	t.Parallel()
+
+	hosts := LocalHosts()
+	for _, want := range []string{"localhost", "127.0.0.1", "::1"} {
+		found := false
+		for _, host := range hosts {
+			found = found || host == want
+		}
+		if !found {
+			t.Fatalf("expected %s in %v", want, hosts)
+		}
+	}
+}
+
+func mustReadCertificate(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+
+	cert, err := readCertificate(path)
+	if err != nil {
+		t.Fatalf("read certificate: %v", err)
+	}
+	return cert
+}
@@ -0,0 +1,3 @@
+// Package tracing installs the OpenTelemetry tracer provider and W3C trace
+// context propagation for the server.
+package tracing
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// Exporters accepted by Options.Exporter.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// ServiceName is reported as service.name on every span.
+const ServiceName = "barnlog"
+
+// Options selects where spans are exported.
+type Options struct {
+	Exporter string
+	// OTLPEndpoint is the base URL of an OTLP/HTTP collector, such as
+	// http://localhost:4318. Spans are posted to its /v1/traces path.
+	OTLPEndpoint string
+	// Environment is reported as deployment.environment.name.
+	Environment string
+	// Stdout receives spans from the stdout exporter. Defaults to os.Stdout.
+	Stdout io.Writer
+}
+
+// Setup installs W3C trace context and baggage propagation and, unless the
+// exporter is none, a global tracer provider that batches spans to it. The
+// returned shutdown flushes pending spans.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	switch opts.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.OTLPEndpoint+"/v1/traces"))
+	case ExporterStdout:
+		out := opts.Stdout
+		if out == nil {
+			out = os.Stdout
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(out))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", opts.Exporter, err)
+	}
+
+	res, err := newResource(opts.Environment)
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newResource adds the service name and environment to the SDK defaults,
+// which include OTEL_RESOURCE_ATTRIBUTES.
+func newResource(environment string) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{semconv.ServiceName(ServiceName)}
+	if environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironmentName(environment))
+	}
+	return resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attrs...))
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TestSetupStdout installs global tracing state, so it must not run in parallel.
+func TestSetupStdout(t *testing.T) {
+	var out bytes.Buffer
+	shutdown, err := Setup(context.Background(), Options{Exporter: ExporterStdout, Environment: "test", Stdout: &out})
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "stdout-span")
+	header := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if !strings.HasPrefix(header.Get("traceparent"), "00-"+span.SpanContext().TraceID().String()) {
+		t.Fatalf("expected a W3C traceparent for the span, got %q", header.Get("traceparent"))
+	}
+	for _, want := range []string{`"Name":"stdout-span"`, `"Value":"barnlog"`, `"Value":"test"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %s in exported spans:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), Options{Exporter: "jaeger"}); err == nil {
+		t.Fatalf("expected an error for an unknown exporter")
+	}
+}
@@ -0,0 +1,2 @@
+// Package webhook provides the HTTP sender that delivers signed webhook requests.
+package webhook
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the subscription secret.
+	SignatureHeader = "X-Barnlog-Signature"
+	// TimestampHeader carries the Unix time the request was signed at.
+	TimestampHeader = "X-Barnlog-Timestamp"
+	// EventIDHeader carries the ID of the delivered event.
+	EventIDHeader = "X-Barnlog-Event-Id"
+	// DeliveryIDHeader carries the delivery ID, stable across retries.
+	DeliveryIDHeader = "X-Barnlog-Delivery-Id"
+
+	maxDrainedResponseBytes = 64 << 10
+)
+
+// Sender POSTs signed JSON webhook requests. Redirects are not followed and
+// any non-2xx response is reported as an error.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ ports.WebhookSender = (*Sender)(nil)
+
+// NewSender builds a Sender whose requests time out after timeout.
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// SendWebhook POSTs req.Body to req.URL with signature headers.
+func (s *Sender) SendWebhook(ctx context.Context, req ports.WebhookRequest) error {
+	timestamp := s.now().Unix()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "barnlog-webhooks/1")
+	httpReq.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, timestamp, req.Body))
+	httpReq.Header.Set(EventIDHeader, req.EventID)
+	httpReq.Header.Set(DeliveryIDHeader, strconv.FormatInt(req.DeliveryID, 10))
+
+	// #nosec G704 -- the URL is an operator-registered webhook subscription.
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseBytes))
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("webhook responded with status " + strconv.Itoa(resp.StatusCode))
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body signed at timestamp.
+// Receivers recompute it with their copy of the secret and compare in constant time.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"barnlog/backend/internal/ports"
+)
+
+func TestSender_SendWebhookSignsBody(t *testing.T) {
+	t.Parallel()
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	got := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	sender := NewSender(time.Second)
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	body := []byte(`{"event":{"event_id":"e1"}}`)
+	if err := sender.SendWebhook(context.Background(), ports.WebhookRequest{
+		URL:        server.URL,
+		Secret:     "whsec_test",
+		DeliveryID: 42,
+		EventID:    "e1",
+		Body:       body,
+	}); err != nil {
+		t.Fatalf("send webhook: %v", err)
+	}
+
+	r := <-got
+	if string(r.body) != string(body) {
+		t.Fatalf("expected body %s, got %s", body, r.body)
+	}
+	if r.header.Get(TimestampHeader) != "1700000000" {
+		t.Fatalf("unexpected timestamp header %q", r.header.Get(TimestampHeader))
+	}
+	want := Sign("whsec_test", 1700000000, body)
+	if !hmac.Equal([]byte(r.header.Get(SignatureHeader)), []byte(want)) {
+		t.Fatalf("expected signature %q, got %q", want, r.header.Get(SignatureHeader))
+	}
+	if r.header.Get(EventIDHeader) != "e1" || r.header.Get(DeliveryIDHeader) != strconv.Itoa(42) {
+		t.Fatalf("unexpected id headers: %v", r.header)
+	}
+	if r.header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected content type %q", r.header.Get("Content-Type"))
+	}
+}
+
+func TestSender_SendWebhookRejectsNon2xx(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "redirect is not followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/elsewhere", http.StatusFound)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tc.handler)
+			t.Cleanup(server.Close)
+
+			err := NewSender(time.Second).SendWebhook(context.Background(), ports.WebhookRequest{
+				URL:    server.URL,
+				Secret: "whsec_test",
+				Body:   []byte(`{}`),
+			})
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
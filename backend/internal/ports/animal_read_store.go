@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"barnlog/backend/internal/domain"
+)
+
+// AnimalState is the folded animal aggregate together with its stream version.
+type AnimalState struct {
+	Animal  domain.Animal
+	Version int64
+}
+
+// AnimalReadStore defines persistence operations needed by animal read use cases.
+// Animals of other barns are not found.
+type AnimalReadStore interface {
+	LoadAnimal(ctx context.Context, barnID, animalID string) (AnimalState, bool, error)
+	ListAnimalEvents(ctx context.Context, barnID, animalID string) ([]EventRecord, error)
+}
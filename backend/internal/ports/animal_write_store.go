@@ -8,6 +8,10 @@ import (
 // ErrConflict signals an idempotency or uniqueness conflict in write storage.
 var ErrConflict = errors.New("conflict")
 
+// ErrEventVoided signals an amendment of an event that was voided before the
+// amendment was written.
+var ErrEventVoided = errors.New("event_voided")
+
 // ErrIdempotencyPayloadMismatch signals same idempotency key but different payload.
 var ErrIdempotencyPayloadMismatch = errors.New("idempotency_payload_mismatch")
 
@@ -15,14 +19,19 @@ var ErrIdempotencyPayloadMismatch = errors.New("idempotency_payload_mismatch")
 var ErrIdempotencyEventTypeMismatch = errors.New("idempotency_event_type_mismatch")
 
 // CreateAnimalRecordInput is the storage-level payload for writing animal-created events.
+// The idempotency key (Source, RequestID) is unique within BarnID.
 type CreateAnimalRecordInput struct {
-	Name      string
-	Species   string
-	Tag       string
-	Birthdate string
-	PhotoID   string
-	Source    string
-	RequestID string
+	BarnID        string
+	Name          string
+	Species       string
+	Tag           string
+	Birthdate     string
+	PhotoID       string
+	Source        string
+	RequestID     string
+	CreatedBy     string
+	DeviceID      string
+	ClientVersion string
 }
 
 // CreateAnimalRecordOutput contains IDs produced by persisted animal creation.
@@ -32,9 +41,15 @@ type CreateAnimalRecordOutput struct {
 	Replayed bool
 }
 
+// PhotoStore checks uploaded photo content referenced by commands.
+// A barn can only see the photos uploaded to it.
+type PhotoStore interface {
+	PhotoExists(ctx context.Context, barnID, photoID string) (bool, error)
+}
+
 // AnimalWriteStore defines persistence operations needed by create-animal use cases.
 type AnimalWriteStore interface {
+	PhotoStore
 	FindCreateAnimalReplay(ctx context.Context, in CreateAnimalRecordInput) (CreateAnimalRecordOutput, bool, error)
 	CreateAnimalRecord(ctx context.Context, in CreateAnimalRecordInput) (CreateAnimalRecordOutput, error)
-	PhotoExists(ctx context.Context, photoID string) (bool, error)
 }
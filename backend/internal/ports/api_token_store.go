@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// APIToken is a personal API token. Only a hash of the token is stored; the
+// token itself is shown once when it is created.
+type APIToken struct {
+	ID        string
+	UserID    string
+	Name      string
+	TokenHash string
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt is zero for tokens that do not expire.
+	ExpiresAt time.Time
+}
+
+// APITokenRecordInput is the storage-level payload for a new API token.
+type APITokenRecordInput struct {
+	UserID    string
+	Name      string
+	TokenHash string
+	Scopes    []string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// APITokenStore persists API tokens.
+type APITokenStore interface {
+	CreateAPIToken(ctx context.Context, in APITokenRecordInput) (APIToken, error)
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (APIToken, bool, error)
+	ListAPITokens(ctx context.Context, userID string) ([]APIToken, error)
+	// DeleteAPIToken removes a token of userID and reports whether it existed.
+	DeleteAPIToken(ctx context.Context, userID string, tokenID string) (bool, error)
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Device is a registered client device, such as a phone, that syncs with its
+// own credential. Only a hash of the device secret is stored; the secret is
+// shown once when the device is registered.
+type Device struct {
+	ID         string
+	UserID     string
+	Name       string
+	SecretHash string
+	CreatedAt  time.Time
+}
+
+// DeviceRecordInput is the storage-level payload for a new device.
+type DeviceRecordInput struct {
+	UserID     string
+	Name       string
+	SecretHash string
+	CreatedAt  time.Time
+}
+
+// DeviceStore persists registered devices.
+type DeviceStore interface {
+	CreateDevice(ctx context.Context, in DeviceRecordInput) (Device, error)
+	GetDeviceBySecretHash(ctx context.Context, secretHash string) (Device, bool, error)
+	ListDevices(ctx context.Context, userID string) ([]Device, error)
+	// DeleteDevice removes a device of userID and reports whether it existed.
+	DeleteDevice(ctx context.Context, userID string, deviceID string) (bool, error)
+}
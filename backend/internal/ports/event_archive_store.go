@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"iter"
+)
+
+// ArchivedEvent is an events row with every column, as exported and imported.
+// MetadataJSON is empty when the row has no metadata.
+type ArchivedEvent struct {
+	Position      int64
+	ID            string
+	BarnID        string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	CreatedBy     string
+	Source        string
+	RequestID     string
+	EventVersion  int64
+	PayloadJSON   string
+	MetadataJSON  string
+	OccurredAt    string
+	RecordedAt    string
+}
+
+// ImportEventsResult counts the outcome of an import.
+type ImportEventsResult struct {
+	Imported int
+	Skipped  int
+}
+
+// EventArchiveStore reads and writes the raw event log for backup and migration.
+type EventArchiveStore interface {
+	// ExportEvents yields the events of barnID in position order, or every
+	// event when barnID is empty.
+	ExportEvents(ctx context.Context, barnID string) iter.Seq2[ArchivedEvent, error]
+	// ImportEvents inserts events in the order given, in one transaction.
+	// Positions are reassigned. An event already stored under the same ID and
+	// (barn_id, source, request_id) with the same payload is skipped; any other clash
+	// fails the whole import with ErrConflict.
+	ImportEvents(ctx context.Context, events iter.Seq2[ArchivedEvent, error]) (ImportEventsResult, error)
+}
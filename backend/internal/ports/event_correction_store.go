@@ -0,0 +1,49 @@
+package ports
+
+import "context"
+
+// EventRecord is a persisted event as read back from storage.
+type EventRecord struct {
+	Position      int64
+	ID            string
+	BarnID        string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	EventVersion  int64
+	CreatedBy     string
+	PayloadJSON   string
+	OccurredAt    string
+	RecordedAt    string
+}
+
+// EventCorrectionRecordInput is the storage-level payload for event.corrected and event.voided events.
+type EventCorrectionRecordInput struct {
+	BarnID         string
+	AggregateType  string
+	AggregateID    string
+	EventType      string
+	TargetEventID  string
+	Reason         string
+	PayloadVersion int64
+	Payload        []byte
+	Source         string
+	RequestID      string
+	CreatedBy      string
+	DeviceID       string
+	ClientVersion  string
+}
+
+// EventCorrectionRecordOutput contains the ID of a persisted correction or void.
+type EventCorrectionRecordOutput struct {
+	EventID  string
+	Replayed bool
+}
+
+// EventCorrectionStore defines persistence operations needed by correction and void use cases.
+type EventCorrectionStore interface {
+	GetEvent(ctx context.Context, barnID, eventID string) (EventRecord, bool, error)
+	ListAggregateEvents(ctx context.Context, barnID, aggregateType, aggregateID string) ([]EventRecord, error)
+	FindEventCorrectionReplay(ctx context.Context, in EventCorrectionRecordInput) (EventCorrectionRecordOutput, bool, error)
+	AppendEventCorrection(ctx context.Context, in EventCorrectionRecordInput) (EventCorrectionRecordOutput, error)
+}
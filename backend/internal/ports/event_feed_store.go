@@ -0,0 +1,22 @@
+package ports
+
+import "context"
+
+// EventFeedFilter narrows an event feed; empty fields match every event.
+type EventFeedFilter struct {
+	AggregateType string
+	AggregateID   string
+	EventType     string
+}
+
+// EventFeedStore reads the events of one barn across aggregates in global append order.
+type EventFeedStore interface {
+	ListEventsAfter(
+		ctx context.Context,
+		barnID string,
+		filter EventFeedFilter,
+		afterPosition int64,
+		limit int,
+	) ([]EventRecord, error)
+	LatestPosition(ctx context.Context, barnID string) (int64, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+// EventMetrics counts the outcome of event appends by event type.
+type EventMetrics interface {
+	EventAppended(eventType string)
+	// EventReplayed counts requests answered from an earlier append with the same idempotency key.
+	EventReplayed(eventType string)
+	// EventAppendFailed counts appends that failed in storage rather than on a business rule.
+	EventAppendFailed(eventType string)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrIdentityRejected signals an authorization code or ID token the identity
+// provider flow does not accept, as opposed to the provider being unreachable.
+var ErrIdentityRejected = errors.New("identity rejected")
+
+// IdentityClaims are the verified claims of an OpenID Connect ID token.
+// Claims holds every claim, including Subject, as decoded from JSON.
+type IdentityClaims struct {
+	Subject string
+	Claims  map[string]any
+}
+
+// IdentityProvider runs the OpenID Connect authorization code flow with PKCE
+// against one provider.
+type IdentityProvider interface {
+	// AuthCodeURL returns the URL of the provider's authorization endpoint that
+	// starts a login bound to state, nonce and the S256 codeChallenge.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code with its PKCE codeVerifier and
+	// returns the claims of the ID token after checking its signature, issuer,
+	// audience, expiry and nonce. Rejected codes and tokens wrap ErrIdentityRejected.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (IdentityClaims, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "context"
+
+// ReadinessStore reports the state of the database for the readiness probe.
+type ReadinessStore interface {
+	Ping(ctx context.Context) error
+	// SchemaVersion returns the applied migration version. Dirty reports a
+	// migration that failed half way.
+	SchemaVersion(ctx context.Context) (version uint, dirty bool, err error)
+	// PendingOutbox counts events not yet fanned out to webhook subscriptions.
+	PendingOutbox(ctx context.Context) (int64, error)
+	// ReplicationLag counts events appended after the checkpoint of target.
+	ReplicationLag(ctx context.Context, target string) (int64, error)
+}
@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// ReplicationCheckpoint is the last event position shipped to a replication target.
+type ReplicationCheckpoint struct {
+	Target    string
+	Position  int64
+	UpdatedAt string
+}
+
+// ReplicationStore tails the local event log and tracks how far each replication target has got.
+type ReplicationStore interface {
+	// ListEventsSince returns up to limit events with position > after, in position order.
+	ListEventsSince(ctx context.Context, after int64, limit int) ([]ArchivedEvent, error)
+	// GetReplicationCheckpoint returns the last position shipped to target, or 0 if none was.
+	GetReplicationCheckpoint(ctx context.Context, target string) (int64, error)
+	SaveReplicationCheckpoint(ctx context.Context, target string, position int64, at time.Time) error
+	ListReplicationCheckpoints(ctx context.Context) ([]ReplicationCheckpoint, error)
+}
+
+// ReplicationTarget receives replicated events. Delivering an event the target
+// already holds must be a no-op so a batch can be retried after a crash.
+type ReplicationTarget interface {
+	// Name identifies the target; checkpoints are stored under it.
+	Name() string
+	ReplicateEvents(ctx context.Context, events []ArchivedEvent) (ImportEventsResult, error)
+}
+
+// NodeRole is the replication role of this instance.
+type NodeRole string
+
+const (
+	// NodeRolePrimary nodes accept writes. A database without a recorded role is primary.
+	NodeRolePrimary NodeRole = "primary"
+	// NodeRoleFollower nodes only receive replicated events until they are promoted.
+	NodeRoleFollower NodeRole = "follower"
+)
+
+// NodeRoleStore persists the replication role of the database.
+type NodeRoleStore interface {
+	GetNodeRole(ctx context.Context) (NodeRole, error)
+	SetNodeRole(ctx context.Context, role NodeRole, at time.Time) error
+}
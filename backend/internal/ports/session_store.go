@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Session is a server-side login session. Only a hash of the session token is
+// stored, so a copy of the database cannot be used to hijack sessions.
+type Session struct {
+	TokenHash string
+	UserID    string
+	CSRFToken string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// SessionStore persists login sessions.
+type SessionStore interface {
+	CreateSession(ctx context.Context, session Session) error
+	GetSession(ctx context.Context, tokenHash string) (Session, bool, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	// DeleteUserSessions signs userID out everywhere.
+	DeleteUserSessions(ctx context.Context, userID string) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+}
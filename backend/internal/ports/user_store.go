@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"context"
+
+	"barnlog/backend/internal/domain"
+)
+
+// CreateUserRecordInput is the storage-level payload for starting a user stream.
+// Username must already be normalized; stores reject a username that is taken
+// with ErrConflict. The user starts with Role in BarnID. OIDCSubject links the
+// user to an identity provider account; PasswordHash may then be empty.
+type CreateUserRecordInput struct {
+	Username     string
+	PasswordHash string
+	OIDCSubject  string
+	BarnID       string
+	Role         domain.Role
+	Source       string
+	RequestID    string
+	CreatedBy    string
+}
+
+// ChangePasswordRecordInput is the storage-level payload for a user.password_changed event.
+type ChangePasswordRecordInput struct {
+	UserID       string
+	PasswordHash string
+	Source       string
+	RequestID    string
+	CreatedBy    string
+}
+
+// ChangeRoleRecordInput is the storage-level payload for a user.role_changed
+// event. An empty Role ends the membership in BarnID.
+type ChangeRoleRecordInput struct {
+	UserID    string
+	BarnID    string
+	Role      domain.Role
+	Source    string
+	RequestID string
+	CreatedBy string
+}
+
+// UserStore appends to and folds user streams. Users are shared by every barn;
+// their streams are stored in domain.ServerBarnID.
+type UserStore interface {
+	CreateUser(ctx context.Context, in CreateUserRecordInput) (domain.User, error)
+	ChangePassword(ctx context.Context, in ChangePasswordRecordInput) error
+	ChangeRole(ctx context.Context, in ChangeRoleRecordInput) error
+	GetUser(ctx context.Context, userID string) (domain.User, bool, error)
+	FindUserByUsername(ctx context.Context, username string) (domain.User, bool, error)
+}
+
+// PasswordHasher derives and checks password hashes in a self-describing encoding.
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+	VerifyPassword(hash, password string) (bool, error)
+}
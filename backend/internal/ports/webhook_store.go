@@ -0,0 +1,97 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Webhook is a registered webhook subscription.
+// Empty EventTypes subscribes to every event type.
+type Webhook struct {
+	ID         string
+	BarnID     string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// WebhookRecordInput is the storage-level payload for creating or replacing a subscription.
+// Secret is ignored on update; subscriptions keep the secret they were created with.
+// BarnID is the barn the subscription belongs to; an update only finds
+// subscriptions of that barn.
+type WebhookRecordInput struct {
+	BarnID     string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+}
+
+// WebhookStore persists webhook subscriptions. Each subscription belongs to one
+// barn and is not found from any other.
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, in WebhookRecordInput) (Webhook, error)
+	GetWebhook(ctx context.Context, barnID, webhookID string) (Webhook, bool, error)
+	ListWebhooks(ctx context.Context, barnID string) ([]Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID string, in WebhookRecordInput) (Webhook, bool, error)
+	DeleteWebhook(ctx context.Context, barnID, webhookID string) (bool, error)
+}
+
+// WebhookDeliveryStatus is the lifecycle state of one event delivery to one subscription.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending deliveries are retried until they succeed or run out of attempts.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered deliveries were acknowledged with a 2xx response.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead deliveries exhausted their attempts and are no longer retried.
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is a pending delivery that is due, joined with its subscription and event.
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID string
+	URL       string
+	Secret    string
+	Attempts  int
+	Event     EventRecord
+}
+
+// WebhookDeliveryAttempt records the outcome of one delivery attempt.
+type WebhookDeliveryAttempt struct {
+	DeliveryID    int64
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// WebhookOutboxStore reads the transactional outbox and tracks per-subscription deliveries.
+type WebhookOutboxStore interface {
+	// ListPendingOutbox returns events not yet fanned out to subscriptions, in append order.
+	ListPendingOutbox(ctx context.Context, limit int) ([]EventRecord, error)
+	// ScheduleWebhookDeliveries creates a pending delivery per subscription and marks the
+	// outbox entry dispatched in one transaction.
+	ScheduleWebhookDeliveries(ctx context.Context, eventID string, webhookIDs []string, at time.Time) error
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, attempt WebhookDeliveryAttempt) error
+}
+
+// WebhookRequest is one signed POST to a subscriber.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventID    string
+	Body       []byte
+}
+
+// WebhookSender delivers webhook requests. Any non-nil error counts as a failed attempt.
+type WebhookSender interface {
+	SendWebhook(ctx context.Context, req WebhookRequest) error
+}
@@ -97,8 +97,10 @@ func TestJSONSystemResponseContracts(t *testing.T) {
 
 	errorResponse := mustMap(t, schemas["httpapi.errorResponse"], "components.schemas.httpapi.errorResponse")
 	errorRequired := mustSlice(t, errorResponse["required"], "components.schemas.httpapi.errorResponse.required")
-	if !containsString(errorRequired, "error") {
-		t.Fatalf("expected errorResponse required fields to contain %q, got %v", "error", errorRequired)
+	for _, field := range []string{"type", "title", "status", "code"} {
+		if !containsString(errorRequired, field) {
+			t.Fatalf("expected errorResponse required fields to contain %q, got %v", field, errorRequired)
+		}
 	}
 }
 
@@ -26,6 +26,7 @@ export default defineConfig({
       env: {
         ...process.env,
         BARNLOG_AUTO_MIGRATE: "true",
+        BARNLOG_CORS_ALLOWED_ORIGINS: frontendURL,
         BARNLOG_DB_PATH: dbPath,
         BARNLOG_ENV: "test",
         BARNLOG_FILE_DIR: fileDir,
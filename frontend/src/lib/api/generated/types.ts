@@ -23,8 +23,10 @@ export interface paths {
                 header?: {
                     /** @description Idempotency request key (omit to disable idempotency) */
                     "X-Request-Id"?: string;
-                    /** @description Request source */
+                    /** @description Request source (ignored for registered devices, which always record device:<device ID>) */
                     "X-Barnlog-Source"?: string;
+                    /** @description Client application version (stored in event metadata) */
+                    "X-Barnlog-Client-Version"?: string;
                 };
                 path?: never;
                 cookie?: never;
@@ -69,7 +71,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
                 /** @description Conflict */
@@ -78,7 +80,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
                 /** @description Request Entity Too Large */
@@ -87,7 +89,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
                 /** @description Unsupported Media Type */
@@ -96,7 +98,18 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Too Many Requests (rate_limited) */
+                429: {
+                    headers: {
+                        /** @description Seconds until the client's budget allows the request */
+                        "Retry-After"?: number;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -105,7 +118,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
             };
@@ -116,7 +138,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/healthz": {
+    "/animals/{animalId}": {
         parameters: {
             query?: never;
             header?: never;
@@ -124,14 +146,17 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Health check
-         * @description Returns service liveness status.
+         * Get animal
+         * @description Returns the current state of an animal folded from its event stream. Voided animals are reported as not found.
          */
         get: {
             parameters: {
                 query?: never;
                 header?: never;
-                path?: never;
+                path: {
+                    /** @description Animal ID */
+                    animalId: string;
+                };
                 cookie?: never;
             };
             requestBody?: never;
@@ -142,7 +167,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.statusResponse"];
+                        "application/json": components["schemas"]["httpapi.animalResponse"];
+                    };
+                };
+                /** @description Not Found (animal_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
             };
@@ -155,46 +198,135 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/readyz": {
+    "/animals/{animalId}/events/{eventId}/correction": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Readiness check
-         * @description Returns service readiness status and current UTC timestamp.
+         * Correct event
+         * @description Replaces the payload of an earlier event by appending an event.corrected event. The original event is kept unchanged.
          */
-        get: {
+        post: {
             parameters: {
                 query?: never;
-                header?: never;
-                path?: never;
+                header?: {
+                    /** @description Idempotency request key (omit to disable idempotency) */
+                    "X-Request-Id"?: string;
+                    /** @description Request source (ignored for registered devices, which always record device:<device ID>) */
+                    "X-Barnlog-Source"?: string;
+                    /** @description Client application version (stored in event metadata) */
+                    "X-Barnlog-Client-Version"?: string;
+                };
+                path: {
+                    /** @description Animal ID */
+                    animalId: string;
+                    /** @description ID of the event to amend */
+                    eventId: string;
+                };
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description Correction reason and replacement payload */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["httpapi.correctEventRequest"];
+                };
+            };
             responses: {
-                /** @description OK */
+                /** @description Idempotent replay */
                 200: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.readyResponse"];
+                        "application/json": components["schemas"]["httpapi.eventAmendmentResponse"];
+                    };
+                };
+                /** @description Created */
+                201: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.eventAmendmentResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_json | invalid_input | reason_required | payload_invalid | name_required | species_invalid | birthdate_invalid | photo_not_found | event_aggregate_mismatch | event_not_correctable) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Not Found (animal_not_found | event_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Conflict (event_voided | conflict | idempotency_payload_mismatch | idempotency_event_type_mismatch) */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
             };
         };
-        put?: never;
-        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/uploads/animal-photos": {
+    "/animals/{animalId}/events/{eventId}/void": {
         parameters: {
             query?: never;
             header?: never;
@@ -204,59 +336,96 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Upload animal photo
-         * @description Uploads an animal photo (max 10 MiB; allowed MIME types: image/jpeg, image/png, image/webp, image/gif) and returns a generated file_id.
+         * Void event
+         * @description Marks an earlier event as void by appending an event.voided event. The original event is kept unchanged.
          */
         post: {
             parameters: {
                 query?: never;
-                header?: never;
-                path?: never;
+                header?: {
+                    /** @description Idempotency request key (omit to disable idempotency) */
+                    "X-Request-Id"?: string;
+                    /** @description Request source (ignored for registered devices, which always record device:<device ID>) */
+                    "X-Barnlog-Source"?: string;
+                    /** @description Client application version (stored in event metadata) */
+                    "X-Barnlog-Client-Version"?: string;
+                };
+                path: {
+                    /** @description Animal ID */
+                    animalId: string;
+                    /** @description ID of the event to amend */
+                    eventId: string;
+                };
                 cookie?: never;
             };
-            /** @description Animal photo file (max 10 MiB; allowed MIME types: image/jpeg, image/png, image/webp, image/gif) */
+            /** @description Void reason */
             requestBody: {
                 content: {
-                    /**
-                     * @example {
-                     *       "file": "(binary file)"
-                     *     }
-                     */
-                    "multipart/form-data": {
-                        /**
-                         * Format: binary
-                         * @description Animal photo file to upload
-                         */
-                        file: string;
-                    };
+                    "application/json": components["schemas"]["httpapi.voidEventRequest"];
                 };
             };
             responses: {
+                /** @description Idempotent replay */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.eventAmendmentResponse"];
+                    };
+                };
                 /** @description Created */
                 201: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.uploadFileResponse"];
+                        "application/json": components["schemas"]["httpapi.eventAmendmentResponse"];
                     };
                 };
-                /** @description Bad Request (invalid_multipart | file_required | multiple_files_not_allowed | invalid_file | unsupported_file_type) */
+                /** @description Bad Request (invalid_json | invalid_input | reason_required | event_aggregate_mismatch | event_not_correctable) */
                 400: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
-                /** @description Request Entity Too Large (file_too_large) */
+                /** @description Not Found (animal_not_found | event_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Conflict (event_voided | conflict | idempotency_payload_mismatch | idempotency_event_type_mismatch) */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
                 413: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
                 /** @description Internal Server Error (internal_error) */
@@ -265,7 +434,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["httpapi.errorResponse"];
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
                     };
                 };
             };
@@ -276,61 +454,2000 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-}
-export type webhooks = Record<string, never>;
-export interface components {
-    schemas: {
-        "httpapi.createAnimalRequest": {
-            /**
-             * Format: date
-             * @example 2021-03-04
-             */
-            birthdate?: string;
-            /** @example Nanny */
-            name: string;
-            /** @example photo_1 */
-            photo_id?: string;
-            /**
-             * @example goat
-             * @enum {string}
-             */
-            species: "goat" | "pig" | "dog" | "cat";
-            /** @example G-7 */
-            tag?: string;
-        };
-        "httpapi.createAnimalResponse": {
-            /** @example animal_123 */
-            animal_id: string;
-            /**
-             * Format: date
-             * @example 2021-03-04
-             */
-            birthdate?: string;
-            /** @example event_123 */
-            event_id: string;
-            /** @example Nanny */
-            name?: string;
-            /** @example photo_1 */
-            photo_id?: string;
-            /** @example goat */
-            species?: string;
-            /** @example G-7 */
-            tag?: string;
+    "/animals/{animalId}/timeline": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
         };
-        "httpapi.errorResponse": {
-            /** @example invalid_json */
-            error: string;
+        /**
+         * Get animal timeline
+         * @description Returns the events of an animal in append order with corrections and voids applied.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description Animal ID */
+                    animalId: string;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.timelineResponse"];
+                    };
+                };
+                /** @description Not Found (animal_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
         };
-        "httpapi.readyResponse": {
-            /** @example ready */
-            status: string;
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/devices": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List devices
+         * @description Lists the devices registered by the signed-in user. Device secrets are not returned. Only available with a session cookie.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.deviceListResponse"];
+                    };
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        /**
+         * Register device
+         * @description Registers a device, such as a phone, for the signed-in user. The device secret is returned once and only its hash is stored. Only available with a session cookie.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Device */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["httpapi.registerDeviceRequest"];
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.deviceResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_json | invalid_input) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Forbidden (csrf_token_invalid) */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/devices/{deviceId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Revoke device
+         * @description Revokes a device of the signed-in user, for example a lost phone. Its secret stops working immediately. Only available with a session cookie.
+         */
+        delete: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description Device ID */
+                    deviceId: string;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description No Content */
+                204: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Forbidden (csrf_token_invalid) */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Not Found (device_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/login": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Log in
+         * @description Checks a username and password and starts a session. The session token is set as the HttpOnly barnlog_session cookie; the response carries the CSRF token that writes made with the cookie must echo in X-CSRF-Token.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Credentials */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["httpapi.loginRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.sessionResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_json) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unauthorized (invalid_credentials) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/logout": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Log out
+         * @description Ends the current session and clears the session cookie.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description No Content */
+                204: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Forbidden (csrf_token_invalid) */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/oidc/callback": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Finish single sign-on
+         * @description The redirect URI registered with the OpenID Connect provider. Checks the state against the barnlog_oidc cookie set by /auth/oidc/login, redeems the authorization code with its PKCE verifier and verifies the ID token. The user named by the username claim is created on first login and bound to the token's subject; their memberships are replaced by the ones the roles claim grants. On success the barnlog_session cookie is set and the browser is redirected to /. Fetch the CSRF token from /auth/session afterwards.
+         */
+        get: {
+            parameters: {
+                query?: {
+                    /** @description Authorization code issued by the provider */
+                    code?: string;
+                    /** @description State echoed by the provider */
+                    state?: string;
+                    /** @description Error reported by the provider instead of a code */
+                    error?: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description Found; the session cookie is set and Location is / */
+                302: {
+                    headers: {
+                        Location?: string;
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Bad Request (username_invalid) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unauthorized (sso_failed) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Forbidden (forbidden) */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Not Found (not_found) when single sign-on is not configured */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Conflict (username_taken) */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/oidc/login": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Start single sign-on
+         * @description Starts an OpenID Connect login with the authorization code flow and PKCE. Sets the short-lived HttpOnly barnlog_oidc cookie holding the state, nonce and code verifier and redirects the browser to the provider.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description Found; Location is the provider's authorization endpoint */
+                302: {
+                    headers: {
+                        Location?: string;
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Not Found (not_found) when single sign-on is not configured */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/session": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Current session
+         * @description Returns the signed-in user and the CSRF token of the current session, so a reloaded client can resume without logging in again.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.sessionResponse"];
+                    };
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/tokens": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List API tokens
+         * @description Lists the API tokens of the signed-in user. Token secrets are not returned. Only available with a session cookie.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.apiTokenListResponse"];
+                    };
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        /**
+         * Create API token
+         * @description Creates an API token for the signed-in user. The token is returned once and only its hash is stored. Only available with a session cookie.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description API token */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["httpapi.createApiTokenRequest"];
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.apiTokenResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_json | invalid_input | scope_invalid) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Forbidden (csrf_token_invalid) */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/tokens/{tokenId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Revoke API token
+         * @description Revokes an API token of the signed-in user. Only available with a session cookie.
+         */
+        delete: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description API token ID */
+                    tokenId: string;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description No Content */
+                204: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Unauthorized (unauthenticated) */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Forbidden (csrf_token_invalid) */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Not Found (token_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/events/export": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Export event log
+         * @description Streams every event of the request's barn as newline-delimited JSON in position order, one httpapi.archivedEvent per line. A failure after the first line aborts the response instead of ending it cleanly.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description NDJSON stream of httpapi.archivedEvent */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/x-ndjson": string;
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/events/import": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Import event log
+         * @description Replays an NDJSON export into the event log in one transaction. Positions are reassigned in file order. Each event is written to the barn it names, which the caller needs the transfer permission in. Events already present under the same ID, (barn_id, source, request_id) and payload are skipped, so re-importing a file is a no-op; any other clash rejects the whole import. Imported events are not delivered to webhooks. Follower nodes accept imports, which is how a primary replicates into them.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description NDJSON stream of httpapi.archivedEvent */
+            requestBody: {
+                content: {
+                    "application/x-ndjson": string;
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.importEventsResponse"];
+                    };
+                };
+                /** @description Bad Request (import_invalid) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Conflict (conflict) */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/events/stream": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Stream events
+         * @description Streams newly appended events as Server-Sent Events. Each message carries the global event position as `id`, the event type as `event` and an httpapi.streamEvent JSON object as `data`.
+         * Without a cursor the stream starts at the current end of the log. Reconnecting clients resume after `Last-Event-ID` (or the `after` query parameter).
+         */
+        get: {
+            parameters: {
+                query?: {
+                    /** @description Only stream events of this aggregate type */
+                    aggregate_type?: string;
+                    /** @description Only stream events of this aggregate ID */
+                    aggregate_id?: string;
+                    /** @description Only stream events of this event type */
+                    event_type?: string;
+                    /** @description Stream events after this global position (ignored when Last-Event-ID is sent) */
+                    after?: number;
+                };
+                header?: {
+                    /** @description Global position of the last event the client received */
+                    "Last-Event-ID"?: string;
+                };
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        /**
+                         * @example id: 42
+                         *     event: animal.created
+                         *     data: {"position":42,"event_id":"event_123","aggregate_type":"animal","aggregate_id":"animal_123","event_type":"animal.created","created_by":"user:anna","occurred_at":"2026-02-22T20:32:13Z","recorded_at":"2026-02-22 20:32:13","payload":{"name":"Nanny","species":"goat"}}
+                         *     
+                         *     
+                         */
+                        "text/event-stream": string;
+                    };
+                };
+                /** @description Bad Request (invalid_cursor) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/healthz": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Health check
+         * @description Returns service liveness status.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.statusResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/readyz": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Readiness check
+         * @description Checks that the database answers, is migrated to the version this build expects and that the upload directory is writable, and reports how many events the webhook outbox and replication have not processed yet. Returns 503 with the same body when any check fails.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.readyResponse"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.readyResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/uploads/animal-photos": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Upload animal photo
+         * @description Uploads an animal photo (max 10 MiB; allowed MIME types: image/jpeg, image/png, image/webp, image/gif) and returns a generated file_id.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Animal photo file (max 10 MiB; allowed MIME types: image/jpeg, image/png, image/webp, image/gif) */
+            requestBody: {
+                content: {
+                    /**
+                     * @example {
+                     *       "file": "(binary file)"
+                     *     }
+                     */
+                    "multipart/form-data": {
+                        /**
+                         * Format: binary
+                         * @description Animal photo file to upload
+                         */
+                        file: string;
+                    };
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.uploadFileResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_multipart | file_required | multiple_files_not_allowed | invalid_file | unsupported_file_type) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large (file_too_large) */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Too Many Requests (rate_limited; the hourly upload byte budget counts the request's Content-Length) */
+                429: {
+                    headers: {
+                        /** @description Seconds until the client's budget allows the request */
+                        "Retry-After"?: number;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/webhooks": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * List webhooks
+         * @description Lists webhook subscriptions. Secrets are not returned.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.webhookListResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        /**
+         * Create webhook
+         * @description Registers a webhook subscription. Events appended from now on are POSTed to the URL as JSON signed with the returned secret (X-Barnlog-Signature is "sha256=" + hex HMAC-SHA256 of "<X-Barnlog-Timestamp>.<body>"). Failed deliveries are retried with exponential backoff and dead-lettered after the configured number of attempts.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Webhook subscription */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["httpapi.createWebhookRequest"];
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.webhookResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_json | webhook_url_invalid | webhook_event_type_invalid) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/webhooks/{webhookId}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get webhook
+         * @description Returns a webhook subscription. The secret is not returned.
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description Webhook ID */
+                    webhookId: string;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.webhookResponse"];
+                    };
+                };
+                /** @description Not Found (webhook_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        /**
+         * Update webhook
+         * @description Replaces the URL, event type filter and active flag of a webhook subscription. The secret is kept. Deliveries to inactive webhooks are paused, not dropped.
+         */
+        put: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description Webhook ID */
+                    webhookId: string;
+                };
+                cookie?: never;
+            };
+            /** @description Webhook subscription */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["httpapi.updateWebhookRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["httpapi.webhookResponse"];
+                    };
+                };
+                /** @description Bad Request (invalid_json | webhook_url_invalid | webhook_event_type_invalid) */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Not Found (webhook_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Request Entity Too Large */
+                413: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Unsupported Media Type (unsupported_media_type) */
+                415: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        post?: never;
+        /**
+         * Delete webhook
+         * @description Deletes a webhook subscription and its pending deliveries.
+         */
+        delete: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description Webhook ID */
+                    webhookId: string;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description No Content */
+                204: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Not Found (webhook_not_found) */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Internal Server Error (internal_error) */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+                /** @description Service Unavailable (read_only on a follower node) */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/problem+json": components["schemas"]["httpapi.errorResponse"];
+                    };
+                };
+            };
+        };
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+}
+export type webhooks = Record<string, never>;
+export interface components {
+    schemas: {
+        "httpapi.animalResponse": {
+            /** @example animal_123 */
+            animal_id: string;
+            /**
+             * Format: date
+             * @example 2021-03-04
+             */
+            birthdate?: string;
+            /** @example Nanny */
+            name: string;
+            /** @example photo_1 */
+            photo_id?: string;
+            /** @example goat */
+            species: string;
+            /** @example G-7 */
+            tag?: string;
+            /**
+             * @description Number of events folded into this state
+             * @example 3
+             */
+            version: number;
+        };
+        "httpapi.apiTokenListResponse": {
+            items: components["schemas"]["httpapi.apiTokenResponse"][];
+        };
+        "httpapi.apiTokenResponse": {
+            /** @example 2026-03-01T12:00:00Z */
+            created_at: string;
+            /**
+             * @description Omitted for tokens that do not expire
+             * @example 2027-03-01T12:00:00Z
+             */
+            expires_at?: string;
+            /** @example 3b9e4f0a7c2d4e8f9a1b6c5d0e7f2a3b */
+            id: string;
+            /** @example scale integration */
+            name: string;
+            /**
+             * @example [
+             *       "animals:read",
+             *       "animals:write"
+             *     ]
+             */
+            scopes: ("animals:read" | "animals:write" | "events:import" | "events:read" | "uploads:write" | "webhooks:read" | "webhooks:write")[];
+            /**
+             * @description Only returned when the token is created. Send it as "Authorization: Bearer <token>".
+             * @example blt_Qm9WcjN5bVhmT2hLc0p2a0x3ZlJ6cUNkVnRnYkU2eVk
+             */
+            token?: string;
+        };
+        /** @description One line of an NDJSON event log export. Every column of the events table is included. */
+        "httpapi.archivedEvent": {
+            /** @example animal_123 */
+            aggregate_id: string;
+            /** @example animal */
+            aggregate_type: string;
+            /**
+             * @description Barn of the event. Logs exported before barns existed omit it; on import such events go to the request's barn, or to the server barn for user events.
+             * @example default
+             */
+            barn_id?: string;
+            /**
+             * @description Time the event was recorded (events.created_at)
+             * @example 2026-03-04 05:06:07
+             */
+            created_at: string;
+            /** @example user:anna */
+            created_by: string;
+            /** @example animal.created */
+            event_type: string;
+            /** @example 1 */
+            event_version: number;
+            /** @example event_123 */
+            id: string;
+            metadata: {
+                [key: string]: unknown;
+            } | null;
+            /** @example 2026-03-04T05:06:07Z */
+            occurred_at: string;
+            payload: {
+                [key: string]: unknown;
+            };
+            /**
+             * Format: int64
+             * @description Position in the exporting database. Ignored on import; positions are reassigned.
+             * @example 42
+             */
+            position: number;
+            /** @example req-1 */
+            request_id: string;
+            /** @example web.app */
+            source: string;
+        };
+        "httpapi.correctEventRequest": {
+            /**
+             * @description Replacement payload of the target event
+             * @example {
+             *       "birthdate": "2021-03-04",
+             *       "name": "Nanny",
+             *       "species": "goat",
+             *       "tag": "G-8"
+             *     }
+             */
+            payload: {
+                [key: string]: unknown;
+            };
+            /** @example Tag was misread */
+            reason: string;
+        };
+        "httpapi.createApiTokenRequest": {
+            /**
+             * @description RFC 3339 time after which the token stops working; omit for a token that does not expire
+             * @example 2027-03-01T12:00:00Z
+             */
+            expires_at?: string;
+            /**
+             * @description What the token is for, 1-100 characters
+             * @example scale integration
+             */
+            name: string;
+            /**
+             * @description Operations the token may call; at least one
+             * @example [
+             *       "animals:read",
+             *       "animals:write"
+             *     ]
+             */
+            scopes: ("animals:read" | "animals:write" | "events:import" | "events:read" | "uploads:write" | "webhooks:read" | "webhooks:write")[];
+        };
+        "httpapi.createAnimalRequest": {
+            /**
+             * Format: date
+             * @example 2021-03-04
+             */
+            birthdate?: string;
+            /** @example Nanny */
+            name: string;
+            /** @example photo_1 */
+            photo_id?: string;
+            /**
+             * @example goat
+             * @enum {string}
+             */
+            species: "goat" | "pig" | "dog" | "cat";
+            /** @example G-7 */
+            tag?: string;
+        };
+        "httpapi.createAnimalResponse": {
+            /** @example animal_123 */
+            animal_id: string;
+            /**
+             * Format: date
+             * @example 2021-03-04
+             */
+            birthdate?: string;
+            /** @example event_123 */
+            event_id: string;
+            /** @example Nanny */
+            name?: string;
+            /** @example photo_1 */
+            photo_id?: string;
+            /** @example goat */
+            species?: string;
+            /** @example G-7 */
+            tag?: string;
+        };
+        "httpapi.createWebhookRequest": {
+            /**
+             * @description Defaults to true
+             * @example true
+             */
+            active?: boolean;
+            /**
+             * @description Event types to deliver; empty or omitted delivers every event type
+             * @example [
+             *       "animal.created"
+             *     ]
+             */
+            event_types?: string[];
+            /**
+             * @description Absolute http or https URL that receives signed POST requests
+             * @example https://example.com/barnlog/webhook
+             */
+            url: string;
+        };
+        "httpapi.deviceListResponse": {
+            items: components["schemas"]["httpapi.deviceResponse"][];
+        };
+        "httpapi.deviceResponse": {
+            /** @example 2026-03-01T12:00:00Z */
+            created_at: string;
+            /** @example 8c1f2e3d4b5a69788796a5b4c3d2e1f0 */
+            id: string;
+            /** @example barn phone */
+            name: string;
+            /**
+             * @description Only returned when the device is registered. The device sends it as "Authorization: Bearer <secret>".
+             * @example bld_q7V3p0sXn2m9cKfD4hWzLr8tYbAeJ6uG1oNiE5vR0lM
+             */
+            secret?: string;
+        };
+        /** @description RFC 9457 problem details, served as application/problem+json. */
+        "httpapi.errorResponse": {
+            /**
+             * @description Stable error code; for validation errors the code of the first invalid field
+             * @example name_required
+             */
+            code: string;
+            /**
+             * @description What went wrong in this request, when there is more to say than the title
+             * @example name is required; species must be one of goat, pig, dog, cat
+             */
+            detail?: string;
+            /** @description Every invalid field of a validation error */
+            errors?: components["schemas"]["httpapi.fieldError"][];
+            /** @example 400 */
+            status: number;
+            /** @example Name is required */
+            title: string;
+            /**
+             * @description URI identifying the problem type, urn:barnlog:problem:<code>
+             * @example urn:barnlog:problem:name_required
+             */
+            type: string;
+        };
+        "httpapi.eventAmendmentResponse": {
+            /** @example animal_123 */
+            animal_id: string;
+            /**
+             * @description ID of the appended event.corrected or event.voided event
+             * @example event_456
+             */
+            event_id: string;
+            /** @example event_123 */
+            target_event_id: string;
+        };
+        "httpapi.fieldError": {
+            /** @example species_invalid */
+            code: string;
+            /** @example species must be one of goat, pig, dog, cat */
+            detail?: string;
+            /**
+             * @description Name of the invalid request field
+             * @example species
+             */
+            field: string;
+        };
+        "httpapi.importEventsResponse": {
+            /** @example 120 */
+            imported: number;
+            /**
+             * @description Events already present under the same ID, idempotency key and payload
+             * @example 3
+             */
+            skipped: number;
+        };
+        "httpapi.loginRequest": {
+            /** @example correct horse battery staple */
+            password: string;
+            /** @example anna */
+            username: string;
+        };
+        "httpapi.membershipResponse": {
+            /**
+             * @description Barn the role applies in; "server" applies in every barn
+             * @example default
+             */
+            barn_id: string;
+            /**
+             * @example worker
+             * @enum {string}
+             */
+            role: "owner" | "manager" | "worker" | "viewer";
+        };
+        "httpapi.readyCheck": {
+            /**
+             * @description Why the check failed
+             * @example database is at version 4, expected 5
+             */
+            error?: string;
+            /**
+             * Format: int64
+             * @description Events the consumer has not processed yet. Reported by webhook_outbox and replication; lag alone does not fail a check.
+             * @example 0
+             */
+            lag?: number;
+            /**
+             * @example database
+             * @enum {string}
+             */
+            name: "database" | "migrations" | "file_dir" | "webhook_outbox" | "replication";
+            /**
+             * @example ok
+             * @enum {string}
+             */
+            status: "ok" | "fail";
+        };
+        "httpapi.readyResponse": {
+            checks: components["schemas"]["httpapi.readyCheck"][];
+            /**
+             * @example ready
+             * @enum {string}
+             */
+            status: "ready" | "not_ready";
             /** @example 2026-02-22T20:32:13Z */
             timestamp: string;
         };
+        "httpapi.registerDeviceRequest": {
+            /**
+             * @description What the device is, 1-100 characters
+             * @example barn phone
+             */
+            name: string;
+        };
+        "httpapi.sessionResponse": {
+            /**
+             * @description Send this value in the X-CSRF-Token header of every POST, PUT and DELETE made with the session cookie
+             * @example 8kq2c9Vw0mYc6mGf3Yk1p1n8HcKkW3m2tq0JzVQe9xQ
+             */
+            csrf_token: string;
+            /** @example 2026-03-08T12:00:00Z */
+            expires_at: string;
+            /** @description Barns the user belongs to, ordered by barn ID; the role in the request's barn decides which operations the session may use */
+            memberships: components["schemas"]["httpapi.membershipResponse"][];
+            /** @example 5f0c6a7e9b1d4c3a8e2f7b6d1a0c9e8f */
+            user_id: string;
+            /** @example anna */
+            username: string;
+        };
         "httpapi.statusResponse": {
             /** @example ok */
             status: string;
         };
+        "httpapi.streamEvent": {
+            /** @example animal_123 */
+            aggregate_id: string;
+            /** @example animal */
+            aggregate_type: string;
+            /** @example user:anna */
+            created_by: string;
+            /** @example event_123 */
+            event_id: string;
+            /** @example animal.created */
+            event_type: string;
+            /** @example 2026-02-22T20:32:13Z */
+            occurred_at: string;
+            payload: {
+                [key: string]: unknown;
+            };
+            /**
+             * Format: int64
+             * @description Global append position; also sent as the SSE event id
+             * @example 42
+             */
+            position: number;
+            /** @example 2026-02-22 20:32:13 */
+            recorded_at: string;
+        };
+        "httpapi.timelineCorrection": {
+            /** @example system */
+            created_by: string;
+            /** @example event_456 */
+            event_id: string;
+            /**
+             * @example event.corrected
+             * @enum {string}
+             */
+            event_type: "event.corrected" | "event.voided";
+            /** @example 2026-02-22T20:32:13Z */
+            occurred_at: string;
+            /** @description Replacement payload (event.corrected only) */
+            payload?: {
+                [key: string]: unknown;
+            };
+            /** @example Tag was misread */
+            reason: string;
+            /** @example 2026-02-22 20:32:13 */
+            recorded_at: string;
+        };
+        "httpapi.timelineEntry": {
+            /** @description Corrections and voids applied to this event, oldest first */
+            corrections: components["schemas"]["httpapi.timelineCorrection"][];
+            /** @example system */
+            created_by: string;
+            /** @example event_123 */
+            event_id: string;
+            /** @example animal.created */
+            event_type: string;
+            /** @example 2026-02-22T20:32:13Z */
+            occurred_at: string;
+            /** @description Payload as originally recorded */
+            original_payload: {
+                [key: string]: unknown;
+            };
+            /** @description Payload with the latest correction applied */
+            payload: {
+                [key: string]: unknown;
+            };
+            /** @example 2026-02-22 20:32:13 */
+            recorded_at: string;
+            /**
+             * @example corrected
+             * @enum {string}
+             */
+            status: "active" | "corrected" | "voided";
+        };
+        "httpapi.timelineResponse": {
+            /** @example animal_123 */
+            animal_id: string;
+            entries: components["schemas"]["httpapi.timelineEntry"][];
+        };
+        "httpapi.updateWebhookRequest": {
+            /** @example true */
+            active: boolean;
+            /**
+             * @description Event types to deliver; empty or omitted delivers every event type
+             * @example [
+             *       "animal.created"
+             *     ]
+             */
+            event_types?: string[];
+            /** @example https://example.com/barnlog/webhook */
+            url: string;
+        };
         "httpapi.uploadFileResponse": {
             /** @example image/png */
             content_type: string;
@@ -341,6 +2458,36 @@ export interface components {
             /** @example 248123 */
             size_bytes: number;
         };
+        "httpapi.voidEventRequest": {
+            /** @example Entered twice */
+            reason: string;
+        };
+        "httpapi.webhookListResponse": {
+            items: components["schemas"]["httpapi.webhookResponse"][];
+        };
+        "httpapi.webhookResponse": {
+            /** @example true */
+            active: boolean;
+            /** @example 2026-03-04 05:06:07 */
+            created_at: string;
+            /**
+             * @example [
+             *       "animal.created"
+             *     ]
+             */
+            event_types: string[];
+            /** @example 3f2a9c0e8b7d4e1f9a6b5c4d3e2f1a0b */
+            id: string;
+            /**
+             * @description HMAC-SHA256 signing secret. Only returned when the webhook is created.
+             * @example whsec_0123456789abcdef
+             */
+            secret?: string;
+            /** @example 2026-03-04 05:06:07 */
+            updated_at: string;
+            /** @example https://example.com/barnlog/webhook */
+            url: string;
+        };
     };
     responses: never;
     parameters: never;
@@ -100,7 +100,7 @@ describe("uploadAnimalPhoto invalid success payload (missing file_name)", () =>
 describe("uploadAnimalPhoto failure from backend error code", () => {
   it("returns parsed backend code", async () => {
     stubFetch({
-      json: vi.fn().mockResolvedValue({ error: "unsupported_file_type" }),
+      json: vi.fn().mockResolvedValue({ code: "unsupported_file_type" }),
       ok: false,
       status: 400,
     });
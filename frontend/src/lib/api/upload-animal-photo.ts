@@ -121,7 +121,7 @@ const parseErrorCodeFromResponse = async (
 ): Promise<UploadErrorCode | undefined> => {
   try {
     const payload = (await response.json()) as ErrorResponse;
-    return parseUploadErrorCode(payload?.error);
+    return parseUploadErrorCode(payload?.code);
   } catch {
     return undefined;
   }